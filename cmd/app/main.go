@@ -8,7 +8,11 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
+	// Profiles are validated against the IANA time zones, which are embedded
+	// so they do not depend on the image.
+	_ "time/tzdata"
 
 	"github.com/IBM/sarama"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -17,19 +21,31 @@ import (
 	"github.com/mati/go-ticket/internal/api"
 	"github.com/mati/go-ticket/internal/api/middleware"
 	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/authz"
+	"github.com/mati/go-ticket/internal/blob"
+	"github.com/mati/go-ticket/internal/currency"
 	"github.com/mati/go-ticket/internal/domain"
 	"github.com/mati/go-ticket/internal/event_handler"
 	"github.com/mati/go-ticket/internal/kafka"
+	"github.com/mati/go-ticket/internal/oidc"
 	"github.com/mati/go-ticket/internal/postgres"
 	"github.com/mati/go-ticket/internal/rabbitmq"
 	"github.com/mati/go-ticket/internal/ratelimit"
 	"github.com/mati/go-ticket/internal/services"
+	"github.com/mati/go-ticket/internal/waitingroom"
 	"github.com/mati/go-ticket/internal/workers"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/redis/go-redis/v9"
 	httpSwagger "github.com/swaggo/http-swagger/v2"
 )
 
+// Kafka topics the outbox relay publishes to.
+const (
+	bookingEventsTopic = "booking_events_topic"
+	userEventsTopic    = "user_events_topic"
+	userErasureTopic   = "user_erasure_topic"
+)
+
 // @title Go Ticket API
 // @version 1.0
 // @description Simple API for booking tickets.
@@ -38,6 +54,10 @@ import (
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name Authorization
+// @description Organization API key, sent as "ApiKey gtk_...".
 func main() {
 	logger := setupLogger()
 	slog.SetDefault(logger)
@@ -64,9 +84,64 @@ func run(logger *slog.Logger) error {
 		return errors.New("JWT_SECRET_KEY is not set")
 	}
 
-	authService, err := auth.NewJWTService(secretKey)
+	signingAlgorithm := domain.SigningAlgorithm(os.Getenv("JWT_SIGNING_ALG"))
+	if signingAlgorithm == "" {
+		signingAlgorithm = domain.SigningAlgorithmRS256
+	}
+	keyring, err := setupKeyring(signingAlgorithm, secretKey)
+	if err != nil {
+		return fmt.Errorf("failed to create JWT keyring: %w", err)
+	}
+	keyRotationInterval := auth.DefaultKeyRotationInterval
+	if interval := os.Getenv("JWT_KEY_ROTATION_INTERVAL"); interval != "" {
+		keyRotationInterval, err = time.ParseDuration(interval)
+		if err != nil {
+			return fmt.Errorf("invalid JWT_KEY_ROTATION_INTERVAL: %w", err)
+		}
+	}
+	authService := auth.NewJWTService(keyring)
+
+	// Calendar feed URLs end up in calendar apps and shared links, so they
+	// are signed with a key of their own.
+	calendarFeedSigner, err := auth.NewTokenSigner(os.Getenv("CALENDAR_FEED_SECRET_KEY"))
+	if err != nil {
+		return fmt.Errorf("invalid CALENDAR_FEED_SECRET_KEY: %w", err)
+	}
+	// Admission passes are signed with a key of their own, so that
+	// JWT_SECRET_KEY never signs anything but access tokens.
+	waitingRoomSigner, err := auth.NewTokenSigner(os.Getenv("WAITING_ROOM_SECRET_KEY"))
+	if err != nil {
+		return fmt.Errorf("invalid WAITING_ROOM_SECRET_KEY: %w", err)
+	}
+
+	publicBaseURL := os.Getenv("PUBLIC_BASE_URL")
+	if publicBaseURL == "" {
+		publicBaseURL = "http://localhost:8080"
+	}
+
+	// TOTP_ISSUER names the service in authenticator apps.
+	totpIssuer := os.Getenv("TOTP_ISSUER")
+	if totpIssuer == "" {
+		totpIssuer = "go-ticket"
+	}
+
+	policy, err := setupPolicy(logger)
+	if err != nil {
+		return fmt.Errorf("failed to load authorization policy: %w", err)
+	}
+
+	identityProviders, oidcMockProvider, err := setupOIDCProviders(publicBaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to configure OIDC providers: %w", err)
+	}
+
+	blobDir := os.Getenv("BLOB_STORE_DIR")
+	if blobDir == "" {
+		blobDir = "./data/blobs"
+	}
+	blobStore, err := blob.NewFileSystemStore(blobDir)
 	if err != nil {
-		return fmt.Errorf("failed to create JWT service: %w", err)
+		return fmt.Errorf("failed to create blob store: %w", err)
 	}
 
 	initialCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -84,6 +159,24 @@ func run(logger *slog.Logger) error {
 	}
 	logger.Info("Database migrations completed")
 
+	// Signing keys are stored in the database, so they load after migrations.
+	var keyManager *auth.KeyManager
+	if signingAlgorithm != domain.SigningAlgorithmHS256 {
+		keyManager, err = auth.NewKeyManager(
+			postgres.NewSigningKeyRepository(postgres.New(pool)),
+			postgres.NewPgxTxManager(pool),
+			keyring,
+			secretKey,
+			keyRotationInterval,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create key manager: %w", err)
+		}
+		if err := keyManager.Rotate(initialCtx); err != nil {
+			return fmt.Errorf("failed to load signing keys: %w", err)
+		}
+	}
+
 	// Redis
 	redisClient := ratelimit.NewClient()
 	defer func() {
@@ -94,30 +187,16 @@ func run(logger *slog.Logger) error {
 	authLimiter := ratelimit.NewRateLimiter(redisClient, 5, 15*time.Minute)
 	apiLimiter := ratelimit.NewRateLimiter(redisClient, 100, 1*time.Minute)
 	rateLimitAuth := middleware.RateLimiterMiddleware(authLimiter, middleware.IPKey)
+	feedLimiter := ratelimit.NewRateLimiter(redisClient, 60, 1*time.Hour)
 	rateLimitAPI := middleware.RateLimiterMiddleware(apiLimiter, middleware.UserKey)
-
-	// === Repositories ===
-	eventRepository, bookingRepository, userRepository := setupRepositories(pool)
-	// === Services ===
-	bookingService, userService, outboxRepository := setupServices(
-		eventRepository,
-		bookingRepository,
-		userRepository,
-		authService,
-		pool,
+	rateLimitFeed := middleware.RateLimiterMiddleware(feedLimiter, middleware.IPKey)
+	resendLimiter := ratelimit.NewRateLimiter(redisClient, 3, 1*time.Hour)
+	rateLimitResend := middleware.RateLimiterMiddleware(
+		resendLimiter,
+		middleware.PrefixedKey("verify_resend", middleware.UserKey),
 	)
-	// === Handlers ===
-	eventHandler := api.NewHTTPHandler(eventRepository, bookingRepository, bookingService)
-	authHandler := api.NewAuthHandler(userService)
 
-	mux := http.NewServeMux()
-	setupRoutes(mux, authService, eventHandler, authHandler, rateLimitAuth, rateLimitAPI)
-
-	erChan := make(chan error, 3)
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt)
-
-	// RabbitMQ
+	// RabbitMQ, also used by services to send account emails
 	connection, rabbitMQPublisher, err := setupRabbitMQ(rabbitMQURL)
 	if err != nil {
 		return err
@@ -135,6 +214,231 @@ func run(logger *slog.Logger) error {
 		}
 	}()
 
+	// === Repositories ===
+	eventRepository, bookingRepository, userRepository, presaleRepository, pricingRuleRepository := setupRepositories(
+		pool,
+	)
+	feeRepository := postgres.NewFeeRepository(postgres.New(pool))
+	ledgerRepository := postgres.NewLedgerRepository(postgres.New(pool))
+	walletRepository := postgres.NewWalletRepository(postgres.New(pool))
+	refreshTokenRepository := postgres.NewRefreshTokenRepository(postgres.New(pool))
+	organizationRepository := postgres.NewOrganizationRepository(postgres.New(pool))
+	orderRepository := postgres.NewOrderRepository(postgres.New(pool))
+	auditRepository := postgres.NewAuditRepository(postgres.New(pool))
+	twoFactorRepository := postgres.NewTwoFactorRepository(postgres.New(pool))
+	revocationList := auth.NewRevocationList(redisClient)
+	// === Services ===
+	loginSecurityRepository := postgres.NewLoginSecurityRepository(postgres.New(pool))
+	loginSecurityService := services.NewLoginSecurityService(
+		loginSecurityRepository,
+		postgres.NewOutBoxRepository(postgres.New(pool)),
+	)
+	bookingService, userService, verificationService, outboxRepository := setupServices(
+		eventRepository,
+		bookingRepository,
+		userRepository,
+		presaleRepository,
+		pricingRuleRepository,
+		feeRepository,
+		ledgerRepository,
+		walletRepository,
+		refreshTokenRepository,
+		twoFactorRepository,
+		loginSecurityService,
+		authService,
+		revocationList,
+		pool,
+		publicBaseURL+"/auth/verify",
+	)
+	calendarService := services.NewCalendarService(
+		eventRepository,
+		bookingRepository,
+		userRepository,
+		postgres.NewCalendarFeedRepository(postgres.New(pool)),
+		calendarFeedSigner,
+	)
+	waitingRoom := waitingroom.NewRoom(redisClient, waitingRoomSigner, 10*time.Minute)
+	pricingService := services.NewPricingService(eventRepository, pricingRuleRepository)
+	currencyService := services.NewCurrencyService(currency.DefaultRateSource())
+	salesScheduleService := services.NewSalesScheduleService(
+		eventRepository,
+		outboxRepository,
+		postgres.NewPgxTxManager(pool),
+	)
+	inventoryService := services.NewInventoryService(
+		eventRepository,
+		postgres.NewInventoryRepository(postgres.New(pool)),
+		postgres.NewPgxTxManager(pool),
+	)
+	receiptService := services.NewReceiptService(
+		bookingRepository,
+		eventRepository,
+		postgres.NewReceiptRepository(postgres.New(pool)),
+		blobStore,
+		postgres.NewPgxTxManager(pool),
+	)
+	ledgerService := services.NewLedgerService(ledgerRepository, postgres.NewPgxTxManager(pool))
+	walletService := services.NewWalletService(walletRepository, ledgerRepository, postgres.NewPgxTxManager(pool))
+	passwordResetService := services.NewPasswordResetService(
+		userRepository,
+		postgres.NewPasswordResetRepository(postgres.New(pool)),
+		refreshTokenRepository,
+		outboxRepository,
+		revocationList,
+		postgres.NewPgxTxManager(pool),
+		publicBaseURL+"/reset-password",
+	)
+	adminUserService := services.NewAdminUserService(
+		userRepository,
+		refreshTokenRepository,
+		auditRepository,
+		revocationList,
+		postgres.NewPgxTxManager(pool),
+	)
+	organizerApplicationService := services.NewOrganizerApplicationService(
+		userRepository,
+		refreshTokenRepository,
+		organizationRepository,
+		organizationRepository,
+		organizationRepository,
+		auditRepository,
+		revocationList,
+		postgres.NewPgxTxManager(pool),
+	)
+	organizationService := services.NewOrganizationService(
+		userRepository,
+		organizationRepository,
+		organizationRepository,
+		eventRepository,
+		bookingRepository,
+		orderRepository,
+		auditRepository,
+		postgres.NewPgxTxManager(pool),
+	)
+	apiKeyService := services.NewAPIKeyService(
+		postgres.NewAPIKeyRepository(postgres.New(pool)),
+		userRepository,
+		organizationRepository,
+		organizationRepository,
+		auditRepository,
+		postgres.NewPgxTxManager(pool),
+	)
+	oidcService := services.NewOIDCService(
+		identityProviders,
+		userRepository,
+		postgres.NewIdentityRepository(postgres.New(pool)),
+		refreshTokenRepository,
+		userService,
+		revocationList,
+		postgres.NewPgxTxManager(pool),
+	)
+	twoFactorService := services.NewTwoFactorService(
+		userRepository,
+		twoFactorRepository,
+		auditRepository,
+		userService,
+		loginSecurityService,
+		totpIssuer,
+		postgres.NewPgxTxManager(pool),
+	)
+	orderService := services.NewOrderService(
+		orderRepository,
+		bookingRepository,
+		eventRepository,
+		bookingService,
+		postgres.NewPgxTxManager(pool),
+	)
+	// === Handlers ===
+	eventHandler := api.NewHTTPHandler(
+		eventRepository,
+		bookingRepository,
+		organizationService,
+		bookingService,
+		pricingService,
+		currencyService,
+	)
+	profileRepository := postgres.NewProfileRepository(postgres.New(pool))
+	profileService := services.NewProfileService(userRepository, profileRepository, postgres.NewPgxTxManager(pool))
+	privacyService := services.NewPrivacyService(
+		userRepository,
+		profileRepository,
+		bookingRepository,
+		loginSecurityRepository,
+		organizationRepository,
+		postgres.NewDataExportRepository(postgres.New(pool)),
+		postgres.NewErasureRepository(postgres.New(pool)),
+		auditRepository,
+		outboxRepository,
+		blobStore,
+		revocationList,
+		postgres.NewPgxTxManager(pool),
+	)
+	authHandler := api.NewAuthHandler(userService, passwordResetService, verificationService)
+	calendarHandler := api.NewCalendarHandler(calendarService, publicBaseURL)
+	waitingRoomHandler := api.NewWaitingRoomHandler(waitingRoom)
+	inventoryHandler := api.NewInventoryHandler(inventoryService)
+	presaleHandler := api.NewPresaleHandler(eventRepository, presaleRepository)
+	pricingHandler := api.NewPricingHandler(pricingService)
+	feeHandler := api.NewFeeHandler(userRepository, feeRepository)
+	bookingHandler := api.NewBookingHandler(bookingService, receiptService, organizationService, policy)
+	ledgerHandler := api.NewLedgerHandler(ledgerService)
+	orderHandler := api.NewOrderHandler(orderService, organizationService, policy)
+	walletHandler := api.NewWalletHandler(walletService)
+	jwksHandler := api.NewJWKSHandler(keyring)
+	adminUserHandler := api.NewAdminUserHandler(adminUserService)
+	organizerApplicationHandler := api.NewOrganizerApplicationHandler(organizerApplicationService)
+	organizationHandler := api.NewOrganizationHandler(organizationService)
+	oidcHandler := api.NewOIDCHandler(oidcService)
+	twoFactorHandler := api.NewTwoFactorHandler(twoFactorService)
+	loginSecurityHandler := api.NewLoginSecurityHandler(loginSecurityService)
+	apiKeyHandler := api.NewAPIKeyHandler(apiKeyService)
+	privacyHandler := api.NewPrivacyHandler(privacyService)
+	profileHandler := api.NewProfileHandler(profileService)
+
+	mux := http.NewServeMux()
+	setupRoutes(
+		mux,
+		authService,
+		revocationList,
+		userRepository,
+		waitingRoom,
+		apiKeyService,
+		policy,
+		eventRepository,
+		organizationService,
+		eventHandler,
+		authHandler,
+		calendarHandler,
+		waitingRoomHandler,
+		inventoryHandler,
+		presaleHandler,
+		pricingHandler,
+		feeHandler,
+		bookingHandler,
+		ledgerHandler,
+		orderHandler,
+		walletHandler,
+		jwksHandler,
+		adminUserHandler,
+		organizerApplicationHandler,
+		organizationHandler,
+		oidcHandler,
+		oidcMockProvider,
+		twoFactorHandler,
+		loginSecurityHandler,
+		apiKeyHandler,
+		privacyHandler,
+		profileHandler,
+		rateLimitAuth,
+		rateLimitAPI,
+		rateLimitFeed,
+		rateLimitResend,
+	)
+
+	erChan := make(chan error, 10)
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt)
+
 	// Producer
 	workerCtx, relayCancel := context.WithCancel(context.Background())
 	defer relayCancel()
@@ -156,7 +460,7 @@ func run(logger *slog.Logger) error {
 	}()
 
 	// RabbitMQ Email
-	consumer, worker, errSetUpEmail := setupEmailWorker(connection, redisClient, logger)
+	consumer, worker, errSetUpEmail := setupEmailWorker(connection, redisClient, profileService, logger)
 	if errSetUpEmail != nil {
 		return errSetUpEmail
 	}
@@ -175,8 +479,14 @@ func run(logger *slog.Logger) error {
 	}()
 
 	// Consumer
-	bookingEvent := event_handler.NewBookingEventHandler(logger, rabbitMQPublisher)
-	consumerGroup, consumerWorker, err := setupKafkaConsumer(logger, bookingEvent)
+	bookingEvent := event_handler.NewBookingEventHandler(logger, rabbitMQPublisher, profileService)
+	consumerGroup, consumerWorker, err := setupKafkaConsumer(
+		logger,
+		producer,
+		"bookingEvent-group",
+		bookingEventsTopic,
+		bookingEvent,
+	)
 	if err != nil {
 		return err
 	}
@@ -192,6 +502,137 @@ func run(logger *slog.Logger) error {
 			erChan <- fmt.Errorf("consumer error: %w", err)
 		}
 	}()
+
+	// Receipts
+	receiptEvent := event_handler.NewReceiptEventHandler(logger, receiptService)
+	receiptGroup, receiptWorker, err := setupKafkaConsumer(
+		logger,
+		producer,
+		"receipt-group",
+		bookingEventsTopic,
+		receiptEvent,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := receiptGroup.Close(); err != nil {
+			logger.Error("failed to close receipt consumer group", "error", err)
+		}
+	}()
+
+	go func() {
+		if err := receiptWorker.Start(workerCtx); err != nil {
+			erChan <- fmt.Errorf("receipt consumer error: %w", err)
+		}
+	}()
+
+	// Account emails
+	accountEvent := event_handler.NewAccountEventHandler(logger, rabbitMQPublisher)
+	accountGroup, accountWorker, err := setupKafkaConsumer(
+		logger,
+		producer,
+		"accountEvent-group",
+		userEventsTopic,
+		accountEvent,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := accountGroup.Close(); err != nil {
+			logger.Error("failed to close account consumer group", "error", err)
+		}
+	}()
+
+	go func() {
+		if err := accountWorker.Start(workerCtx); err != nil {
+			erChan <- fmt.Errorf("account consumer error: %w", err)
+		}
+	}()
+
+	// Erased users
+	erasureEvent := event_handler.NewUserErasureEventHandler(logger, worker)
+	erasureGroup, erasureWorker, err := setupKafkaConsumer(
+		logger,
+		producer,
+		"userErasure-group",
+		userErasureTopic,
+		erasureEvent,
+	)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := erasureGroup.Close(); err != nil {
+			logger.Error("failed to close user erasure consumer group", "error", err)
+		}
+	}()
+
+	go func() {
+		if err := erasureWorker.Start(workerCtx); err != nil {
+			erChan <- fmt.Errorf("user erasure consumer error: %w", err)
+		}
+	}()
+
+	// Waiting room
+	admitter := workers.NewWaitingRoomAdmitter(waitingRoom, logger)
+	go func() {
+		if err := admitter.Start(workerCtx); err != nil {
+			erChan <- fmt.Errorf("waiting room admitter error: %w", err)
+		}
+	}()
+
+	// Sales windows
+	salesScheduler := workers.NewSalesScheduler(salesScheduleService, 15*time.Second, logger)
+	go func() {
+		if err := salesScheduler.Start(workerCtx); err != nil {
+			erChan <- fmt.Errorf("sales scheduler error: %w", err)
+		}
+	}()
+
+	// Inventory reconciliation
+	reconciler := workers.NewInventoryReconcileWorker(inventoryService, time.Minute, logger)
+	go func() {
+		if err := reconciler.Start(workerCtx); err != nil {
+			erChan <- fmt.Errorf("inventory reconciler error: %w", err)
+		}
+	}()
+
+	// Organizer payouts
+	payoutWorker := workers.NewPayoutWorker(ledgerService, 24*time.Hour, logger)
+	go func() {
+		if err := payoutWorker.Start(workerCtx); err != nil {
+			erChan <- fmt.Errorf("payout worker error: %w", err)
+		}
+	}()
+
+	// Unpaid invoice orders
+	orderExpiryWorker := workers.NewOrderExpiryWorker(orderService, time.Minute, logger)
+	go func() {
+		if err := orderExpiryWorker.Start(workerCtx); err != nil {
+			erChan <- fmt.Errorf("order expiry worker error: %w", err)
+		}
+	}()
+
+	// Data exports
+	dataExportWorker := workers.NewDataExportWorker(privacyService, time.Minute, logger)
+	go func() {
+		if err := dataExportWorker.Start(workerCtx); err != nil {
+			erChan <- fmt.Errorf("data export worker error: %w", err)
+		}
+	}()
+
+	// Signing key rotation
+	if keyManager != nil {
+		keyRotationWorker := workers.NewKeyRotationWorker(keyManager, 5*time.Minute, logger)
+		go func() {
+			if err := keyRotationWorker.Start(workerCtx); err != nil {
+				erChan <- fmt.Errorf("key rotation worker error: %w", err)
+			}
+		}()
+	}
+
 	srv := setupServer(mux)
 
 	go func() {
@@ -217,31 +658,76 @@ func run(logger *slog.Logger) error {
 	return nil
 }
 
+// router is what routes are registered on, an *http.ServeMux.
+type router interface {
+	Handle(pattern string, handler http.Handler)
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+}
+
 func setupRoutes(
-	mux *http.ServeMux,
+	mux router,
 	authService *auth.JWTService,
+	revocationList middleware.RevocationChecker,
+	users middleware.UserReader,
+	admissionChecker middleware.AdmissionChecker,
+	apiKeys middleware.APIKeyAuthenticator,
+	policy *authz.Policy,
+	eventRepository domain.EventRepository,
+	organizationService services.OrganizationServiceInterface,
 	eventHandler *api.HTTPHandler,
 	authHandler *api.AuthHandler,
+	calendarHandler *api.CalendarHandler,
+	waitingRoomHandler *api.WaitingRoomHandler,
+	inventoryHandler *api.InventoryHandler,
+	presaleHandler *api.PresaleHandler,
+	pricingHandler *api.PricingHandler,
+	feeHandler *api.FeeHandler,
+	bookingHandler *api.BookingHandler,
+	ledgerHandler *api.LedgerHandler,
+	orderHandler *api.OrderHandler,
+	walletHandler *api.WalletHandler,
+	jwksHandler *api.JWKSHandler,
+	adminUserHandler *api.AdminUserHandler,
+	organizerApplicationHandler *api.OrganizerApplicationHandler,
+	organizationHandler *api.OrganizationHandler,
+	oidcHandler *api.OIDCHandler,
+	oidcMockProvider *oidc.MockProvider,
+	twoFactorHandler *api.TwoFactorHandler,
+	loginSecurityHandler *api.LoginSecurityHandler,
+	apiKeyHandler *api.APIKeyHandler,
+	privacyHandler *api.PrivacyHandler,
+	profileHandler *api.ProfileHandler,
 	rateLimitAuth func(http.HandlerFunc) http.HandlerFunc,
 	rateLimitAPI func(http.HandlerFunc) http.HandlerFunc,
+	rateLimitFeed func(http.HandlerFunc) http.HandlerFunc,
+	rateLimitResend func(http.HandlerFunc) http.HandlerFunc,
 ) {
 	auth := func(handler http.HandlerFunc) http.HandlerFunc {
-		return middleware.AuthMiddleware(authService, handler)
+		return middleware.AuthMiddleware(authService, revocationList, users, handler)
 	}
 
-	requireOrganizer := func(handler http.HandlerFunc) http.HandlerFunc {
-		return middleware.RequireRole([]domain.UserRole{domain.UserRoleOrganizer}, handler)
+	// authOrKey also accepts organization API keys that have the scope.
+	authOrKey := func(scope domain.APIKeyScope, handler http.HandlerFunc) http.HandlerFunc {
+		return middleware.APIKeyMiddleware(apiKeys, scope, handler, auth(handler))
 	}
 
-	requireAdmin := func(handler http.HandlerFunc) http.HandlerFunc {
-		return middleware.RequireRole([]domain.UserRole{domain.UserRoleAdmin}, handler)
+	// can lets requests through when the policy grants their user the
+	// permission. Which events, bookings and organizations they may act on is
+	// checked in the handlers and services.
+	can := func(permission authz.Permission, handler http.HandlerFunc) http.HandlerFunc {
+		return middleware.RequirePermission(policy, permission, handler)
 	}
 
-	requireAll := func(handler http.HandlerFunc) http.HandlerFunc {
-		return middleware.RequireRole(
-			[]domain.UserRole{domain.UserRoleUser, domain.UserRoleAdmin, domain.UserRoleOrganizer},
-			handler,
-		)
+	// canOnEvent also checks that the user may use the permission on the
+	// event of the path.
+	canOnEvent := func(permission authz.Permission, param string, handler http.HandlerFunc) http.HandlerFunc {
+		return can(permission, api.EventPermission(
+			policy, eventRepository, organizationService, permission, param, handler,
+		))
+	}
+
+	requireAdmissionPass := func(handler http.HandlerFunc) http.HandlerFunc {
+		return middleware.RequireAdmissionPass(admissionChecker, handler)
 	}
 
 	// Swagger
@@ -252,14 +738,243 @@ func setupRoutes(
 	// === Public endpoints ===
 	mux.HandleFunc("POST /auth/register", rateLimitAuth(authHandler.Register))
 	mux.HandleFunc("POST /auth/login", rateLimitAuth(authHandler.Login))
+	mux.HandleFunc("POST /auth/login/2fa", rateLimitAuth(twoFactorHandler.CompleteLogin))
+	mux.HandleFunc("POST /auth/login/2fa/enroll", rateLimitAuth(twoFactorHandler.EnrollForLogin))
+	mux.HandleFunc("POST /auth/refresh", rateLimitAuth(authHandler.Refresh))
+	mux.HandleFunc("POST /auth/password/forgot", rateLimitAuth(authHandler.ForgotPassword))
+	mux.HandleFunc("POST /auth/password/reset", rateLimitAuth(authHandler.ResetPassword))
+	mux.HandleFunc("GET /auth/verify", rateLimitAuth(authHandler.VerifyEmail))
+	mux.HandleFunc("GET /.well-known/jwks.json", jwksHandler.JWKS)
+	mux.HandleFunc("GET /auth/oidc/{provider}/login", rateLimitAuth(oidcHandler.Login))
+	mux.HandleFunc("GET /auth/oidc/{provider}/callback", rateLimitAuth(oidcHandler.Callback))
+	if oidcMockProvider != nil {
+		mux.Handle("/oidc-mock/", http.StripPrefix("/oidc-mock", oidcMockProvider))
+	}
 
 	// === Protected endpoints ===
-	mux.HandleFunc("POST /events", auth(requireOrganizer(rateLimitAPI(eventHandler.CreateEvent))))
-	mux.HandleFunc("PUT /events/{id}", auth(requireOrganizer(rateLimitAPI(eventHandler.UpdateEvent))))
-	mux.HandleFunc("DELETE /events/{id}", auth(requireAdmin(rateLimitAPI(eventHandler.DeleteEvent))))
-	mux.HandleFunc("GET /events/{id}", auth(requireAll(rateLimitAPI(eventHandler.GetEvent))))
-	mux.HandleFunc("GET /events", auth(requireAll(rateLimitAPI(eventHandler.ListEvents))))
-	mux.HandleFunc("POST /events/{event_id}/bookings", auth(requireAll(rateLimitAPI(eventHandler.CreateBooking))))
+	mux.HandleFunc("POST /auth/logout", auth(can(authz.AccountManage, authHandler.Logout)))
+	mux.HandleFunc("POST /auth/verify/resend", auth(can(authz.AccountManage, rateLimitResend(
+		authHandler.ResendVerification,
+	))))
+	// Events and their bookings are authorized by membership of their
+	// organization in the handlers.
+	mux.HandleFunc("POST /events", authOrKey(domain.APIKeyScopeEventsWrite, can(authz.EventCreate, rateLimitAPI(
+		eventHandler.CreateEvent,
+	))))
+	mux.HandleFunc("PUT /events/{id}", authOrKey(domain.APIKeyScopeEventsWrite, can(authz.EventUpdate, rateLimitAPI(
+		eventHandler.UpdateEvent,
+	))))
+	mux.HandleFunc("DELETE /events/{id}", auth(can(authz.EventDelete, rateLimitAPI(eventHandler.DeleteEvent))))
+	mux.HandleFunc("PUT /events/{id}/inventory", auth(canOnEvent(authz.EventInventoryManage, "id", rateLimitAPI(
+		inventoryHandler.ConfigureShards,
+	))))
+	mux.HandleFunc("POST /events/{id}/presales", auth(canOnEvent(authz.EventPresaleManage, "id", rateLimitAPI(
+		presaleHandler.CreatePresale,
+	))))
+	mux.HandleFunc("GET /events/{id}/presales", auth(canOnEvent(authz.EventPresaleManage, "id", rateLimitAPI(
+		presaleHandler.ListPresales,
+	))))
+	mux.HandleFunc("DELETE /events/{id}/presales/{presale_id}", auth(canOnEvent(authz.EventPresaleManage, "id",
+		rateLimitAPI(presaleHandler.DeletePresale),
+	)))
+	mux.HandleFunc("POST /events/{id}/pricing-rules", auth(canOnEvent(authz.EventPricingManage, "id", rateLimitAPI(
+		pricingHandler.CreateRule,
+	))))
+	mux.HandleFunc("GET /events/{id}/pricing-rules", auth(canOnEvent(authz.EventPricingManage, "id", rateLimitAPI(
+		pricingHandler.ListRules,
+	))))
+	mux.HandleFunc("DELETE /events/{id}/pricing-rules/{rule_id}", auth(canOnEvent(authz.EventPricingManage, "id",
+		rateLimitAPI(pricingHandler.DeleteRule),
+	)))
+	mux.HandleFunc("GET /events/{id}", authOrKey(domain.APIKeyScopeEventsRead, can(authz.EventRead, rateLimitAPI(
+		api.EventResource(eventHandler.GetEvent, calendarHandler.EventCalendar),
+	))))
+	mux.HandleFunc("GET /events", authOrKey(domain.APIKeyScopeEventsRead, can(authz.EventRead, rateLimitAPI(
+		eventHandler.ListEvents,
+	))))
+	mux.HandleFunc("POST /events/{event_id}/bookings", authOrKey(domain.APIKeyScopeBookingsWrite, can(
+		authz.BookingCreate, rateLimitAPI(requireAdmissionPass(eventHandler.CreateBooking)),
+	)))
+	mux.HandleFunc("POST /events/{event_id}/orders", authOrKey(domain.APIKeyScopeBookingsWrite, can(
+		authz.OrderCreate, rateLimitAPI(requireAdmissionPass(orderHandler.CreateOrder)),
+	)))
+	mux.HandleFunc("PUT /events/{event_id}/queue", auth(canOnEvent(authz.EventQueueManage, "event_id", rateLimitAPI(
+		waitingRoomHandler.Activate,
+	))))
+	mux.HandleFunc("DELETE /events/{event_id}/queue", auth(canOnEvent(authz.EventQueueManage, "event_id",
+		rateLimitAPI(waitingRoomHandler.Deactivate),
+	)))
+	mux.HandleFunc("POST /events/{event_id}/queue", auth(can(authz.EventQueueJoin, rateLimitAPI(waitingRoomHandler.Join))))
+	mux.HandleFunc("GET /events/{event_id}/queue", auth(can(authz.EventQueueJoin, rateLimitAPI(
+		waitingRoomHandler.Status,
+	))))
+	mux.HandleFunc("POST /bookings/{id}/confirm", authOrKey(domain.APIKeyScopeBookingsWrite, can(
+		authz.BookingConfirm, rateLimitAPI(bookingHandler.ConfirmBooking),
+	)))
+	mux.HandleFunc("POST /bookings/{id}/refund", authOrKey(domain.APIKeyScopeBookingsWrite, can(
+		authz.BookingCancel, rateLimitAPI(bookingHandler.RefundBooking),
+	)))
+	mux.HandleFunc("GET /bookings/{id}/receipt", authOrKey(domain.APIKeyScopeBookingsRead, can(
+		authz.ReceiptRead, rateLimitAPI(bookingHandler.GetReceipt),
+	)))
+	mux.HandleFunc("GET /orders/{id}", auth(can(authz.OrderRead, rateLimitAPI(orderHandler.GetOrder))))
+	mux.HandleFunc("POST /orders/{id}/mark-paid", auth(can(authz.OrderMarkPaid, rateLimitAPI(orderHandler.MarkPaid))))
+	mux.HandleFunc("GET /organizers/{id}/fees", auth(can(authz.FeeManage, rateLimitAPI(feeHandler.GetFees))))
+	mux.HandleFunc("PUT /organizers/{id}/fees", auth(can(authz.FeeManage, rateLimitAPI(feeHandler.SetFees))))
+	mux.HandleFunc("GET /organizers/me/balance", auth(can(authz.LedgerRead, rateLimitAPI(ledgerHandler.GetBalance))))
+	mux.HandleFunc("POST /gift-cards", auth(can(authz.GiftCardIssue, rateLimitAPI(walletHandler.IssueGiftCard))))
+	mux.HandleFunc("GET /me/wallet", auth(can(authz.WalletUse, rateLimitAPI(walletHandler.GetWallet))))
+	mux.HandleFunc("POST /me/wallet/redeem", auth(can(authz.WalletUse, rateLimitAPI(walletHandler.RedeemGiftCard))))
+	mux.HandleFunc("GET /me/calendar", auth(can(authz.AccountManage, rateLimitAPI(calendarHandler.CalendarFeedURL))))
+	mux.HandleFunc("POST /me/calendar", auth(can(authz.AccountManage, rateLimitAPI(
+		calendarHandler.RegenerateCalendarFeedURL,
+	))))
+	mux.HandleFunc("GET /admin/users", auth(can(authz.UserManage, rateLimitAPI(adminUserHandler.ListUsers))))
+	mux.HandleFunc("GET /admin/users/{id}", auth(can(authz.UserManage, rateLimitAPI(adminUserHandler.GetUser))))
+	mux.HandleFunc("PUT /admin/users/{id}/role", auth(can(authz.UserManage, rateLimitAPI(adminUserHandler.ChangeRole))))
+	mux.HandleFunc("PUT /admin/users/{id}/suspension", auth(can(authz.UserManage, rateLimitAPI(
+		adminUserHandler.SuspendUser,
+	))))
+	mux.HandleFunc("DELETE /admin/users/{id}/suspension", auth(can(authz.UserManage, rateLimitAPI(
+		adminUserHandler.UnsuspendUser,
+	))))
+	mux.HandleFunc("DELETE /admin/users/{id}/sessions", auth(can(authz.UserManage, rateLimitAPI(
+		adminUserHandler.ForceLogout,
+	))))
+	mux.HandleFunc("DELETE /admin/users/{id}/2fa", auth(can(authz.SecurityManage, rateLimitAPI(
+		twoFactorHandler.ResetUser,
+	))))
+	mux.HandleFunc("GET /admin/security/2fa", auth(can(authz.SecurityManage, rateLimitAPI(twoFactorHandler.GetPolicy))))
+	mux.HandleFunc("PUT /admin/security/2fa", auth(can(authz.SecurityManage, rateLimitAPI(
+		twoFactorHandler.UpdatePolicy,
+	))))
+	// Changes to two-factor authentication are confirmed with a code, so they
+	// share the stricter auth rate limit.
+	mux.HandleFunc("GET /me/2fa", auth(can(authz.AccountManage, rateLimitAPI(twoFactorHandler.Status))))
+	mux.HandleFunc("DELETE /me/2fa", auth(can(authz.AccountManage, rateLimitAuth(twoFactorHandler.Disable))))
+	mux.HandleFunc("POST /me/2fa/enroll", auth(can(authz.AccountManage, rateLimitAuth(twoFactorHandler.Enroll))))
+	mux.HandleFunc("POST /me/2fa/confirm", auth(can(authz.AccountManage, rateLimitAuth(twoFactorHandler.Confirm))))
+	mux.HandleFunc("POST /me/2fa/recovery-codes", auth(can(authz.AccountManage, rateLimitAuth(
+		twoFactorHandler.RegenerateRecoveryCodes,
+	))))
+	mux.HandleFunc("GET /me/security/logins", auth(can(authz.AccountManage, rateLimitAPI(
+		loginSecurityHandler.ListLogins,
+	))))
+	mux.HandleFunc("GET /me", auth(can(authz.AccountManage, rateLimitAPI(profileHandler.GetMe))))
+	mux.HandleFunc("PATCH /me", auth(can(authz.AccountManage, rateLimitAPI(profileHandler.UpdateMe))))
+	mux.HandleFunc("GET /me/data-export", auth(can(authz.AccountManage, rateLimitAPI(privacyHandler.GetDataExport))))
+	mux.HandleFunc("DELETE /me", auth(can(authz.AccountManage, rateLimitAuth(privacyHandler.DeleteMe))))
+	mux.HandleFunc("POST /me/organizer-application", auth(can(authz.OrganizerApplicationSubmit, rateLimitAPI(
+		organizerApplicationHandler.Apply,
+	))))
+	mux.HandleFunc("GET /me/organizer-application", auth(can(authz.OrganizerApplicationSubmit, rateLimitAPI(
+		organizerApplicationHandler.GetMyApplication,
+	))))
+	mux.HandleFunc("GET /admin/organizer-applications", auth(can(authz.OrganizerApplicationReview, rateLimitAPI(
+		organizerApplicationHandler.ListApplications,
+	))))
+	mux.HandleFunc("POST /admin/organizer-applications/{id}/approve", auth(can(authz.OrganizerApplicationReview,
+		rateLimitAPI(organizerApplicationHandler.Approve),
+	)))
+	mux.HandleFunc("POST /admin/organizer-applications/{id}/reject", auth(can(authz.OrganizerApplicationReview,
+		rateLimitAPI(organizerApplicationHandler.Reject),
+	)))
+	mux.HandleFunc("GET /me/organizations", auth(can(authz.OrganizationRead, rateLimitAPI(
+		organizationHandler.ListMyOrganizations,
+	))))
+	mux.HandleFunc("GET /organizations/{id}", auth(can(authz.OrganizationRead, rateLimitAPI(
+		organizationHandler.GetOrganization,
+	))))
+	mux.HandleFunc("POST /organizations/{id}/members", auth(can(authz.OrganizationManage, rateLimitAPI(
+		organizationHandler.InviteMember,
+	))))
+	mux.HandleFunc("DELETE /organizations/{id}/members/{user_id}", auth(can(authz.OrganizationManage, rateLimitAPI(
+		organizationHandler.RemoveMember,
+	))))
+	mux.HandleFunc("POST /organizations/{id}/api-keys", auth(can(authz.OrganizationManage, rateLimitAPI(
+		apiKeyHandler.CreateAPIKey,
+	))))
+	mux.HandleFunc("GET /organizations/{id}/api-keys", auth(can(authz.OrganizationManage, rateLimitAPI(
+		apiKeyHandler.ListAPIKeys,
+	))))
+	mux.HandleFunc("GET /organizations/{id}/api-keys/{key_id}", auth(can(authz.OrganizationManage, rateLimitAPI(
+		apiKeyHandler.GetAPIKey,
+	))))
+	mux.HandleFunc("PUT /organizations/{id}/api-keys/{key_id}", auth(can(authz.OrganizationManage, rateLimitAPI(
+		apiKeyHandler.UpdateAPIKey,
+	))))
+	mux.HandleFunc("DELETE /organizations/{id}/api-keys/{key_id}", auth(can(authz.OrganizationManage, rateLimitAPI(
+		apiKeyHandler.RevokeAPIKey,
+	))))
+
+	// === Token-protected feeds (calendar clients cannot send a bearer token) ===
+	mux.HandleFunc("GET /me/calendar.ics", rateLimitFeed(calendarHandler.UserCalendarFeed))
+}
+
+// setupKeyring returns an HS256 keyring for local development or an empty
+// asymmetric one to be filled by a KeyManager.
+func setupKeyring(algorithm domain.SigningAlgorithm, secretKey string) (*auth.Keyring, error) {
+	if algorithm == domain.SigningAlgorithmHS256 {
+		return auth.NewHMACKeyring(secretKey)
+	}
+	return auth.NewKeyring(algorithm)
+}
+
+// setupPolicy loads the authorization policy from AUTHZ_POLICY_FILE, or the
+// default policy when it is not set.
+func setupPolicy(logger *slog.Logger) (*authz.Policy, error) {
+	config, err := authz.DefaultConfig()
+	if path := os.Getenv("AUTHZ_POLICY_FILE"); path != "" {
+		config, err = authz.LoadConfig(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return authz.NewPolicy(config, logger)
+}
+
+// setupOIDCProviders configures the identity providers named in
+// OIDC_PROVIDERS from OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID and
+// OIDC_<NAME>_CLIENT_SECRET. OIDC_MOCK_PROVIDER=true adds the "mock"
+// provider, served by this app under /oidc-mock, for development.
+func setupOIDCProviders(publicBaseURL string) (map[string]services.IdentityProvider, *oidc.MockProvider, error) {
+	providers := map[string]services.IdentityProvider{}
+	redirectURL := func(name string) string {
+		return publicBaseURL + "/auth/oidc/" + name + "/callback"
+	}
+	for name := range strings.SplitSeq(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		config := oidc.ProviderConfig{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  redirectURL(name),
+		}
+		if config.Issuer == "" || config.ClientID == "" {
+			return nil, nil, fmt.Errorf("%sISSUER and %sCLIENT_ID are required", prefix, prefix)
+		}
+		providers[name] = oidc.NewProvider(config, nil)
+	}
+
+	if os.Getenv("OIDC_MOCK_PROVIDER") != "true" {
+		return providers, nil, nil
+	}
+	mockProvider, err := oidc.NewMockProvider(publicBaseURL + "/oidc-mock")
+	if err != nil {
+		return nil, nil, err
+	}
+	providers["mock"] = oidc.NewProvider(oidc.ProviderConfig{
+		Name:        "mock",
+		Issuer:      mockProvider.Issuer(),
+		ClientID:    "go-ticket",
+		RedirectURL: redirectURL("mock"),
+	}, nil)
+	return providers, mockProvider, nil
 }
 
 func setupServer(mux *http.ServeMux) *http.Server {
@@ -302,25 +1017,66 @@ func setupRepositories(pool *pgxpool.Pool) (
 	*postgres.EventRepository,
 	*postgres.BookingRepository,
 	*postgres.UserRepository,
+	*postgres.PresaleRepository,
+	*postgres.PricingRuleRepository,
 ) {
 	queries := postgres.New(pool)
 	return postgres.NewEventRepository(queries),
 		postgres.NewBookingRepository(queries),
-		postgres.NewUserRepository(queries)
+		postgres.NewUserRepository(queries),
+		postgres.NewPresaleRepository(queries),
+		postgres.NewPricingRuleRepository(queries)
 }
 
 func setupServices(
 	eventRepository *postgres.EventRepository,
 	bookingRepository *postgres.BookingRepository,
 	userRepository *postgres.UserRepository,
+	presaleRepository *postgres.PresaleRepository,
+	pricingRuleRepository *postgres.PricingRuleRepository,
+	feeRepository *postgres.FeeRepository,
+	ledgerRepository *postgres.LedgerRepository,
+	walletRepository *postgres.WalletRepository,
+	refreshTokenRepository *postgres.RefreshTokenRepository,
+	twoFactorRepository *postgres.TwoFactorRepository,
+	loginSecurityService *services.LoginSecurityService,
 	authService *auth.JWTService,
+	revocationList *auth.RevocationList,
 	pool *pgxpool.Pool,
-) (*services.BookingService, *services.UserService, *postgres.OutBoxRepository) {
+	verifyURL string,
+) (*services.BookingService, *services.UserService, *services.EmailVerificationService, *postgres.OutBoxRepository) {
 	transactionManager := postgres.NewPgxTxManager(pool)
 	outboxRepository := postgres.NewOutBoxRepository(postgres.New(pool))
-	bookingService := services.NewBookingService(eventRepository, bookingRepository, outboxRepository, transactionManager)
-	userService := services.NewUserService(userRepository, authService)
-	return bookingService, userService, outboxRepository
+	bookingService := services.NewBookingService(
+		eventRepository,
+		bookingRepository,
+		outboxRepository,
+		presaleRepository,
+		pricingRuleRepository,
+		feeRepository,
+		ledgerRepository,
+		walletRepository,
+		userRepository,
+		transactionManager,
+	)
+	verificationService := services.NewEmailVerificationService(
+		userRepository,
+		postgres.NewEmailVerificationRepository(postgres.New(pool)),
+		outboxRepository,
+		transactionManager,
+		verifyURL,
+	)
+	userService := services.NewUserService(
+		userRepository,
+		refreshTokenRepository,
+		twoFactorRepository,
+		verificationService,
+		loginSecurityService,
+		authService,
+		revocationList,
+		transactionManager,
+	)
+	return bookingService, userService, verificationService, outboxRepository
 }
 
 func setupKafkaRelay(outboxRepository *postgres.OutBoxRepository) (sarama.SyncProducer, *workers.OutboxRelay, error) {
@@ -348,10 +1104,10 @@ func setupKafkaRelay(outboxRepository *postgres.OutBoxRepository) (sarama.SyncPr
 
 func setupKafkaConsumer(
 	logger *slog.Logger,
+	deadLetters sarama.SyncProducer,
+	groupID string,
+	topic string,
 	event domain.EventHandler) (sarama.ConsumerGroup, *workers.KafkaConsumerWorker, error) {
-	const (
-		topic = "booking_events_topic"
-	)
 	kafkaAddr := os.Getenv("KAFKA_ADDR")
 	if kafkaAddr == "" {
 		return nil, nil, errors.New("KAFKA_ADDR is not set")
@@ -363,12 +1119,12 @@ func setupKafkaConsumer(
 	}
 	config.Consumer.Offsets.Initial = sarama.OffsetNewest
 
-	consumerGroup, err := sarama.NewConsumerGroup([]string{kafkaAddr}, "bookingEvent-group", config)
+	consumerGroup, err := sarama.NewConsumerGroup([]string{kafkaAddr}, groupID, config)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create consumer group: %w", err)
 	}
 
-	kafkaConsumer := kafka.NewKafkaConsumer(consumerGroup, []string{topic}, logger, event)
+	kafkaConsumer := kafka.NewKafkaConsumer(consumerGroup, []string{topic}, logger, event, deadLetters)
 	kafkaConsumerWorker := workers.NewKafkaConsumerWorker(kafkaConsumer, logger)
 	return consumerGroup, kafkaConsumerWorker, nil
 }
@@ -390,13 +1146,14 @@ func setupRabbitMQ(url string) (*amqp.Connection, *rabbitmq.RabbitMQPublisher, e
 func setupEmailWorker(
 	conn *amqp.Connection,
 	redisClient *redis.Client,
+	preferences domain.NotificationPreferenceChecker,
 	logger *slog.Logger) (consumer *rabbitmq.RabbitMQConsumer, worker *workers.EmailWorker, err error) {
 	consumer, err = rabbitmq.NewRabbitMqConsumer(conn, "booking.notifications")
 	if err != nil {
 		return nil, nil, err
 	}
 
-	worker = workers.NewEmailWorker(logger, redisClient, consumer)
+	worker = workers.NewEmailWorker(logger, redisClient, consumer, preferences)
 
 	return consumer, worker, nil
 }
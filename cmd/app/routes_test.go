@@ -0,0 +1,347 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/authz"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type principal string
+
+const (
+	public    principal = "public"
+	user      principal = "user"
+	organizer principal = "organizer"
+	admin     principal = "admin"
+	apiKey    principal = "api_key"
+)
+
+var principals = []principal{public, user, organizer, admin, apiKey}
+
+var (
+	everyone = []principal{public, user, organizer, admin, apiKey}
+	members  = []principal{user, organizer, admin}
+	staff    = []principal{organizer, admin}
+	admins   = []principal{admin}
+)
+
+// routeMatrix lists who may call every route, as allowed by the default
+// policy. Event routes are called on an event of the organizer.
+var routeMatrix = map[string][]principal{
+	"GET /swagger/":                                   everyone,
+	"POST /auth/register":                             everyone,
+	"POST /auth/login":                                everyone,
+	"POST /auth/login/2fa":                            everyone,
+	"POST /auth/login/2fa/enroll":                     everyone,
+	"POST /auth/refresh":                              everyone,
+	"POST /auth/password/forgot":                      everyone,
+	"POST /auth/password/reset":                       everyone,
+	"GET /auth/verify":                                everyone,
+	"GET /.well-known/jwks.json":                      everyone,
+	"GET /auth/oidc/{provider}/login":                 everyone,
+	"GET /auth/oidc/{provider}/callback":              everyone,
+	"GET /me/calendar.ics":                            everyone,
+	"POST /auth/logout":                               members,
+	"POST /auth/verify/resend":                        members,
+	"POST /events":                                    {user, organizer, admin, apiKey},
+	"PUT /events/{id}":                                {user, organizer, admin, apiKey},
+	"DELETE /events/{id}":                             admins,
+	"PUT /events/{id}/inventory":                      staff,
+	"POST /events/{id}/presales":                      staff,
+	"GET /events/{id}/presales":                       staff,
+	"DELETE /events/{id}/presales/{presale_id}":       staff,
+	"POST /events/{id}/pricing-rules":                 staff,
+	"GET /events/{id}/pricing-rules":                  staff,
+	"DELETE /events/{id}/pricing-rules/{rule_id}":     staff,
+	"GET /events/{id}":                                {user, organizer, admin, apiKey},
+	"GET /events":                                     {user, organizer, admin, apiKey},
+	"POST /events/{event_id}/bookings":                {user, organizer, admin, apiKey},
+	"POST /events/{event_id}/orders":                  {user, organizer, admin, apiKey},
+	"PUT /events/{event_id}/queue":                    staff,
+	"DELETE /events/{event_id}/queue":                 staff,
+	"POST /events/{event_id}/queue":                   members,
+	"GET /events/{event_id}/queue":                    members,
+	"POST /bookings/{id}/confirm":                     {user, organizer, admin, apiKey},
+	"POST /bookings/{id}/refund":                      {user, organizer, admin, apiKey},
+	"GET /bookings/{id}/receipt":                      {user, organizer, admin, apiKey},
+	"GET /orders/{id}":                                members,
+	"POST /orders/{id}/mark-paid":                     admins,
+	"GET /organizers/{id}/fees":                       admins,
+	"PUT /organizers/{id}/fees":                       admins,
+	"GET /organizers/me/balance":                      {organizer},
+	"POST /gift-cards":                                admins,
+	"GET /me/wallet":                                  members,
+	"POST /me/wallet/redeem":                          members,
+	"GET /me/calendar":                                members,
+	"POST /me/calendar":                               members,
+	"GET /admin/users":                                admins,
+	"GET /admin/users/{id}":                           admins,
+	"PUT /admin/users/{id}/role":                      admins,
+	"PUT /admin/users/{id}/suspension":                admins,
+	"DELETE /admin/users/{id}/suspension":             admins,
+	"DELETE /admin/users/{id}/sessions":               admins,
+	"DELETE /admin/users/{id}/2fa":                    admins,
+	"GET /admin/security/2fa":                         admins,
+	"PUT /admin/security/2fa":                         admins,
+	"GET /me/2fa":                                     members,
+	"DELETE /me/2fa":                                  members,
+	"POST /me/2fa/enroll":                             members,
+	"POST /me/2fa/confirm":                            members,
+	"POST /me/2fa/recovery-codes":                     members,
+	"GET /me/security/logins":                         members,
+	"GET /me":                                         members,
+	"PATCH /me":                                       members,
+	"GET /me/data-export":                             members,
+	"DELETE /me":                                      members,
+	"POST /me/organizer-application":                  members,
+	"GET /me/organizer-application":                   members,
+	"GET /admin/organizer-applications":               admins,
+	"POST /admin/organizer-applications/{id}/approve": admins,
+	"POST /admin/organizer-applications/{id}/reject":  admins,
+	"GET /me/organizations":                           members,
+	"GET /organizations/{id}":                         members,
+	"POST /organizations/{id}/members":                members,
+	"DELETE /organizations/{id}/members/{user_id}":    members,
+	"POST /organizations/{id}/api-keys":               members,
+	"GET /organizations/{id}/api-keys":                members,
+	"GET /organizations/{id}/api-keys/{key_id}":       members,
+	"PUT /organizations/{id}/api-keys/{key_id}":       members,
+	"DELETE /organizations/{id}/api-keys/{key_id}":    members,
+}
+
+// recordingRouter records the patterns routes are registered with.
+type recordingRouter struct {
+	*http.ServeMux
+	patterns []string
+}
+
+func (r *recordingRouter) Handle(pattern string, handler http.Handler) {
+	r.patterns = append(r.patterns, pattern)
+	r.ServeMux.Handle(pattern, handler)
+}
+
+func (r *recordingRouter) HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request)) {
+	r.patterns = append(r.patterns, pattern)
+	r.ServeMux.HandleFunc(pattern, handler)
+}
+
+type stubRevocations struct{}
+
+func (stubRevocations) IsRevoked(context.Context, uuid.UUID) (bool, error)       { return false, nil }
+func (stubRevocations) IsUserSuspended(context.Context, uuid.UUID) (bool, error) { return false, nil }
+
+type stubAPIKeys struct {
+	key  *domain.APIKey
+	user *domain.User
+}
+
+func (s stubAPIKeys) AuthenticateAPIKey(context.Context, string) (*domain.APIKey, *domain.User, error) {
+	return s.key, s.user, nil
+}
+
+// stubEvents returns the events of its map.
+type stubEvents struct {
+	domain.EventRepository
+	events map[uuid.UUID]*domain.Event
+}
+
+func (s stubEvents) GetEvent(_ context.Context, id uuid.UUID) (*domain.Event, error) {
+	event, ok := s.events[id]
+	if !ok {
+		return nil, domain.ErrEventNotFound
+	}
+	return event, nil
+}
+
+// stubOrganizations lets no one act on the events of an organization.
+type stubOrganizations struct {
+	services.OrganizationServiceInterface
+}
+
+func (stubOrganizations) AuthorizeEvent(
+	context.Context,
+	services.Actor,
+	*domain.Event,
+	domain.OrganizationAction,
+) error {
+	return domain.ErrOrganizationForbidden
+}
+
+type routeFixture struct {
+	mux      *recordingRouter
+	headers  map[principal]string
+	eventIDs struct{ owned, other uuid.UUID }
+}
+
+// newRouteFixture sets up the routes with handlers that stop at the rate
+// limiters, so a request answered with 204 passed authorization.
+func newRouteFixture(t *testing.T) *routeFixture {
+	t.Helper()
+	keyring, err := auth.NewHMACKeyring("test-secret")
+	if err != nil {
+		t.Fatalf("NewHMACKeyring() error = %v", err)
+	}
+	jwtService := auth.NewJWTService(keyring)
+	config, err := authz.DefaultConfig()
+	if err != nil {
+		t.Fatalf("DefaultConfig() error = %v", err)
+	}
+	policy, err := authz.NewPolicy(config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+
+	fixture := &routeFixture{
+		mux:     &recordingRouter{ServeMux: http.NewServeMux()},
+		headers: map[principal]string{},
+	}
+	users := map[principal]*domain.User{}
+	for _, p := range []principal{user, organizer, admin} {
+		u, err := domain.NewUser(uuid.New(), string(p)+"@example.com", "hash", domain.UserRole(p))
+		if err != nil {
+			t.Fatalf("NewUser() error = %v", err)
+		}
+		token, err := jwtService.GenerateToken(u)
+		if err != nil {
+			t.Fatalf("GenerateToken() error = %v", err)
+		}
+		users[p] = u
+		fixture.headers[p] = "Bearer " + token.Token
+	}
+	scopes := []domain.APIKeyScope{
+		domain.APIKeyScopeEventsRead, domain.APIKeyScopeEventsWrite,
+		domain.APIKeyScopeBookingsRead, domain.APIKeyScopeBookingsWrite,
+	}
+	key, rawKey, err := domain.NewAPIKey(uuid.New(), users[organizer].ID(), "Kiosk", scopes, time.Time{}, time.Now())
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+	fixture.headers[apiKey] = "ApiKey " + rawKey
+
+	events := map[uuid.UUID]*domain.Event{}
+	for _, organizerID := range []uuid.UUID{users[organizer].ID(), uuid.New()} {
+		event, err := domain.NewEvent(
+			uuid.New(), "Concert", domain.UnmarshalMoney(100, "EUR"), time.Now(), time.Now().Add(time.Hour), 100,
+		)
+		if err != nil {
+			t.Fatalf("NewEvent() error = %v", err)
+		}
+		event.AssignOrganizer(organizerID)
+		events[event.ID()] = event
+		if organizerID == users[organizer].ID() {
+			fixture.eventIDs.owned = event.ID()
+		} else {
+			fixture.eventIDs.other = event.ID()
+		}
+	}
+
+	stop := func(http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+	setupRoutes(
+		fixture.mux,
+		jwtService,
+		stubRevocations{},
+		nil,
+		nil,
+		stubAPIKeys{key: key, user: users[organizer]},
+		policy,
+		stubEvents{events: events},
+		stubOrganizations{},
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		api.NewJWKSHandler(keyring),
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+		stop, stop, stop, stop,
+	)
+	return fixture
+}
+
+func (f *routeFixture) call(t *testing.T, method, path string, p principal) int {
+	t.Helper()
+	// The body is not JSON, so handlers that run before the rate limiters
+	// stop at decoding it.
+	req := httptest.NewRequest(method, path, strings.NewReader("-"))
+	if header, ok := f.headers[p]; ok {
+		req.Header.Set("Authorization", header)
+	}
+	recorder := httptest.NewRecorder()
+	f.mux.ServeHTTP(recorder, req)
+	return recorder.Code
+}
+
+func TestRoutes_PermissionMatrix(t *testing.T) {
+	fixture := newRouteFixture(t)
+
+	for _, pattern := range fixture.mux.patterns {
+		if _, ok := routeMatrix[pattern]; !ok {
+			t.Errorf("route %q is missing from the permission matrix", pattern)
+		}
+	}
+	for pattern := range routeMatrix {
+		if !slices.Contains(fixture.mux.patterns, pattern) {
+			t.Errorf("permission matrix lists unknown route %q", pattern)
+		}
+	}
+
+	for pattern, allowed := range routeMatrix {
+		method, path, _ := strings.Cut(pattern, " ")
+		path = strings.NewReplacer(
+			"{id}", fixture.eventIDs.owned.String(),
+			"{event_id}", fixture.eventIDs.owned.String(),
+			"{provider}", "mock",
+			"{presale_id}", uuid.NewString(),
+			"{rule_id}", uuid.NewString(),
+			"{user_id}", uuid.NewString(),
+			"{key_id}", uuid.NewString(),
+		).Replace(path)
+		if strings.HasSuffix(path, "/") {
+			path += "index.html"
+		}
+		for _, p := range principals {
+			t.Run(pattern+" as "+string(p), func(t *testing.T) {
+				code := fixture.call(t, method, path, p)
+				denied := code == http.StatusUnauthorized || code == http.StatusForbidden
+				if slices.Contains(allowed, p) == denied {
+					t.Errorf("status = %d, want allowed = %v", code, slices.Contains(allowed, p))
+				}
+			})
+		}
+	}
+}
+
+func TestRoutes_EventPermissionsNeedOwnership(t *testing.T) {
+	fixture := newRouteFixture(t)
+	tests := []struct {
+		name    string
+		eventID uuid.UUID
+		p       principal
+		want    int
+	}{
+		{"organizer of the event", fixture.eventIDs.owned, organizer, http.StatusNoContent},
+		{"another organizer", fixture.eventIDs.other, organizer, http.StatusForbidden},
+		{"admin", fixture.eventIDs.other, admin, http.StatusNoContent},
+		{"unknown event", uuid.New(), organizer, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := fixture.call(t, http.MethodGet, "/events/"+tt.eventID.String()+"/pricing-rules", tt.p); code != tt.want {
+				t.Errorf("status = %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Command ledger-verify reconciles the organizer ledger against the bookings.
+// It exits with status 1 when any journal entry is unbalanced or the cash
+// posted for a booking differs from its total.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+func main() {
+	discrepancies, err := run()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ledger-verify:", err)
+		os.Exit(2)
+	}
+	if discrepancies > 0 {
+		os.Exit(1)
+	}
+}
+
+func run() (int, error) {
+	dbUrl := os.Getenv("DATABASE_URL")
+	if dbUrl == "" {
+		return 0, errors.New("DATABASE_URL is not set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, dbUrl)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create database connection pool: %w", err)
+	}
+	defer pool.Close()
+
+	ledgerService := services.NewLedgerService(
+		postgres.NewLedgerRepository(postgres.New(pool)),
+		postgres.NewPgxTxManager(pool),
+	)
+	discrepancies, err := ledgerService.Verify(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, d := range discrepancies {
+		fmt.Printf("%s\t%s\n", d.Reference, d.Reason)
+	}
+	if len(discrepancies) == 0 {
+		fmt.Println("ledger reconciles with bookings")
+	} else {
+		fmt.Printf("%d discrepancies found\n", len(discrepancies))
+	}
+	return len(discrepancies), nil
+}
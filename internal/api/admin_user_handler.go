@@ -0,0 +1,276 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type AdminUserHandler struct {
+	adminUserService services.AdminUserServiceInterface
+}
+
+func NewAdminUserHandler(adminUserService services.AdminUserServiceInterface) *AdminUserHandler {
+	return &AdminUserHandler{adminUserService: adminUserService}
+}
+
+// @Summary List users
+// @Description List users, newest first. email matches part of the address, ignoring case.
+// @Tags admin
+// @Produce json
+// @Param email query string false "Part of the email address"
+// @Param role query string false "Role" Enums(user, organizer, admin)
+// @Param limit query int false "Page size, 50 by default and at most 100"
+// @Param offset query int false "Number of users to skip"
+// @Success 200 {object} dto.UserListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users [get]
+// @Security BearerAuth
+func (h *AdminUserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.UserFilter{
+		Email: query.Get("email"),
+		Role:  domain.UserRole(query.Get("role")),
+	}
+	var err error
+	if filter.Limit, err = parseIntParam(query.Get("limit")); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	if filter.Offset, err = parseIntParam(query.Get("offset")); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+	if filter.Role != "" && filter.Role != domain.UserRoleUser &&
+		filter.Role != domain.UserRoleOrganizer && filter.Role != domain.UserRoleAdmin {
+		code, message := MapDomainError(domain.ErrUserRoleInvalid)
+		ResponseError(w, code, message)
+		return
+	}
+
+	filter = filter.WithPageDefaults()
+	users, err := h.adminUserService.ListUsers(r.Context(), filter)
+	if err != nil {
+		slog.Error("Failed to list users", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToUserListResponse(users, filter))
+}
+
+// @Summary Get a user
+// @Description Get a user with the latest audit log entries about them.
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.UserDetailResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{id} [get]
+// @Security BearerAuth
+func (h *AdminUserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	user, records, err := h.adminUserService.GetUser(r.Context(), userID)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToUserDetailResponse(user, records))
+}
+
+// @Summary Change the role of a user
+// @Description Give a user a new role. The sessions of the user end, so the role applies from their next sign-in.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param body body dto.ChangeRoleRequest true "New role"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{id}/role [put]
+// @Security BearerAuth
+func (h *AdminUserHandler) ChangeRole(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	var req dto.ChangeRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	admin, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	user, err := h.adminUserService.ChangeRole(r.Context(), admin.ID, userID, domain.UserRole(req.Role))
+	if err != nil {
+		slog.Error("Failed to change user role", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToUserResponse(user))
+}
+
+// @Summary Suspend a user
+// @Description Disable an account and end its sessions. The user cannot sign in until unsuspended.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param body body dto.SuspendUserRequest false "Reason for the audit log"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{id}/suspension [put]
+// @Security BearerAuth
+func (h *AdminUserHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	var req dto.SuspendUserRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			ResponseError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	admin, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	user, err := h.adminUserService.SuspendUser(r.Context(), admin.ID, userID, req.Reason)
+	if err != nil {
+		slog.Error("Failed to suspend user", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToUserResponse(user))
+}
+
+// @Summary Unsuspend a user
+// @Description Enable a suspended account again.
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{id}/suspension [delete]
+// @Security BearerAuth
+func (h *AdminUserHandler) UnsuspendUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	admin, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	user, err := h.adminUserService.UnsuspendUser(r.Context(), admin.ID, userID)
+	if err != nil {
+		slog.Error("Failed to unsuspend user", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToUserResponse(user))
+}
+
+// @Summary Force logout
+// @Description End every session of a user, including their live access tokens.
+// @Tags admin
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{id}/sessions [delete]
+// @Security BearerAuth
+func (h *AdminUserHandler) ForceLogout(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	admin, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.adminUserService.ForceLogout(r.Context(), admin.ID, userID); err != nil {
+		slog.Error("Failed to force logout", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
+
+// parseIntParam parses an optional non-negative integer query parameter.
+// An empty value is zero.
+func parseIntParam(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return n, nil
+}
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type APIKeyHandler struct {
+	apiKeyService services.APIKeyServiceInterface
+}
+
+func NewAPIKeyHandler(apiKeyService services.APIKeyServiceInterface) *APIKeyHandler {
+	return &APIKeyHandler{apiKeyService: apiKeyService}
+}
+
+// @Summary Create an API key
+// @Description Create a key that lets integrations such as box-office kiosks call the API for the organization.
+// @Description The key acts as you, within the organization and its scopes only, and is shown this one time.
+// @Description Scopes: events:read, events:write, bookings:read, bookings:write. Owners and managers only.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param body body dto.CreateAPIKeyRequest true "Key name, scopes and optional expiry"
+// @Success 201 {object} dto.CreatedAPIKeyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations/{id}/api-keys [post]
+// @Security BearerAuth
+func (h *APIKeyHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	var req dto.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	scopes, err := domain.ParseAPIKeyScopes(req.Scopes)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	actor, ok := actorFromRequest(r)
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	key, rawKey, err := h.apiKeyService.CreateAPIKey(
+		r.Context(), actor, organizationID, req.Name, scopes, dto.TimeOrZero(req.ExpiresAt),
+	)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseCreated(w, dto.ToCreatedAPIKeyResponse(key, rawKey))
+}
+
+// @Summary List API keys
+// @Description List the API keys of the organization, newest first. Owners and managers only.
+// @Tags organization
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} dto.APIKeyListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations/{id}/api-keys [get]
+// @Security BearerAuth
+func (h *APIKeyHandler) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	actor, ok := actorFromRequest(r)
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	keys, err := h.apiKeyService.ListAPIKeys(r.Context(), actor, organizationID)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToAPIKeyListResponse(keys))
+}
+
+// @Summary Get an API key
+// @Description Get an API key of the organization, with when it was last used. Owners and managers only.
+// @Tags organization
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param key_id path string true "API key ID"
+// @Success 200 {object} dto.APIKeyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations/{id}/api-keys/{key_id} [get]
+// @Security BearerAuth
+func (h *APIKeyHandler) GetAPIKey(w http.ResponseWriter, r *http.Request) {
+	organizationID, keyID, ok := parseAPIKeyPath(w, r)
+	if !ok {
+		return
+	}
+
+	actor, ok := actorFromRequest(r)
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	key, err := h.apiKeyService.GetAPIKey(r.Context(), actor, organizationID, keyID)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToAPIKeyResponse(key))
+}
+
+// @Summary Update an API key
+// @Description Rename an API key and replace its scopes. Owners and managers only.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param key_id path string true "API key ID"
+// @Param body body dto.UpdateAPIKeyRequest true "Key name and scopes"
+// @Success 200 {object} dto.APIKeyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations/{id}/api-keys/{key_id} [put]
+// @Security BearerAuth
+func (h *APIKeyHandler) UpdateAPIKey(w http.ResponseWriter, r *http.Request) {
+	organizationID, keyID, ok := parseAPIKeyPath(w, r)
+	if !ok {
+		return
+	}
+	var req dto.UpdateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	scopes, err := domain.ParseAPIKeyScopes(req.Scopes)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	actor, ok := actorFromRequest(r)
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	key, err := h.apiKeyService.UpdateAPIKey(r.Context(), actor, organizationID, keyID, req.Name, scopes)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToAPIKeyResponse(key))
+}
+
+// @Summary Revoke an API key
+// @Description Delete an API key of the organization. It stops working at once. Owners and managers only.
+// @Tags organization
+// @Param id path string true "Organization ID"
+// @Param key_id path string true "API key ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations/{id}/api-keys/{key_id} [delete]
+// @Security BearerAuth
+func (h *APIKeyHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	organizationID, keyID, ok := parseAPIKeyPath(w, r)
+	if !ok {
+		return
+	}
+
+	actor, ok := actorFromRequest(r)
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.apiKeyService.RevokeAPIKey(r.Context(), actor, organizationID, keyID); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
+
+// parseAPIKeyPath reads the organization and key IDs of a key's path, and
+// writes the error response when they are malformed.
+func parseAPIKeyPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	keyID, err := uuid.Parse(r.PathValue("key_id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid key id")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return organizationID, keyID, true
+}
@@ -2,23 +2,37 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
 
 	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
 	"github.com/mati/go-ticket/internal/domain"
 	"github.com/mati/go-ticket/internal/services"
 )
 
 type AuthHandler struct {
-	userService services.UserServiceInterface
+	userService          services.UserServiceInterface
+	passwordResetService services.PasswordResetServiceInterface
+	verificationService  services.EmailVerificationServiceInterface
 }
 
-func NewAuthHandler(userService services.UserServiceInterface) *AuthHandler {
-	return &AuthHandler{userService: userService}
+func NewAuthHandler(
+	userService services.UserServiceInterface,
+	passwordResetService services.PasswordResetServiceInterface,
+	verificationService services.EmailVerificationServiceInterface,
+) *AuthHandler {
+	return &AuthHandler{
+		userService:          userService,
+		passwordResetService: passwordResetService,
+		verificationService:  verificationService,
+	}
 }
 
 // @Summary Register a new user
-// @Description Register a new user with email and password
+// @Description Register a new user with email and password. A verification link is emailed to the user,
+// @Description who cannot book until the address is verified.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -49,17 +63,22 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ResponseCreated(w, map[string]string{"message": "User registered successfully"})
+	ResponseCreated(w, map[string]string{"message": "User registered successfully, check your email to verify it"})
 }
 
 // @Summary Login user
-// @Description Login user with email and password
+// @Description Login user with email and password. Returns a 15 minute access token and a refresh token.
+// @Description Users of two-factor authentication get 202 with a challenge to complete at /auth/login/2fa.
+// @Description Repeated wrong passwords lock the account out for a while; its owner is emailed and sign-ins
+// @Description get 401, as for unknown addresses.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param body body dto.LoginRequest true "User data"
-// @Success 200 {object} map[string]string
+// @Success 200 {object} dto.TokenResponse
+// @Success 202 {object} dto.TwoFactorChallengeResponse
 // @Failure 400 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
@@ -77,12 +96,231 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.userService.LoginUser(r.Context(), req.Email, req.Password)
+	login, err := h.userService.LoginUser(r.Context(), req.Email, req.Password, loginClient(r))
 	if err != nil {
 		code, message := MapDomainError(err)
 		ResponseError(w, code, message)
 		return
 	}
 
-	ResponseOK(w, map[string]string{"token": token})
+	respondLogin(w, login)
+}
+
+// @Summary Refresh a session
+// @Description Exchange a refresh token for a new access token and refresh token. Each refresh token works
+// @Description once; reusing one ends the session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param body body dto.RefreshRequest true "Refresh token"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/refresh [post]
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req dto.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	session, err := h.userService.RefreshSession(r.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			slog.Warn("Refresh token reused, session revoked") //nolint:gosec // G706: slog uses structured fields
+		}
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	respondSession(w, session)
+}
+
+// @Summary Logout
+// @Description Revoke the access token of the request. A refresh token ends its session as well, and
+// @Description allSessions ends every session of the user.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param body body dto.LogoutRequest false "Sessions to end"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/logout [post]
+// @Security BearerAuth
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req dto.LogoutRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			ResponseError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	err := h.userService.Logout(r.Context(), services.Logout{
+		UserID:               user.ID,
+		AccessTokenID:        user.TokenID,
+		AccessTokenExpiresAt: user.TokenExpiresAt,
+		RefreshToken:         req.RefreshToken,
+		AllSessions:          req.AllSessions,
+	})
+	if err != nil {
+		slog.Error("Failed to logout", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
+
+// @Summary Request a password reset
+// @Description Email a single-use reset link, valid for one hour. The response is the same whether or not
+// @Description the account exists.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param body body dto.ForgotPasswordRequest true "Account email"
+// @Success 202 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.passwordResetService.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		slog.Error("Failed to request password reset", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseJSON(w, http.StatusAccepted, map[string]string{
+		"message": "If an account exists for this email, a reset link has been sent",
+	})
+}
+
+// @Summary Reset password
+// @Description Set a new password with a reset token. Every session of the account is ended.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param body body dto.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req dto.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token == "" {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.passwordResetService.ResetPassword(r.Context(), req.Token, req.Password); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, map[string]string{"message": "Password has been reset"})
+}
+
+// @Summary Verify email address
+// @Description Activate the account a verification link was sent to. Links are valid for 24 hours and work once.
+// @Tags auth
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/verify [get]
+func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		code, message := MapDomainError(domain.ErrVerifyTokenInvalid)
+		ResponseError(w, code, message)
+		return
+	}
+
+	if err := h.verificationService.VerifyEmail(r.Context(), token); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, map[string]string{"message": "Email address verified"})
+}
+
+// @Summary Resend verification email
+// @Description Email a new verification link to the signed-in user. Links sent before stop working.
+// @Tags auth
+// @Produce json
+// @Success 202 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 429 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/verify/resend [post]
+// @Security BearerAuth
+func (h *AuthHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.verificationService.ResendVerification(r.Context(), user.ID); err != nil {
+		slog.Error("Failed to resend verification email", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseJSON(w, http.StatusAccepted, map[string]string{"message": "A new verification link has been sent"})
+}
+
+// respondLogin answers a sign-in with its session, or with 202 Accepted and
+// the challenge to complete with a second factor.
+func respondLogin(w http.ResponseWriter, login services.Login) {
+	if login.Challenge == nil {
+		respondSession(w, login.Session)
+		return
+	}
+	ResponseJSON(w, http.StatusAccepted, dto.TwoFactorChallengeResponse{
+		ChallengeToken:     login.Challenge.Token,
+		ExpiresAt:          login.Challenge.ExpiresAt,
+		EnrollmentRequired: login.Challenge.EnrollmentRequired,
+	})
+}
+
+func respondSession(w http.ResponseWriter, session services.Session) {
+	ResponseOK(w, dto.ToTokenResponse(
+		session.AccessToken.Token,
+		session.AccessToken.ExpiresAt,
+		session.RefreshToken,
+	))
+}
+
+// loginClient tells where a sign-in request comes from, for the login
+// history of the account.
+func loginClient(r *http.Request) services.LoginClient {
+	return services.LoginClient{
+		IPAddress: middleware.IPKey(r),
+		UserAgent: r.UserAgent(),
+	}
 }
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/authz"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+// EventPermission lets requests through to next when the signed-in user may
+// use a permission on the event named by the param path value: on any event,
+// on events they organize, or on events of an organization they manage
+// events for.
+func EventPermission(
+	policy *authz.Policy,
+	eventRepository domain.EventRepository,
+	organizationService services.OrganizationServiceInterface,
+	permission authz.Permission,
+	param string,
+	next http.HandlerFunc,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		eventID, err := uuid.Parse(r.PathValue(param))
+		if err != nil {
+			ResponseError(w, http.StatusBadRequest, "invalid "+pathParamName(param))
+			return
+		}
+
+		user, ok := middleware.GetUserDataFromContext(r.Context())
+		if !ok {
+			ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+			return
+		}
+		subject := user.Subject()
+		if policy.CanAny(r.Context(), subject, permission) {
+			next(w, r)
+			return
+		}
+
+		event, err := eventRepository.GetEvent(r.Context(), eventID)
+		if err != nil {
+			code, message := MapDomainError(err)
+			ResponseError(w, code, message)
+			return
+		}
+		err = policy.Authorize(r.Context(), subject, permission, authz.Resource{
+			Type:    "event",
+			ID:      event.ID(),
+			OwnerID: event.OrganizerID(),
+		})
+		if errors.Is(err, domain.ErrPermissionDenied) && event.OrganizationID() != uuid.Nil &&
+			policy.Can(r.Context(), subject, permission) {
+			actor := services.Actor{ID: user.ID, Role: user.Role, OrganizationID: user.OrganizationID}
+			err = organizationService.AuthorizeEvent(
+				r.Context(), actor, event, domain.OrganizationActionManageEvents,
+			)
+		}
+		if err != nil {
+			code, message := MapDomainError(err)
+			ResponseError(w, code, message)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func pathParamName(param string) string {
+	if param == "event_id" {
+		return "event id"
+	}
+	return param
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/authz"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/pdf"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type BookingHandler struct {
+	bookingService      services.ConfirmBookingService
+	receiptService      services.ReceiptServiceInterface
+	organizationService services.OrganizationServiceInterface
+	policy              *authz.Policy
+}
+
+func NewBookingHandler(
+	bookingService services.ConfirmBookingService,
+	receiptService services.ReceiptServiceInterface,
+	organizationService services.OrganizationServiceInterface,
+	policy *authz.Policy,
+) *BookingHandler {
+	return &BookingHandler{
+		bookingService:      bookingService,
+		receiptService:      receiptService,
+		organizationService: organizationService,
+		policy:              policy,
+	}
+}
+
+// authorize checks that the signed-in user may use a permission on a
+// booking: on any booking, or on the bookings of an event whose organization
+// lets them take the action. It writes the error response when not.
+func (h *BookingHandler) authorize(
+	w http.ResponseWriter,
+	r *http.Request,
+	bookingID uuid.UUID,
+	permission authz.Permission,
+	action domain.OrganizationAction,
+) bool {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return false
+	}
+	if h.policy.CanAny(r.Context(), user.Subject(), permission) {
+		return true
+	}
+	actor := services.Actor{ID: user.ID, Role: user.Role, OrganizationID: user.OrganizationID}
+	if err := h.organizationService.AuthorizeBooking(r.Context(), actor, bookingID, action); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return false
+	}
+	return true
+}
+
+// @Summary Confirm a booking
+// @Description Confirm a pending booking. The receipt is generated asynchronously.
+// @Description Requires a role in the organization of the event that manages bookings.
+// @Tags booking
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {object} dto.BookingResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /bookings/{id}/confirm [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (h *BookingHandler) ConfirmBooking(w http.ResponseWriter, r *http.Request) {
+	bookingID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	if !h.authorize(w, r, bookingID, authz.BookingConfirm, domain.OrganizationActionManageBookings) {
+		return
+	}
+
+	booking, err := h.bookingService.ConfirmBooking(r.Context(), bookingID)
+	if err != nil {
+		slog.Error("Failed to confirm booking", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToBookingResponse(booking))
+}
+
+// @Summary Refund a booking
+// @Description Cancel a confirmed booking and reverse its sale in the organizer ledger.
+// @Description Requires a role in the organization of the event that manages bookings.
+// @Tags booking
+// @Produce json
+// @Param id path string true "Booking ID"
+// @Success 200 {object} dto.BookingResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /bookings/{id}/refund [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (h *BookingHandler) RefundBooking(w http.ResponseWriter, r *http.Request) {
+	bookingID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	if !h.authorize(w, r, bookingID, authz.BookingCancel, domain.OrganizationActionManageBookings) {
+		return
+	}
+
+	booking, err := h.bookingService.RefundBooking(r.Context(), bookingID)
+	if err != nil {
+		slog.Error("Failed to refund booking", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToBookingResponse(booking))
+}
+
+// @Summary Download a booking receipt
+// @Description Download the PDF receipt of a confirmed booking
+// @Tags booking
+// @Produce application/pdf
+// @Param id path string true "Booking ID"
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /bookings/{id}/receipt [get]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (h *BookingHandler) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	bookingID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Members of the organization of the event may read the receipts of
+	// its bookings, customers only their own.
+	actor := services.Actor{ID: user.ID, Role: user.Role, OrganizationID: user.OrganizationID}
+	staff := h.policy.CanAny(r.Context(), user.Subject(), authz.ReceiptRead) ||
+		h.organizationService.AuthorizeBooking(
+			r.Context(), actor, bookingID, domain.OrganizationActionViewBookings,
+		) == nil
+	receipt, document, err := h.receiptService.GetReceipt(r.Context(), bookingID, services.Requester{
+		Email: user.Email,
+		Staff: staff,
+	})
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	w.Header().Set("Content-Type", pdf.ContentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(document)))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", receipt.InvoiceNumber()+".pdf"))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(document); err != nil {
+		slog.Error("Failed to write receipt", "error", err)
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/ical"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+const icsSuffix = ".ics"
+
+type CalendarHandler struct {
+	calendarService services.CalendarServiceInterface
+	baseURL         string
+}
+
+func NewCalendarHandler(calendarService services.CalendarServiceInterface, baseURL string) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: calendarService,
+		baseURL:         strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// EventResource serves GET /events/{id}: ids ending in ".ics" are routed to
+// the iCalendar export, everything else to the JSON handler. The standard
+// mux does not allow a wildcard followed by a literal inside one segment.
+func EventResource(jsonHandler, icsHandler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.PathValue("id"), icsSuffix) {
+			icsHandler(w, r)
+			return
+		}
+		jsonHandler(w, r)
+	}
+}
+
+// @Summary Export an event as iCalendar
+// @Description Download a single event as an .ics file
+// @Tags calendar
+// @Produce text/calendar
+// @Param id path string true "Event ID"
+// @Success 200 {string} string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /events/{id}.ics [get]
+func (h *CalendarHandler) EventCalendar(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimSuffix(r.PathValue("id"), icsSuffix)
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	calendar, err := h.calendarService.EventCalendar(r.Context(), parsedID)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	writeCalendar(w, calendar, fmt.Sprintf("event-%s.ics", parsedID))
+}
+
+// @Summary Get calendar feed URL
+// @Description Returns the secret URL of the user's booking calendar feed
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /me/calendar [get]
+// @Security BearerAuth
+func (h *CalendarHandler) CalendarFeedURL(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	token, err := h.calendarService.FeedToken(r.Context(), user.ID)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, map[string]string{"url": h.feedURL(token)})
+}
+
+// @Summary Regenerate calendar feed URL
+// @Description Replaces the secret URL of the user's booking calendar feed; the previous URL stops working
+// @Tags calendar
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Router /me/calendar [post]
+// @Security BearerAuth
+func (h *CalendarHandler) RegenerateCalendarFeedURL(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	token, err := h.calendarService.RegenerateFeedToken(r.Context(), user.ID)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, map[string]string{"url": h.feedURL(token)})
+}
+
+func (h *CalendarHandler) feedURL(token string) string {
+	return fmt.Sprintf("%s/me/calendar.ics?token=%s", h.baseURL, url.QueryEscape(token))
+}
+
+// @Summary Booking calendar feed
+// @Description iCalendar feed of the user's confirmed and cancelled bookings, authorized by the feed token
+// @Tags calendar
+// @Produce text/calendar
+// @Param token query string true "Feed token"
+// @Success 200 {string} string
+// @Failure 401 {object} map[string]string
+// @Router /me/calendar.ics [get]
+func (h *CalendarHandler) UserCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	calendar, err := h.calendarService.UserCalendar(r.Context(), token)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	writeCalendar(w, calendar, "bookings.ics")
+}
+
+func writeCalendar(w http.ResponseWriter, calendar *ical.Calendar, filename string) {
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	if err := calendar.Encode(w); err != nil {
+		slog.Error("Error encoding calendar", "error", err)
+	}
+}
@@ -0,0 +1,92 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type UserResponse struct {
+	ID          string     `json:"id"`
+	Email       string     `json:"email"`
+	Role        string     `json:"role" example:"organizer"`
+	Status      string     `json:"status" example:"active"`
+	SuspendedAt *time.Time `json:"suspendedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+type UserListResponse struct {
+	Users  []UserResponse `json:"users"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+type AuditRecordResponse struct {
+	ID        string            `json:"id"`
+	ActorID   string            `json:"actorID,omitempty"`
+	Action    string            `json:"action" example:"user.role_changed"`
+	Details   map[string]string `json:"details"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+type UserDetailResponse struct {
+	UserResponse
+	AuditLog []AuditRecordResponse `json:"auditLog"`
+}
+
+type ChangeRoleRequest struct {
+	Role string `json:"role" example:"organizer"`
+}
+
+type SuspendUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+func ToUserResponse(user *domain.User) UserResponse {
+	resp := UserResponse{
+		ID:        user.ID().String(),
+		Email:     user.Email(),
+		Role:      string(user.Role()),
+		Status:    string(user.Status()),
+		CreatedAt: user.CreatedAt(),
+		UpdatedAt: user.UpdatedAt(),
+	}
+	if user.IsSuspended() {
+		suspendedAt := user.SuspendedAt()
+		resp.SuspendedAt = &suspendedAt
+	}
+	return resp
+}
+
+func ToUserListResponse(users []*domain.User, filter domain.UserFilter) UserListResponse {
+	resp := UserListResponse{
+		Users:  make([]UserResponse, len(users)),
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	for i, user := range users {
+		resp.Users[i] = ToUserResponse(user)
+	}
+	return resp
+}
+
+func ToUserDetailResponse(user *domain.User, records []*domain.AuditRecord) UserDetailResponse {
+	resp := UserDetailResponse{
+		UserResponse: ToUserResponse(user),
+		AuditLog:     make([]AuditRecordResponse, len(records)),
+	}
+	for i, record := range records {
+		resp.AuditLog[i] = AuditRecordResponse{
+			ID:        record.ID().String(),
+			Action:    string(record.Action()),
+			Details:   record.Details(),
+			CreatedAt: record.CreatedAt(),
+		}
+		if record.ActorID() != uuid.Nil {
+			resp.AuditLog[i].ActorID = record.ActorID().String()
+		}
+	}
+	return resp
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" example:"Box office kiosk"`
+	Scopes []string `json:"scopes" example:"events:read,bookings:write"`
+	// ExpiresAt is optional; keys without it never expire.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type UpdateAPIKeyRequest struct {
+	Name   string   `json:"name" example:"Box office kiosk"`
+	Scopes []string `json:"scopes" example:"events:read,bookings:write"`
+}
+
+type APIKeyResponse struct {
+	ID             string     `json:"id"`
+	OrganizationID string     `json:"organizationID"`
+	CreatedBy      string     `json:"createdBy"`
+	Name           string     `json:"name"`
+	Prefix         string     `json:"prefix"`
+	Scopes         []string   `json:"scopes"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt     *time.Time `json:"lastUsedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+// CreatedAPIKeyResponse carries the key to use, which is only ever shown
+// in this response.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" example:"gtk_abcdefghijkl_SECRET"`
+}
+
+type APIKeyListResponse struct {
+	APIKeys []APIKeyResponse `json:"apiKeys"`
+}
+
+func ToAPIKeyResponse(key *domain.APIKey) APIKeyResponse {
+	scopes := make([]string, len(key.Scopes()))
+	for i, scope := range key.Scopes() {
+		scopes[i] = string(scope)
+	}
+	return APIKeyResponse{
+		ID:             key.ID().String(),
+		OrganizationID: key.OrganizationID().String(),
+		CreatedBy:      key.CreatedBy().String(),
+		Name:           key.Name(),
+		Prefix:         key.Prefix(),
+		Scopes:         scopes,
+		ExpiresAt:      timeOrNil(key.ExpiresAt()),
+		LastUsedAt:     timeOrNil(key.LastUsedAt()),
+		CreatedAt:      key.CreatedAt(),
+	}
+}
+
+func ToCreatedAPIKeyResponse(key *domain.APIKey, rawKey string) CreatedAPIKeyResponse {
+	return CreatedAPIKeyResponse{APIKeyResponse: ToAPIKeyResponse(key), Key: rawKey}
+}
+
+func ToAPIKeyListResponse(keys []*domain.APIKey) APIKeyListResponse {
+	resp := APIKeyListResponse{APIKeys: make([]APIKeyResponse, len(keys))}
+	for i, key := range keys {
+		resp.APIKeys[i] = ToAPIKeyResponse(key)
+	}
+	return resp
+}
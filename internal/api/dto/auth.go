@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 type RegisterRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password" log:"-"` //nolint:gosec // G706: slog uses structured fields
@@ -9,3 +11,38 @@ type LoginRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password" log:"-"` //nolint:gosec // G706: slog uses structured fields
 }
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" log:"-"`
+}
+
+type LogoutRequest struct {
+	// RefreshToken ends the session it belongs to.
+	RefreshToken string `json:"refreshToken,omitempty" log:"-"`
+	// AllSessions ends every session of the user.
+	AllSessions bool `json:"allSessions,omitempty"`
+}
+
+// TokenResponse keeps the "token" field of the original login response.
+type TokenResponse struct {
+	Token        string    `json:"token"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	RefreshToken string    `json:"refreshToken"`
+}
+
+func ToTokenResponse(accessToken string, expiresAt time.Time, refreshToken string) TokenResponse {
+	return TokenResponse{
+		Token:        accessToken,
+		ExpiresAt:    expiresAt,
+		RefreshToken: refreshToken,
+	}
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" log:"-"`
+	Password string `json:"password" log:"-"` //nolint:gosec // G706: slog uses structured fields
+}
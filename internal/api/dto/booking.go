@@ -3,18 +3,32 @@ package dto
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/mati/go-ticket/internal/domain"
 )
 
 type CreateBookingRequest struct {
+	AccessCode string `json:"accessCode,omitempty"`
+	UseWallet  bool   `json:"useWallet,omitempty"`
 }
 
 type BookingResponse struct {
-	ID        string    `json:"id"`
-	EventID   string    `json:"eventID"`
-	UserEmail string    `json:"userEmail"`
-	CreatedAt time.Time `json:"createdAt"`
-	Status    string    `json:"status"`
+	ID        string     `json:"id"`
+	EventID   string     `json:"eventID"`
+	UserEmail string     `json:"userEmail"`
+	CreatedAt time.Time  `json:"createdAt"`
+	Status    string     `json:"status"`
+	Price     Money      `json:"price"`
+	LineItems []LineItem `json:"lineItems,omitempty"`
+	Total     Money      `json:"total"`
+	OrderID   string     `json:"orderID,omitempty"`
+}
+
+// LineItem is one component of a booking's price.
+type LineItem struct {
+	Kind            string `json:"kind" example:"service_fee"`
+	Amount          Money  `json:"amount"`
+	RateBasisPoints int    `json:"rateBasisPoints,omitempty" example:"2300"`
 }
 
 func ToBookingResponse(booking *domain.Booking) BookingResponse {
@@ -24,7 +38,33 @@ func ToBookingResponse(booking *domain.Booking) BookingResponse {
 		UserEmail: booking.UserEmail(),
 		CreatedAt: booking.CreatedAt(),
 		Status:    string(booking.Status()),
+		Price:     ToMoney(booking.Price()),
+		LineItems: toLineItems(booking.LineItems()),
+		Total:     ToMoney(booking.Total()),
+		OrderID:   orderID(booking),
+	}
+}
+
+func orderID(booking *domain.Booking) string {
+	if booking.OrderID() == uuid.Nil {
+		return ""
 	}
+	return booking.OrderID().String()
+}
+
+func toLineItems(items []domain.LineItem) []LineItem {
+	if len(items) == 0 {
+		return nil
+	}
+	responses := make([]LineItem, len(items))
+	for i, item := range items {
+		responses[i] = LineItem{
+			Kind:            string(item.Kind),
+			Amount:          ToMoney(item.Amount),
+			RateBasisPoints: item.RateBasisPoints,
+		}
+	}
+	return responses
 }
 
 func ToBookingListResponse(bookings []*domain.Booking) []BookingResponse {
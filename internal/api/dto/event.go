@@ -3,47 +3,93 @@ package dto
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/mati/go-ticket/internal/domain"
 )
 
 // Request DTOs
 type CreateEventRequest struct {
-	Name     string    `json:"name"`
-	StartAt  time.Time `json:"startAt"`
-	EndAt    time.Time `json:"endAt"`
-	Price    int64     `json:"price"`
-	Capacity int       `json:"capacity"`
+	Name           string     `json:"name"`
+	StartAt        time.Time  `json:"startAt"`
+	EndAt          time.Time  `json:"endAt"`
+	Price          Money      `json:"price"`
+	Capacity       int        `json:"capacity"`
+	SalesStartAt   *time.Time `json:"salesStartAt,omitempty"`
+	SalesEndAt     *time.Time `json:"salesEndAt,omitempty"`
+	VenueCountry   string     `json:"venueCountry,omitempty"`
+	OrganizationID string     `json:"organizationID,omitempty"`
+	TimeZone       string     `json:"timeZone,omitempty" example:"Europe/Warsaw"`
 }
 
 type UpdateEventRequest struct {
-	Name    string    `json:"name"`
-	StartAt time.Time `json:"startAt"`
-	EndAt   time.Time `json:"endAt"`
-	Price   int64     `json:"price"`
+	Name         string     `json:"name"`
+	StartAt      time.Time  `json:"startAt"`
+	EndAt        time.Time  `json:"endAt"`
+	Price        Money      `json:"price"`
+	SalesStartAt *time.Time `json:"salesStartAt,omitempty"`
+	SalesEndAt   *time.Time `json:"salesEndAt,omitempty"`
+	VenueCountry string     `json:"venueCountry,omitempty"`
+	TimeZone     string     `json:"timeZone,omitempty" example:"Europe/Warsaw"`
 }
 
 // Response DTOs
 type EventResponse struct {
-	ID             string    `json:"id"`
-	Name           string    `json:"name"`
-	Price          int64     `json:"price"`
-	StartAt        time.Time `json:"startAt"`
-	EndAt          time.Time `json:"endAt"`
-	Capacity       int       `json:"capacity"`
-	AvailableSpots int       `json:"availableSpots"`
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Price          Money      `json:"price"`
+	StartAt        time.Time  `json:"startAt"`
+	EndAt          time.Time  `json:"endAt"`
+	Capacity       int        `json:"capacity"`
+	AvailableSpots int        `json:"availableSpots"`
+	CurrentPrice   Money      `json:"currentPrice"`
+	DisplayPrice   *Money     `json:"displayPrice,omitempty"`
+	SalesStartAt   *time.Time `json:"salesStartAt,omitempty"`
+	SalesEndAt     *time.Time `json:"salesEndAt,omitempty"`
+	OrganizerID    string     `json:"organizerID,omitempty"`
+	OrganizationID string     `json:"organizationID,omitempty"`
+	VenueCountry   string     `json:"venueCountry,omitempty"`
+	TimeZone       string     `json:"timeZone"`
 }
 
 func ToEventResponse(event *domain.Event) EventResponse {
 	startAt, endAt := event.StartAndEndAt()
-	return EventResponse{
+	salesStartAt, salesEndAt := event.SalesWindow()
+	resp := EventResponse{
 		ID:             event.ID().String(),
 		Name:           event.Name(),
-		Price:          event.Price(),
+		Price:          ToMoney(event.Price()),
 		StartAt:        startAt,
 		EndAt:          endAt,
 		Capacity:       event.Capacity(),
 		AvailableSpots: event.AvailableSpots(),
+		CurrentPrice:   ToMoney(event.Price()),
+		SalesStartAt:   timeOrNil(salesStartAt),
+		SalesEndAt:     timeOrNil(salesEndAt),
+		VenueCountry:   event.VenueCountry(),
+		TimeZone:       event.TimeZone(),
 	}
+	if event.OrganizerID() != uuid.Nil {
+		resp.OrganizerID = event.OrganizerID().String()
+	}
+	if event.OrganizationID() != uuid.Nil {
+		resp.OrganizationID = event.OrganizationID().String()
+	}
+	return resp
+}
+
+// TimeOrZero dereferences an optional timestamp, mapping nil to the zero time.
+func TimeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
 }
 
 func ToEventListResponse(events []*domain.Event) []EventResponse {
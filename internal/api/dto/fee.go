@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type FeeScheduleRequest struct {
+	PercentBasisPoints int   `json:"percentBasisPoints" example:"500"`
+	Fixed              int64 `json:"fixed" example:"99"`
+}
+
+type FeeScheduleResponse struct {
+	OrganizerID        string `json:"organizerID"`
+	PercentBasisPoints int    `json:"percentBasisPoints"`
+	Fixed              int64  `json:"fixed"`
+}
+
+func ToFeeScheduleResponse(organizerID uuid.UUID, schedule domain.FeeSchedule) FeeScheduleResponse {
+	return FeeScheduleResponse{
+		OrganizerID:        organizerID.String(),
+		PercentBasisPoints: schedule.PercentBasisPoints(),
+		Fixed:              schedule.Fixed(),
+	}
+}
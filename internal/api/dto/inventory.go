@@ -0,0 +1,5 @@
+package dto
+
+type ConfigureInventoryRequest struct {
+	Shards int `json:"shards"`
+}
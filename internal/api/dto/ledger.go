@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type OrganizerBalanceResponse struct {
+	Balances    []BalanceResponse    `json:"balances"`
+	Settlements []SettlementResponse `json:"settlements"`
+}
+
+type BalanceResponse struct {
+	Available Money `json:"available"`
+	PaidOut   Money `json:"paidOut"`
+}
+
+type SettlementResponse struct {
+	ID        string    `json:"id"`
+	Amount    Money     `json:"amount"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func ToOrganizerBalanceResponse(
+	balances []domain.OrganizerBalance,
+	settlements []*domain.Settlement,
+) OrganizerBalanceResponse {
+	resp := OrganizerBalanceResponse{
+		Balances:    make([]BalanceResponse, len(balances)),
+		Settlements: make([]SettlementResponse, len(settlements)),
+	}
+	for i, balance := range balances {
+		resp.Balances[i] = BalanceResponse{
+			Available: ToMoney(balance.Available),
+			PaidOut:   ToMoney(balance.PaidOut),
+		}
+	}
+	for i, settlement := range settlements {
+		resp.Settlements[i] = SettlementResponse{
+			ID:        settlement.ID().String(),
+			Amount:    ToMoney(settlement.Amount()),
+			CreatedAt: settlement.CreatedAt(),
+		}
+	}
+	return resp
+}
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type LoginRecordResponse struct {
+	ID        string    `json:"id"`
+	IPAddress string    `json:"ipAddress"`
+	UserAgent string    `json:"userAgent"`
+	Outcome   string    `json:"outcome" example:"success"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type LoginHistoryResponse struct {
+	Logins []LoginRecordResponse `json:"logins"`
+	Limit  int                   `json:"limit"`
+	Offset int                   `json:"offset"`
+}
+
+func ToLoginHistoryResponse(records []*domain.LoginRecord, page domain.LoginHistoryPage) LoginHistoryResponse {
+	resp := LoginHistoryResponse{
+		Logins: make([]LoginRecordResponse, len(records)),
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	}
+	for i, record := range records {
+		resp.Logins[i] = LoginRecordResponse{
+			ID:        record.ID().String(),
+			IPAddress: record.IPAddress(),
+			UserAgent: record.UserAgent(),
+			Outcome:   string(record.Outcome()),
+			CreatedAt: record.CreatedAt(),
+		}
+	}
+	return resp
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"encoding/json"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// Money is the wire format of every amount exposed by the API. Amount is in
+// the minor unit of Currency; Display is a human readable rendering and is
+// ignored on input.
+type Money struct {
+	Amount   int64  `json:"amount"   example:"2500"`
+	Currency string `json:"currency" example:"EUR"`
+	Display  string `json:"display,omitempty" example:"25.00 EUR"`
+}
+
+// ToMoney converts a domain amount to its wire format.
+func ToMoney(m domain.Money) Money {
+	return Money{
+		Amount:   m.Amount(),
+		Currency: string(m.Currency()),
+		Display:  m.String(),
+	}
+}
+
+// ToDomain validates the amount, falling back to domain.DefaultCurrency when
+// no currency was sent.
+func (m Money) ToDomain() (domain.Money, error) {
+	currency := domain.Currency(m.Currency)
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	return domain.NewMoney(m.Amount, currency)
+}
+
+// UnmarshalJSON accepts the object form as well as a bare integer amount,
+// which older clients send.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var amount int64
+	if err := json.Unmarshal(data, &amount); err == nil {
+		*m = Money{Amount: amount}
+		return nil
+	}
+	type plain Money
+	return json.Unmarshal(data, (*plain)(m))
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// CreateOrderRequest books several attendees under one purchaser, paid by
+// invoice. Attendees without an email, or Seats without Attendees, are booked
+// under the purchaser's email.
+type CreateOrderRequest struct {
+	CompanyName string     `json:"companyName,omitempty" example:"Acme Corp"`
+	Seats       int        `json:"seats,omitempty" example:"10"`
+	Attendees   []string   `json:"attendees,omitempty"`
+	AccessCode  string     `json:"accessCode,omitempty"`
+	DueAt       *time.Time `json:"dueAt,omitempty"`
+}
+
+// AttendeeEmails returns one email per seat; empty entries stand for the
+// purchaser.
+func (r CreateOrderRequest) AttendeeEmails() []string {
+	if len(r.Attendees) > 0 {
+		return r.Attendees
+	}
+	if r.Seats < 0 || r.Seats > domain.MaxOrderSeats {
+		return nil
+	}
+	return make([]string, r.Seats)
+}
+
+type OrderResponse struct {
+	ID             string            `json:"id"`
+	EventID        string            `json:"eventID"`
+	PurchaserEmail string            `json:"purchaserEmail"`
+	CompanyName    string            `json:"companyName,omitempty"`
+	Status         string            `json:"status" example:"awaiting_invoice_payment"`
+	DueAt          time.Time         `json:"dueAt"`
+	Total          Money             `json:"total"`
+	Bookings       []BookingResponse `json:"bookings"`
+	CreatedAt      time.Time         `json:"createdAt"`
+}
+
+func ToOrderResponse(order *domain.Order) OrderResponse {
+	return OrderResponse{
+		ID:             order.ID().String(),
+		EventID:        order.EventID().String(),
+		PurchaserEmail: order.PurchaserEmail(),
+		CompanyName:    order.CompanyName(),
+		Status:         string(order.Status()),
+		DueAt:          order.DueAt(),
+		Total:          ToMoney(order.Total()),
+		Bookings:       ToBookingListResponse(order.Bookings()),
+		CreatedAt:      order.CreatedAt(),
+	}
+}
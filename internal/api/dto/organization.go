@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type InviteMemberRequest struct {
+	Email string `json:"email" example:"door@example.com"`
+	Role  string `json:"role" example:"scanner"`
+}
+
+type MembershipResponse struct {
+	OrganizationID string    `json:"organizationID"`
+	UserID         string    `json:"userID"`
+	Email          string    `json:"email"`
+	Role           string    `json:"role" example:"manager"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+type MembershipListResponse struct {
+	Memberships []MembershipResponse `json:"memberships"`
+}
+
+type OrganizationResponse struct {
+	ID        string               `json:"id"`
+	OwnerID   string               `json:"ownerID"`
+	Name      string               `json:"name"`
+	Website   string               `json:"website,omitempty"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Members   []MembershipResponse `json:"members"`
+}
+
+func ToMembershipResponse(membership *domain.Membership) MembershipResponse {
+	return MembershipResponse{
+		OrganizationID: membership.OrganizationID().String(),
+		UserID:         membership.UserID().String(),
+		Email:          membership.UserEmail(),
+		Role:           string(membership.Role()),
+		CreatedAt:      membership.CreatedAt(),
+	}
+}
+
+func ToMembershipListResponse(memberships []*domain.Membership) MembershipListResponse {
+	resp := MembershipListResponse{Memberships: make([]MembershipResponse, len(memberships))}
+	for i, membership := range memberships {
+		resp.Memberships[i] = ToMembershipResponse(membership)
+	}
+	return resp
+}
+
+func ToOrganizationResponse(
+	organization *domain.Organization,
+	members []*domain.Membership,
+) OrganizationResponse {
+	resp := OrganizationResponse{
+		ID:        organization.ID().String(),
+		OwnerID:   organization.OwnerID().String(),
+		Name:      organization.Name(),
+		Website:   organization.Website(),
+		CreatedAt: organization.CreatedAt(),
+		Members:   make([]MembershipResponse, len(members)),
+	}
+	for i, membership := range members {
+		resp.Members[i] = ToMembershipResponse(membership)
+	}
+	return resp
+}
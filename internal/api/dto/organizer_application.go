@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type OrganizerApplicationRequest struct {
+	OrganizationName string `json:"organizationName" example:"Riverside Concerts"`
+	Website          string `json:"website,omitempty" example:"https://riverside.example"`
+	Description      string `json:"description,omitempty"`
+}
+
+type RejectApplicationRequest struct {
+	Note string `json:"note"`
+}
+
+type OrganizerApplicationResponse struct {
+	ID               string     `json:"id"`
+	UserID           string     `json:"userID"`
+	OrganizationName string     `json:"organizationName"`
+	Website          string     `json:"website,omitempty"`
+	Description      string     `json:"description,omitempty"`
+	Status           string     `json:"status" example:"pending"`
+	ReviewNote       string     `json:"reviewNote,omitempty"`
+	ReviewedAt       *time.Time `json:"reviewedAt,omitempty"`
+	OrganizationID   string     `json:"organizationID,omitempty"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+type OrganizerApplicationListResponse struct {
+	Applications []OrganizerApplicationResponse `json:"applications"`
+	Limit        int                            `json:"limit"`
+	Offset       int                            `json:"offset"`
+}
+
+func ToOrganizerApplicationResponse(application *domain.OrganizerApplication) OrganizerApplicationResponse {
+	resp := OrganizerApplicationResponse{
+		ID:               application.ID().String(),
+		UserID:           application.UserID().String(),
+		OrganizationName: application.OrganizationName(),
+		Website:          application.Website(),
+		Description:      application.Description(),
+		Status:           string(application.Status()),
+		ReviewNote:       application.ReviewNote(),
+		CreatedAt:        application.CreatedAt(),
+	}
+	if !application.IsPending() {
+		reviewedAt := application.ReviewedAt()
+		resp.ReviewedAt = &reviewedAt
+	}
+	if application.OrganizationID() != uuid.Nil {
+		resp.OrganizationID = application.OrganizationID().String()
+	}
+	return resp
+}
+
+func ToOrganizerApplicationListResponse(
+	applications []*domain.OrganizerApplication,
+	filter domain.ApplicationFilter,
+) OrganizerApplicationListResponse {
+	resp := OrganizerApplicationListResponse{
+		Applications: make([]OrganizerApplicationResponse, len(applications)),
+		Limit:        filter.Limit,
+		Offset:       filter.Offset,
+	}
+	for i, application := range applications {
+		resp.Applications[i] = ToOrganizerApplicationResponse(application)
+	}
+	return resp
+}
@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type CreatePresaleRequest struct {
+	Name       string    `json:"name"`
+	StartAt    time.Time `json:"startAt"`
+	EndAt      time.Time `json:"endAt"`
+	AccessCode string    `json:"accessCode"`
+}
+
+type PresaleResponse struct {
+	ID      string    `json:"id"`
+	EventID string    `json:"eventID"`
+	Name    string    `json:"name"`
+	StartAt time.Time `json:"startAt"`
+	EndAt   time.Time `json:"endAt"`
+}
+
+func ToPresaleResponse(presale *domain.Presale) PresaleResponse {
+	startAt, endAt := presale.StartAndEndAt()
+	return PresaleResponse{
+		ID:      presale.ID().String(),
+		EventID: presale.EventID().String(),
+		Name:    presale.Name(),
+		StartAt: startAt,
+		EndAt:   endAt,
+	}
+}
+
+func ToPresaleListResponse(presales []*domain.Presale) []PresaleResponse {
+	responses := make([]PresaleResponse, len(presales))
+	for i, presale := range presales {
+		responses[i] = ToPresaleResponse(presale)
+	}
+	return responses
+}
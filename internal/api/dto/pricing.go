@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type CreatePricingRuleRequest struct {
+	Trigger        string `json:"trigger"`
+	Threshold      int    `json:"threshold"`
+	AdjustmentKind string `json:"adjustmentKind"`
+	Adjustment     int64  `json:"adjustment"`
+}
+
+type PricingRuleResponse struct {
+	ID             string    `json:"id"`
+	EventID        string    `json:"eventID"`
+	Trigger        string    `json:"trigger"`
+	Threshold      int       `json:"threshold"`
+	AdjustmentKind string    `json:"adjustmentKind"`
+	Adjustment     int64     `json:"adjustment"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func ToPricingRuleResponse(rule *domain.PricingRule) PricingRuleResponse {
+	return PricingRuleResponse{
+		ID:             rule.ID().String(),
+		EventID:        rule.EventID().String(),
+		Trigger:        string(rule.Trigger()),
+		Threshold:      rule.Threshold(),
+		AdjustmentKind: string(rule.AdjustmentKind()),
+		Adjustment:     rule.Adjustment(),
+		CreatedAt:      rule.CreatedAt(),
+	}
+}
+
+func ToPricingRuleListResponse(rules []*domain.PricingRule) []PricingRuleResponse {
+	responses := make([]PricingRuleResponse, len(rules))
+	for i, rule := range rules {
+		responses[i] = ToPricingRuleResponse(rule)
+	}
+	return responses
+}
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type DataExportResponse struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status" example:"pending"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func ToDataExportResponse(export *domain.DataExport) DataExportResponse {
+	return DataExportResponse{
+		ID:        export.ID().String(),
+		Status:    string(export.Status()),
+		CreatedAt: export.CreatedAt(),
+	}
+}
@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type ChannelPreference struct {
+	Enabled   bool `json:"enabled"`
+	Reminders bool `json:"reminders"`
+	Marketing bool `json:"marketing"`
+}
+
+type NotificationPreferencesResponse struct {
+	Channels   map[string]ChannelPreference `json:"channels"`
+	WebhookURL string                       `json:"webhookUrl"`
+}
+
+type ProfileResponse struct {
+	ID            string                          `json:"id"`
+	Email         string                          `json:"email"`
+	Role          string                          `json:"role" example:"user"`
+	Status        string                          `json:"status" example:"active"`
+	DisplayName   string                          `json:"displayName"`
+	Phone         string                          `json:"phone" example:"+48123456789"`
+	Locale        string                          `json:"locale" example:"pl-PL"`
+	TimeZone      string                          `json:"timeZone" example:"Europe/Warsaw"`
+	Notifications NotificationPreferencesResponse `json:"notifications"`
+}
+
+// UpdateNotificationsRequest replaces the preferences of the channels it
+// names; the other channels keep theirs.
+type UpdateNotificationsRequest struct {
+	Channels   map[string]ChannelPreference `json:"channels,omitempty"`
+	WebhookURL *string                      `json:"webhookUrl,omitempty"`
+}
+
+// UpdateProfileRequest changes the fields it carries. An empty string clears
+// a field.
+type UpdateProfileRequest struct {
+	DisplayName   *string                     `json:"displayName,omitempty"`
+	Phone         *string                     `json:"phone,omitempty" example:"+48123456789"`
+	Locale        *string                     `json:"locale,omitempty" example:"pl-PL"`
+	TimeZone      *string                     `json:"timeZone,omitempty" example:"Europe/Warsaw"`
+	Notifications *UpdateNotificationsRequest `json:"notifications,omitempty"`
+}
+
+func (r UpdateProfileRequest) ToDomain() domain.ProfileUpdate {
+	update := domain.ProfileUpdate{
+		DisplayName: r.DisplayName,
+		Phone:       r.Phone,
+		Locale:      r.Locale,
+		TimeZone:    r.TimeZone,
+	}
+	if r.Notifications != nil {
+		update.WebhookURL = r.Notifications.WebhookURL
+		update.Channels = make(map[domain.NotificationChannel]domain.ChannelPreference, len(r.Notifications.Channels))
+		for channel, preference := range r.Notifications.Channels {
+			update.Channels[domain.NotificationChannel(channel)] = domain.ChannelPreference(preference)
+		}
+	}
+	return update
+}
+
+func ToProfileResponse(user *domain.User, profile *domain.Profile) ProfileResponse {
+	notifications := profile.Notifications()
+	channels := make(map[string]ChannelPreference, len(notifications.Channels))
+	for channel, preference := range notifications.Channels {
+		channels[string(channel)] = ChannelPreference(preference)
+	}
+	return ProfileResponse{
+		ID:          user.ID().String(),
+		Email:       user.Email(),
+		Role:        string(user.Role()),
+		Status:      string(user.Status()),
+		DisplayName: profile.DisplayName(),
+		Phone:       profile.Phone(),
+		Locale:      profile.Locale(),
+		TimeZone:    profile.TimeZone(),
+		Notifications: NotificationPreferencesResponse{
+			Channels:   channels,
+			WebhookURL: notifications.WebhookURL,
+		},
+	}
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// TwoFactorChallengeResponse answers a login that needs a second factor. The
+// challenge is completed at POST /auth/login/2fa. When enrollmentRequired is
+// set, an authenticator is enrolled first at POST /auth/login/2fa/enroll.
+type TwoFactorChallengeResponse struct {
+	ChallengeToken     string    `json:"challengeToken"`
+	ExpiresAt          time.Time `json:"expiresAt"`
+	EnrollmentRequired bool      `json:"enrollmentRequired"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challengeToken" log:"-"`
+	// Code is a code of the authenticator app or a recovery code.
+	Code string `json:"code" log:"-"`
+}
+
+// TwoFactorLoginResponse holds recovery codes when the login confirmed a new
+// authenticator. They are shown this one time.
+type TwoFactorLoginResponse struct {
+	TokenResponse
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"`
+}
+
+type TwoFactorLoginEnrollRequest struct {
+	ChallengeToken string `json:"challengeToken" log:"-"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" log:"-"`
+}
+
+// TwoFactorEnrollmentResponse is shown to the user as a QR code of
+// provisioningURI, with the secret for manual entry.
+type TwoFactorEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioningURI" example:"otpauth://totp/go-ticket:ada@example.com?secret=..."`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type TwoFactorStatusResponse struct {
+	Enabled           bool `json:"enabled"`
+	Required          bool `json:"required"`
+	RecoveryCodesLeft int  `json:"recoveryCodesLeft"`
+}
+
+type TwoFactorPolicyRequest struct {
+	RequiredRoles []string `json:"requiredRoles" example:"admin,organizer"`
+}
+
+type TwoFactorPolicyResponse struct {
+	RequiredRoles []string `json:"requiredRoles"`
+}
+
+func ToTwoFactorPolicyResponse(roles []domain.UserRole) TwoFactorPolicyResponse {
+	resp := TwoFactorPolicyResponse{RequiredRoles: make([]string, len(roles))}
+	for i, role := range roles {
+		resp.RequiredRoles[i] = string(role)
+	}
+	return resp
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mati/go-ticket/internal/waitingroom"
+)
+
+type ActivateWaitingRoomRequest struct {
+	AdmitPerSecond int `json:"admitPerSecond"`
+}
+
+type QueueTicketResponse struct {
+	Position  int64  `json:"position"`
+	PollToken string `json:"pollToken"`
+}
+
+type QueueStatusResponse struct {
+	Position       int64      `json:"position"`
+	Admitted       bool       `json:"admitted"`
+	AdmissionPass  string     `json:"admissionPass,omitempty"`
+	PassExpiresAt  *time.Time `json:"passExpiresAt,omitempty"`
+	AdmitPerSecond int        `json:"admitPerSecond"`
+}
+
+func ToQueueTicketResponse(ticket *waitingroom.Ticket) QueueTicketResponse {
+	return QueueTicketResponse{
+		Position:  ticket.Position,
+		PollToken: ticket.PollToken,
+	}
+}
+
+func ToQueueStatusResponse(status *waitingroom.Status) QueueStatusResponse {
+	resp := QueueStatusResponse{
+		Position:       status.Position,
+		Admitted:       status.Admitted,
+		AdmissionPass:  status.AdmissionPass,
+		AdmitPerSecond: status.AdmitPerSecond,
+	}
+	if status.Admitted {
+		resp.PassExpiresAt = &status.PassExpiresAt
+	}
+	return resp
+}
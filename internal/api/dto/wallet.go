@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type IssueGiftCardRequest struct {
+	Amount Money `json:"amount"`
+}
+
+// GiftCardResponse carries the code of a newly issued card. The code is not
+// stored and cannot be shown again.
+type GiftCardResponse struct {
+	ID        string    `json:"id"`
+	Code      string    `json:"code" example:"K7QD-3MZP-XA2R-9FTB"`
+	Amount    Money     `json:"amount"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type RedeemGiftCardRequest struct {
+	Code string `json:"code" example:"K7QD-3MZP-XA2R-9FTB"`
+}
+
+type WalletResponse struct {
+	Balances     []Money                     `json:"balances"`
+	Transactions []WalletTransactionResponse `json:"transactions"`
+}
+
+type WalletTransactionResponse struct {
+	ID           string    `json:"id"`
+	Kind         string    `json:"kind" example:"gift_card_redeemed"`
+	ReferenceID  string    `json:"referenceID"`
+	Amount       Money     `json:"amount"`
+	BalanceAfter Money     `json:"balanceAfter"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func ToGiftCardResponse(card *domain.GiftCard, code string) GiftCardResponse {
+	return GiftCardResponse{
+		ID:        card.ID().String(),
+		Code:      code,
+		Amount:    ToMoney(card.Amount()),
+		CreatedAt: card.CreatedAt(),
+	}
+}
+
+func ToWalletResponse(wallets []*domain.Wallet, transactions []*domain.WalletTransaction) WalletResponse {
+	resp := WalletResponse{
+		Balances:     make([]Money, len(wallets)),
+		Transactions: make([]WalletTransactionResponse, len(transactions)),
+	}
+	for i, wallet := range wallets {
+		resp.Balances[i] = ToMoney(wallet.Balance())
+	}
+	for i, transaction := range transactions {
+		resp.Transactions[i] = WalletTransactionResponse{
+			ID:           transaction.ID().String(),
+			Kind:         string(transaction.Kind()),
+			ReferenceID:  transaction.Reference().String(),
+			Amount:       ToMoney(transaction.Amount()),
+			BalanceAfter: ToMoney(transaction.BalanceAfter()),
+			CreatedAt:    transaction.CreatedAt(),
+		}
+	}
+	return resp
+}
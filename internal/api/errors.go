@@ -13,22 +13,105 @@ type errorMapping struct {
 }
 
 var errorsMap = map[error]errorMapping{
-	domain.ErrEventNotFound:          {http.StatusNotFound, "Event not found"},
-	domain.ErrEventIsFull:            {http.StatusConflict, "Event is full, no available spots"},
-	domain.ErrEventNameEmpty:         {http.StatusBadRequest, "Event name cannot be empty"},
-	domain.ErrEventPriceNegative:     {http.StatusBadRequest, "Event price must be positive"},
-	domain.ErrEventStartAfterEnd:     {http.StatusBadRequest, "Event start time must be before end time"},
-	domain.ErrEventIDNil:             {http.StatusBadRequest, "Invalid event ID"},
-	domain.ErrBookingNotFound:        {http.StatusNotFound, "Booking not found"},
-	domain.ErrBookingIDNil:           {http.StatusBadRequest, "Invalid booking ID"},
-	domain.ErrBookingEventIDInvalid:  {http.StatusBadRequest, "Invalid event ID for booking"},
-	domain.ErrBookingUserEmailEmpty:  {http.StatusBadRequest, "User email is required"},
-	domain.ErrBookingStatusInvalid:   {http.StatusBadRequest, "Invalid booking status"},
-	domain.ErrUserNotFound:           {http.StatusNotFound, "User not found"},
-	domain.ErrInvalidCredentials:     {http.StatusUnauthorized, "Invalid credentials"},
-	domain.ErrUserPasswordTooShort:   {http.StatusBadRequest, "Password is too short"},
-	domain.ErrUserEmailEmpty:         {http.StatusBadRequest, "Email is required"},
-	domain.ErrUserEmailAlreadyExists: {http.StatusConflict, "User already exists"},
+	domain.ErrEventNotFound:           {http.StatusNotFound, "Event not found"},
+	domain.ErrEventIsFull:             {http.StatusConflict, "Event is full, no available spots"},
+	domain.ErrEventNameEmpty:          {http.StatusBadRequest, "Event name cannot be empty"},
+	domain.ErrEventPriceNegative:      {http.StatusBadRequest, "Event price must be positive"},
+	domain.ErrEventStartAfterEnd:      {http.StatusBadRequest, "Event start time must be before end time"},
+	domain.ErrEventIDNil:              {http.StatusBadRequest, "Invalid event ID"},
+	domain.ErrEventShardsInvalid:      {http.StatusBadRequest, "Inventory shard count is out of range"},
+	domain.ErrEventNotOnSale:          {http.StatusForbidden, "Tickets for this event are not on sale yet"},
+	domain.ErrEventSalesClosed:        {http.StatusGone, "Ticket sales for this event have closed"},
+	domain.ErrSalesWindowInvalid:      {http.StatusBadRequest, "Sales window start must be before its end"},
+	domain.ErrVenueCountryInvalid:     {http.StatusBadRequest, "Venue country must be a two-letter ISO 3166 code"},
+	domain.ErrEventTimeZoneInvalid:    {http.StatusBadRequest, "Time zone must be an IANA time zone name"},
+	domain.ErrPresaleNotFound:         {http.StatusNotFound, "Presale not found"},
+	domain.ErrPresaleNameEmpty:        {http.StatusBadRequest, "Presale name cannot be empty"},
+	domain.ErrAccessCodeEmpty:         {http.StatusBadRequest, "Access code is required"},
+	domain.ErrAccessCodeInvalid:       {http.StatusForbidden, "Access code is invalid"},
+	domain.ErrBookingNotFound:         {http.StatusNotFound, "Booking not found"},
+	domain.ErrBookingIDNil:            {http.StatusBadRequest, "Invalid booking ID"},
+	domain.ErrBookingEventIDInvalid:   {http.StatusBadRequest, "Invalid event ID for booking"},
+	domain.ErrBookingUserEmailEmpty:   {http.StatusBadRequest, "User email is required"},
+	domain.ErrBookingStatusInvalid:    {http.StatusBadRequest, "Invalid booking status"},
+	domain.ErrBookingPriceNegative:    {http.StatusBadRequest, "Booking price cannot be negative"},
+	domain.ErrBookingCancelled:        {http.StatusConflict, "Cancelled bookings cannot be confirmed"},
+	domain.ErrBookingNotRefundable:    {http.StatusConflict, "Only confirmed bookings can be refunded"},
+	domain.ErrReceiptNotFound:         {http.StatusNotFound, "Receipt not found or not generated yet"},
+	domain.ErrWalletInsufficientFunds: {http.StatusConflict, "Insufficient wallet balance"},
+	domain.ErrWalletAmountInvalid:     {http.StatusBadRequest, "Amount must be positive"},
+	domain.ErrGiftCardNotFound:        {http.StatusNotFound, "Gift card not found"},
+	domain.ErrGiftCardRedeemed:        {http.StatusConflict, "Gift card has already been redeemed"},
+	domain.ErrOrderNotFound:           {http.StatusNotFound, "Order not found"},
+	domain.ErrOrderSeatsInvalid:       {http.StatusBadRequest, "An order must hold 1 to 100 seats"},
+	domain.ErrOrderDueDateInvalid:     {http.StatusBadRequest, "Invoice must be due before the event starts"},
+	domain.ErrOrderNotAwaitingPayment: {http.StatusConflict, "Order is not awaiting payment"},
+	domain.ErrPricingRuleNotFound:     {http.StatusNotFound, "Pricing rule not found"},
+	domain.ErrPricingRuleInvalid:      {http.StatusBadRequest, "Invalid pricing rule trigger, threshold or adjustment"},
+	domain.ErrFeeScheduleNotFound:     {http.StatusNotFound, "Fee schedule not found"},
+	domain.ErrFeeScheduleInvalid:      {http.StatusBadRequest, "Fee percentage must be 0-10000 bps, amounts non-negative"},
+	domain.ErrCurrencyInvalid:         {http.StatusBadRequest, "Currency must be a three-letter ISO 4217 code"},
+	domain.ErrCurrencyMismatch:        {http.StatusBadRequest, "Amounts must share the same currency"},
+	domain.ErrExchangeRateMissing:     {http.StatusBadRequest, "Currency conversion is not supported"},
+	domain.ErrUserNotFound:            {http.StatusNotFound, "User not found"},
+	domain.ErrInvalidCredentials:      {http.StatusUnauthorized, "Invalid credentials"},
+	domain.ErrResetTokenInvalid:       {http.StatusBadRequest, "Reset link is invalid or expired"},
+	domain.ErrVerifyTokenInvalid:      {http.StatusBadRequest, "Verification link is invalid or expired"},
+	domain.ErrUserUnverified:          {http.StatusForbidden, "Verify your email address first"},
+	domain.ErrUserAlreadyVerified:     {http.StatusConflict, "Email address is already verified"},
+	domain.ErrUserSuspended:           {http.StatusForbidden, "Account is suspended"},
+	domain.ErrUserSelfModification:    {http.StatusForbidden, "Admins cannot change their own account"},
+	domain.ErrUserErased:              {http.StatusConflict, "Account was erased"},
+	domain.ErrUserRoleInvalid:         {http.StatusBadRequest, "Role must be user, organizer or admin"},
+	domain.ErrOrganizationNameEmpty:   {http.StatusBadRequest, "Organization name is required"},
+	domain.ErrOrganizationNotFound:    {http.StatusNotFound, "Organization not found"},
+	domain.ErrApplicationNotFound:     {http.StatusNotFound, "Organizer application not found"},
+	domain.ErrApplicationPending:      {http.StatusConflict, "An organizer application is already pending"},
+	domain.ErrApplicationNotPending:   {http.StatusConflict, "Organizer application was already reviewed"},
+	domain.ErrUserAlreadyOrganizer:    {http.StatusConflict, "Account can already organize events"},
+	domain.ErrOrganizationRoleInvalid: {http.StatusBadRequest, "Role must be owner, manager, box_office or scanner"},
+	domain.ErrMembershipNotFound:      {http.StatusNotFound, "Member not found"},
+	domain.ErrMembershipExists:        {http.StatusConflict, "User is already a member"},
+	domain.ErrOrganizationForbidden:   {http.StatusForbidden, "Not allowed in this organization"},
+	domain.ErrOrganizationRequired:    {http.StatusBadRequest, "organizationID is required"},
+	domain.ErrMemberIsOwner:           {http.StatusConflict, "The owner cannot be removed from the organization"},
+	domain.ErrRefreshTokenInvalid:     {http.StatusUnauthorized, "Refresh token is invalid or expired"},
+	domain.ErrRefreshTokenReused:      {http.StatusUnauthorized, "Refresh token was already used, session revoked"},
+	domain.ErrOIDCProviderUnknown:     {http.StatusNotFound, "Unknown identity provider"},
+	domain.ErrOIDCStateInvalid:        {http.StatusBadRequest, "Sign-in expired, please try again"},
+	domain.ErrOIDCLoginFailed:         {http.StatusUnauthorized, "Sign-in with the identity provider failed"},
+	domain.ErrOIDCEmailUnverified:     {http.StatusForbidden, "The identity provider did not verify your email address"},
+	domain.ErrIdentityNotFound:        {http.StatusNotFound, "Identity not found"},
+	domain.ErrTwoFactorEnabled:        {http.StatusConflict, "Two-factor authentication is already enabled"},
+	domain.ErrTwoFactorNotEnrolled:    {http.StatusConflict, "Two-factor authentication is not set up"},
+	domain.ErrTwoFactorCodeInvalid:    {http.StatusUnauthorized, "Invalid two-factor code"},
+	domain.ErrTwoFactorRequired:       {http.StatusForbidden, "Two-factor authentication is required for your role"},
+	domain.ErrTwoFactorRoleInvalid:    {http.StatusBadRequest, "2FA can only be required for organizers and admins"},
+	domain.ErrLoginChallengeInvalid:   {http.StatusUnauthorized, "Sign-in expired, please log in again"},
+	domain.ErrAccountLocked:           {http.StatusTooManyRequests, "Too many failed sign-ins, try again later"},
+	domain.ErrAPIKeyNotFound:          {http.StatusNotFound, "API key not found"},
+	domain.ErrAPIKeyNameEmpty:         {http.StatusBadRequest, "API key name is required"},
+	domain.ErrAPIKeyScopeInvalid:      {http.StatusBadRequest, "Unknown API key scope, or none given"},
+	domain.ErrAPIKeyExpiryInvalid:     {http.StatusBadRequest, "expiresAt must be in the future"},
+	domain.ErrAPIKeyInvalid:           {http.StatusUnauthorized, "Invalid API key"},
+	domain.ErrPermissionDenied:        {http.StatusForbidden, "Forbidden"},
+	domain.ErrOwnerCannotErase:        {http.StatusConflict, "Organization owners cannot erase their account"},
+	domain.ErrDisplayNameTooLong:      {http.StatusBadRequest, "Display name must be at most 100 characters"},
+	domain.ErrPhoneInvalid:            {http.StatusBadRequest, "Phone must be in E.164 format, e.g. +48123456789"},
+	domain.ErrLocaleInvalid:           {http.StatusBadRequest, "Locale must look like en or pt-BR"},
+	domain.ErrTimeZoneInvalid:         {http.StatusBadRequest, "Time zone must be an IANA time zone, e.g. Europe/Warsaw"},
+	domain.ErrWebhookURLInvalid:       {http.StatusBadRequest, "webhookUrl must be an https URL"},
+	domain.ErrChannelInvalid:          {http.StatusBadRequest, "Channels must be email, sms or webhook"},
+	domain.ErrPhoneRequired:           {http.StatusBadRequest, "SMS notifications need a phone number"},
+	domain.ErrWebhookURLRequired:      {http.StatusBadRequest, "Webhook notifications need a webhookUrl"},
+	domain.ErrUserPasswordTooShort:    {http.StatusBadRequest, "Password is too short"},
+	domain.ErrUserEmailEmpty:          {http.StatusBadRequest, "Email is required"},
+	domain.ErrUserEmailAlreadyExists:  {http.StatusConflict, "User already exists"},
+	domain.ErrAdmissionPassRequired:   {http.StatusForbidden, "Waiting room is active, admission pass required"},
+	domain.ErrAdmissionPassInvalid:    {http.StatusForbidden, "Admission pass is invalid or expired"},
+	domain.ErrQueueTicketInvalid:      {http.StatusNotFound, "Queue ticket not found"},
+	domain.ErrWaitingRoomInactive:     {http.StatusConflict, "Waiting room is not active for this event"},
+	domain.ErrWaitingRoomRateInvalid:  {http.StatusBadRequest, "Admission rate must be positive"},
 }
 
 // MapDomainError maps domain errors to HTTP status codes and user-friendly messages.
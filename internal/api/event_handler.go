@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
@@ -13,31 +14,42 @@ import (
 )
 
 type HTTPHandler struct {
-	eventRepository   domain.EventRepository
-	bookingRepository domain.BookingRepository
-	bookingService    services.CreateBookingService
+	eventRepository     domain.EventRepository
+	bookingRepository   domain.BookingRepository
+	organizationService services.OrganizationServiceInterface
+	bookingService      services.CreateBookingService
+	pricingService      services.PricingServiceInterface
+	currencyService     services.CurrencyServiceInterface
 }
 
 func NewHTTPHandler(
 	eventRepository domain.EventRepository,
 	bookingRepository domain.BookingRepository,
+	organizationService services.OrganizationServiceInterface,
 	bookingService services.CreateBookingService,
+	pricingService services.PricingServiceInterface,
+	currencyService services.CurrencyServiceInterface,
 ) *HTTPHandler {
 	return &HTTPHandler{
-		eventRepository:   eventRepository,
-		bookingRepository: bookingRepository,
-		bookingService:    bookingService,
+		eventRepository:     eventRepository,
+		bookingRepository:   bookingRepository,
+		organizationService: organizationService,
+		bookingService:      bookingService,
+		pricingService:      pricingService,
+		currencyService:     currencyService,
 	}
 }
 
 // @Summary Create a new event
-// @Description Create a new event
+// @Description Create a new event for an organization you manage events for.
+// @Description organizationID may be left out by members of a single organization.
 // @Tags event
 // @Accept json
 // @Produce json
 // @Param body body dto.CreateEventRequest true "Event data"
 // @Success 201 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /events [post]
 func (h *HTTPHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
@@ -48,15 +60,68 @@ func (h *HTTPHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	var organizationID uuid.UUID
+	if req.OrganizationID != "" {
+		var err error
+		if organizationID, err = uuid.Parse(req.OrganizationID); err != nil {
+			ResponseError(w, http.StatusBadRequest, "invalid organizationID")
+			return
+		}
+	}
+
+	price, err := req.Price.ToDomain()
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
 	id := uuid.New()
-	event, err := domain.NewEvent(id, req.Name, req.Price, req.StartAt, req.EndAt, req.Capacity)
+	event, err := domain.NewEvent(id, req.Name, price, req.StartAt, req.EndAt, req.Capacity)
+
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
 
+	err = event.ScheduleSales(dto.TimeOrZero(req.SalesStartAt), dto.TimeOrZero(req.SalesEndAt))
 	if err != nil {
 		code, message := MapDomainError(err)
 		ResponseError(w, code, message)
 		return
 	}
 
+	if err := event.LocateVenue(req.VenueCountry); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	if err := event.SetTimeZone(req.TimeZone); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	if actor, ok := actorFromRequest(r); ok {
+		organization, err := h.organizationService.EventOrganization(r.Context(), actor, organizationID)
+		if err != nil {
+			code, message := MapDomainError(err)
+			ResponseError(w, code, message)
+			return
+		}
+		// The owner of the organization is paid for its events, whoever of
+		// its members creates them. Admins, and organizers without an
+		// organization, create events of their own.
+		if organization != nil {
+			event.AssignOrganizer(organization.OwnerID())
+			event.AssignOrganization(organization.ID())
+		} else {
+			event.AssignOrganizer(actor.ID)
+		}
+	}
+
 	err = h.eventRepository.CreateEvent(r.Context(), event)
 	if err != nil {
 		slog.Error("Failed to create event", "error", err)
@@ -77,6 +142,7 @@ func (h *HTTPHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 // @Param body body dto.UpdateEventRequest true "Event data"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /events/{id} [put]
 func (h *HTTPHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
@@ -108,6 +174,18 @@ func (h *HTTPHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	actor, ok := actorFromRequest(r)
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	err = h.organizationService.AuthorizeEvent(r.Context(), actor, event, domain.OrganizationActionManageEvents)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
 	err = event.UpdateName(req.Name)
 	if err != nil {
 		code, message := MapDomainError(err)
@@ -122,6 +200,25 @@ func (h *HTTPHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = event.ScheduleSales(dto.TimeOrZero(req.SalesStartAt), dto.TimeOrZero(req.SalesEndAt))
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	if err := event.LocateVenue(req.VenueCountry); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	if err := event.SetTimeZone(req.TimeZone); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
 	err = h.eventRepository.UpdateEvent(r.Context(), event)
 	if err != nil {
 		slog.Error("Failed to update event", "error", err)
@@ -173,6 +270,7 @@ func (h *HTTPHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Event ID"
+// @Param currency query string false "ISO 4217 code to show the current price in"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
@@ -190,6 +288,13 @@ func (h *HTTPHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	displayCurrency, err := parseDisplayCurrency(r)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
 	event, err := h.eventRepository.GetEvent(r.Context(), parsedId)
 	if err != nil {
 		slog.Error("Failed to get event", "error", err)
@@ -198,7 +303,13 @@ func (h *HTTPHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := dto.ToEventResponse(event)
+	resp, err := h.toPricedEventResponse(r.Context(), event, displayCurrency)
+	if err != nil {
+		slog.Error("Failed to quote event price", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(resp)
@@ -215,11 +326,19 @@ func (h *HTTPHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 // @Tags event
 // @Accept json
 // @Produce json
+// @Param currency query string false "ISO 4217 code to show current prices in"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /events [get]
 func (h *HTTPHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	displayCurrency, err := parseDisplayCurrency(r)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
 	events, err := h.eventRepository.ListEvents(r.Context())
 	if err != nil {
 		slog.Error("Failed to list events", "error", err)
@@ -228,7 +347,16 @@ func (h *HTTPHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	resp := dto.ToEventListResponse(events)
+	resp := make([]dto.EventResponse, len(events))
+	for i, event := range events {
+		resp[i], err = h.toPricedEventResponse(r.Context(), event, displayCurrency)
+		if err != nil {
+			slog.Error("Failed to quote event price", "error", err)
+			code, message := MapDomainError(err)
+			ResponseError(w, code, message)
+			return
+		}
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(resp)
@@ -241,7 +369,7 @@ func (h *HTTPHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary Create a booking
-// @Description Create a booking
+// @Description Create a booking. With useWallet, the wallet balance pays as much of the total as it covers.
 // @Tags booking
 // @Accept json
 // @Produce json
@@ -249,6 +377,8 @@ func (h *HTTPHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 // @Param body body dto.CreateBookingRequest true "Booking data"
 // @Success 201 {object} map[string]string
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 410 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /events/{event_id}/bookings [post]
 func (h *HTTPHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
@@ -283,7 +413,15 @@ func (h *HTTPHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.bookingService.CreateBooking(r.Context(), booking)
+	opts := services.CreateBookingOptions{
+		AccessCode:     req.AccessCode,
+		CustomerID:     user.ID,
+		OrganizationID: user.OrganizationID,
+	}
+	if req.UseWallet {
+		opts.WalletUserID = user.ID
+	}
+	err = h.bookingService.CreateBooking(r.Context(), booking, opts)
 	if err != nil {
 		code, message := MapDomainError(err)
 		ResponseError(w, code, message)
@@ -292,3 +430,37 @@ func (h *HTTPHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 
 	ResponseCreated(w, dto.ToBookingResponse(booking))
 }
+
+// toPricedEventResponse fills in the quoted price and, when a display
+// currency was requested, its converted equivalent.
+func (h *HTTPHandler) toPricedEventResponse(
+	ctx context.Context,
+	event *domain.Event,
+	displayCurrency domain.Currency,
+) (dto.EventResponse, error) {
+	resp := dto.ToEventResponse(event)
+	currentPrice, err := h.pricingService.CurrentPrice(ctx, event)
+	if err != nil {
+		return dto.EventResponse{}, err
+	}
+	resp.CurrentPrice = dto.ToMoney(currentPrice)
+
+	if displayCurrency == "" {
+		return resp, nil
+	}
+	displayPrice, err := h.currencyService.Convert(ctx, currentPrice, displayCurrency)
+	if err != nil {
+		return dto.EventResponse{}, err
+	}
+	display := dto.ToMoney(displayPrice)
+	resp.DisplayPrice = &display
+	return resp, nil
+}
+
+func parseDisplayCurrency(r *http.Request) (domain.Currency, error) {
+	code := r.URL.Query().Get("currency")
+	if code == "" {
+		return "", nil
+	}
+	return domain.ParseCurrency(code)
+}
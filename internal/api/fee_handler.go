@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type FeeHandler struct {
+	userRepository domain.UserRepository
+	feeRepository  domain.FeeRepository
+}
+
+func NewFeeHandler(userRepository domain.UserRepository, feeRepository domain.FeeRepository) *FeeHandler {
+	return &FeeHandler{
+		userRepository: userRepository,
+		feeRepository:  feeRepository,
+	}
+}
+
+// @Summary Get an organizer's service fee
+// @Description Get the service fee charged on every ticket of the organizer's events
+// @Tags fee
+// @Produce json
+// @Param id path string true "Organizer user ID"
+// @Success 200 {object} dto.FeeScheduleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizers/{id}/fees [get]
+// @Security BearerAuth
+func (h *FeeHandler) GetFees(w http.ResponseWriter, r *http.Request) {
+	organizerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	schedule, err := h.feeRepository.GetFeeSchedule(r.Context(), organizerID)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToFeeScheduleResponse(organizerID, schedule))
+}
+
+// @Summary Set an organizer's service fee
+// @Description Set the percentage (in basis points) and fixed part of the organizer's service fee
+// @Tags fee
+// @Accept json
+// @Produce json
+// @Param id path string true "Organizer user ID"
+// @Param body body dto.FeeScheduleRequest true "Fee schedule"
+// @Success 200 {object} dto.FeeScheduleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizers/{id}/fees [put]
+// @Security BearerAuth
+func (h *FeeHandler) SetFees(w http.ResponseWriter, r *http.Request) {
+	organizerID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req dto.FeeScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	schedule, err := domain.NewFeeSchedule(req.PercentBasisPoints, req.Fixed)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	if _, err := h.userRepository.GetUserByID(r.Context(), organizerID); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	if err := h.feeRepository.SaveFeeSchedule(r.Context(), organizerID, schedule); err != nil {
+		slog.Error("Failed to save fee schedule", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToFeeScheduleResponse(organizerID, schedule))
+}
@@ -13,18 +13,23 @@ import (
 	"github.com/mati/go-ticket/internal/api/dto"
 	"github.com/mati/go-ticket/internal/api/middleware"
 	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
 	"github.com/stretchr/testify/assert"
 )
 
 const validEmail = "user@example.com"
 
 type MockCreateBookingService struct {
-	OnCreateBooking func(ctx context.Context, booking *domain.Booking) error
+	OnCreateBooking func(ctx context.Context, booking *domain.Booking, opts services.CreateBookingOptions) error
 }
 
-func (m *MockCreateBookingService) CreateBooking(ctx context.Context, booking *domain.Booking) error {
+func (m *MockCreateBookingService) CreateBooking(
+	ctx context.Context,
+	booking *domain.Booking,
+	opts services.CreateBookingOptions,
+) error {
 	if m.OnCreateBooking != nil {
-		return m.OnCreateBooking(ctx, booking)
+		return m.OnCreateBooking(ctx, booking, opts)
 	}
 	return nil
 }
@@ -33,7 +38,7 @@ func TestCreateBooking_Success(t *testing.T) {
 	validEventID := uuid.New()
 
 	mockCreateBookingService := &MockCreateBookingService{
-		OnCreateBooking: func(ctx context.Context, booking *domain.Booking) error {
+		OnCreateBooking: func(ctx context.Context, booking *domain.Booking, _ services.CreateBookingOptions) error {
 			if booking.EventID() != validEventID {
 				assert.Equal(t, validEventID, booking.EventID())
 			}
@@ -50,7 +55,7 @@ func TestCreateBooking_Success(t *testing.T) {
 		},
 	}
 
-	handler := NewHTTPHandler(nil, nil, mockCreateBookingService)
+	handler := NewHTTPHandler(nil, nil, nil, mockCreateBookingService, nil, nil)
 
 	reqBody := dto.CreateBookingRequest{}
 
@@ -74,12 +79,12 @@ func TestCreate_Booking_EventFull(t *testing.T) {
 	validEventID := uuid.New()
 
 	mockCreateBookingService := &MockCreateBookingService{
-		OnCreateBooking: func(ctx context.Context, booking *domain.Booking) error {
+		OnCreateBooking: func(ctx context.Context, booking *domain.Booking, _ services.CreateBookingOptions) error {
 			return domain.ErrEventIsFull
 		},
 	}
 
-	handler := NewHTTPHandler(nil, nil, mockCreateBookingService)
+	handler := NewHTTPHandler(nil, nil, nil, mockCreateBookingService, nil, nil)
 
 	reqBody := dto.CreateBookingRequest{}
 
@@ -103,12 +108,12 @@ func TestCreateBooking_EventNotFound(t *testing.T) {
 	validEventID := uuid.New()
 
 	mockCreateBookingService := &MockCreateBookingService{
-		OnCreateBooking: func(ctx context.Context, booking *domain.Booking) error {
+		OnCreateBooking: func(ctx context.Context, booking *domain.Booking, _ services.CreateBookingOptions) error {
 			return domain.ErrEventNotFound
 		},
 	}
 
-	handler := NewHTTPHandler(nil, nil, mockCreateBookingService)
+	handler := NewHTTPHandler(nil, nil, nil, mockCreateBookingService, nil, nil)
 
 	reqBody := dto.CreateBookingRequest{}
 
@@ -127,3 +132,33 @@ func TestCreateBooking_EventNotFound(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, recorder.Code)
 }
+
+func TestCreateBooking_PresaleAccessCode(t *testing.T) {
+	validEventID := uuid.New()
+
+	mockCreateBookingService := &MockCreateBookingService{
+		OnCreateBooking: func(ctx context.Context, booking *domain.Booking, opts services.CreateBookingOptions) error {
+			if opts.AccessCode != "FANS" {
+				return domain.ErrEventNotOnSale
+			}
+			return nil
+		},
+	}
+
+	handler := NewHTTPHandler(nil, nil, nil, mockCreateBookingService, nil, nil)
+
+	for accessCode, wantCode := range map[string]int{"": http.StatusForbidden, "FANS": http.StatusCreated} {
+		body, err := json.Marshal(dto.CreateBookingRequest{AccessCode: accessCode})
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest("POST", fmt.Sprintf("/events/%s/bookings", validEventID), bytes.NewReader(body))
+		req.SetPathValue("event_id", validEventID.String())
+		req = req.WithContext(middleware.WithTestUser(req.Context(), validEmail))
+
+		recorder := httptest.NewRecorder()
+
+		handler.CreateBooking(recorder, req)
+
+		assert.Equal(t, wantCode, recorder.Code, "access code %q", accessCode)
+	}
+}
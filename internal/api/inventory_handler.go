@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type InventoryHandler struct {
+	service services.InventoryServiceInterface
+}
+
+func NewInventoryHandler(service services.InventoryServiceInterface) *InventoryHandler {
+	return &InventoryHandler{service: service}
+}
+
+// @Summary Configure inventory sharding
+// @Description Split the event's remaining spots across N counter rows to reduce lock contention (0 disables sharding)
+// @Tags event
+// @Accept json
+// @Param id path string true "Event ID"
+// @Param body body dto.ConfigureInventoryRequest true "Shard count"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/{id}/inventory [put]
+// @Security BearerAuth
+func (h *InventoryHandler) ConfigureShards(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req dto.ConfigureInventoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.service.ConfigureShards(r.Context(), eventID, req.Shards); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/mati/go-ticket/internal/auth"
+)
+
+// jwksMaxAge is how long verifiers may cache the key set, in seconds. It is
+// well below auth.KeyPublishLead, so new keys are known before they sign.
+const jwksMaxAge = "900"
+
+type JWKSHandler struct {
+	keyring *auth.Keyring
+}
+
+func NewJWKSHandler(keyring *auth.Keyring) *JWKSHandler {
+	return &JWKSHandler{keyring: keyring}
+}
+
+// @Summary Get the token verification keys
+// @Description JSON Web Key Set with the public keys access tokens are signed with, matched by the "kid"
+// @Description header. Empty when tokens are signed with HS256.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} auth.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age="+jwksMaxAge)
+	ResponseOK(w, h.keyring.JWKS())
+}
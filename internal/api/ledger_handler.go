@@ -0,0 +1,45 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type LedgerHandler struct {
+	ledgerService services.LedgerServiceInterface
+}
+
+func NewLedgerHandler(ledgerService services.LedgerServiceInterface) *LedgerHandler {
+	return &LedgerHandler{ledgerService: ledgerService}
+}
+
+// @Summary Get my balance
+// @Description Get what the platform owes the organizer per currency and the latest payouts
+// @Tags ledger
+// @Produce json
+// @Success 200 {object} dto.OrganizerBalanceResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizers/me/balance [get]
+// @Security BearerAuth
+func (h *LedgerHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	balance, err := h.ledgerService.OrganizerBalance(r.Context(), user.ID)
+	if err != nil {
+		slog.Error("Failed to get organizer balance", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToOrganizerBalanceResponse(balance.Balances, balance.Settlements))
+}
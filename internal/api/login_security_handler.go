@@ -0,0 +1,63 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type LoginSecurityHandler struct {
+	loginSecurityService services.LoginSecurityServiceInterface
+}
+
+func NewLoginSecurityHandler(loginSecurityService services.LoginSecurityServiceInterface) *LoginSecurityHandler {
+	return &LoginSecurityHandler{loginSecurityService: loginSecurityService}
+}
+
+// @Summary List my sign-ins
+// @Description List the sign-in attempts on the account of the user, newest first, with their IP address,
+// @Description user agent and outcome. Attempts are kept for 90 days.
+// @Tags security
+// @Produce json
+// @Param limit query int false "Page size, 50 by default and at most 100"
+// @Param offset query int false "Number of sign-ins to skip"
+// @Success 200 {object} dto.LoginHistoryResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/security/logins [get]
+// @Security BearerAuth
+func (h *LoginSecurityHandler) ListLogins(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	query := r.URL.Query()
+	var page domain.LoginHistoryPage
+	var err error
+	if page.Limit, err = parseIntParam(query.Get("limit")); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	if page.Offset, err = parseIntParam(query.Get("offset")); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	page = page.WithDefaults()
+	records, err := h.loginSecurityService.ListLogins(r.Context(), user.ID, page)
+	if err != nil {
+		slog.Error("Failed to list sign-ins", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToLoginHistoryResponse(records, page))
+}
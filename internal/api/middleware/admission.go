@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+const AdmissionPassHeader = "X-Admission-Pass"
+
+// admissionRetryAfter is how many seconds clients are told to wait when the
+// waiting room cannot be checked.
+const admissionRetryAfter = "5"
+
+type AdmissionChecker interface {
+	RequiresPass(ctx context.Context, eventID uuid.UUID) (bool, error)
+	// ConsumePass checks the pass and uses it up.
+	ConsumePass(ctx context.Context, pass string, eventID, userID uuid.UUID) error
+	// RestorePass gives back a pass used up by a request that failed.
+	RestorePass(ctx context.Context, pass string, eventID, userID uuid.UUID) error
+}
+
+// RequireAdmissionPass rejects requests for events with an active waiting
+// room unless they carry a valid admission pass. The event is read from the
+// "event_id" path value. A pass lets one request through; it is given back
+// when the request fails, so the user can correct it and try again. When the
+// waiting room cannot be checked, requests are turned away rather than let
+// through without a pass.
+func RequireAdmissionPass(checker AdmissionChecker, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		eventID, err := uuid.Parse(r.PathValue("event_id"))
+		if err != nil {
+			next(w, r)
+			return
+		}
+
+		required, err := checker.RequiresPass(r.Context(), eventID)
+		if err != nil {
+			slog.Error("Waiting room check failed", "error", err)
+			w.Header().Set("Retry-After", admissionRetryAfter)
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if !required {
+			next(w, r)
+			return
+		}
+
+		user, ok := GetUserDataFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		pass := r.Header.Get(AdmissionPassHeader)
+		if pass == "" {
+			http.Error(w, domain.ErrAdmissionPassRequired.Error(), http.StatusForbidden)
+			return
+		}
+
+		if err := checker.ConsumePass(r.Context(), pass, eventID, user.ID); err != nil {
+			if !errors.Is(err, domain.ErrAdmissionPassInvalid) {
+				slog.Error("Admission pass verification failed", "error", err)
+			}
+			http.Error(w, domain.ErrAdmissionPassInvalid.Error(), http.StatusForbidden)
+			return
+		}
+
+		record := &ResponseRecord{ResponseWriter: w, Status: http.StatusOK}
+		next(record, r)
+		if record.Status >= http.StatusBadRequest {
+			if err := checker.RestorePass(context.WithoutCancel(r.Context()), pass, eventID, user.ID); err != nil {
+				slog.Error("Failed to restore admission pass", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+type unavailableChecker struct{}
+
+func (unavailableChecker) RequiresPass(context.Context, uuid.UUID) (bool, error) {
+	return false, errors.New("redis: connection refused")
+}
+
+func (unavailableChecker) ConsumePass(context.Context, string, uuid.UUID, uuid.UUID) error {
+	return errors.New("redis: connection refused")
+}
+
+func (unavailableChecker) RestorePass(context.Context, string, uuid.UUID, uuid.UUID) error {
+	return errors.New("redis: connection refused")
+}
+
+func TestRequireAdmissionPass_FailsClosedWhenWaitingRoomIsUnavailable(t *testing.T) {
+	called := false
+	handler := RequireAdmissionPass(unavailableChecker{}, func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/events/"+uuid.NewString()+"/bookings", nil)
+	req.SetPathValue("event_id", uuid.NewString())
+	recorder := httptest.NewRecorder()
+	handler(recorder, req)
+
+	if called {
+		t.Error("booking handler ran without an admission check")
+	}
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusServiceUnavailable)
+	}
+	if got := recorder.Header().Get("Retry-After"); got != admissionRetryAfter {
+		t.Errorf("Retry-After = %q, want %q", got, admissionRetryAfter)
+	}
+}
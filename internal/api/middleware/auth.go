@@ -2,13 +2,15 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
-	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/authz"
 	"github.com/mati/go-ticket/internal/domain"
 )
 
@@ -16,13 +18,55 @@ type userData struct {
 	ID    uuid.UUID
 	Role  domain.UserRole
 	Email string
+	// TokenID and TokenExpiresAt identify the access token of the request,
+	// so it can be revoked on logout.
+	TokenID        uuid.UUID
+	TokenExpiresAt time.Time
+	// APIKeyID and OrganizationID are set for requests made with an API key,
+	// which act as its creator within the key's organization only.
+	APIKeyID       uuid.UUID
+	OrganizationID uuid.UUID
+}
+
+// Subject returns the user as the policy sees them.
+func (u userData) Subject() authz.Subject {
+	return authz.Subject{UserID: u.ID, Role: u.Role, APIKeyID: u.APIKeyID}
+}
+
+// RevocationChecker reports whether an access token was revoked before it
+// expired, and whether its user was suspended.
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, tokenID uuid.UUID) (bool, error)
+	IsUserSuspended(ctx context.Context, userID uuid.UUID) (bool, error)
+}
+
+// UserReader reads users from the database, where suspensions are recorded
+// before they are flagged for the RevocationChecker.
+type UserReader interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
+}
+
+// APIKeyAuthenticator returns the API key a request was made with and the
+// user it acts as.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, rawKey string) (*domain.APIKey, *domain.User, error)
 }
 
 type contextKey string
 
 const userContextKey contextKey = "user"
 
-func AuthMiddleware(jwtService *auth.JWTService, next http.HandlerFunc) http.HandlerFunc {
+// AuthMiddleware authenticates requests by their bearer token and rejects
+// revoked tokens and suspended users. Token revocation lookups fail open so a
+// Redis outage does not lock every user out; the short access token TTL
+// bounds the exposure. Suspension lookups fall back to the user in the
+// database instead, and fail closed when that cannot be read either.
+func AuthMiddleware(
+	jwtService *auth.JWTService,
+	revocations RevocationChecker,
+	users UserReader,
+	next http.HandlerFunc,
+) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
@@ -43,6 +87,10 @@ func AuthMiddleware(jwtService *auth.JWTService, next http.HandlerFunc) http.Han
 		}
 
 		claims, err := jwtService.VerifyToken(bearerToken)
+		var tokenID uuid.UUID
+		if err == nil {
+			tokenID, err = claims.TokenID()
+		}
 		if err != nil {
 			slog.Warn( //nolint:gosec // G706: slog uses structured fields
 				"Authentication failed",
@@ -55,17 +103,106 @@ func AuthMiddleware(jwtService *auth.JWTService, next http.HandlerFunc) http.Han
 			return
 		}
 
+		revoked, err := revocations.IsRevoked(r.Context(), tokenID)
+		if err != nil {
+			slog.Error("Token revocation check failed", "error", err)
+		}
+		if revoked {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		suspended, err := revocations.IsUserSuspended(r.Context(), claims.UserID)
+		if err != nil {
+			slog.Error("User suspension check failed", "error", err)
+			suspended, err = isSuspendedInDatabase(r.Context(), users, claims.UserID)
+		}
+		if err != nil {
+			slog.Error("User suspension fallback failed", "error", err)
+			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if suspended {
+			http.Error(w, "Account suspended", http.StatusForbidden)
+			return
+		}
+
 		var user = userData{
-			ID:    claims.UserID,
-			Role:  claims.Role,
-			Email: claims.Email,
+			ID:             claims.UserID,
+			Role:           claims.Role,
+			Email:          claims.Email,
+			TokenID:        tokenID,
+			TokenExpiresAt: claims.ExpiresAt.Time,
 		}
 		ctx := context.WithValue(r.Context(), userContextKey, user)
 		next(w, r.WithContext(ctx))
 	}
 }
 
-func RequireRole(allowedRoles []domain.UserRole, next http.HandlerFunc) http.HandlerFunc {
+func isSuspendedInDatabase(ctx context.Context, users UserReader, userID uuid.UUID) (bool, error) {
+	user, err := users.GetUserByID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return user.IsSuspended(), nil
+}
+
+// APIKeyMiddleware authenticates requests with an "Authorization: ApiKey"
+// header and lets them through when the key has the scope. They carry the
+// same user data as a bearer token of the key's creator, plus the key and
+// its organization. Any other request is passed to bearer.
+func APIKeyMiddleware(
+	keys APIKeyAuthenticator,
+	scope domain.APIKeyScope,
+	next http.HandlerFunc,
+	bearer http.HandlerFunc,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rawKey, ok := strings.CutPrefix(r.Header.Get("Authorization"), "ApiKey ")
+		if !ok {
+			bearer(w, r)
+			return
+		}
+
+		key, user, err := keys.AuthenticateAPIKey(r.Context(), rawKey)
+		switch {
+		case errors.Is(err, domain.ErrUserSuspended):
+			http.Error(w, "Account suspended", http.StatusForbidden)
+			return
+		case errors.Is(err, domain.ErrAPIKeyInvalid):
+			slog.Warn( //nolint:gosec // G706: slog uses structured fields
+				"API key authentication failed",
+				"ip", r.RemoteAddr,
+				"path", r.URL.Path,
+				"method", r.Method,
+			)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		case err != nil:
+			slog.Error("API key authentication failed", "error", err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+		if !key.Allows(scope) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), userContextKey, userData{
+			ID:             user.ID(),
+			Role:           user.Role(),
+			Email:          user.Email(),
+			APIKeyID:       key.ID(),
+			OrganizationID: key.OrganizationID(),
+		})
+		next(w, r.WithContext(ctx))
+	}
+}
+
+// RequirePermission lets requests through when the policy grants their user
+// the permission, on some resource or on any. Which resources they may act on
+// is checked further in.
+func RequirePermission(policy *authz.Policy, permission authz.Permission, next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		user, ok := r.Context().Value(userContextKey).(userData)
 		if !ok {
@@ -73,7 +210,7 @@ func RequireRole(allowedRoles []domain.UserRole, next http.HandlerFunc) http.Han
 			return
 		}
 
-		if slices.Contains(allowedRoles, user.Role) {
+		if policy.Can(r.Context(), user.Subject(), permission) {
 			next(w, r)
 			return
 		}
@@ -0,0 +1,193 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestAuthMiddleware_RejectsRevokedTokensAndSuspendedUsers(t *testing.T) {
+	mr := miniredis.RunT(t)
+	revocations := auth.NewRevocationList(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	keyring, err := auth.NewHMACKeyring("test-secret")
+	if err != nil {
+		t.Fatalf("NewHMACKeyring() error = %v", err)
+	}
+	jwtService := auth.NewJWTService(keyring)
+	user, err := domain.NewUser(uuid.New(), "member@example.com", "hash", domain.UserRoleUser)
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	users := stubUsers{user.ID(): user}
+	handler := AuthMiddleware(jwtService, revocations, users, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	call := func(token auth.AccessToken) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token.Token)
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder.Code
+	}
+
+	revoked, err := jwtService.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	live, err := jwtService.GenerateToken(user)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if err := revocations.Revoke(context.Background(), revoked.ID, revoked.ExpiresAt); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if code := call(revoked); code != http.StatusUnauthorized {
+		t.Errorf("revoked token status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := call(live); code != http.StatusNoContent {
+		t.Errorf("live token status = %d, want %d", code, http.StatusNoContent)
+	}
+
+	if err := revocations.SuspendUser(context.Background(), user.ID()); err != nil {
+		t.Fatalf("SuspendUser() error = %v", err)
+	}
+	if code := call(live); code != http.StatusForbidden {
+		t.Errorf("suspended user status = %d, want %d", code, http.StatusForbidden)
+	}
+}
+
+// stubUsers returns the users of its map, and an error for any other.
+type stubUsers map[uuid.UUID]*domain.User
+
+func (s stubUsers) GetUserByID(_ context.Context, id uuid.UUID) (*domain.User, error) {
+	user, ok := s[id]
+	if !ok {
+		return nil, errors.New("database unavailable")
+	}
+	return user, nil
+}
+
+func TestAuthMiddleware_ChecksSuspensionInDatabaseWhenRedisIsDown(t *testing.T) {
+	mr := miniredis.RunT(t)
+	revocations := auth.NewRevocationList(redis.NewClient(&redis.Options{
+		Addr:          mr.Addr(),
+		MaxRetries:    -1,
+		DialerRetries: 1,
+	}))
+	keyring, err := auth.NewHMACKeyring("test-secret")
+	if err != nil {
+		t.Fatalf("NewHMACKeyring() error = %v", err)
+	}
+	jwtService := auth.NewJWTService(keyring)
+	active, err := domain.NewUser(uuid.New(), "active@example.com", "hash", domain.UserRoleUser)
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	suspended, err := domain.NewUser(uuid.New(), "suspended@example.com", "hash", domain.UserRoleUser)
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	suspended.Suspend(time.Now())
+	unknown, err := domain.NewUser(uuid.New(), "unknown@example.com", "hash", domain.UserRoleUser)
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	users := stubUsers{active.ID(): active, suspended.ID(): suspended}
+	handler := AuthMiddleware(jwtService, revocations, users, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mr.Close()
+
+	tests := []struct {
+		name string
+		user *domain.User
+		want int
+	}{
+		{"active user", active, http.StatusNoContent},
+		{"suspended user", suspended, http.StatusForbidden},
+		{"database unavailable too", unknown, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwtService.GenerateToken(tt.user)
+			if err != nil {
+				t.Fatalf("GenerateToken() error = %v", err)
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token.Token)
+			recorder := httptest.NewRecorder()
+			handler(recorder, req)
+			if recorder.Code != tt.want {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.want)
+			}
+		})
+	}
+}
+
+type stubAPIKeys struct {
+	key  *domain.APIKey
+	raw  string
+	user *domain.User
+}
+
+func (s stubAPIKeys) AuthenticateAPIKey(_ context.Context, rawKey string) (*domain.APIKey, *domain.User, error) {
+	if rawKey != s.raw {
+		return nil, nil, domain.ErrAPIKeyInvalid
+	}
+	return s.key, s.user, nil
+}
+
+func TestAPIKeyMiddleware_ActsAsCreatorWithinScope(t *testing.T) {
+	user, err := domain.NewUser(uuid.New(), "manager@example.com", "hash", domain.UserRoleOrganizer)
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	scopes := []domain.APIKeyScope{domain.APIKeyScopeEventsRead}
+	key, raw, err := domain.NewAPIKey(uuid.New(), user.ID(), "Kiosk", scopes, time.Time{}, time.Now())
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+	keys := stubAPIKeys{key: key, raw: raw, user: user}
+
+	var got userData
+	next := func(w http.ResponseWriter, r *http.Request) {
+		got, _ = GetUserDataFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}
+	bearer := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}
+	call := func(scope domain.APIKeyScope, authorization string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", authorization)
+		recorder := httptest.NewRecorder()
+		APIKeyMiddleware(keys, scope, next, bearer)(recorder, req)
+		return recorder.Code
+	}
+
+	if code := call(domain.APIKeyScopeEventsRead, "ApiKey "+raw); code != http.StatusNoContent {
+		t.Fatalf("key in scope status = %d, want %d", code, http.StatusNoContent)
+	}
+	if got.ID != user.ID() || got.Role != domain.UserRoleOrganizer || got.APIKeyID != key.ID() ||
+		got.OrganizationID != key.OrganizationID() {
+		t.Errorf("user data = %+v, want the creator with the key and its organization", got)
+	}
+	if code := call(domain.APIKeyScopeBookingsWrite, "ApiKey "+raw); code != http.StatusForbidden {
+		t.Errorf("key out of scope status = %d, want %d", code, http.StatusForbidden)
+	}
+	if code := call(domain.APIKeyScopeEventsRead, "ApiKey "+raw+"x"); code != http.StatusUnauthorized {
+		t.Errorf("wrong key status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := call(domain.APIKeyScopeEventsRead, "Bearer token"); code != http.StatusTeapot {
+		t.Errorf("bearer token status = %d, want it passed on", code)
+	}
+}
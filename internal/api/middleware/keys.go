@@ -3,6 +3,8 @@ package middleware
 import (
 	"net"
 	"net/http"
+
+	"github.com/google/uuid"
 )
 
 func IPKey(r *http.Request) string {
@@ -18,5 +20,17 @@ func UserKey(r *http.Request) string {
 	if !ok {
 		return ""
 	}
+	// API keys get limits of their own, apart from their creator's.
+	if user.APIKeyID != uuid.Nil {
+		return "api_key:" + user.APIKeyID.String()
+	}
 	return user.ID.String()
 }
+
+// PrefixedKey namespaces the keys of getKey, so a limiter keyed by the same
+// client as another one keeps its own count.
+func PrefixedKey(prefix string, getKey func(r *http.Request) string) func(r *http.Request) string {
+	return func(r *http.Request) string {
+		return prefix + ":" + getKey(r)
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type OIDCHandler struct {
+	oidcService services.OIDCServiceInterface
+}
+
+func NewOIDCHandler(oidcService services.OIDCServiceInterface) *OIDCHandler {
+	return &OIDCHandler{oidcService: oidcService}
+}
+
+// @Summary Sign in with an identity provider
+// @Description Redirect to an OpenID Connect provider to sign in. The provider sends the user back to
+// @Description the callback endpoint.
+// @Tags auth
+// @Param provider path string true "Provider name"
+// @Param login_hint query string false "Email address of the account to sign in with"
+// @Success 302
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/oidc/{provider}/login [get]
+func (h *OIDCHandler) Login(w http.ResponseWriter, r *http.Request) {
+	authURL, err := h.oidcService.StartLogin(r.Context(), r.PathValue("provider"), r.URL.Query().Get("login_hint"))
+	if err != nil {
+		slog.Error("Failed to start OIDC login", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// @Summary Complete a sign-in with an identity provider
+// @Description The provider redirects here with a code. Returns a 15 minute access token and a refresh token.
+// @Description The provider account is linked to the user with its verified email address, who is created
+// @Description if needed. Users of two-factor authentication get 202 with a challenge, as at /auth/login.
+// @Tags auth
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param code query string true "Authorization code"
+// @Param state query string true "Sign-in state"
+// @Success 200 {object} dto.TokenResponse
+// @Success 202 {object} dto.TwoFactorChallengeResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/oidc/{provider}/callback [get]
+func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if providerErr := query.Get("error"); providerErr != "" {
+		slog.Warn("OIDC provider refused the sign-in", //nolint:gosec // G706: slog uses structured fields
+			"error", providerErr)
+		code, message := MapDomainError(domain.ErrOIDCLoginFailed)
+		ResponseError(w, code, message)
+		return
+	}
+	if query.Get("code") == "" || query.Get("state") == "" {
+		ResponseError(w, http.StatusBadRequest, "code and state are required")
+		return
+	}
+
+	login, err := h.oidcService.CompleteLogin(
+		r.Context(), r.PathValue("provider"), query.Get("state"), query.Get("code"), loginClient(r),
+	)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	respondLogin(w, login)
+}
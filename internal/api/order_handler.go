@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/authz"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type OrderHandler struct {
+	orderService        services.OrderServiceInterface
+	organizationService services.OrganizationServiceInterface
+	policy              *authz.Policy
+}
+
+func NewOrderHandler(
+	orderService services.OrderServiceInterface,
+	organizationService services.OrganizationServiceInterface,
+	policy *authz.Policy,
+) *OrderHandler {
+	return &OrderHandler{orderService: orderService, organizationService: organizationService, policy: policy}
+}
+
+// @Summary Create a group order
+// @Description Book several attendees under one purchaser, paid by invoice. Spots stay reserved until the
+// @Description invoice is paid or falls due (14 days by default).
+// @Tags order
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param order body dto.CreateOrderRequest true "Order"
+// @Success 201 {object} dto.OrderResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/{event_id}/orders [post]
+// @Security BearerAuth
+// @Security ApiKeyAuth
+func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("event_id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid event id")
+		return
+	}
+
+	var req dto.CreateOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	dueAt := time.Now().Add(domain.DefaultInvoicePaymentTerm)
+	if req.DueAt != nil {
+		dueAt = *req.DueAt
+	}
+	order, err := domain.NewInvoiceOrder(uuid.New(), eventID, user.Email, req.CompanyName, req.AttendeeEmails(), dueAt)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	err = h.orderService.CreateInvoiceOrder(r.Context(), order, services.CreateBookingOptions{
+		AccessCode:     req.AccessCode,
+		CustomerID:     user.ID,
+		OrganizationID: user.OrganizationID,
+	})
+	if err != nil {
+		slog.Error("Failed to create order", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseCreated(w, dto.ToOrderResponse(order))
+}
+
+// @Summary Get a group order
+// @Description Get an order with its bookings. Customers can only read their own orders, members of the
+// @Description organization of the event the orders of its events.
+// @Tags order
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /orders/{id} [get]
+// @Security BearerAuth
+func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	// Members of the organization of the event may read its orders,
+	// customers only their own.
+	actor := services.Actor{ID: user.ID, Role: user.Role, OrganizationID: user.OrganizationID}
+	staff := h.policy.CanAny(r.Context(), user.Subject(), authz.OrderRead) ||
+		h.organizationService.AuthorizeOrder(
+			r.Context(), actor, orderID, domain.OrganizationActionViewBookings,
+		) == nil
+	order, err := h.orderService.GetOrder(r.Context(), orderID, services.Requester{
+		Email: user.Email,
+		Staff: staff,
+	})
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToOrderResponse(order))
+}
+
+// @Summary Mark a group order paid
+// @Description Record the invoice payment and confirm every booking of the order
+// @Tags order
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /orders/{id}/mark-paid [post]
+// @Security BearerAuth
+func (h *OrderHandler) MarkPaid(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	order, err := h.orderService.MarkOrderPaid(r.Context(), orderID)
+	if err != nil {
+		slog.Error("Failed to mark order paid", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToOrderResponse(order))
+}
@@ -0,0 +1,181 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type OrganizationHandler struct {
+	organizationService services.OrganizationServiceInterface
+}
+
+func NewOrganizationHandler(organizationService services.OrganizationServiceInterface) *OrganizationHandler {
+	return &OrganizationHandler{organizationService: organizationService}
+}
+
+// actorFromRequest returns the signed-in user as a services.Actor.
+func actorFromRequest(r *http.Request) (services.Actor, bool) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		return services.Actor{}, false
+	}
+	return services.Actor{ID: user.ID, Role: user.Role, OrganizationID: user.OrganizationID}, true
+}
+
+// @Summary List your organizations
+// @Description List the organizations you are a member of, with your role in each.
+// @Tags organization
+// @Produce json
+// @Success 200 {object} dto.MembershipListResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/organizations [get]
+// @Security BearerAuth
+func (h *OrganizationHandler) ListMyOrganizations(w http.ResponseWriter, r *http.Request) {
+	actor, ok := actorFromRequest(r)
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	memberships, err := h.organizationService.ListMyOrganizations(r.Context(), actor.ID)
+	if err != nil {
+		slog.Error("Failed to list organizations", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToMembershipListResponse(memberships))
+}
+
+// @Summary Get an organization
+// @Description Get an organization with its members. Only members and admins may see it.
+// @Tags organization
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} dto.OrganizationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations/{id} [get]
+// @Security BearerAuth
+func (h *OrganizationHandler) GetOrganization(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	actor, ok := actorFromRequest(r)
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	organization, members, err := h.organizationService.GetOrganization(r.Context(), actor, organizationID)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToOrganizationResponse(organization, members))
+}
+
+// @Summary Invite a member
+// @Description Add the account registered with an email to the organization.
+// @Description Owners may add managers, box office and scanner staff; managers box office and scanner staff.
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param body body dto.InviteMemberRequest true "Member email and role"
+// @Success 201 {object} dto.MembershipResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations/{id}/members [post]
+// @Security BearerAuth
+func (h *OrganizationHandler) InviteMember(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	var req dto.InviteMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	actor, ok := actorFromRequest(r)
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	membership, err := h.organizationService.InviteMember(
+		r.Context(), actor, organizationID, req.Email, domain.OrganizationRole(req.Role),
+	)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseCreated(w, dto.ToMembershipResponse(membership))
+}
+
+// @Summary Remove a member
+// @Description Remove a member from the organization. Members may leave on their own; the owner always stays.
+// @Tags organization
+// @Param id path string true "Organization ID"
+// @Param user_id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /organizations/{id}/members/{user_id} [delete]
+// @Security BearerAuth
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	organizationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	userID, err := uuid.Parse(r.PathValue("user_id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	actor, ok := actorFromRequest(r)
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.organizationService.RemoveMember(r.Context(), actor, organizationID, userID); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
@@ -0,0 +1,222 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type OrganizerApplicationHandler struct {
+	applicationService services.OrganizerApplicationServiceInterface
+}
+
+func NewOrganizerApplicationHandler(
+	applicationService services.OrganizerApplicationServiceInterface,
+) *OrganizerApplicationHandler {
+	return &OrganizerApplicationHandler{applicationService: applicationService}
+}
+
+// @Summary Apply to organize events
+// @Description Submit the details of your organization for review. Requires a verified email address.
+// @Tags organizer
+// @Accept json
+// @Produce json
+// @Param body body dto.OrganizerApplicationRequest true "Organization details"
+// @Success 201 {object} dto.OrganizerApplicationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/organizer-application [post]
+// @Security BearerAuth
+func (h *OrganizerApplicationHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	var req dto.OrganizerApplicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	application, err := h.applicationService.Apply(r.Context(), user.ID, services.OrganizerApplicationDetails{
+		OrganizationName: req.OrganizationName,
+		Website:          req.Website,
+		Description:      req.Description,
+	})
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseCreated(w, dto.ToOrganizerApplicationResponse(application))
+}
+
+// @Summary Get your organizer application
+// @Description Get the status of your latest organizer application.
+// @Tags organizer
+// @Produce json
+// @Success 200 {object} dto.OrganizerApplicationResponse
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/organizer-application [get]
+// @Security BearerAuth
+func (h *OrganizerApplicationHandler) GetMyApplication(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	application, err := h.applicationService.GetMyApplication(r.Context(), user.ID)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToOrganizerApplicationResponse(application))
+}
+
+// @Summary List organizer applications
+// @Description List applications in a status, oldest first.
+// @Tags admin
+// @Produce json
+// @Param status query string false "Status, pending by default" Enums(pending, approved, rejected)
+// @Param limit query int false "Page size, 50 by default and at most 100"
+// @Param offset query int false "Number of applications to skip"
+// @Success 200 {object} dto.OrganizerApplicationListResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/organizer-applications [get]
+// @Security BearerAuth
+func (h *OrganizerApplicationHandler) ListApplications(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := domain.ApplicationFilter{Status: domain.ApplicationStatus(query.Get("status"))}
+	switch filter.Status {
+	case "", domain.ApplicationStatusPending, domain.ApplicationStatusApproved, domain.ApplicationStatusRejected:
+	default:
+		ResponseError(w, http.StatusBadRequest, "invalid status")
+		return
+	}
+	var err error
+	if filter.Limit, err = parseIntParam(query.Get("limit")); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	if filter.Offset, err = parseIntParam(query.Get("offset")); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid offset")
+		return
+	}
+
+	filter = filter.WithPageDefaults()
+	applications, err := h.applicationService.ListApplications(r.Context(), filter)
+	if err != nil {
+		slog.Error("Failed to list organizer applications", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToOrganizerApplicationListResponse(applications, filter))
+}
+
+// @Summary Approve an organizer application
+// @Description Make the applicant an organizer who owns a new organization.
+// @Description Their access tokens are revoked, so the next refresh carries the new role.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Application ID"
+// @Success 200 {object} dto.OrganizerApplicationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/organizer-applications/{id}/approve [post]
+// @Security BearerAuth
+func (h *OrganizerApplicationHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	admin, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	application, err := h.applicationService.Approve(r.Context(), admin.ID, applicationID)
+	if err != nil {
+		slog.Error("Failed to approve organizer application", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToOrganizerApplicationResponse(application))
+}
+
+// @Summary Reject an organizer application
+// @Description Turn an application down. The note is shown to the applicant, who may apply again.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Application ID"
+// @Param body body dto.RejectApplicationRequest false "Note for the applicant"
+// @Success 200 {object} dto.OrganizerApplicationResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/organizer-applications/{id}/reject [post]
+// @Security BearerAuth
+func (h *OrganizerApplicationHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	applicationID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	var req dto.RejectApplicationRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			ResponseError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	admin, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	application, err := h.applicationService.Reject(r.Context(), admin.ID, applicationID, req.Note)
+	if err != nil {
+		slog.Error("Failed to reject organizer application", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToOrganizerApplicationResponse(application))
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type PresaleHandler struct {
+	eventRepository   domain.EventRepository
+	presaleRepository domain.PresaleRepository
+}
+
+func NewPresaleHandler(
+	eventRepository domain.EventRepository,
+	presaleRepository domain.PresaleRepository,
+) *PresaleHandler {
+	return &PresaleHandler{
+		eventRepository:   eventRepository,
+		presaleRepository: presaleRepository,
+	}
+}
+
+// @Summary Create a presale
+// @Description Open an access-code restricted sales window before general sales
+// @Tags presale
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param body body dto.CreatePresaleRequest true "Presale data"
+// @Success 201 {object} dto.PresaleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/{id}/presales [post]
+// @Security BearerAuth
+func (h *PresaleHandler) CreatePresale(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req dto.CreatePresaleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if _, err := h.eventRepository.GetEvent(r.Context(), eventID); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	presale, err := domain.NewPresale(uuid.New(), eventID, req.Name, req.StartAt, req.EndAt, req.AccessCode)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	if err := h.presaleRepository.CreatePresale(r.Context(), presale); err != nil {
+		slog.Error("Failed to create presale", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseCreated(w, dto.ToPresaleResponse(presale))
+}
+
+// @Summary List presales
+// @Description List the presale windows of an event
+// @Tags presale
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {array} dto.PresaleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/{id}/presales [get]
+// @Security BearerAuth
+func (h *PresaleHandler) ListPresales(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	presales, err := h.presaleRepository.ListPresales(r.Context(), eventID)
+	if err != nil {
+		slog.Error("Failed to list presales", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToPresaleListResponse(presales))
+}
+
+// @Summary Delete a presale
+// @Description Delete a presale window of an event
+// @Tags presale
+// @Param id path string true "Event ID"
+// @Param presale_id path string true "Presale ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/{id}/presales/{presale_id} [delete]
+// @Security BearerAuth
+func (h *PresaleHandler) DeletePresale(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	presaleID, err := uuid.Parse(r.PathValue("presale_id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid presale id")
+		return
+	}
+
+	if err := h.presaleRepository.DeletePresale(r.Context(), eventID, presaleID); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type PricingHandler struct {
+	service services.PricingServiceInterface
+}
+
+func NewPricingHandler(service services.PricingServiceInterface) *PricingHandler {
+	return &PricingHandler{service: service}
+}
+
+// @Summary Create a pricing rule
+// @Description Raise or lower the price once a share of the capacity is sold or the event start approaches
+// @Tags pricing
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param body body dto.CreatePricingRuleRequest true "Pricing rule"
+// @Success 201 {object} dto.PricingRuleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/{id}/pricing-rules [post]
+// @Security BearerAuth
+func (h *PricingHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req dto.CreatePricingRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	rule, err := domain.NewPricingRule(
+		uuid.New(),
+		eventID,
+		domain.PricingTrigger(req.Trigger),
+		req.Threshold,
+		domain.PriceAdjustment(req.AdjustmentKind),
+		req.Adjustment,
+	)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	if err := h.service.CreateRule(r.Context(), rule); err != nil {
+		slog.Error("Failed to create pricing rule", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseCreated(w, dto.ToPricingRuleResponse(rule))
+}
+
+// @Summary List pricing rules
+// @Description List the pricing rules of an event
+// @Tags pricing
+// @Produce json
+// @Param id path string true "Event ID"
+// @Success 200 {array} dto.PricingRuleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/{id}/pricing-rules [get]
+// @Security BearerAuth
+func (h *PricingHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	rules, err := h.service.ListRules(r.Context(), eventID)
+	if err != nil {
+		slog.Error("Failed to list pricing rules", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToPricingRuleListResponse(rules))
+}
+
+// @Summary Delete a pricing rule
+// @Description Delete a pricing rule of an event
+// @Tags pricing
+// @Param id path string true "Event ID"
+// @Param rule_id path string true "Pricing rule ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/{id}/pricing-rules/{rule_id} [delete]
+// @Security BearerAuth
+func (h *PricingHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+	ruleID, err := uuid.Parse(r.PathValue("rule_id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid rule id")
+		return
+	}
+
+	if err := h.service.DeleteRule(r.Context(), eventID, ruleID); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
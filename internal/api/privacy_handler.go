@@ -0,0 +1,90 @@
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type PrivacyHandler struct {
+	privacyService services.PrivacyServiceInterface
+}
+
+func NewPrivacyHandler(privacyService services.PrivacyServiceInterface) *PrivacyHandler {
+	return &PrivacyHandler{privacyService: privacyService}
+}
+
+// @Summary Export my data
+// @Description Export the profile, bookings and login history of the user as JSON files in a ZIP archive.
+// @Description The archive is built in the background: poll until the response is the archive instead of
+// @Description the pending export. Archives are kept for 7 days, after which a new export is started.
+// @Tags privacy
+// @Produce application/zip
+// @Produce json
+// @Success 200 {file} file
+// @Success 202 {object} dto.DataExportResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/data-export [get]
+// @Security BearerAuth
+func (h *PrivacyHandler) GetDataExport(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	export, archive, err := h.privacyService.RequestDataExport(r.Context(), user.ID)
+	if err != nil {
+		slog.Error("Failed to export user data", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+	if archive == nil {
+		ResponseJSON(w, http.StatusAccepted, dto.ToDataExportResponse(export))
+		return
+	}
+
+	filename := "go-ticket-export-" + export.CreatedAt().Format("2006-01-02") + ".zip"
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Length", strconv.Itoa(len(archive)))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(archive); err != nil {
+		slog.Error("Failed to write data export", "error", err)
+	}
+}
+
+// @Summary Erase my account
+// @Description Erase the account of the user. Credentials, sessions, login history and memberships are
+// @Description deleted; bookings and orders are kept for accounting under an anonymized email address.
+// @Description Organization owners have to hand their organization over first.
+// @Tags privacy
+// @Success 204
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me [delete]
+// @Security BearerAuth
+func (h *PrivacyHandler) DeleteMe(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.privacyService.EraseUser(r.Context(), user.ID); err != nil {
+		slog.Error("Failed to erase user", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
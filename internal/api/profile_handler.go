@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type ProfileHandler struct {
+	profileService services.ProfileServiceInterface
+}
+
+func NewProfileHandler(profileService services.ProfileServiceInterface) *ProfileHandler {
+	return &ProfileHandler{profileService: profileService}
+}
+
+// @Summary Get my profile
+// @Description Get the account of the user with their profile and notification preferences.
+// @Tags profile
+// @Produce json
+// @Success 200 {object} dto.ProfileResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me [get]
+// @Security BearerAuth
+func (h *ProfileHandler) GetMe(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	account, profile, err := h.profileService.GetProfile(r.Context(), user.ID)
+	if err != nil {
+		slog.Error("Failed to get profile", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToProfileResponse(account, profile))
+}
+
+// @Summary Update my profile
+// @Description Change the profile and notification preferences of the user. Only the fields sent change,
+// @Description and an empty string clears a field. Phone numbers use the E.164 format and time zones the
+// @Description IANA names. SMS notifications need a phone number and webhook notifications a webhookUrl;
+// @Description reminders and marketing are opt-in per channel.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param body body dto.UpdateProfileRequest true "Fields to change"
+// @Success 200 {object} dto.ProfileResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me [patch]
+// @Security BearerAuth
+func (h *ProfileHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	account, profile, err := h.profileService.UpdateProfile(r.Context(), user.ID, req.ToDomain())
+	if err != nil {
+		slog.Error("Failed to update profile", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToProfileResponse(account, profile))
+}
@@ -0,0 +1,358 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type TwoFactorHandler struct {
+	twoFactorService services.TwoFactorServiceInterface
+}
+
+func NewTwoFactorHandler(twoFactorService services.TwoFactorServiceInterface) *TwoFactorHandler {
+	return &TwoFactorHandler{twoFactorService: twoFactorService}
+}
+
+// @Summary Complete a two-factor login
+// @Description Complete the challenge of a login with a code of the authenticator app or a recovery code.
+// @Description A challenge takes 5 wrong codes and expires after 5 minutes. Completing it with a newly enrolled
+// @Description authenticator enables it and returns recovery codes, shown this one time.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param body body dto.TwoFactorLoginRequest true "Challenge and code"
+// @Success 200 {object} dto.TwoFactorLoginResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/login/2fa [post]
+func (h *TwoFactorHandler) CompleteLogin(w http.ResponseWriter, r *http.Request) {
+	var req dto.TwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ChallengeToken == "" || req.Code == "" {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	login, err := h.twoFactorService.CompleteLogin(r.Context(), req.ChallengeToken, req.Code, loginClient(r))
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.TwoFactorLoginResponse{
+		TokenResponse: dto.ToTokenResponse(
+			login.Session.AccessToken.Token,
+			login.Session.AccessToken.ExpiresAt,
+			login.Session.RefreshToken,
+		),
+		RecoveryCodes: login.RecoveryCodes,
+	})
+}
+
+// @Summary Enroll an authenticator during login
+// @Description Enroll an authenticator for a login challenge with enrollmentRequired, as the role of the user
+// @Description requires two-factor authentication. Complete the challenge with a first code of it.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param body body dto.TwoFactorLoginEnrollRequest true "Challenge"
+// @Success 200 {object} dto.TwoFactorEnrollmentResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /auth/login/2fa/enroll [post]
+func (h *TwoFactorHandler) EnrollForLogin(w http.ResponseWriter, r *http.Request) {
+	var req dto.TwoFactorLoginEnrollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ChallengeToken == "" {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	enrollment, err := h.twoFactorService.EnrollForLogin(r.Context(), req.ChallengeToken)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	respondEnrollment(w, enrollment)
+}
+
+// @Summary Get two-factor status
+// @Description Tell whether two-factor authentication is on, whether the role of the user requires it and how
+// @Description many recovery codes are left.
+// @Tags two-factor
+// @Produce json
+// @Success 200 {object} dto.TwoFactorStatusResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/2fa [get]
+// @Security BearerAuth
+func (h *TwoFactorHandler) Status(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	status, err := h.twoFactorService.Status(r.Context(), user.ID)
+	if err != nil {
+		slog.Error("Failed to get two-factor status", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.TwoFactorStatusResponse{
+		Enabled:           status.Enabled,
+		Required:          status.Required,
+		RecoveryCodesLeft: status.RecoveryCodesLeft,
+	})
+}
+
+// @Summary Enroll an authenticator
+// @Description Create a TOTP secret to scan into an authenticator app. It is enabled once confirmed with a
+// @Description first code at /me/2fa/confirm; enrolling again replaces an unconfirmed secret.
+// @Tags two-factor
+// @Produce json
+// @Success 200 {object} dto.TwoFactorEnrollmentResponse
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/2fa/enroll [post]
+// @Security BearerAuth
+func (h *TwoFactorHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	enrollment, err := h.twoFactorService.Enroll(r.Context(), user.ID)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	respondEnrollment(w, enrollment)
+}
+
+// @Summary Confirm an authenticator
+// @Description Enable the enrolled authenticator with a first code. Returns recovery codes, shown this one time.
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Param body body dto.TwoFactorCodeRequest true "Authenticator code"
+// @Success 200 {object} dto.RecoveryCodesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/2fa/confirm [post]
+// @Security BearerAuth
+func (h *TwoFactorHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	userID, twoFactorCode, ok := decodeTwoFactorCode(w, r)
+	if !ok {
+		return
+	}
+
+	recoveryCodes, err := h.twoFactorService.Confirm(r.Context(), userID, twoFactorCode)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// @Summary Regenerate recovery codes
+// @Description Replace the recovery codes with new ones, confirmed with a code. Earlier codes stop working.
+// @Tags two-factor
+// @Accept json
+// @Produce json
+// @Param body body dto.TwoFactorCodeRequest true "Authenticator or recovery code"
+// @Success 200 {object} dto.RecoveryCodesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/2fa/recovery-codes [post]
+// @Security BearerAuth
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID, twoFactorCode, ok := decodeTwoFactorCode(w, r)
+	if !ok {
+		return
+	}
+
+	recoveryCodes, err := h.twoFactorService.RegenerateRecoveryCodes(r.Context(), userID, twoFactorCode)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.RecoveryCodesResponse{RecoveryCodes: recoveryCodes})
+}
+
+// @Summary Turn two-factor authentication off
+// @Description Remove the authenticator and recovery codes, confirmed with a code. Refused when the role of the
+// @Description user requires two-factor authentication.
+// @Tags two-factor
+// @Accept json
+// @Param body body dto.TwoFactorCodeRequest true "Authenticator or recovery code"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/2fa [delete]
+// @Security BearerAuth
+func (h *TwoFactorHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	userID, twoFactorCode, ok := decodeTwoFactorCode(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.twoFactorService.Disable(r.Context(), userID, twoFactorCode); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
+
+// @Summary Get the two-factor policy
+// @Description List the roles whose users must use two-factor authentication.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} dto.TwoFactorPolicyResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/security/2fa [get]
+// @Security BearerAuth
+func (h *TwoFactorHandler) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.twoFactorService.RequiredRoles(r.Context())
+	if err != nil {
+		slog.Error("Failed to list roles requiring two-factor authentication", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToTwoFactorPolicyResponse(roles))
+}
+
+// @Summary Set the two-factor policy
+// @Description Require two-factor authentication for organizers, admins or both; an empty list requires it for
+// @Description no one. Users of those roles without an authenticator enroll one at their next sign-in.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param body body dto.TwoFactorPolicyRequest true "Roles"
+// @Success 200 {object} dto.TwoFactorPolicyResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/security/2fa [put]
+// @Security BearerAuth
+func (h *TwoFactorHandler) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	var req dto.TwoFactorPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	admin, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	roles := make([]domain.UserRole, len(req.RequiredRoles))
+	for i, role := range req.RequiredRoles {
+		roles[i] = domain.UserRole(role)
+	}
+	roles, err := h.twoFactorService.RequireForRoles(r.Context(), admin.ID, roles)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToTwoFactorPolicyResponse(roles))
+}
+
+// @Summary Reset the two-factor authentication of a user
+// @Description Remove the authenticator and recovery codes of a user who lost both.
+// @Tags admin
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /admin/users/{id}/2fa [delete]
+// @Security BearerAuth
+func (h *TwoFactorHandler) ResetUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	admin, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.twoFactorService.ResetTwoFactor(r.Context(), admin.ID, userID); err != nil {
+		slog.Error("Failed to reset two-factor authentication", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
+
+// decodeTwoFactorCode reads the signed-in user and the code of a request,
+// answering it when either is missing.
+func decodeTwoFactorCode(w http.ResponseWriter, r *http.Request) (uuid.UUID, string, bool) {
+	var req dto.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return uuid.Nil, "", false
+	}
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return uuid.Nil, "", false
+	}
+	return user.ID, req.Code, true
+}
+
+func respondEnrollment(w http.ResponseWriter, enrollment services.TwoFactorEnrollment) {
+	ResponseOK(w, dto.TwoFactorEnrollmentResponse{
+		Secret:          enrollment.Secret,
+		ProvisioningURI: enrollment.ProvisioningURI,
+	})
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/waitingroom"
+)
+
+type WaitingRoom interface {
+	Activate(ctx context.Context, eventID uuid.UUID, admitPerSecond int) error
+	Deactivate(ctx context.Context, eventID uuid.UUID) error
+	Join(ctx context.Context, eventID, userID uuid.UUID) (*waitingroom.Ticket, error)
+	Status(ctx context.Context, pollToken string) (*waitingroom.Status, error)
+}
+
+type WaitingRoomHandler struct {
+	room WaitingRoom
+}
+
+func NewWaitingRoomHandler(room WaitingRoom) *WaitingRoomHandler {
+	return &WaitingRoomHandler{room: room}
+}
+
+// @Summary Activate the waiting room
+// @Description Require an admission pass for bookings and admit queued users at the given rate
+// @Tags waiting-room
+// @Accept json
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param body body dto.ActivateWaitingRoomRequest true "Admission rate"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/{event_id}/queue [put]
+// @Security BearerAuth
+func (h *WaitingRoomHandler) Activate(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("event_id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req dto.ActivateWaitingRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.room.Activate(r.Context(), eventID, req.AdmitPerSecond); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
+
+// @Summary Deactivate the waiting room
+// @Description Stop requiring admission passes and drop the queue
+// @Tags waiting-room
+// @Param event_id path string true "Event ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/{event_id}/queue [delete]
+// @Security BearerAuth
+func (h *WaitingRoomHandler) Deactivate(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("event_id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	if err := h.room.Deactivate(r.Context(), eventID); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseNoContent(w)
+}
+
+// @Summary Join the waiting room
+// @Description Join the event queue and receive a position and a polling token
+// @Tags waiting-room
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Success 202 {object} dto.QueueTicketResponse
+// @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /events/{event_id}/queue [post]
+// @Security BearerAuth
+func (h *WaitingRoomHandler) Join(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(r.PathValue("event_id"))
+	if err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	ticket, err := h.room.Join(r.Context(), eventID, user.ID)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseJSON(w, http.StatusAccepted, dto.ToQueueTicketResponse(ticket))
+}
+
+// @Summary Poll the waiting room
+// @Description Returns the queue position, or an admission pass once admitted
+// @Tags waiting-room
+// @Produce json
+// @Param event_id path string true "Event ID"
+// @Param token query string true "Polling token"
+// @Success 200 {object} dto.QueueStatusResponse
+// @Failure 404 {object} map[string]string
+// @Router /events/{event_id}/queue [get]
+// @Security BearerAuth
+func (h *WaitingRoomHandler) Status(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		ResponseError(w, http.StatusBadRequest, "token is required")
+		return
+	}
+
+	status, err := h.room.Status(r.Context(), token)
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToQueueStatusResponse(status))
+}
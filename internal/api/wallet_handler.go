@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/mati/go-ticket/internal/api/dto"
+	"github.com/mati/go-ticket/internal/api/middleware"
+	"github.com/mati/go-ticket/internal/services"
+)
+
+type WalletHandler struct {
+	walletService services.WalletServiceInterface
+}
+
+func NewWalletHandler(walletService services.WalletServiceInterface) *WalletHandler {
+	return &WalletHandler{walletService: walletService}
+}
+
+// @Summary Issue a gift card
+// @Description Record a sold gift card and return its code. The code is shown only once.
+// @Tags wallet
+// @Accept json
+// @Produce json
+// @Param body body dto.IssueGiftCardRequest true "Gift card value"
+// @Success 201 {object} dto.GiftCardResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /gift-cards [post]
+// @Security BearerAuth
+func (h *WalletHandler) IssueGiftCard(w http.ResponseWriter, r *http.Request) {
+	var req dto.IssueGiftCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	amount, err := req.Amount.ToDomain()
+	if err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	card, giftCode, err := h.walletService.IssueGiftCard(r.Context(), amount, user.ID)
+	if err != nil {
+		slog.Error("Failed to issue gift card", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseCreated(w, dto.ToGiftCardResponse(card, giftCode))
+}
+
+// @Summary Redeem a gift card
+// @Description Credit a gift card to your wallet
+// @Tags wallet
+// @Accept json
+// @Produce json
+// @Param body body dto.RedeemGiftCardRequest true "Gift card code"
+// @Success 200 {object} dto.WalletResponse
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/wallet/redeem [post]
+// @Security BearerAuth
+func (h *WalletHandler) RedeemGiftCard(w http.ResponseWriter, r *http.Request) {
+	var req dto.RedeemGiftCardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		ResponseError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if _, err := h.walletService.RedeemGiftCard(r.Context(), user.ID, req.Code); err != nil {
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	h.respondWallet(w, r)
+}
+
+// @Summary Get my wallet
+// @Description Get your wallet balance per currency and latest transactions
+// @Tags wallet
+// @Produce json
+// @Success 200 {object} dto.WalletResponse
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /me/wallet [get]
+// @Security BearerAuth
+func (h *WalletHandler) GetWallet(w http.ResponseWriter, r *http.Request) {
+	h.respondWallet(w, r)
+}
+
+func (h *WalletHandler) respondWallet(w http.ResponseWriter, r *http.Request) {
+	user, ok := middleware.GetUserDataFromContext(r.Context())
+	if !ok {
+		ResponseError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	summary, err := h.walletService.GetWallet(r.Context(), user.ID)
+	if err != nil {
+		slog.Error("Failed to get wallet", "error", err)
+		code, message := MapDomainError(err)
+		ResponseError(w, code, message)
+		return
+	}
+
+	ResponseOK(w, dto.ToWalletResponse(summary.Wallets, summary.Transactions))
+}
@@ -9,6 +9,14 @@ import (
 	"github.com/mati/go-ticket/internal/domain"
 )
 
+// AccessTokenTTL is how long an access token is valid. Sessions last longer
+// through refresh tokens.
+const AccessTokenTTL = 15 * time.Minute
+
+// ErrTokenNotRevocable is returned for tokens issued without a jti or expiry,
+// which could not be revoked.
+var ErrTokenNotRevocable = errors.New("token has no id or expiry")
+
 type Claims struct {
 	UserID uuid.UUID       `json:"user_id"`
 	Email  string          `json:"email"`
@@ -16,39 +24,64 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
+// JWTService signs tokens with the current key of a keyring and names it in
+// the "kid" header, so tokens signed with older keys still verify.
 type JWTService struct {
-	secretKey []byte
+	keyring *Keyring
 }
 
-func NewJWTService(secretKey string) (*JWTService, error) {
-	if secretKey == "" {
-		return nil, errors.New("JWT_SECRET_KEY is not set")
-	}
+func NewJWTService(keyring *Keyring) *JWTService {
+	return &JWTService{keyring: keyring}
+}
 
-	return &JWTService{secretKey: []byte(secretKey)}, nil
+// AccessToken is a signed access token with the ID (jti) and expiry it
+// carries, which are needed to revoke it.
+type AccessToken struct {
+	Token     string
+	ID        uuid.UUID
+	ExpiresAt time.Time
 }
 
-func (s *JWTService) GenerateToken(user *domain.User) (string, error) {
+func (s *JWTService) GenerateToken(user *domain.User) (AccessToken, error) {
+	now := time.Now()
+	key, err := s.keyring.signingKey(now)
+	if err != nil {
+		return AccessToken{}, err
+	}
+
+	access := AccessToken{ID: uuid.New(), ExpiresAt: now.Add(AccessTokenTTL)}
 	claims := &Claims{
 		UserID: user.ID(),
 		Email:  user.Email(),
 		Role:   user.Role(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        access.ID.String(),
+			ExpiresAt: jwt.NewNumericDate(access.ExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+	access.Token, err = token.SignedString(key.signKey)
+	if err != nil {
+		return AccessToken{}, err
+	}
+	return access, nil
 }
 
 func (s *JWTService) VerifyToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, err := s.keyring.verificationKey(kid)
+		if err != nil {
+			return nil, err
+		}
+		// The key decides the algorithm, never the token header.
+		if token.Method.Alg() != key.method.Alg() {
 			return nil, jwt.ErrSignatureInvalid
 		}
-		return s.secretKey, nil
+		return key.verifyKey, nil
 	})
 	if err != nil {
 		return nil, err
@@ -58,3 +91,13 @@ func (s *JWTService) VerifyToken(tokenString string) (*Claims, error) {
 	}
 	return token.Claims.(*Claims), nil
 }
+
+// TokenID returns the jti of verified claims. Tokens without a jti or expiry
+// yield ErrTokenNotRevocable.
+func (c *Claims) TokenID() (uuid.UUID, error) {
+	id, err := uuid.Parse(c.ID)
+	if err != nil || c.ExpiresAt == nil {
+		return uuid.Nil, ErrTokenNotRevocable
+	}
+	return id, nil
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+const (
+	// DefaultKeyRotationInterval is how long a key signs before it is replaced.
+	DefaultKeyRotationInterval = 30 * 24 * time.Hour
+	// KeyPublishLead is how long a new key is published before it signs. It
+	// must exceed the JWKS cache lifetime plus the rotation check interval.
+	KeyPublishLead = time.Hour
+
+	rsaKeyBits = 2048
+)
+
+// KeyManager generates, rotates and loads the asymmetric signing keys of a
+// keyring. Keys are shared through the database, so every instance signs
+// with the same key and verifies tokens issued by the others.
+type KeyManager struct {
+	repository       domain.SigningKeyRepository
+	tm               domain.TransactionManager
+	keyring          *Keyring
+	aead             cipher.AEAD
+	rotationInterval time.Duration
+}
+
+// NewKeyManager creates a manager for keyring. Private keys are encrypted at
+// rest with a key derived from secretKey.
+func NewKeyManager(
+	repository domain.SigningKeyRepository,
+	tm domain.TransactionManager,
+	keyring *Keyring,
+	secretKey string,
+	rotationInterval time.Duration,
+) (*KeyManager, error) {
+	if secretKey == "" {
+		return nil, errors.New("key encryption secret is not set")
+	}
+	if rotationInterval <= AccessTokenTTL {
+		return nil, fmt.Errorf("key rotation interval must exceed %s", AccessTokenTTL)
+	}
+	sum := sha256.Sum256([]byte("signing-keys:" + secretKey))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyManager{
+		repository:       repository,
+		tm:               tm,
+		keyring:          keyring,
+		aead:             aead,
+		rotationInterval: rotationInterval,
+	}, nil
+}
+
+// Rotate makes sure a signing key of the configured algorithm exists, starts
+// publishing its successor once it is due, deletes keys that no longer
+// verify any token and reloads the keyring. It is safe to call from every
+// instance: rotations are serialized by a database lock.
+func (m *KeyManager) Rotate(ctx context.Context) error {
+	now := time.Now()
+	err := m.tm.RunInTx(ctx, func(ctx context.Context) error {
+		if err := m.repository.LockSigningKeys(ctx); err != nil {
+			return err
+		}
+		keys, err := m.repository.ListSigningKeys(ctx, now)
+		if err != nil {
+			return err
+		}
+
+		var newest *domain.SigningKey
+		for _, key := range keys {
+			if key.Algorithm() == m.keyring.algorithm && key.RetiresAt().IsZero() {
+				newest = key
+				break
+			}
+		}
+		switch {
+		case newest == nil:
+			// First start or a switch of algorithm: sign right away.
+			err = m.replaceKeys(ctx, now, now)
+		case now.Sub(newest.ActivatesAt()) >= m.rotationInterval:
+			err = m.replaceKeys(ctx, now, now.Add(KeyPublishLead))
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = m.repository.DeleteRetiredSigningKeys(ctx, now)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return m.Load(ctx)
+}
+
+// replaceKeys creates a key signing from activatesAt and retires the others
+// once the last token they may sign has expired.
+func (m *KeyManager) replaceKeys(ctx context.Context, now, activatesAt time.Time) error {
+	key, err := m.generateKey(now, activatesAt)
+	if err != nil {
+		return err
+	}
+	if err := m.repository.CreateSigningKey(ctx, key); err != nil {
+		return err
+	}
+	return m.repository.RetireSigningKeys(ctx, key.ID(), activatesAt.Add(AccessTokenTTL))
+}
+
+// Load replaces the keys of the keyring with the stored ones.
+func (m *KeyManager) Load(ctx context.Context) error {
+	stored, err := m.repository.ListSigningKeys(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	keys := make([]keyringKey, 0, len(stored))
+	for _, key := range stored {
+		loaded, err := m.decryptKey(key)
+		if err != nil {
+			return fmt.Errorf("failed to load signing key %s: %w", key.ID(), err)
+		}
+		keys = append(keys, loaded)
+	}
+	m.keyring.replace(keys)
+	return nil
+}
+
+func (m *KeyManager) generateKey(now, activatesAt time.Time) (*domain.SigningKey, error) {
+	var private crypto.Signer
+	var err error
+	switch m.keyring.algorithm {
+	case domain.SigningAlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case domain.SigningAlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, domain.ErrSigningKeyInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+
+	id := rand.Text()
+	// The key ID is bound to the ciphertext, so rows cannot be swapped.
+	nonce := make([]byte, m.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	sealed := m.aead.Seal(nonce, nonce, der, []byte(id))
+	return domain.NewSigningKey(id, m.keyring.algorithm, sealed, now, activatesAt)
+}
+
+func (m *KeyManager) decryptKey(key *domain.SigningKey) (keyringKey, error) {
+	sealed := key.PrivateKey()
+	if len(sealed) < m.aead.NonceSize() {
+		return keyringKey{}, domain.ErrSigningKeyInvalid
+	}
+	nonce, ciphertext := sealed[:m.aead.NonceSize()], sealed[m.aead.NonceSize():]
+	der, err := m.aead.Open(nil, nonce, ciphertext, []byte(key.ID()))
+	if err != nil {
+		return keyringKey{}, err
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return keyringKey{}, err
+	}
+
+	loaded := keyringKey{id: key.ID(), activatesAt: key.ActivatesAt()}
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		loaded.method, loaded.signKey, loaded.verifyKey = jwt.SigningMethodRS256, private, &private.PublicKey
+	case ed25519.PrivateKey:
+		loaded.method, loaded.signKey, loaded.verifyKey = jwt.SigningMethodEdDSA, private, private.Public()
+	default:
+		return keyringKey{}, domain.ErrSigningKeyInvalid
+	}
+	if loaded.method.Alg() != string(key.Algorithm()) {
+		return keyringKey{}, domain.ErrSigningKeyInvalid
+	}
+	return loaded, nil
+}
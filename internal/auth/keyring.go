@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// localKeyID is the "kid" of the HS256 key used for local development.
+const localKeyID = "local"
+
+var (
+	ErrNoSigningKey = errors.New("no active signing key")
+	ErrKeyUnknown   = errors.New("unknown signing key")
+)
+
+// keyringKey is a key usable for verification and, once active, for signing.
+type keyringKey struct {
+	id          string
+	method      jwt.SigningMethod
+	signKey     any
+	verifyKey   any
+	activatesAt time.Time
+}
+
+// Keyring holds the keys tokens are signed and verified with. Exactly one key
+// signs at a time, the newest active one of the configured algorithm, while
+// every loaded key verifies, so tokens outlive a rotation.
+type Keyring struct {
+	mu        sync.RWMutex
+	algorithm domain.SigningAlgorithm
+	keys      []keyringKey
+}
+
+// NewKeyring creates an empty keyring signing with an asymmetric algorithm.
+// Keys are loaded by a KeyManager.
+func NewKeyring(algorithm domain.SigningAlgorithm) (*Keyring, error) {
+	if !algorithm.IsValid() || algorithm == domain.SigningAlgorithmHS256 {
+		return nil, domain.ErrSigningKeyInvalid
+	}
+	return &Keyring{algorithm: algorithm}, nil
+}
+
+// NewHMACKeyring creates a keyring with one HS256 key. Its tokens can only be
+// verified by holders of the secret, so it publishes no keys.
+func NewHMACKeyring(secretKey string) (*Keyring, error) {
+	if secretKey == "" {
+		return nil, errors.New("JWT_SECRET_KEY is not set")
+	}
+	return &Keyring{
+		algorithm: domain.SigningAlgorithmHS256,
+		keys: []keyringKey{{
+			id:        localKeyID,
+			method:    jwt.SigningMethodHS256,
+			signKey:   []byte(secretKey),
+			verifyKey: []byte(secretKey),
+		}},
+	}, nil
+}
+
+// replace swaps the loaded keys, ordered newest first.
+func (k *Keyring) replace(keys []keyringKey) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.keys = keys
+}
+
+func (k *Keyring) signingKey(now time.Time) (keyringKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for _, key := range k.keys {
+		if key.method.Alg() == string(k.algorithm) && !key.activatesAt.After(now) {
+			return key, nil
+		}
+	}
+	return keyringKey{}, ErrNoSigningKey
+}
+
+func (k *Keyring) verificationKey(id string) (keyringKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	for _, key := range k.keys {
+		if key.id == id {
+			return key, nil
+		}
+	}
+	return keyringKey{}, ErrKeyUnknown
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 keys (RFC 8037)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of the keyring, including keys that do not
+// sign yet. Symmetric keys are never published.
+func (k *Keyring) JWKS() JWKSet {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range k.keys {
+		jwk := JWK{Use: "sig", Alg: key.method.Alg(), Kid: key.id}
+		switch public := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
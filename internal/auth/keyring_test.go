@@ -0,0 +1,259 @@
+package auth
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type memorySigningKeys struct {
+	keys []*domain.SigningKey
+}
+
+func (r *memorySigningKeys) LockSigningKeys(context.Context) error {
+	return nil
+}
+
+func (r *memorySigningKeys) ListSigningKeys(_ context.Context, now time.Time) ([]*domain.SigningKey, error) {
+	var keys []*domain.SigningKey
+	for _, key := range r.keys {
+		if key.RetiresAt().IsZero() || key.RetiresAt().After(now) {
+			keys = append(keys, key)
+		}
+	}
+	slices.SortFunc(keys, func(a, b *domain.SigningKey) int {
+		return b.ActivatesAt().Compare(a.ActivatesAt())
+	})
+	return keys, nil
+}
+
+func (r *memorySigningKeys) CreateSigningKey(_ context.Context, key *domain.SigningKey) error {
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+func (r *memorySigningKeys) RetireSigningKeys(_ context.Context, keepID string, retiresAt time.Time) error {
+	for i, key := range r.keys {
+		if key.ID() != keepID && key.RetiresAt().IsZero() {
+			r.keys[i] = r.withTimes(key, key.ActivatesAt(), retiresAt)
+		}
+	}
+	return nil
+}
+
+func (r *memorySigningKeys) DeleteRetiredSigningKeys(_ context.Context, now time.Time) (int64, error) {
+	before := len(r.keys)
+	r.keys = slices.DeleteFunc(r.keys, func(key *domain.SigningKey) bool {
+		return !key.RetiresAt().IsZero() && !key.RetiresAt().After(now)
+	})
+	return int64(before - len(r.keys)), nil
+}
+
+// shift moves the lifetime of every key back by d, as if d had passed.
+func (r *memorySigningKeys) shift(d time.Duration) {
+	for i, key := range r.keys {
+		retiresAt := key.RetiresAt()
+		if !retiresAt.IsZero() {
+			retiresAt = retiresAt.Add(-d)
+		}
+		r.keys[i] = r.withTimes(key, key.ActivatesAt().Add(-d), retiresAt)
+	}
+}
+
+func (r *memorySigningKeys) withTimes(key *domain.SigningKey, activatesAt, retiresAt time.Time) *domain.SigningKey {
+	return domain.UnmarshalSigningKey(
+		key.ID(), key.Algorithm(), key.PrivateKey(), key.CreatedAt(), activatesAt, retiresAt,
+	)
+}
+
+type inlineTx struct{}
+
+func (inlineTx) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func testUser(t *testing.T) *domain.User {
+	t.Helper()
+	user, err := domain.NewUser(uuid.New(), "keys@example.com", "hash", domain.UserRoleUser)
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	return user
+}
+
+func newTestKeyManager(t *testing.T, algorithm domain.SigningAlgorithm) (*KeyManager, *memorySigningKeys, *Keyring) {
+	t.Helper()
+	keyring, err := NewKeyring(algorithm)
+	if err != nil {
+		t.Fatalf("NewKeyring() error = %v", err)
+	}
+	repository := &memorySigningKeys{}
+	manager, err := NewKeyManager(repository, inlineTx{}, keyring, "test-secret", DefaultKeyRotationInterval)
+	if err != nil {
+		t.Fatalf("NewKeyManager() error = %v", err)
+	}
+	if err := manager.Rotate(context.Background()); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	return manager, repository, keyring
+}
+
+func signedKeyID(t *testing.T, token string) string {
+	t.Helper()
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, &Claims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified() error = %v", err)
+	}
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
+
+func TestJWTService_SignsAndVerifiesWithEachAlgorithm(t *testing.T) {
+	hmacKeyring, err := NewHMACKeyring("test-secret")
+	if err != nil {
+		t.Fatalf("NewHMACKeyring() error = %v", err)
+	}
+	_, _, rsaKeyring := newTestKeyManager(t, domain.SigningAlgorithmRS256)
+	_, _, edKeyring := newTestKeyManager(t, domain.SigningAlgorithmEdDSA)
+
+	tests := []struct {
+		name    string
+		keyring *Keyring
+		alg     string
+		jwks    int
+	}{
+		{"HS256", hmacKeyring, "HS256", 0},
+		{"RS256", rsaKeyring, "RS256", 1},
+		{"EdDSA", edKeyring, "EdDSA", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewJWTService(tt.keyring)
+			user := testUser(t)
+
+			access, err := service.GenerateToken(user)
+			if err != nil {
+				t.Fatalf("GenerateToken() error = %v", err)
+			}
+			claims, err := service.VerifyToken(access.Token)
+			if err != nil {
+				t.Fatalf("VerifyToken() error = %v", err)
+			}
+			if claims.UserID != user.ID() || claims.ID != access.ID.String() {
+				t.Errorf("VerifyToken() = %+v, want user %s and jti %s", claims, user.ID(), access.ID)
+			}
+
+			parsed, _, _ := new(jwt.Parser).ParseUnverified(access.Token, &Claims{})
+			if parsed.Method.Alg() != tt.alg {
+				t.Errorf("alg = %s, want %s", parsed.Method.Alg(), tt.alg)
+			}
+			jwks := tt.keyring.JWKS()
+			if len(jwks.Keys) != tt.jwks {
+				t.Fatalf("JWKS() has %d keys, want %d", len(jwks.Keys), tt.jwks)
+			}
+			if tt.jwks > 0 && jwks.Keys[0].Kid != signedKeyID(t, access.Token) {
+				t.Errorf("JWKS() kid = %s, want the signing kid", jwks.Keys[0].Kid)
+			}
+		})
+	}
+}
+
+func TestKeyManager_RotationKeepsOldTokensValid(t *testing.T) {
+	manager, repository, keyring := newTestKeyManager(t, domain.SigningAlgorithmEdDSA)
+	service := NewJWTService(keyring)
+	ctx := context.Background()
+
+	old, err := service.GenerateToken(testUser(t))
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	oldKeyID := signedKeyID(t, old.Token)
+
+	// The key is due: its successor is published but does not sign yet.
+	repository.shift(DefaultKeyRotationInterval)
+	if err := manager.Rotate(ctx); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if got := len(keyring.JWKS().Keys); got != 2 {
+		t.Fatalf("JWKS() has %d keys, want 2", got)
+	}
+	pending, err := service.GenerateToken(testUser(t))
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if signedKeyID(t, pending.Token) != oldKeyID {
+		t.Error("a new key should not sign before it is published for KeyPublishLead")
+	}
+
+	// Once active, the new key signs and tokens of the old one still verify.
+	repository.shift(KeyPublishLead)
+	if err := manager.Load(ctx); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	rotated, err := service.GenerateToken(testUser(t))
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if signedKeyID(t, rotated.Token) == oldKeyID {
+		t.Error("expected the new key to sign after activation")
+	}
+	if _, err := service.VerifyToken(old.Token); err != nil {
+		t.Errorf("VerifyToken() of a token signed before rotation error = %v", err)
+	}
+
+	// The old key is dropped once its last token has expired.
+	repository.shift(AccessTokenTTL)
+	if err := manager.Rotate(ctx); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if got := len(keyring.JWKS().Keys); got != 1 {
+		t.Errorf("JWKS() has %d keys, want 1", got)
+	}
+	if _, err := service.VerifyToken(old.Token); err == nil {
+		t.Error("expected tokens of a retired key to be rejected")
+	}
+}
+
+func TestJWTService_RejectsAlgorithmNotMatchingKey(t *testing.T) {
+	_, _, keyring := newTestKeyManager(t, domain.SigningAlgorithmRS256)
+	service := NewJWTService(keyring)
+	access, err := service.GenerateToken(testUser(t))
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	// An HS256 token naming the RSA key, keyed with public material.
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{})
+	forged.Header["kid"] = signedKeyID(t, access.Token)
+	signed, err := forged.SignedString([]byte(keyring.JWKS().Keys[0].N))
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	if _, err := service.VerifyToken(signed); err == nil {
+		t.Error("expected a token signed with another algorithm to be rejected")
+	}
+
+	unknown := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{})
+	unknown.Header["kid"] = "unknown"
+	signed, _ = unknown.SignedString([]byte("secret"))
+	if _, err := service.VerifyToken(signed); err == nil {
+		t.Error("expected a token with an unknown kid to be rejected")
+	}
+}
+
+func TestKeyManager_RejectsKeysEncryptedWithAnotherSecret(t *testing.T) {
+	_, repository, _ := newTestKeyManager(t, domain.SigningAlgorithmEdDSA)
+	keyring, _ := NewKeyring(domain.SigningAlgorithmEdDSA)
+	other, err := NewKeyManager(repository, inlineTx{}, keyring, "other-secret", DefaultKeyRotationInterval)
+	if err != nil {
+		t.Fatalf("NewKeyManager() error = %v", err)
+	}
+	if err := other.Load(context.Background()); err == nil {
+		t.Error("expected Load() to fail with the wrong secret")
+	}
+}
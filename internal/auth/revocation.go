@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	revokedTokenKeyPrefix  = "revoked_token:"
+	suspendedUserKeyPrefix = "suspended_user:"
+)
+
+// RevocationList is a denylist of access token IDs kept in Redis. Entries
+// expire with the token they revoke, so the list stays small. It also flags
+// suspended users, whose every token is rejected until they are unsuspended.
+type RevocationList struct {
+	client *redis.Client
+}
+
+func NewRevocationList(client *redis.Client) *RevocationList {
+	return &RevocationList{client: client}
+}
+
+// Revoke rejects the token with the given ID until it expires. Tokens that
+// already expired are skipped.
+func (l *RevocationList) Revoke(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return l.client.Set(ctx, revokedTokenKeyPrefix+tokenID.String(), 1, ttl).Err()
+}
+
+func (l *RevocationList) IsRevoked(ctx context.Context, tokenID uuid.UUID) (bool, error) {
+	err := l.client.Get(ctx, revokedTokenKeyPrefix+tokenID.String()).Err()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SuspendUser rejects every token of a user until UnsuspendUser is called.
+func (l *RevocationList) SuspendUser(ctx context.Context, userID uuid.UUID) error {
+	return l.client.Set(ctx, suspendedUserKeyPrefix+userID.String(), 1, 0).Err()
+}
+
+func (l *RevocationList) UnsuspendUser(ctx context.Context, userID uuid.UUID) error {
+	return l.client.Del(ctx, suspendedUserKeyPrefix+userID.String()).Err()
+}
+
+func (l *RevocationList) IsUserSuspended(ctx context.Context, userID uuid.UUID) (bool, error) {
+	n, err := l.client.Exists(ctx, suspendedUserKeyPrefix+userID.String()).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
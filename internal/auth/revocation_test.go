@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRevocationList(t *testing.T) {
+	mr := miniredis.RunT(t)
+	list := NewRevocationList(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	ctx := context.Background()
+
+	tokenID := uuid.New()
+	if err := list.Revoke(ctx, tokenID, time.Now().Add(time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	revoked, err := list.IsRevoked(ctx, tokenID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !revoked {
+		t.Error("expected token to be revoked")
+	}
+
+	revoked, err = list.IsRevoked(ctx, uuid.New())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if revoked {
+		t.Error("expected other token not to be revoked")
+	}
+
+	mr.FastForward(time.Minute + time.Second)
+	revoked, err = list.IsRevoked(ctx, tokenID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if revoked {
+		t.Error("expected entry to expire with the token")
+	}
+}
+
+func TestRevocationList_SkipsExpiredTokens(t *testing.T) {
+	mr := miniredis.RunT(t)
+	list := NewRevocationList(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+
+	if err := list.Revoke(context.Background(), uuid.New(), time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Errorf("expected no entries, got %v", keys)
+	}
+}
+
+func TestRevocationList_SuspendUser(t *testing.T) {
+	mr := miniredis.RunT(t)
+	list := NewRevocationList(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	ctx := context.Background()
+
+	userID := uuid.New()
+	if err := list.SuspendUser(ctx, userID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mr.FastForward(365 * 24 * time.Hour)
+	suspended, err := list.IsUserSuspended(ctx, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !suspended {
+		t.Error("expected suspension not to expire")
+	}
+
+	if err := list.UnsuspendUser(ctx, userID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	suspended, err = list.IsUserSuspended(ctx, userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if suspended {
+		t.Error("expected user to be unsuspended")
+	}
+}
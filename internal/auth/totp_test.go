@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTOTP_RFC6238Vectors(t *testing.T) {
+	// The SHA-1 test vectors of RFC 6238, appendix B.
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+	for _, tt := range tests {
+		step := TOTPStep(time.Unix(tt.unix, 0))
+		key, _ := totpEncoding.DecodeString(secret)
+		if got := hotp(key, uint64(step), 8); got != tt.code {
+			t.Errorf("hotp(%d) = %s, want %s", tt.unix, got, tt.code)
+		}
+		// Six digit codes are the last six digits of eight digit ones.
+		code, err := TOTPCode(secret, step)
+		if err != nil {
+			t.Fatalf("TOTPCode() error = %v", err)
+		}
+		if code != tt.code[2:] {
+			t.Errorf("TOTPCode(%d) = %s, want %s", tt.unix, code, tt.code[2:])
+		}
+	}
+}
+
+func TestVerifyTOTP_AllowsOnePeriodOfDrift(t *testing.T) {
+	secret := NewTOTPSecret()
+	now := time.Unix(1700000000, 0)
+	step := TOTPStep(now)
+
+	for _, offset := range []int64{-1, 0, 1} {
+		code, _ := TOTPCode(secret, step+offset)
+		got, ok := VerifyTOTP(secret, code, now)
+		if !ok || got != step+offset {
+			t.Errorf("VerifyTOTP(step%+d) = %d, %v, want %d, true", offset, got, ok, step+offset)
+		}
+	}
+	code, _ := TOTPCode(secret, step+2)
+	if _, ok := VerifyTOTP(secret, code, now); ok {
+		t.Error("VerifyTOTP() accepted a code two periods ahead")
+	}
+	if _, ok := VerifyTOTP(secret, "12345", now); ok {
+		t.Error("VerifyTOTP() accepted a short code")
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("go-ticket", "ada@example.com", "JBSWY3DPEHPK3PXP")
+	want := "otpauth://totp/go-ticket:ada@example.com?"
+	if !strings.HasPrefix(uri, want) || !strings.Contains(uri, "secret=JBSWY3DPEHPK3PXP") ||
+		!strings.Contains(uri, "issuer=go-ticket") {
+		t.Errorf("TOTPProvisioningURI() = %s", uri)
+	}
+}
@@ -0,0 +1,89 @@
+package authz
+
+import "strings"
+
+// Permission names something a role may do, as "<resource>.<action>". A
+// permission with the AnySuffix lets a role do it on every resource of the
+// kind; without it, a role may only do it on resources it owns or that
+// another check, such as organization membership, lets it act on.
+type Permission string
+
+// AnySuffix marks the permission to act on any resource of a kind.
+const AnySuffix = ".any"
+
+const (
+	// AccountManage covers the caller's own account: sessions, email
+	// verification, two-factor authentication and login history.
+	AccountManage Permission = "account.manage"
+	WalletUse     Permission = "wallet.use"
+	GiftCardIssue Permission = "gift_card.issue"
+
+	EventRead            Permission = "event.read"
+	EventCreate          Permission = "event.create"
+	EventUpdate          Permission = "event.update"
+	EventDelete          Permission = "event.delete"
+	EventInventoryManage Permission = "event.inventory.manage"
+	EventPresaleManage   Permission = "event.presale.manage"
+	EventPricingManage   Permission = "event.pricing.manage"
+	EventQueueManage     Permission = "event.queue.manage"
+	EventQueueJoin       Permission = "event.queue.join"
+
+	BookingCreate  Permission = "booking.create"
+	BookingConfirm Permission = "booking.confirm"
+	BookingCancel  Permission = "booking.cancel"
+	ReceiptRead    Permission = "receipt.read"
+
+	OrderCreate   Permission = "order.create"
+	OrderRead     Permission = "order.read"
+	OrderMarkPaid Permission = "order.mark_paid"
+
+	FeeManage  Permission = "fee.manage"
+	LedgerRead Permission = "ledger.read"
+
+	UserManage     Permission = "user.manage"
+	SecurityManage Permission = "security.manage"
+
+	OrganizerApplicationSubmit Permission = "organizer_application.submit"
+	OrganizerApplicationReview Permission = "organizer_application.review"
+
+	OrganizationRead   Permission = "organization.read"
+	OrganizationManage Permission = "organization.manage"
+)
+
+var permissions = []Permission{
+	AccountManage,
+	WalletUse,
+	GiftCardIssue,
+	EventRead,
+	EventCreate,
+	EventUpdate,
+	EventDelete,
+	EventInventoryManage,
+	EventPresaleManage,
+	EventPricingManage,
+	EventQueueManage,
+	EventQueueJoin,
+	BookingCreate,
+	BookingConfirm,
+	BookingCancel,
+	ReceiptRead,
+	OrderCreate,
+	OrderRead,
+	OrderMarkPaid,
+	FeeManage,
+	LedgerRead,
+	UserManage,
+	SecurityManage,
+	OrganizerApplicationSubmit,
+	OrganizerApplicationReview,
+	OrganizationRead,
+	OrganizationManage,
+}
+
+// Any returns the permission to do p on any resource of its kind.
+func (p Permission) Any() Permission {
+	if strings.HasSuffix(string(p), AnySuffix) {
+		return p
+	}
+	return p + AnySuffix
+}
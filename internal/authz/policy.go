@@ -0,0 +1,159 @@
+// Package authz decides what users may do. Roles are granted permissions in
+// a policy config; handlers and the route middleware ask one Policy, which
+// logs every decision for audit.
+package authz
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+//go:embed policy.json
+var defaultConfig []byte
+
+var roles = []domain.UserRole{domain.UserRoleUser, domain.UserRoleOrganizer, domain.UserRoleAdmin}
+
+// Config grants each role its permissions.
+type Config struct {
+	Roles map[domain.UserRole][]Permission `json:"roles"`
+}
+
+// DefaultConfig returns the policy shipped with the app.
+func DefaultConfig() (Config, error) {
+	return parseConfig(defaultConfig)
+}
+
+// LoadConfig reads a policy from a JSON file shaped like policy.json.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: the path comes from the operator's config
+	if err != nil {
+		return Config{}, err
+	}
+	return parseConfig(data)
+}
+
+func parseConfig(data []byte) (Config, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var config Config
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, fmt.Errorf("parse authorization policy: %w", err)
+	}
+	return config, nil
+}
+
+// Subject is the user a decision is made for.
+type Subject struct {
+	UserID uuid.UUID
+	Role   domain.UserRole
+	// APIKeyID is set for requests made with an API key. Keys are confined to
+	// their organization, so they never hold permissions on any resource.
+	APIKeyID uuid.UUID
+}
+
+// Resource is what a permission is checked on.
+type Resource struct {
+	Type string
+	ID   uuid.UUID
+	// OwnerID is the user the resource belongs to.
+	OwnerID uuid.UUID
+}
+
+// Policy decides whether subjects may do something, by the permissions
+// granted to their role.
+type Policy struct {
+	grants map[domain.UserRole]map[Permission]bool
+	logger *slog.Logger
+}
+
+// NewPolicy builds a policy from a config. Every role must be configured,
+// and only with known permissions.
+func NewPolicy(config Config, logger *slog.Logger) (*Policy, error) {
+	grants := make(map[domain.UserRole]map[Permission]bool, len(roles))
+	for role, permissions := range config.Roles {
+		if !slices.Contains(roles, role) {
+			return nil, fmt.Errorf("authorization policy: unknown role %q", role)
+		}
+		grants[role] = make(map[Permission]bool, len(permissions))
+		for _, permission := range permissions {
+			if !known(permission) {
+				return nil, fmt.Errorf("authorization policy: unknown permission %q for role %q", permission, role)
+			}
+			grants[role][permission] = true
+		}
+	}
+	for _, role := range roles {
+		if _, ok := grants[role]; !ok {
+			return nil, fmt.Errorf("authorization policy: role %q is not configured", role)
+		}
+	}
+	return &Policy{grants: grants, logger: logger}, nil
+}
+
+// Can reports whether the subject may do something at all, on some resource
+// or on any. Routes are guarded by it; which resources the subject may act
+// on is checked by Authorize or by the service.
+func (p *Policy) Can(ctx context.Context, subject Subject, permission Permission) bool {
+	allowed := p.grants[subject.Role][permission] || p.holdsAny(subject, permission)
+	p.log(ctx, subject, permission, Resource{}, allowed)
+	return allowed
+}
+
+// CanAny reports whether the subject may do something on every resource of
+// its kind, regardless of ownership or organization membership.
+func (p *Policy) CanAny(ctx context.Context, subject Subject, permission Permission) bool {
+	allowed := p.holdsAny(subject, permission)
+	p.log(ctx, subject, permission.Any(), Resource{}, allowed)
+	return allowed
+}
+
+// Authorize checks that the subject may do something on a resource: on any
+// resource, or on one it owns. It returns domain.ErrPermissionDenied
+// otherwise.
+func (p *Policy) Authorize(ctx context.Context, subject Subject, permission Permission, resource Resource) error {
+	owner := resource.OwnerID != uuid.Nil && resource.OwnerID == subject.UserID
+	allowed := p.holdsAny(subject, permission) || (owner && p.grants[subject.Role][permission])
+	p.log(ctx, subject, permission, resource, allowed)
+	if !allowed {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+func (p *Policy) holdsAny(subject Subject, permission Permission) bool {
+	return subject.APIKeyID == uuid.Nil && p.grants[subject.Role][permission.Any()]
+}
+
+func (p *Policy) log(ctx context.Context, subject Subject, permission Permission, resource Resource, allowed bool) {
+	level := slog.LevelInfo
+	if !allowed {
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{
+		slog.String("user_id", subject.UserID.String()),
+		slog.String("role", string(subject.Role)),
+		slog.String("permission", string(permission)),
+		slog.Bool("allowed", allowed),
+	}
+	if subject.APIKeyID != uuid.Nil {
+		attrs = append(attrs, slog.String("api_key_id", subject.APIKeyID.String()))
+	}
+	if resource.Type != "" {
+		attrs = append(attrs, slog.String("resource_type", resource.Type), slog.String("resource_id", resource.ID.String()))
+	}
+	p.logger.LogAttrs(ctx, level, "Authorization decision", attrs...)
+}
+
+func known(permission Permission) bool {
+	return slices.Contains(permissions, Permission(strings.TrimSuffix(string(permission), AnySuffix)))
+}
@@ -0,0 +1,132 @@
+package authz_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/authz"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func newPolicy(t *testing.T, config authz.Config) *authz.Policy {
+	t.Helper()
+	policy, err := authz.NewPolicy(config, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewPolicy() error = %v", err)
+	}
+	return policy
+}
+
+func TestDefaultConfig_Loads(t *testing.T) {
+	config, err := authz.DefaultConfig()
+	if err != nil {
+		t.Fatalf("DefaultConfig() error = %v", err)
+	}
+	newPolicy(t, config)
+}
+
+func TestNewPolicy_Validation(t *testing.T) {
+	valid := map[domain.UserRole][]authz.Permission{
+		domain.UserRoleUser:      {authz.EventRead},
+		domain.UserRoleOrganizer: {authz.EventRead},
+		domain.UserRoleAdmin:     {authz.EventRead.Any()},
+	}
+	tests := []struct {
+		name    string
+		role    domain.UserRole
+		grant   []authz.Permission
+		wantErr bool
+	}{
+		{"valid", domain.UserRoleUser, []authz.Permission{authz.BookingCancel}, false},
+		{"unknown permission", domain.UserRoleUser, []authz.Permission{"event.launch"}, true},
+		{"unknown any permission", domain.UserRoleUser, []authz.Permission{"event.launch.any"}, true},
+		{"unknown role", "auditor", []authz.Permission{authz.EventRead}, true},
+		{"missing role", domain.UserRoleAdmin, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles := make(map[domain.UserRole][]authz.Permission, len(valid))
+			for role, grant := range valid {
+				roles[role] = grant
+			}
+			if tt.grant == nil {
+				delete(roles, tt.role)
+			} else {
+				roles[tt.role] = tt.grant
+			}
+			_, err := authz.NewPolicy(authz.Config{Roles: roles}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPolicy_AuthorizeOwnAndAny(t *testing.T) {
+	policy := newPolicy(t, authz.Config{Roles: map[domain.UserRole][]authz.Permission{
+		domain.UserRoleUser:      {},
+		domain.UserRoleOrganizer: {authz.EventPricingManage},
+		domain.UserRoleAdmin:     {authz.EventPricingManage.Any()},
+	}})
+	ctx := context.Background()
+	organizer := authz.Subject{UserID: uuid.New(), Role: domain.UserRoleOrganizer}
+	admin := authz.Subject{UserID: uuid.New(), Role: domain.UserRoleAdmin}
+	user := authz.Subject{UserID: uuid.New(), Role: domain.UserRoleUser}
+	own := authz.Resource{Type: "event", ID: uuid.New(), OwnerID: organizer.UserID}
+	other := authz.Resource{Type: "event", ID: uuid.New(), OwnerID: uuid.New()}
+
+	tests := []struct {
+		name     string
+		subject  authz.Subject
+		resource authz.Resource
+		wantErr  error
+	}{
+		{"owner", organizer, own, nil},
+		{"not the owner", organizer, other, domain.ErrPermissionDenied},
+		{"any", admin, other, nil},
+		{"no permission", user, authz.Resource{Type: "event", OwnerID: user.UserID}, domain.ErrPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Authorize(ctx, tt.subject, authz.EventPricingManage, tt.resource)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authorize() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if !policy.Can(ctx, organizer, authz.EventPricingManage) || !policy.Can(ctx, admin, authz.EventPricingManage) {
+		t.Error("Can() = false for a role granted the permission")
+	}
+	if policy.Can(ctx, user, authz.EventPricingManage) {
+		t.Error("Can() = true for a role without the permission")
+	}
+	if policy.CanAny(ctx, organizer, authz.EventPricingManage) || !policy.CanAny(ctx, admin, authz.EventPricingManage) {
+		t.Error("CanAny() does not match the any permissions")
+	}
+}
+
+func TestPolicy_APIKeysNeverActOnAny(t *testing.T) {
+	policy := newPolicy(t, authz.Config{Roles: map[domain.UserRole][]authz.Permission{
+		domain.UserRoleUser:      {},
+		domain.UserRoleOrganizer: {},
+		domain.UserRoleAdmin:     {authz.BookingCancel.Any()},
+	}})
+	ctx := context.Background()
+	key := authz.Subject{UserID: uuid.New(), Role: domain.UserRoleAdmin, APIKeyID: uuid.New()}
+
+	if policy.CanAny(ctx, key, authz.BookingCancel) {
+		t.Error("CanAny() = true for an API key")
+	}
+	if policy.Can(ctx, key, authz.BookingCancel) {
+		t.Error("Can() = true for an API key holding only the any permission")
+	}
+	resource := authz.Resource{Type: "booking", ID: uuid.New(), OwnerID: uuid.New()}
+	if err := policy.Authorize(ctx, key, authz.BookingCancel, resource); !errors.Is(err, domain.ErrPermissionDenied) {
+		t.Errorf("Authorize() error = %v, want %v", err, domain.ErrPermissionDenied)
+	}
+}
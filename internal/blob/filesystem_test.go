@@ -0,0 +1,35 @@
+package blob
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSystemStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewFileSystemStore(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, "receipts/a.pdf", []byte("first")))
+	require.NoError(t, store.Put(ctx, "receipts/a.pdf", []byte("second")))
+
+	data, err := store.Get(ctx, "receipts/a.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("second"), data)
+
+	_, err = store.Get(ctx, "receipts/missing.pdf")
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+
+	require.NoError(t, store.Delete(ctx, "receipts/a.pdf"))
+	require.NoError(t, store.Delete(ctx, "receipts/a.pdf"))
+	_, err = store.Get(ctx, "receipts/a.pdf")
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+
+	for _, key := range []string{"", "../escape", "receipts/../../escape", "/absolute", `receipts\a.pdf`} {
+		assert.Error(t, store.Put(ctx, key, []byte("x")), key)
+	}
+}
@@ -0,0 +1,103 @@
+// Package currency converts money between currencies using pluggable exchange rate sources.
+package currency
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// RateSource provides exchange rates. A rate is the number of major units of
+// the target currency one major unit of the source currency buys.
+type RateSource interface {
+	Rate(ctx context.Context, from, to domain.Currency) (*big.Rat, error)
+}
+
+// StaticRateSource serves rates from a fixed table expressed against a base
+// currency. Cross rates are derived through the base.
+type StaticRateSource struct {
+	base  domain.Currency
+	rates map[domain.Currency]*big.Rat
+}
+
+// NewStaticRateSource builds a rate table from decimal strings, e.g. "1.08",
+// giving the value of one unit of base in each currency.
+func NewStaticRateSource(base domain.Currency, rates map[domain.Currency]string) (*StaticRateSource, error) {
+	source := &StaticRateSource{
+		base:  base,
+		rates: map[domain.Currency]*big.Rat{base: big.NewRat(1, 1)},
+	}
+	for code, value := range rates {
+		rate, ok := new(big.Rat).SetString(value)
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid rate %q for %s", value, code)
+		}
+		source.rates[code] = rate
+	}
+	return source, nil
+}
+
+// DefaultRateSource returns a static EUR-based table. It is meant for
+// development and offline tests, not for settling real payments.
+func DefaultRateSource() *StaticRateSource {
+	source, err := NewStaticRateSource(domain.DefaultCurrency, map[domain.Currency]string{
+		"CHF": "0.95",
+		"CZK": "25",
+		"GBP": "0.85",
+		"JPY": "160",
+		"PLN": "4.30",
+		"SEK": "11.50",
+		"USD": "1.08",
+	})
+	if err != nil {
+		panic(err)
+	}
+	return source
+}
+
+// Rate implements RateSource.
+func (s *StaticRateSource) Rate(_ context.Context, from, to domain.Currency) (*big.Rat, error) {
+	fromRate, ok := s.rates[from]
+	if !ok {
+		return nil, domain.ErrExchangeRateMissing
+	}
+	toRate, ok := s.rates[to]
+	if !ok {
+		return nil, domain.ErrExchangeRateMissing
+	}
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
+
+// Convert applies rate to amount and rounds half away from zero to the
+// minor unit of the target currency.
+func Convert(amount domain.Money, rate *big.Rat, to domain.Currency) (domain.Money, error) {
+	value := new(big.Rat).SetInt64(amount.Amount())
+	value.Mul(value, rate)
+	value.Mul(value, pow10(to.MinorUnits()))
+	value.Quo(value, pow10(amount.Currency().MinorUnits()))
+
+	rounded := roundHalfAway(value)
+	if !rounded.IsInt64() {
+		return domain.Money{}, fmt.Errorf("converted amount of %s overflows", amount)
+	}
+	return domain.NewMoney(rounded.Int64(), to)
+}
+
+func pow10(n int) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil))
+}
+
+func roundHalfAway(value *big.Rat) *big.Int {
+	num := new(big.Int).Abs(value.Num())
+	den := value.Denom()
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Mul(rem, big.NewInt(2)).Cmp(den) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	if value.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	return quo
+}
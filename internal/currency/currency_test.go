@@ -0,0 +1,84 @@
+package currency
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticRateSource_Rate(t *testing.T) {
+	source, err := NewStaticRateSource("EUR", map[domain.Currency]string{"USD": "1.25", "PLN": "4"})
+	require.NoError(t, err)
+
+	rate, err := source.Rate(context.Background(), "USD", "PLN")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(16, 5), rate)
+
+	rate, err = source.Rate(context.Background(), "EUR", "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewRat(1, 1), rate)
+
+	_, err = source.Rate(context.Background(), "EUR", "XYZ")
+	assert.ErrorIs(t, err, domain.ErrExchangeRateMissing)
+
+	_, err = NewStaticRateSource("EUR", map[domain.Currency]string{"USD": "-1"})
+	assert.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount domain.Money
+		rate   *big.Rat
+		to     domain.Currency
+		want   domain.Money
+	}{
+		{
+			name:   "two to two decimals",
+			amount: domain.UnmarshalMoney(2500, "EUR"),
+			rate:   big.NewRat(108, 100),
+			to:     "USD",
+			want:   domain.UnmarshalMoney(2700, "USD"),
+		},
+		{
+			name:   "rounds half away from zero",
+			amount: domain.UnmarshalMoney(1, "EUR"),
+			rate:   big.NewRat(1, 2),
+			to:     "USD",
+			want:   domain.UnmarshalMoney(1, "USD"),
+		},
+		{
+			name:   "to zero decimals",
+			amount: domain.UnmarshalMoney(2599, "EUR"),
+			rate:   big.NewRat(160, 1),
+			to:     "JPY",
+			want:   domain.UnmarshalMoney(4158, "JPY"),
+		},
+		{
+			name:   "to three decimals",
+			amount: domain.UnmarshalMoney(100, "EUR"),
+			rate:   big.NewRat(33, 100),
+			to:     "KWD",
+			want:   domain.UnmarshalMoney(330, "KWD"),
+		},
+		{
+			name:   "negative amount",
+			amount: domain.UnmarshalMoney(-3, "EUR"),
+			rate:   big.NewRat(1, 2),
+			to:     "USD",
+			want:   domain.UnmarshalMoney(-2, "USD"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Convert(tt.amount, tt.rate, tt.to)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
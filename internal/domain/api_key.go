@@ -0,0 +1,231 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// apiKeyTag starts every API key, so leaked keys are easy to spot.
+	apiKeyTag = "gtk"
+	// apiKeyPrefixLength is the length of the public part of a key, which
+	// finds it in storage and tells keys apart in listings.
+	apiKeyPrefixLength = 12
+	// APIKeyUsageInterval is how often the last use of a key is recorded.
+	APIKeyUsageInterval = time.Minute
+	maxAPIKeyNameLength = 100
+)
+
+// APIKeyScope is something an API key may do.
+type APIKeyScope string
+
+const (
+	APIKeyScopeEventsRead    APIKeyScope = "events:read"
+	APIKeyScopeEventsWrite   APIKeyScope = "events:write"
+	APIKeyScopeBookingsRead  APIKeyScope = "bookings:read"
+	APIKeyScopeBookingsWrite APIKeyScope = "bookings:write"
+)
+
+var apiKeyScopes = []APIKeyScope{
+	APIKeyScopeEventsRead,
+	APIKeyScopeEventsWrite,
+	APIKeyScopeBookingsRead,
+	APIKeyScopeBookingsWrite,
+}
+
+// ParseAPIKeyScopes validates scope names and returns them sorted, without
+// duplicates. A key needs at least one scope.
+func ParseAPIKeyScopes(names []string) ([]APIKeyScope, error) {
+	scopes := make([]APIKeyScope, 0, len(names))
+	for _, name := range names {
+		scope := APIKeyScope(name)
+		if !slices.Contains(apiKeyScopes, scope) {
+			return nil, ErrAPIKeyScopeInvalid
+		}
+		scopes = append(scopes, scope)
+	}
+	if len(scopes) == 0 {
+		return nil, ErrAPIKeyScopeInvalid
+	}
+	return slices.Compact(slices.Sorted(slices.Values(scopes))), nil
+}
+
+// APIKey lets a machine such as a box-office kiosk call the API on behalf
+// of an organization. It acts as the member who created it, within its
+// organization and scopes only. Only the hash of its secret is stored.
+type APIKey struct {
+	id             uuid.UUID
+	organizationID uuid.UUID
+	createdBy      uuid.UUID
+	name           string
+	prefix         string
+	secretHash     string
+	scopes         []APIKeyScope
+	expiresAt      time.Time
+	lastUsedAt     time.Time
+	createdAt      time.Time
+}
+
+// NewAPIKey creates a key and returns it with the key to hand out, shown
+// this one time. A zero expiresAt makes a key that never expires.
+func NewAPIKey(
+	organizationID, createdBy uuid.UUID,
+	name string,
+	scopes []APIKeyScope,
+	expiresAt time.Time,
+	now time.Time,
+) (*APIKey, string, error) {
+	key := &APIKey{
+		id:             uuid.New(),
+		organizationID: organizationID,
+		createdBy:      createdBy,
+		prefix:         strings.ToLower(rand.Text()[:apiKeyPrefixLength]),
+		expiresAt:      expiresAt,
+		createdAt:      now,
+	}
+	if err := key.Update(name, scopes); err != nil {
+		return nil, "", err
+	}
+	if !expiresAt.IsZero() && !expiresAt.After(now) {
+		return nil, "", ErrAPIKeyExpiryInvalid
+	}
+	secret := rand.Text()
+	key.secretHash = HashToken(secret)
+	return key, apiKeyTag + "_" + key.prefix + "_" + secret, nil
+}
+
+// UnmarshalAPIKey rebuilds an APIKey from persisted values. Zero expiresAt
+// and lastUsedAt mean the key never expires and was never used.
+func UnmarshalAPIKey(
+	id, organizationID, createdBy uuid.UUID,
+	name, prefix, secretHash string,
+	scopes []APIKeyScope,
+	expiresAt, lastUsedAt, createdAt time.Time,
+) *APIKey {
+	return &APIKey{
+		id:             id,
+		organizationID: organizationID,
+		createdBy:      createdBy,
+		name:           name,
+		prefix:         prefix,
+		secretHash:     secretHash,
+		scopes:         scopes,
+		expiresAt:      expiresAt,
+		lastUsedAt:     lastUsedAt,
+		createdAt:      createdAt,
+	}
+}
+
+// SplitAPIKey returns the prefix and the secret of a key handed out by
+// NewAPIKey.
+func SplitAPIKey(key string) (prefix, secret string, err error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyTag || len(parts[1]) != apiKeyPrefixLength || parts[2] == "" {
+		return "", "", ErrAPIKeyInvalid
+	}
+	return parts[1], parts[2], nil
+}
+
+// Verify checks the secret of a key and that the key has not expired.
+func (k *APIKey) Verify(secret string, now time.Time) error {
+	if subtle.ConstantTimeCompare([]byte(HashToken(secret)), []byte(k.secretHash)) != 1 {
+		return ErrAPIKeyInvalid
+	}
+	if !k.expiresAt.IsZero() && !now.Before(k.expiresAt) {
+		return ErrAPIKeyInvalid
+	}
+	return nil
+}
+
+// Allows reports whether the key has a scope.
+func (k *APIKey) Allows(scope APIKeyScope) bool {
+	return slices.Contains(k.scopes, scope)
+}
+
+// Update renames the key and replaces its scopes.
+func (k *APIKey) Update(name string, scopes []APIKeyScope) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrAPIKeyNameEmpty
+	}
+	if len(name) > maxAPIKeyNameLength {
+		name = name[:maxAPIKeyNameLength]
+	}
+	if len(scopes) == 0 {
+		return ErrAPIKeyScopeInvalid
+	}
+	k.name = name
+	k.scopes = scopes
+	return nil
+}
+
+// Use records a use of the key. It reports whether the use is worth
+// storing, which it is once every APIKeyUsageInterval.
+func (k *APIKey) Use(now time.Time) bool {
+	if now.Sub(k.lastUsedAt) < APIKeyUsageInterval {
+		return false
+	}
+	k.lastUsedAt = now
+	return true
+}
+
+func (k *APIKey) ID() uuid.UUID {
+	return k.id
+}
+
+func (k *APIKey) OrganizationID() uuid.UUID {
+	return k.organizationID
+}
+
+func (k *APIKey) CreatedBy() uuid.UUID {
+	return k.createdBy
+}
+
+func (k *APIKey) Name() string {
+	return k.name
+}
+
+func (k *APIKey) Prefix() string {
+	return k.prefix
+}
+
+func (k *APIKey) SecretHash() string {
+	return k.secretHash
+}
+
+func (k *APIKey) Scopes() []APIKeyScope {
+	return k.scopes
+}
+
+func (k *APIKey) ExpiresAt() time.Time {
+	return k.expiresAt
+}
+
+func (k *APIKey) LastUsedAt() time.Time {
+	return k.lastUsedAt
+}
+
+func (k *APIKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+// APIKeyRepository defines the interface for API key persistence.
+type APIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, key *APIKey) error
+	// GetAPIKey returns ErrAPIKeyNotFound for unknown keys.
+	GetAPIKey(ctx context.Context, id uuid.UUID) (*APIKey, error)
+	// GetAPIKeyByPrefix returns ErrAPIKeyNotFound for unknown keys.
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	// ListAPIKeys returns the keys of an organization, newest first.
+	ListAPIKeys(ctx context.Context, organizationID uuid.UUID) ([]*APIKey, error)
+	UpdateAPIKey(ctx context.Context, key *APIKey) error
+	// MarkAPIKeyUsed stores the last use of a key.
+	MarkAPIKeyUsed(ctx context.Context, key *APIKey) error
+	DeleteAPIKey(ctx context.Context, id uuid.UUID) error
+}
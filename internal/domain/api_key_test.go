@@ -0,0 +1,106 @@
+package domain_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestParseAPIKeyScopes(t *testing.T) {
+	scopes, err := domain.ParseAPIKeyScopes([]string{"events:read", "bookings:write", "events:read"})
+	if err != nil {
+		t.Fatalf("ParseAPIKeyScopes() error = %v", err)
+	}
+	want := []domain.APIKeyScope{domain.APIKeyScopeBookingsWrite, domain.APIKeyScopeEventsRead}
+	if !slices.Equal(scopes, want) {
+		t.Errorf("ParseAPIKeyScopes() = %v, want %v", scopes, want)
+	}
+
+	for _, names := range [][]string{nil, {"events:delete"}} {
+		if _, err := domain.ParseAPIKeyScopes(names); !errors.Is(err, domain.ErrAPIKeyScopeInvalid) {
+			t.Errorf("ParseAPIKeyScopes(%v) error = %v, want %v", names, err, domain.ErrAPIKeyScopeInvalid)
+		}
+	}
+}
+
+func TestAPIKey_Verify(t *testing.T) {
+	now := time.Now()
+	scopes := []domain.APIKeyScope{domain.APIKeyScopeEventsRead}
+	key, raw, err := domain.NewAPIKey(uuid.New(), uuid.New(), "Kiosk", scopes, now.Add(time.Hour), now)
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+
+	prefix, secret, err := domain.SplitAPIKey(raw)
+	if err != nil {
+		t.Fatalf("SplitAPIKey() error = %v", err)
+	}
+	if prefix != key.Prefix() {
+		t.Errorf("SplitAPIKey() prefix = %q, want %q", prefix, key.Prefix())
+	}
+	if err := key.Verify(secret, now); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+	if err := key.Verify(secret+"x", now); !errors.Is(err, domain.ErrAPIKeyInvalid) {
+		t.Errorf("Verify() with a wrong secret error = %v, want %v", err, domain.ErrAPIKeyInvalid)
+	}
+	if err := key.Verify(secret, now.Add(time.Hour)); !errors.Is(err, domain.ErrAPIKeyInvalid) {
+		t.Errorf("Verify() after expiry error = %v, want %v", err, domain.ErrAPIKeyInvalid)
+	}
+	if !key.Allows(domain.APIKeyScopeEventsRead) || key.Allows(domain.APIKeyScopeBookingsWrite) {
+		t.Errorf("Allows() does not match scopes %v", key.Scopes())
+	}
+
+	for _, malformed := range []string{"", raw[4:], "gtk_short_secret", raw + "_extra"} {
+		if _, _, err := domain.SplitAPIKey(malformed); !errors.Is(err, domain.ErrAPIKeyInvalid) {
+			t.Errorf("SplitAPIKey(%q) error = %v, want %v", malformed, err, domain.ErrAPIKeyInvalid)
+		}
+	}
+}
+
+func TestNewAPIKey_Validation(t *testing.T) {
+	now := time.Now()
+	scopes := []domain.APIKeyScope{domain.APIKeyScopeEventsRead}
+	tests := []struct {
+		name      string
+		keyName   string
+		scopes    []domain.APIKeyScope
+		expiresAt time.Time
+		wantErr   error
+	}{
+		{"never expires", "Kiosk", scopes, time.Time{}, nil},
+		{"empty name", "  ", scopes, time.Time{}, domain.ErrAPIKeyNameEmpty},
+		{"no scopes", "Kiosk", nil, time.Time{}, domain.ErrAPIKeyScopeInvalid},
+		{"expired", "Kiosk", scopes, now, domain.ErrAPIKeyExpiryInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := domain.NewAPIKey(uuid.New(), uuid.New(), tt.keyName, tt.scopes, tt.expiresAt, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewAPIKey() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPIKey_Use(t *testing.T) {
+	now := time.Now()
+	scopes := []domain.APIKeyScope{domain.APIKeyScopeEventsRead}
+	key, _, err := domain.NewAPIKey(uuid.New(), uuid.New(), "Kiosk", scopes, time.Time{}, now)
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+	if !key.Use(now) {
+		t.Error("Use() of an unused key = false, want true")
+	}
+	if key.Use(now.Add(time.Second)) {
+		t.Error("Use() right after the last use = true, want false")
+	}
+	if !key.Use(now.Add(domain.APIKeyUsageInterval)) {
+		t.Error("Use() an interval after the last use = false, want true")
+	}
+}
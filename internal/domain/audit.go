@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction names an administrative change recorded in the audit log.
+type AuditAction string
+
+const (
+	AuditUserRoleChanged     AuditAction = "user.role_changed"
+	AuditUserSuspended       AuditAction = "user.suspended"
+	AuditUserUnsuspended     AuditAction = "user.unsuspended"
+	AuditUserSessionsRevoked AuditAction = "user.sessions_revoked"
+	AuditUserErased          AuditAction = "user.erased"
+	AuditOrganizerApproved   AuditAction = "user.organizer_approved"
+	AuditOrganizerRejected   AuditAction = "user.organizer_rejected"
+	AuditMemberAdded         AuditAction = "organization.member_added"
+	AuditMemberRemoved       AuditAction = "organization.member_removed"
+	AuditUserTwoFactorReset  AuditAction = "user.two_factor_reset"
+	AuditTwoFactorRequired   AuditAction = "security.two_factor_required"
+	AuditAPIKeyCreated       AuditAction = "organization.api_key_created"
+	AuditAPIKeyUpdated       AuditAction = "organization.api_key_updated"
+	AuditAPIKeyRevoked       AuditAction = "organization.api_key_revoked"
+)
+
+const (
+	// AuditTargetUser is the target type of changes made to user accounts.
+	AuditTargetUser = "user"
+	// AuditTargetOrganization is the target type of changes made to
+	// organizations, their members and their API keys.
+	AuditTargetOrganization = "organization"
+	// AuditTargetSecurityPolicy is the target type of changes made to
+	// platform-wide security settings. Their target ID is uuid.Nil.
+	AuditTargetSecurityPolicy = "security_policy"
+)
+
+// AuditRecord tells who changed what and when. Details hold the values that
+// explain the change, such as the previous and the new role.
+type AuditRecord struct {
+	id         uuid.UUID
+	actorID    uuid.UUID
+	action     AuditAction
+	targetType string
+	targetID   uuid.UUID
+	details    map[string]string
+	createdAt  time.Time
+}
+
+func NewAuditRecord(
+	actorID uuid.UUID,
+	action AuditAction,
+	targetType string,
+	targetID uuid.UUID,
+	details map[string]string,
+	now time.Time,
+) *AuditRecord {
+	if details == nil {
+		details = map[string]string{}
+	}
+	return &AuditRecord{
+		id:         uuid.New(),
+		actorID:    actorID,
+		action:     action,
+		targetType: targetType,
+		targetID:   targetID,
+		details:    details,
+		createdAt:  now,
+	}
+}
+
+// UnmarshalAuditRecord rebuilds an AuditRecord from persisted values. A nil
+// actorID means the acting account was deleted.
+func UnmarshalAuditRecord(
+	id uuid.UUID,
+	actorID uuid.UUID,
+	action AuditAction,
+	targetType string,
+	targetID uuid.UUID,
+	details map[string]string,
+	createdAt time.Time,
+) *AuditRecord {
+	return &AuditRecord{
+		id:         id,
+		actorID:    actorID,
+		action:     action,
+		targetType: targetType,
+		targetID:   targetID,
+		details:    details,
+		createdAt:  createdAt,
+	}
+}
+
+func (r *AuditRecord) ID() uuid.UUID {
+	return r.id
+}
+
+func (r *AuditRecord) ActorID() uuid.UUID {
+	return r.actorID
+}
+
+func (r *AuditRecord) Action() AuditAction {
+	return r.action
+}
+
+func (r *AuditRecord) TargetType() string {
+	return r.targetType
+}
+
+func (r *AuditRecord) TargetID() uuid.UUID {
+	return r.targetID
+}
+
+func (r *AuditRecord) Details() map[string]string {
+	return r.details
+}
+
+func (r *AuditRecord) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// AuditRepository defines the interface for audit log persistence.
+type AuditRepository interface {
+	CreateAuditRecord(ctx context.Context, record *AuditRecord) error
+	// ListAuditRecords returns the latest records about a target, newest first.
+	ListAuditRecords(ctx context.Context, targetType string, targetID uuid.UUID, limit int) ([]*AuditRecord, error)
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+// BlobStore keeps generated documents such as receipts. Keys are slash
+// separated paths, e.g. "receipts/<booking id>.pdf".
+type BlobStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns ErrBlobNotFound when nothing is stored under the key.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Delete removes the blob stored under key, if any.
+	Delete(ctx context.Context, key string) error
+}
@@ -23,16 +23,22 @@ type Booking struct {
 	createdAt time.Time
 	updatedAt time.Time
 	status    BookingStatus
+	price     Money
+	lineItems []LineItem
+	orderID   uuid.UUID
 }
 
 type BookingRepository interface {
 	CreateBooking(ctx context.Context, booking *Booking) error
 	GetBookingByID(ctx context.Context, id uuid.UUID) (*Booking, error)
 	ListBookings(ctx context.Context) ([]Booking, error)
+	ListBookingsByUserEmail(ctx context.Context, userEmail string) ([]*Booking, error)
+	ListBookingsByOrder(ctx context.Context, orderID uuid.UUID) ([]*Booking, error)
 	UpdateBooking(ctx context.Context, booking *Booking) error
 	DeleteBooking(ctx context.Context, id uuid.UUID) error
 	ConfirmBooking(ctx context.Context, id uuid.UUID) error
 	CancelBooking(ctx context.Context, id uuid.UUID) error
+	RefundBooking(ctx context.Context, id uuid.UUID) error
 }
 
 func NewBooking(id uuid.UUID, eventID uuid.UUID, userEmail string, status BookingStatus) (*Booking, error) {
@@ -60,7 +66,7 @@ func NewBooking(id uuid.UUID, eventID uuid.UUID, userEmail string, status Bookin
 
 func (b *Booking) Confirm() error {
 	if b.status == BookingStatusCancelled {
-		return errors.New("cannot confirm a cancelled booking")
+		return ErrBookingCancelled
 	}
 	b.status = BookingStatusConfirmed
 	b.updatedAt = time.Now()
@@ -76,6 +82,16 @@ func (b *Booking) Cancel() error {
 	return nil
 }
 
+// Refund cancels a confirmed booking whose payment is returned.
+func (b *Booking) Refund() error {
+	if b.status != BookingStatusConfirmed {
+		return ErrBookingNotRefundable
+	}
+	b.status = BookingStatusCancelled
+	b.updatedAt = time.Now()
+	return nil
+}
+
 func (b *Booking) ID() uuid.UUID {
 	return b.id
 }
@@ -92,6 +108,87 @@ func (b *Booking) Status() BookingStatus {
 	return b.status
 }
 
+// Price returns the price locked into the booking at reservation time.
+func (b *Booking) Price() Money {
+	return b.price
+}
+
+// LockPrice records the price quoted when the spot was reserved together
+// with its fee and tax breakdown.
+func (b *Booking) LockPrice(breakdown PriceBreakdown) error {
+	if breakdown.Base.IsNegative() {
+		return ErrBookingPriceNegative
+	}
+	if _, err := ParseCurrency(string(breakdown.Base.Currency())); err != nil {
+		return err
+	}
+	b.price = breakdown.Base
+	b.lineItems = breakdown.LineItems()
+	b.updatedAt = time.Now()
+	return nil
+}
+
+// LineItems returns the monetary breakdown locked into the booking. Bookings
+// made before fees were tracked have none.
+func (b *Booking) LineItems() []LineItem {
+	return b.lineItems
+}
+
+// Total returns the sum of the line items, or the price when there are none.
+func (b *Booking) Total() Money {
+	if len(b.lineItems) == 0 {
+		return b.price
+	}
+	total := b.price.WithAmount(0)
+	for _, item := range b.lineItems {
+		total = total.WithAmount(total.Amount() + item.Amount.Amount())
+	}
+	return total
+}
+
+// RestoreLineItems attaches persisted line items to a booking rebuilt by UnmarshalBooking.
+func (b *Booking) RestoreLineItems(items []LineItem) {
+	b.lineItems = items
+}
+
+// ApplyCredit pays part of the total from the customer's wallet by adding a
+// negative wallet credit line item. It must follow LockPrice.
+func (b *Booking) ApplyCredit(credit Money) error {
+	if credit.Currency() != b.Total().Currency() {
+		return ErrCurrencyMismatch
+	}
+	if credit.IsNegative() || credit.Amount() > b.Total().Amount() {
+		return ErrWalletInsufficientFunds
+	}
+	if len(b.lineItems) == 0 {
+		b.lineItems = []LineItem{{Kind: LineItemBase, Amount: b.price}}
+	}
+	b.lineItems = append(b.lineItems, LineItem{Kind: LineItemWalletCredit, Amount: credit.WithAmount(-credit.Amount())})
+	b.updatedAt = time.Now()
+	return nil
+}
+
+// CreditApplied returns the wallet credit spent on the booking.
+func (b *Booking) CreditApplied() Money {
+	credit := b.Total().WithAmount(0)
+	for _, item := range b.lineItems {
+		if item.Kind == LineItemWalletCredit {
+			credit = credit.WithAmount(credit.Amount() - item.Amount.Amount())
+		}
+	}
+	return credit
+}
+
+// OrderID returns the group order the booking belongs to, or uuid.Nil.
+func (b *Booking) OrderID() uuid.UUID {
+	return b.orderID
+}
+
+// AssignOrder records the group order the booking belongs to.
+func (b *Booking) AssignOrder(orderID uuid.UUID) {
+	b.orderID = orderID
+}
+
 func (b *Booking) CreatedAt() time.Time {
 	return b.createdAt
 }
@@ -107,6 +204,7 @@ func UnmarshalBooking(
 	status BookingStatus,
 	createdAt time.Time,
 	updatedAt time.Time,
+	price Money,
 ) *Booking {
 	return &Booking{
 		id:        id,
@@ -115,5 +213,6 @@ func UnmarshalBooking(
 		status:    status,
 		createdAt: createdAt,
 		updatedAt: updatedAt,
+		price:     price,
 	}
 }
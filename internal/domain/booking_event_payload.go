@@ -7,9 +7,12 @@ import (
 )
 
 type BookingEventPayload struct {
-	ID        uuid.UUID `json:"id"`
-	EventID   uuid.UUID `json:"eventID"`
-	UserEmail string    `json:"userEmail"`
-	CreatedAt time.Time `json:"createdAt"`
-	Status    string    `json:"status"`
+	ID        uuid.UUID  `json:"id"`
+	EventID   uuid.UUID  `json:"eventID"`
+	UserEmail string     `json:"userEmail"`
+	CreatedAt time.Time  `json:"createdAt"`
+	Status    string     `json:"status"`
+	Price     Money      `json:"price"`
+	LineItems []LineItem `json:"lineItems,omitempty"`
+	Total     Money      `json:"total"`
 }
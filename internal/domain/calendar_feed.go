@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeed holds the secret the booking calendar feed URL of a user is
+// bound to. Regenerating it turns away the URLs handed out before.
+type CalendarFeed struct {
+	userID    uuid.UUID
+	secret    string
+	createdAt time.Time
+}
+
+// NewCalendarFeed issues a feed secret for a user.
+func NewCalendarFeed(userID uuid.UUID, now time.Time) *CalendarFeed {
+	return &CalendarFeed{userID: userID, secret: rand.Text(), createdAt: now}
+}
+
+// UnmarshalCalendarFeed rebuilds a CalendarFeed from persisted values.
+func UnmarshalCalendarFeed(userID uuid.UUID, secret string, createdAt time.Time) *CalendarFeed {
+	return &CalendarFeed{userID: userID, secret: secret, createdAt: createdAt}
+}
+
+// Matches reports whether secret is the current secret of the feed.
+func (f *CalendarFeed) Matches(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(f.secret), []byte(secret)) == 1
+}
+
+func (f *CalendarFeed) UserID() uuid.UUID {
+	return f.userID
+}
+
+func (f *CalendarFeed) Secret() string {
+	return f.secret
+}
+
+func (f *CalendarFeed) CreatedAt() time.Time {
+	return f.createdAt
+}
+
+// CalendarFeedRepository defines the interface for calendar feed persistence.
+type CalendarFeedRepository interface {
+	// GetCalendarFeed returns ErrCalendarFeedNotFound for users who never
+	// asked for their feed URL.
+	GetCalendarFeed(ctx context.Context, userID uuid.UUID) (*CalendarFeed, error)
+	// CreateCalendarFeed keeps the feed a user already has.
+	CreateCalendarFeed(ctx context.Context, feed *CalendarFeed) error
+	// SaveCalendarFeed replaces the feed a user already has.
+	SaveCalendarFeed(ctx context.Context, feed *CalendarFeed) error
+}
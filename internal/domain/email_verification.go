@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EmailVerificationTokenTTL is how long an email verification link works.
+const EmailVerificationTokenTTL = 24 * time.Hour
+
+// EmailVerificationToken proves that a user can read mail sent to their
+// address. Only its hash is stored.
+type EmailVerificationToken struct {
+	id        uuid.UUID
+	userID    uuid.UUID
+	tokenHash string
+	expiresAt time.Time
+	createdAt time.Time
+	usedAt    time.Time
+}
+
+// NewEmailVerificationToken issues a verification token for a user and
+// returns it with its secret value.
+func NewEmailVerificationToken(userID uuid.UUID, now time.Time) (*EmailVerificationToken, string) {
+	secret := rand.Text()
+	return &EmailVerificationToken{
+		id:        uuid.New(),
+		userID:    userID,
+		tokenHash: HashToken(secret),
+		expiresAt: now.Add(EmailVerificationTokenTTL),
+		createdAt: now,
+	}, secret
+}
+
+// UnmarshalEmailVerificationToken rebuilds an EmailVerificationToken from
+// persisted values. A zero usedAt means the token was not used.
+func UnmarshalEmailVerificationToken(
+	id uuid.UUID,
+	userID uuid.UUID,
+	tokenHash string,
+	expiresAt time.Time,
+	createdAt time.Time,
+	usedAt time.Time,
+) *EmailVerificationToken {
+	return &EmailVerificationToken{
+		id:        id,
+		userID:    userID,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		createdAt: createdAt,
+		usedAt:    usedAt,
+	}
+}
+
+// Use marks the token as used. Used and expired tokens yield
+// ErrVerifyTokenInvalid.
+func (t *EmailVerificationToken) Use(now time.Time) error {
+	if !t.usedAt.IsZero() || !now.Before(t.expiresAt) {
+		return ErrVerifyTokenInvalid
+	}
+	t.usedAt = now
+	return nil
+}
+
+func (t *EmailVerificationToken) ID() uuid.UUID {
+	return t.id
+}
+
+func (t *EmailVerificationToken) UserID() uuid.UUID {
+	return t.userID
+}
+
+func (t *EmailVerificationToken) TokenHash() string {
+	return t.tokenHash
+}
+
+func (t *EmailVerificationToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *EmailVerificationToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *EmailVerificationToken) UsedAt() time.Time {
+	return t.usedAt
+}
+
+// EmailVerificationRepository defines the interface for email verification token persistence.
+type EmailVerificationRepository interface {
+	CreateEmailVerificationToken(ctx context.Context, token *EmailVerificationToken) error
+	// GetEmailVerificationTokenForUpdate looks a token up by its secret value
+	// and locks it; it returns ErrVerifyTokenInvalid for unknown tokens.
+	GetEmailVerificationTokenForUpdate(ctx context.Context, secret string) (*EmailVerificationToken, error)
+	// UseEmailVerificationTokens marks every outstanding token of a user used.
+	UseEmailVerificationTokens(ctx context.Context, userID uuid.UUID, now time.Time) error
+}
@@ -0,0 +1,48 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestEmailVerificationToken_UseOnceBeforeExpiry(t *testing.T) {
+	now := time.Now()
+	token, secret := domain.NewEmailVerificationToken(uuid.New(), now)
+	if token.TokenHash() != domain.HashToken(secret) || token.TokenHash() == secret {
+		t.Error("NewEmailVerificationToken() should store the hash of the secret only")
+	}
+
+	if err := token.Use(now.Add(domain.EmailVerificationTokenTTL)); !errors.Is(err, domain.ErrVerifyTokenInvalid) {
+		t.Errorf("Use() after expiry error = %v, want %v", err, domain.ErrVerifyTokenInvalid)
+	}
+	if err := token.Use(now); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	if err := token.Use(now); !errors.Is(err, domain.ErrVerifyTokenInvalid) {
+		t.Errorf("Use() twice error = %v, want %v", err, domain.ErrVerifyTokenInvalid)
+	}
+}
+
+func TestUser_StartsUnverified(t *testing.T) {
+	user, err := domain.NewUser(uuid.New(), "new@example.com", "hash", domain.UserRoleUser)
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	if user.IsVerified() || user.Status() != domain.UserStatusUnverified {
+		t.Fatalf("NewUser() status = %s, want %s", user.Status(), domain.UserStatusUnverified)
+	}
+
+	if err := user.Verify(); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if !user.IsVerified() {
+		t.Errorf("Status() = %s after Verify(), want %s", user.Status(), domain.UserStatusActive)
+	}
+	if err := user.Verify(); !errors.Is(err, domain.ErrUserAlreadyVerified) {
+		t.Errorf("Verify() twice error = %v, want %v", err, domain.ErrUserAlreadyVerified)
+	}
+}
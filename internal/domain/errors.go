@@ -16,6 +16,30 @@ var (
 	ErrEventIDNil = errors.New("id is nil")
 	// ErrEventCapacityTooLarge is returned when the capacity is too large.
 	ErrEventCapacityTooLarge = errors.New("capacity is too large")
+	// ErrEventShardsInvalid is returned when the shard count is out of range.
+	ErrEventShardsInvalid = errors.New("inventory shards out of range")
+	// ErrEventNotOnSale is returned when booking before general sales open without a valid presale code.
+	ErrEventNotOnSale = errors.New("event is not on sale yet")
+	// ErrEventSalesClosed is returned when booking after the sales window has ended.
+	ErrEventSalesClosed = errors.New("event sales are closed")
+	// ErrSalesWindowInvalid is returned when the sales window or a presale window ends before it starts.
+	ErrSalesWindowInvalid = errors.New("sales window start must be before its end")
+	// ErrVenueCountryInvalid is returned when the venue country is not a two-letter ISO 3166 code.
+	ErrVenueCountryInvalid = errors.New("invalid venue country")
+	// ErrEventTimeZoneInvalid is returned when the time zone is not an IANA time zone.
+	ErrEventTimeZoneInvalid = errors.New("invalid event time zone")
+)
+
+// Presale errors
+var (
+	// ErrPresaleNotFound is returned when the presale does not exist.
+	ErrPresaleNotFound = errors.New("presale not found")
+	// ErrPresaleNameEmpty is returned when the presale name is empty.
+	ErrPresaleNameEmpty = errors.New("presale name is empty")
+	// ErrAccessCodeEmpty is returned when a presale is created without an access code.
+	ErrAccessCodeEmpty = errors.New("access code is empty")
+	// ErrAccessCodeInvalid is returned when the access code does not match any active presale.
+	ErrAccessCodeInvalid = errors.New("access code is invalid")
 )
 
 // Booking errors
@@ -30,6 +54,82 @@ var (
 	ErrBookingUserEmailEmpty = errors.New("userEmail is empty")
 	// ErrBookingStatusInvalid is returned when the status is invalid.
 	ErrBookingStatusInvalid = errors.New("invalid status")
+	// ErrBookingPriceNegative is returned when the locked price is negative.
+	ErrBookingPriceNegative = errors.New("price is negative")
+	// ErrBookingCancelled is returned when confirming a cancelled booking.
+	ErrBookingCancelled = errors.New("cannot confirm a cancelled booking")
+	// ErrBookingNotRefundable is returned when refunding a booking that is not confirmed.
+	ErrBookingNotRefundable = errors.New("only confirmed bookings can be refunded")
+)
+
+// Order errors
+var (
+	// ErrOrderNotFound is returned when the order does not exist.
+	ErrOrderNotFound = errors.New("order not found")
+	// ErrOrderSeatsInvalid is returned when an order has no seats or more than MaxOrderSeats.
+	ErrOrderSeatsInvalid = errors.New("invalid number of seats")
+	// ErrOrderDueDateInvalid is returned when the invoice is due in the past or after the event starts.
+	ErrOrderDueDateInvalid = errors.New("invalid invoice due date")
+	// ErrOrderNotAwaitingPayment is returned when paying or expiring an order that is already settled.
+	ErrOrderNotAwaitingPayment = errors.New("order is not awaiting payment")
+)
+
+// Wallet errors
+var (
+	// ErrWalletInsufficientFunds is returned when a debit exceeds the wallet balance.
+	ErrWalletInsufficientFunds = errors.New("insufficient wallet balance")
+	// ErrGiftCardNotFound is returned when no gift card matches a code.
+	ErrGiftCardNotFound = errors.New("gift card not found")
+	// ErrGiftCardRedeemed is returned when redeeming a gift card a second time.
+	ErrGiftCardRedeemed = errors.New("gift card already redeemed")
+	// ErrWalletAmountInvalid is returned for gift cards and credits of a non-positive amount.
+	ErrWalletAmountInvalid = errors.New("amount must be positive")
+)
+
+// Receipt errors
+var (
+	// ErrReceiptNotFound is returned when no receipt was issued for the booking yet.
+	ErrReceiptNotFound = errors.New("receipt not found")
+	// ErrBlobNotFound is returned when the blob store has nothing under the key.
+	ErrBlobNotFound = errors.New("blob not found")
+)
+
+// Ledger errors
+var (
+	// ErrJournalEntryUnbalanced is returned when the lines of a journal entry do not sum to zero.
+	ErrJournalEntryUnbalanced = errors.New("journal entry is unbalanced")
+	// ErrJournalEntryExists is returned when an entry was already posted for the reference.
+	ErrJournalEntryExists = errors.New("journal entry already posted")
+	// ErrJournalEntryNotFound is returned when no entry was posted for the reference.
+	ErrJournalEntryNotFound = errors.New("journal entry not found")
+	// ErrSettlementInvalid is returned when a payout has no organizer or a non-positive amount.
+	ErrSettlementInvalid = errors.New("invalid settlement")
+)
+
+// Pricing errors
+var (
+	// ErrPricingRuleNotFound is returned when the pricing rule does not exist.
+	ErrPricingRuleNotFound = errors.New("pricing rule not found")
+	// ErrPricingRuleInvalid is returned when the trigger, threshold or adjustment is not supported.
+	ErrPricingRuleInvalid = errors.New("invalid pricing rule")
+)
+
+// Fee errors
+var (
+	// ErrFeeScheduleNotFound is returned when the organizer has no fee schedule.
+	ErrFeeScheduleNotFound = errors.New("fee schedule not found")
+	// ErrFeeScheduleInvalid is returned when the fee percentage is out of range or an amount is negative.
+	ErrFeeScheduleInvalid = errors.New("invalid fee schedule")
+)
+
+// Money errors
+var (
+	// ErrCurrencyInvalid is returned when a currency is not a three-letter ISO 4217 code.
+	ErrCurrencyInvalid = errors.New("invalid currency")
+	// ErrCurrencyMismatch is returned when combining amounts of different currencies.
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrExchangeRateMissing is returned when no rate is known for a currency pair.
+	ErrExchangeRateMissing = errors.New("exchange rate not available")
 )
 
 // User errors
@@ -43,6 +143,171 @@ var (
 	ErrUserNotFound           = errors.New("user not found")
 	ErrInvalidCredentials     = errors.New("invalid credentials")
 	ErrUserPasswordTooShort   = errors.New("password is too short")
+	// ErrUserUnverified is returned when a user who has not verified their
+	// email address tries to book.
+	ErrUserUnverified = errors.New("email address is not verified")
+	// ErrUserAlreadyVerified is returned when verifying an active user.
+	ErrUserAlreadyVerified = errors.New("email address is already verified")
+	// ErrUserSuspended is returned when a suspended user signs in or uses a
+	// session.
+	ErrUserSuspended = errors.New("user is suspended")
+	// ErrUserSelfModification is returned when an admin changes the role of,
+	// or suspends, their own account.
+	ErrUserSelfModification = errors.New("admins cannot change their own account")
+	// ErrUserErased is returned when changing an account that was erased.
+	ErrUserErased = errors.New("user account was erased")
+)
+
+// Organization errors
+var (
+	ErrOrganizationNameEmpty = errors.New("organization name is required")
+	ErrOrganizationNotFound  = errors.New("organization not found")
+	ErrApplicationNotFound   = errors.New("organizer application not found")
+	// ErrApplicationPending is returned when a user applies while an earlier
+	// application is still waiting for review.
+	ErrApplicationPending = errors.New("organizer application is already pending")
+	// ErrApplicationNotPending is returned when reviewing an application that
+	// was already approved or rejected.
+	ErrApplicationNotPending = errors.New("organizer application was already reviewed")
+	// ErrUserAlreadyOrganizer is returned when an organizer or admin applies
+	// to organize events.
+	ErrUserAlreadyOrganizer = errors.New("user can already organize events")
+	// ErrOrganizationRoleInvalid is returned for roles other than owner,
+	// manager, box_office and scanner.
+	ErrOrganizationRoleInvalid = errors.New("invalid organization role")
+	ErrMembershipNotFound      = errors.New("membership not found")
+	ErrMembershipExists        = errors.New("user is already a member of the organization")
+	// ErrOrganizationForbidden is returned when a user acts on an organization,
+	// or on its events and bookings, without a membership that allows it.
+	ErrOrganizationForbidden = errors.New("not allowed in this organization")
+	// ErrOrganizationRequired is returned when a user who manages events for
+	// several organizations creates an event without naming one.
+	ErrOrganizationRequired = errors.New("organization is required")
+	// ErrMemberIsOwner is returned when removing the account that owns an
+	// organization from it.
+	ErrMemberIsOwner = errors.New("the owner cannot be removed from the organization")
+)
+
+// Session errors
+var (
+	// ErrRefreshTokenInvalid is returned for unknown, expired or revoked refresh tokens.
+	ErrRefreshTokenInvalid = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned when a rotated refresh token is presented
+	// again. The whole token family is revoked, as one of the copies was stolen.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+	// ErrResetTokenInvalid is returned for unknown, expired or used
+	// password reset tokens.
+	ErrResetTokenInvalid = errors.New("invalid password reset token")
+	// ErrVerifyTokenInvalid is returned for unknown, expired or used email
+	// verification tokens.
+	ErrVerifyTokenInvalid = errors.New("invalid email verification token")
+	// ErrCalendarFeedNotFound is returned for users who never asked for the
+	// URL of their booking calendar feed.
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+	// ErrSigningKeyInvalid is returned for signing keys without an ID, key
+	// material or a supported asymmetric algorithm.
+	ErrSigningKeyInvalid = errors.New("invalid signing key")
+	// ErrOIDCProviderUnknown is returned for sign-ins with a provider that is
+	// not configured.
+	ErrOIDCProviderUnknown = errors.New("unknown identity provider")
+	// ErrOIDCStateInvalid is returned when a user returns from a provider
+	// with an unknown, used or expired state.
+	ErrOIDCStateInvalid = errors.New("invalid or expired sign-in state")
+	// ErrOIDCLoginFailed is returned when the provider refuses the code or
+	// returns an ID token that does not verify.
+	ErrOIDCLoginFailed = errors.New("sign-in with the identity provider failed")
+	// ErrOIDCEmailUnverified is returned when the provider does not vouch for
+	// the email address of an account that is not linked yet.
+	ErrOIDCEmailUnverified = errors.New("identity provider did not verify the email address")
+	ErrIdentityNotFound    = errors.New("identity not found")
+)
+
+// Two-factor authentication errors
+var (
+	// ErrTwoFactorEnabled is returned when enrolling a user whose two-factor
+	// authentication is already on.
+	ErrTwoFactorEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrTwoFactorNotEnrolled is returned when confirming, using or turning
+	// off two-factor authentication the user has not set up.
+	ErrTwoFactorNotEnrolled = errors.New("two-factor authentication is not set up")
+	// ErrTwoFactorCodeInvalid is returned for wrong, expired or used codes.
+	ErrTwoFactorCodeInvalid = errors.New("invalid two-factor code")
+	// ErrTwoFactorRequired is returned when a user whose role requires
+	// two-factor authentication tries to turn it off.
+	ErrTwoFactorRequired = errors.New("two-factor authentication is required for this role")
+	// ErrTwoFactorRoleInvalid is returned when requiring two-factor
+	// authentication for a role other than organizer and admin.
+	ErrTwoFactorRoleInvalid = errors.New("two-factor authentication can only be required for elevated roles")
+	// ErrLoginChallengeInvalid is returned for unknown, expired or exhausted
+	// login challenges.
+	ErrLoginChallengeInvalid = errors.New("invalid or expired login challenge")
+)
+
+// Login security errors
+var (
+	// ErrAccountLocked is returned when signing in to an account locked out
+	// after repeated failed sign-ins.
+	ErrAccountLocked = errors.New("account is temporarily locked")
+)
+
+// API key errors
+var (
+	ErrAPIKeyNotFound  = errors.New("API key not found")
+	ErrAPIKeyNameEmpty = errors.New("API key name is required")
+	// ErrAPIKeyScopeInvalid is returned for unknown scopes and for keys
+	// without any.
+	ErrAPIKeyScopeInvalid = errors.New("invalid API key scope")
+	// ErrAPIKeyExpiryInvalid is returned when a new key would have expired
+	// already.
+	ErrAPIKeyExpiryInvalid = errors.New("API key expiry must be in the future")
+	// ErrAPIKeyInvalid is returned for malformed, unknown, wrong and expired
+	// keys alike.
+	ErrAPIKeyInvalid = errors.New("invalid API key")
+)
+
+// Authorization errors
+var (
+	// ErrPermissionDenied is returned when the role of a user does not grant
+	// a permission, or grants it only on resources the user owns.
+	ErrPermissionDenied = errors.New("permission denied")
+)
+
+// Privacy errors
+var (
+	ErrDataExportNotFound = errors.New("data export not found")
+	// ErrOwnerCannotErase is returned when the owner of an
+	// organization asks to erase their account, as its payouts go to them.
+	ErrOwnerCannotErase = errors.New("organization owners cannot erase their account")
+)
+
+// Profile errors
+var (
+	ErrDisplayNameTooLong = errors.New("display name is longer than 100 characters")
+	ErrPhoneInvalid       = errors.New("phone number must be in E.164 format")
+	ErrLocaleInvalid      = errors.New("locale must be a language, optionally with a region")
+	ErrTimeZoneInvalid    = errors.New("time zone is not a known IANA time zone")
+	ErrWebhookURLInvalid  = errors.New("webhook URL must be an https URL")
+	ErrChannelInvalid     = errors.New("notification channel must be email, sms or webhook")
+	// ErrPhoneRequired is returned when enabling SMS notifications without
+	// a phone number.
+	ErrPhoneRequired = errors.New("SMS notifications need a phone number")
+	// ErrWebhookURLRequired is returned when enabling webhook notifications
+	// without a webhook URL.
+	ErrWebhookURLRequired = errors.New("webhook notifications need a webhook URL")
+)
+
+// Waiting room errors
+var (
+	// ErrAdmissionPassRequired is returned when the waiting room is active and no admission pass was given.
+	ErrAdmissionPassRequired = errors.New("admission pass required")
+	// ErrAdmissionPassInvalid is returned when the admission pass is forged, expired or issued for another event.
+	ErrAdmissionPassInvalid = errors.New("admission pass is invalid")
+	// ErrQueueTicketInvalid is returned when the polling token is invalid or no longer in the queue.
+	ErrQueueTicketInvalid = errors.New("queue ticket is invalid")
+	// ErrWaitingRoomInactive is returned when joining a queue of an event that has no active waiting room.
+	ErrWaitingRoomInactive = errors.New("waiting room is not active")
+	// ErrWaitingRoomRateInvalid is returned when the admission rate is not positive.
+	ErrWaitingRoomRateInvalid = errors.New("admission rate must be positive")
 )
 
 // Outbox errors
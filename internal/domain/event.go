@@ -4,6 +4,7 @@ package domain
 import (
 	"context"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,22 +12,33 @@ import (
 
 // Event represents an event in the system.
 type Event struct {
-	id             uuid.UUID
-	name           string
-	price          int64
-	startAt        time.Time
-	endAt          time.Time
-	createdAt      time.Time
-	updatedAt      time.Time
-	capacity       int
-	availableSpots int
+	id              uuid.UUID
+	name            string
+	price           Money
+	startAt         time.Time
+	endAt           time.Time
+	createdAt       time.Time
+	updatedAt       time.Time
+	capacity        int
+	availableSpots  int
+	inventoryShards int
+	salesStartAt    time.Time
+	salesEndAt      time.Time
+	organizerID     uuid.UUID
+	venueCountry    string
+	organizationID  uuid.UUID
+	timeZone        string
+	revision        int
 }
 
+// MaxInventoryShards is the upper bound of counter rows an event's capacity can be split across.
+const MaxInventoryShards = 64
+
 // NewEvent creates a new validated Event.
 func NewEvent(
 	id uuid.UUID,
 	name string,
-	price int64,
+	price Money,
 	startAt time.Time,
 	endAt time.Time,
 	capacity int,
@@ -37,9 +49,12 @@ func NewEvent(
 	if name == "" {
 		return nil, ErrEventNameEmpty
 	}
-	if price < 0 {
+	if price.IsNegative() {
 		return nil, ErrEventPriceNegative
 	}
+	if _, err := ParseCurrency(string(price.Currency())); err != nil {
+		return nil, err
+	}
 	if startAt.After(endAt) {
 		return nil, ErrEventStartAfterEnd
 	}
@@ -56,16 +71,25 @@ func NewEvent(
 		updatedAt:      time.Now(),
 		capacity:       capacity,
 		availableSpots: capacity,
+		timeZone:       "UTC",
 	}, nil
 }
 
+// touch records a change of the event.
+func (e *Event) touch() {
+	e.updatedAt = time.Now()
+	e.revision++
+}
+
 // UpdateName updates the event's name.
 func (e *Event) UpdateName(name string) error {
 	if name == "" {
 		return ErrEventNameEmpty
 	}
-	e.name = name
-	e.updatedAt = time.Now()
+	if name != e.name {
+		e.name = name
+		e.touch()
+	}
 	return nil
 }
 
@@ -74,8 +98,8 @@ func (e *Event) Name() string {
 	return e.name
 }
 
-// Price returns the event's price.
-func (e *Event) Price() int64 {
+// Price returns the event's base price.
+func (e *Event) Price() Money {
 	return e.price
 }
 
@@ -89,9 +113,11 @@ func (e *Event) Reschedule(startAt time.Time, endAt time.Time) error {
 	if startAt.After(endAt) {
 		return ErrEventStartAfterEnd
 	}
-	e.startAt = startAt
-	e.endAt = endAt
-	e.updatedAt = time.Now()
+	if !startAt.Equal(e.startAt) || !endAt.Equal(e.endAt) {
+		e.startAt = startAt
+		e.endAt = endAt
+		e.touch()
+	}
 	return nil
 }
 
@@ -110,12 +136,180 @@ func (e *Event) AvailableSpots() int {
 	return e.availableSpots
 }
 
+// InventoryShards returns the number of counter rows the available spots are
+// split across. Zero means the spots are tracked on the event itself.
+func (e *Event) InventoryShards() int {
+	return e.inventoryShards
+}
+
+// IsInventorySharded reports whether the event uses sharded inventory counters.
+func (e *Event) IsInventorySharded() bool {
+	return e.inventoryShards > 0
+}
+
+// ConfigureInventoryShards switches the event between single-row (0) and sharded inventory.
+func (e *Event) ConfigureInventoryShards(shards int) error {
+	if shards < 0 || shards > MaxInventoryShards {
+		return ErrEventShardsInvalid
+	}
+	if shards != e.inventoryShards {
+		e.inventoryShards = shards
+		e.touch()
+	}
+	return nil
+}
+
+// SalesWindow returns when general sales open and close. A zero time means
+// the window is unbounded on that side.
+func (e *Event) SalesWindow() (time.Time, time.Time) {
+	return e.salesStartAt, e.salesEndAt
+}
+
+// ScheduleSales sets the general sales window. Zero times leave the
+// corresponding side open.
+func (e *Event) ScheduleSales(salesStartAt, salesEndAt time.Time) error {
+	if !salesStartAt.IsZero() && !salesEndAt.IsZero() && !salesStartAt.Before(salesEndAt) {
+		return ErrSalesWindowInvalid
+	}
+	if !salesStartAt.Equal(e.salesStartAt) || !salesEndAt.Equal(e.salesEndAt) {
+		e.salesStartAt = salesStartAt
+		e.salesEndAt = salesEndAt
+		e.touch()
+	}
+	return nil
+}
+
+// CheckOnSale reports whether the event can be booked at the given time.
+// Before general sales open, a booking is only allowed during an active
+// presale and with a matching access code.
+func (e *Event) CheckOnSale(now time.Time, presales []*Presale, accessCode string) error {
+	if !e.salesEndAt.IsZero() && !now.Before(e.salesEndAt) {
+		return ErrEventSalesClosed
+	}
+	if e.salesStartAt.IsZero() || !now.Before(e.salesStartAt) {
+		return nil
+	}
+
+	presaleActive := false
+	for _, presale := range presales {
+		if !presale.IsActive(now) {
+			continue
+		}
+		if presale.Accepts(accessCode) {
+			return nil
+		}
+		presaleActive = true
+	}
+
+	if presaleActive && accessCode != "" {
+		return ErrAccessCodeInvalid
+	}
+	return ErrEventNotOnSale
+}
+
+// OrganizerID returns the user who organizes the event, or uuid.Nil for
+// events created before organizers were tracked.
+func (e *Event) OrganizerID() uuid.UUID {
+	return e.organizerID
+}
+
+// AssignOrganizer records the user who organizes the event.
+func (e *Event) AssignOrganizer(organizerID uuid.UUID) {
+	if organizerID != e.organizerID {
+		e.organizerID = organizerID
+		e.touch()
+	}
+}
+
+// OrganizationID returns the organization that owns the event, or uuid.Nil
+// for events created by admins or before organizations existed.
+func (e *Event) OrganizationID() uuid.UUID {
+	return e.organizationID
+}
+
+// AssignOrganization records the organization that owns the event.
+func (e *Event) AssignOrganization(organizationID uuid.UUID) {
+	if organizationID != e.organizationID {
+		e.organizationID = organizationID
+		e.touch()
+	}
+}
+
+// VenueCountry returns the ISO 3166 alpha-2 code of the venue's country,
+// which decides the VAT jurisdiction. Empty when unknown.
+func (e *Event) VenueCountry() string {
+	return e.venueCountry
+}
+
+// LocateVenue sets the venue's country. An empty code clears it.
+func (e *Event) LocateVenue(country string) error {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country != "" && (len(country) != 2 || !isUpperASCII(country)) {
+		return ErrVenueCountryInvalid
+	}
+	if country != e.venueCountry {
+		e.venueCountry = country
+		e.touch()
+	}
+	return nil
+}
+
+// TimeZone returns the IANA time zone of the venue, which calendars show
+// the event in.
+func (e *Event) TimeZone() string {
+	return e.timeZone
+}
+
+// SetTimeZone sets the IANA time zone of the venue. An empty name means UTC.
+func (e *Event) SetTimeZone(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "UTC"
+	}
+	if !isTimeZone(name) {
+		return ErrEventTimeZoneInvalid
+	}
+	if name != e.timeZone {
+		e.timeZone = name
+		e.touch()
+	}
+	return nil
+}
+
+// Revision grows with every change made to the event, so calendars use it
+// as the SEQUENCE of the event.
+func (e *Event) Revision() int {
+	return e.revision
+}
+
+// CreatedAt returns the time the event was created.
+func (e *Event) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// UpdatedAt returns the time the event was last modified.
+func (e *Event) UpdatedAt() time.Time {
+	return e.updatedAt
+}
+
 // NewEventFromPersistence creates an Event from the given parameters.
 func NewEventFromPersistence(id uuid.UUID,
 	name string,
-	price int64,
-	startAt, endAt, createdAt, updatedAt time.Time, capacity int, availableSpots int) *Event {
-	return &Event{id, name, price, startAt, endAt, createdAt, updatedAt, capacity, availableSpots}
+	price Money,
+	startAt, endAt, createdAt, updatedAt time.Time, capacity int, availableSpots int, inventoryShards int,
+	salesStartAt, salesEndAt time.Time, organizerID uuid.UUID, venueCountry string, organizationID uuid.UUID,
+	timeZone string, revision int) *Event {
+	return &Event{
+		id, name, price, startAt, endAt, createdAt, updatedAt, capacity, availableSpots, inventoryShards,
+		salesStartAt, salesEndAt, organizerID, venueCountry, organizationID, timeZone, revision,
+	}
+}
+
+// isTimeZone reports whether name is an IANA time zone. "Local" is refused,
+// as it depends on the machine.
+func isTimeZone(name string) bool {
+	_, err := time.LoadLocation(name)
+	return err == nil && name != "Local"
 }
 
 // EventRepository defines the interface for event persistence.
@@ -126,4 +320,46 @@ type EventRepository interface {
 	GetEvent(ctx context.Context, id uuid.UUID) (*Event, error)
 	ListEvents(ctx context.Context) ([]*Event, error)
 	ReserveSpots(ctx context.Context, eventID uuid.UUID, spots int) error
+	ReleaseSpots(ctx context.Context, eventID uuid.UUID, spots int) error
+}
+
+// SalesTransition is the payload of the SalesOpened and SalesClosed outbox events.
+type SalesTransition struct {
+	EventID      uuid.UUID  `json:"eventID"`
+	Name         string     `json:"name"`
+	SalesStartAt *time.Time `json:"salesStartAt,omitempty"`
+	SalesEndAt   *time.Time `json:"salesEndAt,omitempty"`
+	OccurredAt   time.Time  `json:"occurredAt"`
+}
+
+// SalesScheduleRepository finds events whose sales window boundary has passed
+// and records that the transition was published.
+type SalesScheduleRepository interface {
+	ListEventsDueForSalesOpen(ctx context.Context, now time.Time, limit int) ([]*Event, error)
+	ListEventsDueForSalesClose(ctx context.Context, now time.Time, limit int) ([]*Event, error)
+	MarkSalesOpenedEmitted(ctx context.Context, eventID uuid.UUID) error
+	MarkSalesClosedEmitted(ctx context.Context, eventID uuid.UUID) error
+}
+
+// InventoryShard is one counter row of a sharded event inventory.
+type InventoryShard struct {
+	Shard          int
+	AvailableSpots int
+}
+
+// InventoryDrift describes a sharded event whose counters no longer match its bookings.
+type InventoryDrift struct {
+	EventID        uuid.UUID
+	ShardedSpots   int
+	ExpectedSpots  int
+	ActiveBookings int
+}
+
+// InventoryRepository manages sharded inventory counters.
+type InventoryRepository interface {
+	ReshardInventory(ctx context.Context, eventID uuid.UUID, shards int) error
+	ListInventoryShards(ctx context.Context, eventID uuid.UUID) ([]InventoryShard, error)
+	ListShardedEvents(ctx context.Context) ([]*Event, error)
+	CountActiveBookings(ctx context.Context, eventID uuid.UUID) (int, error)
+	SyncAvailableSpots(ctx context.Context, eventID uuid.UUID, availableSpots int) error
 }
@@ -13,7 +13,7 @@ func TestNewEvent(t *testing.T) {
 	type args struct {
 		id       uuid.UUID
 		name     string
-		price    int64
+		price    domain.Money
 		startAt  time.Time
 		endAt    time.Time
 		capacity int
@@ -29,7 +29,7 @@ func TestNewEvent(t *testing.T) {
 			args: args{
 				id:       uuid.New(),
 				name:     "Metallica Concert",
-				price:    10000, // 100.00
+				price:    domain.UnmarshalMoney(10000, "EUR"), // 100.00 EUR
 				startAt:  time.Now().Add(24 * time.Hour),
 				endAt:    time.Now().Add(26 * time.Hour),
 				capacity: 10,
@@ -41,7 +41,7 @@ func TestNewEvent(t *testing.T) {
 			args: args{
 				id:       uuid.New(),
 				name:     "",
-				price:    100,
+				price:    domain.UnmarshalMoney(100, "EUR"),
 				startAt:  time.Now(),
 				endAt:    time.Now().Add(time.Hour),
 				capacity: 10,
@@ -53,19 +53,31 @@ func TestNewEvent(t *testing.T) {
 			args: args{
 				id:       uuid.New(),
 				name:     "Metallica Concert",
-				price:    -100,
+				price:    domain.UnmarshalMoney(-100, "EUR"),
 				startAt:  time.Now(),
 				endAt:    time.Now().Add(time.Hour),
 				capacity: 10,
 			},
 			wantErr: domain.ErrEventPriceNegative,
 		},
+		{
+			name: "missing currency",
+			args: args{
+				id:       uuid.New(),
+				name:     "Metallica Concert",
+				price:    domain.UnmarshalMoney(100, ""),
+				startAt:  time.Now(),
+				endAt:    time.Now().Add(time.Hour),
+				capacity: 10,
+			},
+			wantErr: domain.ErrCurrencyInvalid,
+		},
 		{
 			name: "endAt before startAt",
 			args: args{
 				id:       uuid.New(),
 				name:     "Metallica Concert",
-				price:    100,
+				price:    domain.UnmarshalMoney(100, "EUR"),
 				startAt:  time.Now().Add(time.Hour),
 				endAt:    time.Now(),
 				capacity: 10,
@@ -105,3 +117,127 @@ func TestNewEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestEvent_ConfigureInventoryShards(t *testing.T) {
+	event, err := domain.NewEvent(
+		uuid.New(),
+		"Hot Event",
+		domain.UnmarshalMoney(100, "EUR"),
+		time.Now(),
+		time.Now().Add(time.Hour),
+		1000,
+	)
+	if err != nil {
+		t.Fatalf("NewEvent() error = %v", err)
+	}
+
+	if err := event.ConfigureInventoryShards(16); err != nil {
+		t.Errorf("ConfigureInventoryShards(16) error = %v", err)
+	}
+	if !event.IsInventorySharded() || event.InventoryShards() != 16 {
+		t.Errorf("ConfigureInventoryShards(16) shards = %v", event.InventoryShards())
+	}
+
+	for _, shards := range []int{-1, domain.MaxInventoryShards + 1} {
+		if err := event.ConfigureInventoryShards(shards); err != domain.ErrEventShardsInvalid {
+			t.Errorf("ConfigureInventoryShards(%d) error = %v, want %v", shards, err, domain.ErrEventShardsInvalid)
+		}
+	}
+
+	if err := event.ConfigureInventoryShards(0); err != nil || event.IsInventorySharded() {
+		t.Errorf("ConfigureInventoryShards(0) error = %v, sharded = %v", err, event.IsInventorySharded())
+	}
+}
+
+func TestEvent_CheckOnSale(t *testing.T) {
+	now := time.Now()
+	event, err := domain.NewEvent(
+		uuid.New(),
+		"On Sale",
+		domain.UnmarshalMoney(100, "EUR"),
+		now.Add(48*time.Hour),
+		now.Add(50*time.Hour),
+		100,
+	)
+	if err != nil {
+		t.Fatalf("NewEvent() error = %v", err)
+	}
+	if err := event.ScheduleSales(now.Add(time.Hour), now.Add(24*time.Hour)); err != nil {
+		t.Fatalf("ScheduleSales() error = %v", err)
+	}
+
+	presale, err := domain.NewPresale(uuid.New(), event.ID(), "Fan club", now.Add(-time.Hour), now.Add(time.Hour), "FANS")
+	if err != nil {
+		t.Fatalf("NewPresale() error = %v", err)
+	}
+	presales := []*domain.Presale{presale}
+
+	tests := []struct {
+		name       string
+		at         time.Time
+		accessCode string
+		wantErr    error
+	}{
+		{name: "before sales without code", at: now, wantErr: domain.ErrEventNotOnSale},
+		{name: "presale with code", at: now, accessCode: "FANS"},
+		{name: "presale with wrong code", at: now, accessCode: "nope", wantErr: domain.ErrAccessCodeInvalid},
+		{
+			name:       "code outside presale",
+			at:         now.Add(-2 * time.Hour),
+			accessCode: "FANS",
+			wantErr:    domain.ErrEventNotOnSale,
+		},
+		{name: "general sale", at: now.Add(2 * time.Hour)},
+		{name: "at sales end", at: now.Add(24 * time.Hour), wantErr: domain.ErrEventSalesClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := event.CheckOnSale(tt.at, presales, tt.accessCode); err != tt.wantErr {
+				t.Errorf("CheckOnSale() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if err := event.ScheduleSales(now.Add(time.Hour), now); err != domain.ErrSalesWindowInvalid {
+		t.Errorf("ScheduleSales() error = %v, wantErr %v", err, domain.ErrSalesWindowInvalid)
+	}
+}
+
+func TestEvent_RevisionGrowsWithChanges(t *testing.T) {
+	now := time.Now()
+	event, err := domain.NewEvent(uuid.New(), "Jazz Night", domain.UnmarshalMoney(100, "EUR"), now, now.Add(time.Hour), 10)
+	if err != nil {
+		t.Fatalf("NewEvent() error = %v", err)
+	}
+	if event.Revision() != 0 || event.TimeZone() != "UTC" {
+		t.Fatalf("NewEvent() revision = %d, time zone = %q", event.Revision(), event.TimeZone())
+	}
+
+	if err := event.Reschedule(now.Add(time.Hour), now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Reschedule() error = %v", err)
+	}
+	if err := event.SetTimeZone("Europe/Warsaw"); err != nil {
+		t.Fatalf("SetTimeZone() error = %v", err)
+	}
+	if event.Revision() != 2 {
+		t.Errorf("Revision() = %d after two changes, want 2", event.Revision())
+	}
+
+	// Saving the same values is not a change.
+	if err := event.UpdateName("Jazz Night"); err != nil {
+		t.Fatalf("UpdateName() error = %v", err)
+	}
+	if err := event.Reschedule(now.Add(time.Hour), now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Reschedule() error = %v", err)
+	}
+	if event.Revision() != 2 {
+		t.Errorf("Revision() = %d after saving the same values, want 2", event.Revision())
+	}
+
+	for _, name := range []string{"Mars/Olympus", "Local"} {
+		if err := event.SetTimeZone(name); err != domain.ErrEventTimeZoneInvalid {
+			t.Errorf("SetTimeZone(%q) error = %v, want %v", name, err, domain.ErrEventTimeZoneInvalid)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// LineItemKind identifies a component of a booking's price.
+type LineItemKind string
+
+const (
+	// LineItemBase is the ticket price quoted from the event and its pricing rules.
+	LineItemBase LineItemKind = "base"
+	// LineItemServiceFee is the organizer's service fee.
+	LineItemServiceFee LineItemKind = "service_fee"
+	// LineItemVAT is the value added tax of the venue's country.
+	LineItemVAT LineItemKind = "vat"
+	// LineItemWalletCredit is the part of the total paid from the customer's
+	// wallet. Its amount is negative.
+	LineItemWalletCredit LineItemKind = "wallet_credit"
+)
+
+// MaxBasisPoints is 100% expressed in basis points.
+const MaxBasisPoints = 10000
+
+// LineItem is one row of a booking's monetary breakdown. RateBasisPoints is
+// set for items derived from a rate, e.g. 2300 for 23% VAT.
+type LineItem struct {
+	Kind            LineItemKind `json:"kind"`
+	Amount          Money        `json:"amount"`
+	RateBasisPoints int          `json:"rateBasisPoints,omitempty"`
+}
+
+// FeeSchedule is the service fee an organizer charges on every ticket: a
+// share of the base price plus a fixed amount in minor units of the event's
+// currency.
+type FeeSchedule struct {
+	percentBasisPoints int
+	fixed              int64
+}
+
+// NewFeeSchedule creates a validated FeeSchedule.
+func NewFeeSchedule(percentBasisPoints int, fixed int64) (FeeSchedule, error) {
+	if percentBasisPoints < 0 || percentBasisPoints > MaxBasisPoints || fixed < 0 {
+		return FeeSchedule{}, ErrFeeScheduleInvalid
+	}
+	return FeeSchedule{percentBasisPoints: percentBasisPoints, fixed: fixed}, nil
+}
+
+// UnmarshalFeeSchedule rebuilds a FeeSchedule from persisted values.
+func UnmarshalFeeSchedule(percentBasisPoints int, fixed int64) FeeSchedule {
+	return FeeSchedule{percentBasisPoints: percentBasisPoints, fixed: fixed}
+}
+
+// PercentBasisPoints returns the percentage part of the fee in basis points.
+func (f FeeSchedule) PercentBasisPoints() int {
+	return f.percentBasisPoints
+}
+
+// Fixed returns the fixed part of the fee in minor units.
+func (f FeeSchedule) Fixed() int64 {
+	return f.fixed
+}
+
+// PriceBreakdown is the full price of a single ticket.
+type PriceBreakdown struct {
+	Base       Money
+	ServiceFee Money
+	VAT        Money
+	// VATRateBasisPoints is the rate VAT was charged at.
+	VATRateBasisPoints int
+}
+
+// PriceOrder computes the breakdown of a ticket sold at base. The service
+// fee is charged on the base price and VAT on the base price plus the fee,
+// both rounded half away from zero to the minor unit.
+func PriceOrder(base Money, fees FeeSchedule, vatRateBasisPoints int) PriceBreakdown {
+	fee := roundDiv(base.Amount()*int64(fees.percentBasisPoints), MaxBasisPoints) + fees.fixed
+	vat := roundDiv((base.Amount()+fee)*int64(vatRateBasisPoints), MaxBasisPoints)
+	return PriceBreakdown{
+		Base:               base,
+		ServiceFee:         base.WithAmount(fee),
+		VAT:                base.WithAmount(vat),
+		VATRateBasisPoints: vatRateBasisPoints,
+	}
+}
+
+// Total returns the amount the customer pays.
+func (b PriceBreakdown) Total() Money {
+	return b.Base.WithAmount(b.Base.Amount() + b.ServiceFee.Amount() + b.VAT.Amount())
+}
+
+// LineItems returns the breakdown in the order it is presented and persisted.
+func (b PriceBreakdown) LineItems() []LineItem {
+	return []LineItem{
+		{Kind: LineItemBase, Amount: b.Base},
+		{Kind: LineItemServiceFee, Amount: b.ServiceFee},
+		{Kind: LineItemVAT, Amount: b.VAT, RateBasisPoints: b.VATRateBasisPoints},
+	}
+}
+
+// FeeRepository stores organizer fee schedules and VAT rates per country.
+type FeeRepository interface {
+	// GetFeeSchedule returns ErrFeeScheduleNotFound when the organizer has no schedule.
+	GetFeeSchedule(ctx context.Context, organizerID uuid.UUID) (FeeSchedule, error)
+	SaveFeeSchedule(ctx context.Context, organizerID uuid.UUID, schedule FeeSchedule) error
+	// GetVATRate returns the rate in basis points, or zero for countries without a configured rate.
+	GetVATRate(ctx context.Context, country string) (int, error)
+}
@@ -0,0 +1,72 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestNewFeeSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		percent int
+		fixed   int64
+		wantErr error
+	}{
+		{name: "valid", percent: 250, fixed: 100},
+		{name: "no fee", percent: 0, fixed: 0},
+		{name: "negative percent", percent: -1, fixed: 0, wantErr: domain.ErrFeeScheduleInvalid},
+		{name: "above 100 percent", percent: 10001, fixed: 0, wantErr: domain.ErrFeeScheduleInvalid},
+		{name: "negative fixed", percent: 0, fixed: -1, wantErr: domain.ErrFeeScheduleInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := domain.NewFeeSchedule(tt.percent, tt.fixed); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewFeeSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPriceOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    int64
+		percent int
+		fixed   int64
+		vat     int
+		wantFee int64
+		wantVAT int64
+	}{
+		{name: "no fee, no VAT", base: 5000, wantFee: 0, wantVAT: 0},
+		{name: "percent and fixed fee", base: 10000, percent: 500, fixed: 99, wantFee: 599},
+		{name: "VAT on base plus fee", base: 10000, percent: 500, fixed: 99, vat: 2300, wantFee: 599, wantVAT: 2438},
+		{name: "fee rounds half up", base: 333, percent: 150, wantFee: 5, wantVAT: 0},
+		{name: "free ticket pays fixed fee only", base: 0, percent: 1000, fixed: 50, vat: 1900, wantFee: 50, wantVAT: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := domain.UnmarshalMoney(tt.base, "EUR")
+			breakdown := domain.PriceOrder(base, domain.UnmarshalFeeSchedule(tt.percent, tt.fixed), tt.vat)
+
+			if breakdown.ServiceFee != domain.UnmarshalMoney(tt.wantFee, "EUR") {
+				t.Errorf("ServiceFee = %v, want %d", breakdown.ServiceFee, tt.wantFee)
+			}
+			if breakdown.VAT != domain.UnmarshalMoney(tt.wantVAT, "EUR") {
+				t.Errorf("VAT = %v, want %d", breakdown.VAT, tt.wantVAT)
+			}
+			wantTotal := domain.UnmarshalMoney(tt.base+tt.wantFee+tt.wantVAT, "EUR")
+			if breakdown.Total() != wantTotal {
+				t.Errorf("Total() = %v, want %v", breakdown.Total(), wantTotal)
+			}
+
+			items := breakdown.LineItems()
+			if len(items) != 3 || items[0].Kind != domain.LineItemBase || items[2].RateBasisPoints != tt.vat {
+				t.Errorf("LineItems() = %+v", items)
+			}
+		})
+	}
+}
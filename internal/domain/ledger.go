@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LedgerAccountKind identifies what a ledger account tracks.
+type LedgerAccountKind string
+
+const (
+	// LedgerAccountCash holds the customer payments collected by the platform.
+	LedgerAccountCash LedgerAccountKind = "cash"
+	// LedgerAccountCustomerCredit is the stored value the platform owes customers:
+	// wallet balances and unredeemed gift cards.
+	LedgerAccountCustomerCredit LedgerAccountKind = "customer_credit"
+	// LedgerAccountOrganizerPayable is what the platform owes an organizer.
+	LedgerAccountOrganizerPayable LedgerAccountKind = "organizer_payable"
+	// LedgerAccountPlatformRevenue collects service fees and tickets of platform-run events.
+	LedgerAccountPlatformRevenue LedgerAccountKind = "platform_revenue"
+	// LedgerAccountVATPayable is the VAT owed to the tax authorities.
+	LedgerAccountVATPayable LedgerAccountKind = "vat_payable"
+)
+
+// LedgerAccount identifies an account by owner, kind and currency.
+// Platform accounts are owned by uuid.Nil.
+type LedgerAccount struct {
+	OwnerID  uuid.UUID
+	Kind     LedgerAccountKind
+	Currency Currency
+}
+
+// PlatformAccount returns a platform-owned account.
+func PlatformAccount(kind LedgerAccountKind, currency Currency) LedgerAccount {
+	return LedgerAccount{OwnerID: uuid.Nil, Kind: kind, Currency: currency}
+}
+
+// OrganizerAccount returns the payable account of an organizer.
+func OrganizerAccount(organizerID uuid.UUID, currency Currency) LedgerAccount {
+	return LedgerAccount{OwnerID: organizerID, Kind: LedgerAccountOrganizerPayable, Currency: currency}
+}
+
+// JournalEntryKind names the business event a journal entry records.
+type JournalEntryKind string
+
+const (
+	JournalBookingConfirmed JournalEntryKind = "booking_confirmed"
+	JournalBookingRefunded  JournalEntryKind = "booking_refunded"
+	JournalPayout           JournalEntryKind = "payout"
+	JournalGiftCardIssued   JournalEntryKind = "gift_card_issued"
+	JournalWalletRefund     JournalEntryKind = "wallet_refund"
+)
+
+// JournalLine posts an amount in minor units to an account. Debits are
+// positive, credits negative.
+type JournalLine struct {
+	Account LedgerAccount
+	Amount  int64
+}
+
+// JournalEntry is an immutable, balanced set of postings. At most one entry
+// of each kind exists per reference (a booking or a settlement).
+type JournalEntry struct {
+	id        uuid.UUID
+	kind      JournalEntryKind
+	reference uuid.UUID
+	lines     []JournalLine
+	createdAt time.Time
+}
+
+// NewJournalEntry creates an entry. Zero-amount lines are dropped and the
+// remaining lines must sum to zero per currency.
+func NewJournalEntry(kind JournalEntryKind, reference uuid.UUID, lines []JournalLine) (*JournalEntry, error) {
+	sums := map[Currency]int64{}
+	posted := make([]JournalLine, 0, len(lines))
+	for _, line := range lines {
+		if line.Amount == 0 {
+			continue
+		}
+		sums[line.Account.Currency] += line.Amount
+		posted = append(posted, line)
+	}
+	for _, sum := range sums {
+		if sum != 0 {
+			return nil, ErrJournalEntryUnbalanced
+		}
+	}
+
+	return &JournalEntry{
+		id:        uuid.New(),
+		kind:      kind,
+		reference: reference,
+		lines:     posted,
+		createdAt: time.Now(),
+	}, nil
+}
+
+// UnmarshalJournalEntry rebuilds a JournalEntry from persisted values.
+func UnmarshalJournalEntry(
+	id uuid.UUID,
+	kind JournalEntryKind,
+	reference uuid.UUID,
+	lines []JournalLine,
+	createdAt time.Time,
+) *JournalEntry {
+	return &JournalEntry{
+		id:        id,
+		kind:      kind,
+		reference: reference,
+		lines:     lines,
+		createdAt: createdAt,
+	}
+}
+
+// BookingJournalEntry records the sale of a confirmed booking: the customer's
+// payment is debited to cash and credited to the organizer (ticket price),
+// the platform (service fee) and the tax authorities (VAT). Tickets of events
+// without an organizer are platform revenue.
+func BookingJournalEntry(booking *Booking, organizerID uuid.UUID) (*JournalEntry, error) {
+	items := booking.LineItems()
+	if len(items) == 0 {
+		items = []LineItem{{Kind: LineItemBase, Amount: booking.Price()}}
+	}
+
+	currency := booking.Total().Currency()
+	lines := []JournalLine{{Account: PlatformAccount(LedgerAccountCash, currency), Amount: booking.Total().Amount()}}
+	for _, item := range items {
+		var account LedgerAccount
+		switch item.Kind {
+		case LineItemBase:
+			account = OrganizerAccount(organizerID, currency)
+			if organizerID == uuid.Nil {
+				account = PlatformAccount(LedgerAccountPlatformRevenue, currency)
+			}
+		case LineItemServiceFee:
+			account = PlatformAccount(LedgerAccountPlatformRevenue, currency)
+		case LineItemVAT:
+			account = PlatformAccount(LedgerAccountVATPayable, currency)
+		case LineItemWalletCredit:
+			// A negative item: the credit spent is debited to the customer.
+			account = PlatformAccount(LedgerAccountCustomerCredit, currency)
+		default:
+			return nil, ErrJournalEntryUnbalanced
+		}
+		lines = append(lines, JournalLine{Account: account, Amount: -item.Amount.Amount()})
+	}
+
+	return NewJournalEntry(JournalBookingConfirmed, booking.ID(), lines)
+}
+
+// PayoutJournalEntry records paying out an organizer balance.
+func PayoutJournalEntry(settlement *Settlement) (*JournalEntry, error) {
+	amount, currency := settlement.Amount().Amount(), settlement.Amount().Currency()
+	return NewJournalEntry(JournalPayout, settlement.ID(), []JournalLine{
+		{Account: OrganizerAccount(settlement.OrganizerID(), currency), Amount: amount},
+		{Account: PlatformAccount(LedgerAccountCash, currency), Amount: -amount},
+	})
+}
+
+// CustomerCreditJournalEntry records cash turned into stored value, e.g. a
+// sold gift card or a refund credited to a wallet instead of paid out.
+func CustomerCreditJournalEntry(kind JournalEntryKind, reference uuid.UUID, amount Money) (*JournalEntry, error) {
+	return NewJournalEntry(kind, reference, []JournalLine{
+		{Account: PlatformAccount(LedgerAccountCash, amount.Currency()), Amount: amount.Amount()},
+		{Account: PlatformAccount(LedgerAccountCustomerCredit, amount.Currency()), Amount: -amount.Amount()},
+	})
+}
+
+// Reversal returns an entry of the given kind that cancels this one, e.g. a
+// refund of a booking sale.
+func (e *JournalEntry) Reversal(kind JournalEntryKind) (*JournalEntry, error) {
+	lines := make([]JournalLine, len(e.lines))
+	for i, line := range e.lines {
+		lines[i] = JournalLine{Account: line.Account, Amount: -line.Amount}
+	}
+	return NewJournalEntry(kind, e.reference, lines)
+}
+
+func (e *JournalEntry) ID() uuid.UUID {
+	return e.id
+}
+
+func (e *JournalEntry) Kind() JournalEntryKind {
+	return e.kind
+}
+
+// Reference returns the booking or settlement the entry was posted for.
+func (e *JournalEntry) Reference() uuid.UUID {
+	return e.reference
+}
+
+func (e *JournalEntry) Lines() []JournalLine {
+	return e.lines
+}
+
+func (e *JournalEntry) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// Settlement records a payout of an organizer balance.
+type Settlement struct {
+	id          uuid.UUID
+	organizerID uuid.UUID
+	amount      Money
+	createdAt   time.Time
+}
+
+// NewSettlement creates a payout of a positive amount.
+func NewSettlement(organizerID uuid.UUID, amount Money) (*Settlement, error) {
+	if organizerID == uuid.Nil || amount.Amount() <= 0 {
+		return nil, ErrSettlementInvalid
+	}
+	return &Settlement{
+		id:          uuid.New(),
+		organizerID: organizerID,
+		amount:      amount,
+		createdAt:   time.Now(),
+	}, nil
+}
+
+// UnmarshalSettlement rebuilds a Settlement from persisted values.
+func UnmarshalSettlement(id, organizerID uuid.UUID, amount Money, createdAt time.Time) *Settlement {
+	return &Settlement{
+		id:          id,
+		organizerID: organizerID,
+		amount:      amount,
+		createdAt:   createdAt,
+	}
+}
+
+func (s *Settlement) ID() uuid.UUID {
+	return s.id
+}
+
+func (s *Settlement) OrganizerID() uuid.UUID {
+	return s.organizerID
+}
+
+func (s *Settlement) Amount() Money {
+	return s.amount
+}
+
+func (s *Settlement) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// OrganizerBalance is what an organizer is owed and has been paid in one currency.
+type OrganizerBalance struct {
+	Available Money
+	PaidOut   Money
+}
+
+// OrganizerPayable is the balance of one organizer payable account.
+type OrganizerPayable struct {
+	OrganizerID uuid.UUID
+	Amount      Money
+}
+
+// LedgerDiscrepancy is a finding of the ledger verification.
+type LedgerDiscrepancy struct {
+	// Reference is the journal entry or booking that does not reconcile.
+	Reference uuid.UUID
+	Reason    string
+}
+
+// LedgerRepository defines the interface for ledger persistence.
+type LedgerRepository interface {
+	// PostEntry stores an entry with its lines. It returns
+	// ErrJournalEntryExists when an entry of the same kind was already posted
+	// for the reference.
+	PostEntry(ctx context.Context, entry *JournalEntry) error
+	// GetEntry returns ErrJournalEntryNotFound when nothing was posted.
+	GetEntry(ctx context.Context, kind JournalEntryKind, reference uuid.UUID) (*JournalEntry, error)
+	OrganizerBalances(ctx context.Context, organizerID uuid.UUID) ([]OrganizerBalance, error)
+	// LockPayableBalances locks all organizer payable accounts until the
+	// transaction ends and returns what each organizer is owed.
+	LockPayableBalances(ctx context.Context) ([]OrganizerPayable, error)
+	CreateSettlement(ctx context.Context, settlement *Settlement, entry *JournalEntry) error
+	ListSettlements(ctx context.Context, organizerID uuid.UUID, limit int) ([]*Settlement, error)
+	// Discrepancies lists unbalanced entries and bookings whose cash postings
+	// differ from their total.
+	Discrepancies(ctx context.Context) ([]LedgerDiscrepancy, error)
+}
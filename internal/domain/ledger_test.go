@@ -0,0 +1,111 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestNewJournalEntry_RejectsUnbalancedLines(t *testing.T) {
+	cash := domain.PlatformAccount(domain.LedgerAccountCash, "EUR")
+	revenue := domain.PlatformAccount(domain.LedgerAccountPlatformRevenue, "EUR")
+
+	_, err := domain.NewJournalEntry(domain.JournalPayout, uuid.New(), []domain.JournalLine{
+		{Account: cash, Amount: 100},
+		{Account: revenue, Amount: -99},
+	})
+	if !errors.Is(err, domain.ErrJournalEntryUnbalanced) {
+		t.Errorf("NewJournalEntry() error = %v, want %v", err, domain.ErrJournalEntryUnbalanced)
+	}
+}
+
+func TestBookingJournalEntry(t *testing.T) {
+	organizerID := uuid.New()
+	booking, err := domain.NewBooking(uuid.New(), uuid.New(), "buyer@example.com", domain.BookingStatusConfirmed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	breakdown := domain.PriceOrder(domain.UnmarshalMoney(10000, "EUR"), domain.UnmarshalFeeSchedule(500, 99), 2300)
+	if err := booking.LockPrice(breakdown); err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := domain.BookingJournalEntry(booking, organizerID)
+	if err != nil {
+		t.Fatalf("BookingJournalEntry() error = %v", err)
+	}
+
+	want := []domain.JournalLine{
+		{Account: domain.PlatformAccount(domain.LedgerAccountCash, "EUR"), Amount: 13037},
+		{Account: domain.OrganizerAccount(organizerID, "EUR"), Amount: -10000},
+		{Account: domain.PlatformAccount(domain.LedgerAccountPlatformRevenue, "EUR"), Amount: -599},
+		{Account: domain.PlatformAccount(domain.LedgerAccountVATPayable, "EUR"), Amount: -2438},
+	}
+	lines := entry.Lines()
+	if len(lines) != len(want) {
+		t.Fatalf("Lines() = %+v, want %+v", lines, want)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Lines()[%d] = %+v, want %+v", i, lines[i], want[i])
+		}
+	}
+
+	refund, err := entry.Reversal(domain.JournalBookingRefunded)
+	if err != nil {
+		t.Fatalf("Reversal() error = %v", err)
+	}
+	if refund.Reference() != booking.ID() || refund.Lines()[0].Amount != -13037 {
+		t.Errorf("Reversal() = %+v", refund.Lines())
+	}
+}
+
+func TestBookingJournalEntry_PlatformEvent(t *testing.T) {
+	booking := domain.UnmarshalBooking(
+		uuid.New(), uuid.New(), "buyer@example.com", domain.BookingStatusConfirmed,
+		time.Now(), time.Now(), domain.UnmarshalMoney(2500, "EUR"),
+	)
+
+	entry, err := domain.BookingJournalEntry(booking, uuid.Nil)
+	if err != nil {
+		t.Fatalf("BookingJournalEntry() error = %v", err)
+	}
+	if got := entry.Lines()[1].Account; got != domain.PlatformAccount(domain.LedgerAccountPlatformRevenue, "EUR") {
+		t.Errorf("ticket credited to %+v, want platform revenue", got)
+	}
+}
+
+func TestNewSettlement(t *testing.T) {
+	_, err := domain.NewSettlement(uuid.New(), domain.UnmarshalMoney(0, "EUR"))
+	if !errors.Is(err, domain.ErrSettlementInvalid) {
+		t.Errorf("NewSettlement(0) error = %v, want %v", err, domain.ErrSettlementInvalid)
+	}
+
+	settlement, err := domain.NewSettlement(uuid.New(), domain.UnmarshalMoney(500, "EUR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, err := domain.PayoutJournalEntry(settlement)
+	if err != nil {
+		t.Fatalf("PayoutJournalEntry() error = %v", err)
+	}
+	payable := domain.OrganizerAccount(settlement.OrganizerID(), "EUR")
+	if entry.Lines()[0].Account != payable || entry.Lines()[0].Amount != 500 {
+		t.Errorf("PayoutJournalEntry() = %+v", entry.Lines())
+	}
+}
+
+func TestBooking_Refund(t *testing.T) {
+	pending, _ := domain.NewBooking(uuid.New(), uuid.New(), "buyer@example.com", domain.BookingStatusPending)
+	if err := pending.Refund(); !errors.Is(err, domain.ErrBookingNotRefundable) {
+		t.Errorf("Refund() on pending error = %v, want %v", err, domain.ErrBookingNotRefundable)
+	}
+
+	confirmed, _ := domain.NewBooking(uuid.New(), uuid.New(), "buyer@example.com", domain.BookingStatusConfirmed)
+	if err := confirmed.Refund(); err != nil || confirmed.Status() != domain.BookingStatusCancelled {
+		t.Errorf("Refund() error = %v, status = %s", err, confirmed.Status())
+	}
+}
@@ -0,0 +1,235 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// LockoutThreshold is the number of failed sign-ins in a row that locks
+	// an account out.
+	LockoutThreshold = 5
+	// LockoutBaseDuration is how long the first lockout lasts. Every further
+	// failure doubles it, up to LockoutMaxDuration.
+	LockoutBaseDuration = time.Minute
+	LockoutMaxDuration  = time.Hour
+	// LockoutResetAfter is how long after the last failed sign-in the
+	// failures are forgotten.
+	LockoutResetAfter = 24 * time.Hour
+	// LoginHistoryRetention is how long sign-in attempts are kept.
+	LoginHistoryRetention = 90 * 24 * time.Hour
+
+	DefaultLoginPageSize = 50
+	MaxLoginPageSize     = 100
+
+	maxUserAgentLength = 512
+)
+
+// LoginOutcome tells how a sign-in attempt ended.
+type LoginOutcome string
+
+const (
+	LoginOutcomeSuccess         LoginOutcome = "success"
+	LoginOutcomeInvalidPassword LoginOutcome = "invalid_password"
+	LoginOutcomeInvalidCode     LoginOutcome = "invalid_code"
+	LoginOutcomeLocked          LoginOutcome = "locked"
+	LoginOutcomeSuspended       LoginOutcome = "suspended"
+)
+
+// CountsTowardLockout reports whether the outcome is a guess that failed,
+// which counts toward locking the account out.
+func (o LoginOutcome) CountsTowardLockout() bool {
+	return o == LoginOutcomeInvalidPassword || o == LoginOutcomeInvalidCode
+}
+
+// AccountLockout counts the failed sign-ins of an account since its last
+// successful one and locks the account out once they reach
+// LockoutThreshold, for longer with each further failure.
+type AccountLockout struct {
+	userID         uuid.UUID
+	failedAttempts int
+	lastFailedAt   time.Time
+	lockedUntil    time.Time
+}
+
+// NewAccountLockout returns the lockout of an account without failed
+// sign-ins.
+func NewAccountLockout(userID uuid.UUID) *AccountLockout {
+	return &AccountLockout{userID: userID}
+}
+
+// UnmarshalAccountLockout rebuilds an AccountLockout from persisted values.
+// A zero lockedUntil means the account has not been locked out.
+func UnmarshalAccountLockout(
+	userID uuid.UUID,
+	failedAttempts int,
+	lastFailedAt time.Time,
+	lockedUntil time.Time,
+) *AccountLockout {
+	return &AccountLockout{
+		userID:         userID,
+		failedAttempts: failedAttempts,
+		lastFailedAt:   lastFailedAt,
+		lockedUntil:    lockedUntil,
+	}
+}
+
+// Check returns ErrAccountLocked while the account is locked out.
+func (l *AccountLockout) Check(now time.Time) error {
+	if now.Before(l.lockedUntil) {
+		return ErrAccountLocked
+	}
+	return nil
+}
+
+// Fail counts a failed sign-in and, from LockoutThreshold failures on, locks
+// the account out.
+func (l *AccountLockout) Fail(now time.Time) {
+	if now.Sub(l.lastFailedAt) >= LockoutResetAfter {
+		l.failedAttempts = 0
+	}
+	l.failedAttempts++
+	l.lastFailedAt = now
+	if l.failedAttempts < LockoutThreshold {
+		return
+	}
+	lockout := LockoutMaxDuration
+	if doublings := l.failedAttempts - LockoutThreshold; doublings < 6 {
+		lockout = min(LockoutBaseDuration<<doublings, LockoutMaxDuration)
+	}
+	l.lockedUntil = now.Add(lockout)
+}
+
+func (l *AccountLockout) UserID() uuid.UUID {
+	return l.userID
+}
+
+func (l *AccountLockout) FailedAttempts() int {
+	return l.failedAttempts
+}
+
+func (l *AccountLockout) LastFailedAt() time.Time {
+	return l.lastFailedAt
+}
+
+func (l *AccountLockout) LockedUntil() time.Time {
+	return l.lockedUntil
+}
+
+// LoginRecord is a sign-in attempt on an account, kept in its login history.
+type LoginRecord struct {
+	id        uuid.UUID
+	userID    uuid.UUID
+	ipAddress string
+	userAgent string
+	outcome   LoginOutcome
+	createdAt time.Time
+}
+
+// NewLoginRecord records a sign-in attempt. Overly long user agents are
+// truncated.
+func NewLoginRecord(userID uuid.UUID, ipAddress, userAgent string, outcome LoginOutcome, now time.Time) *LoginRecord {
+	if agent := []rune(userAgent); len(agent) > maxUserAgentLength {
+		userAgent = string(agent[:maxUserAgentLength])
+	}
+	return &LoginRecord{
+		id:        uuid.New(),
+		userID:    userID,
+		ipAddress: ipAddress,
+		userAgent: userAgent,
+		outcome:   outcome,
+		createdAt: now,
+	}
+}
+
+// UnmarshalLoginRecord rebuilds a LoginRecord from persisted values.
+func UnmarshalLoginRecord(
+	id uuid.UUID,
+	userID uuid.UUID,
+	ipAddress string,
+	userAgent string,
+	outcome LoginOutcome,
+	createdAt time.Time,
+) *LoginRecord {
+	return &LoginRecord{
+		id:        id,
+		userID:    userID,
+		ipAddress: ipAddress,
+		userAgent: userAgent,
+		outcome:   outcome,
+		createdAt: createdAt,
+	}
+}
+
+func (r *LoginRecord) ID() uuid.UUID {
+	return r.id
+}
+
+func (r *LoginRecord) UserID() uuid.UUID {
+	return r.userID
+}
+
+func (r *LoginRecord) IPAddress() string {
+	return r.ipAddress
+}
+
+func (r *LoginRecord) UserAgent() string {
+	return r.userAgent
+}
+
+func (r *LoginRecord) Outcome() LoginOutcome {
+	return r.outcome
+}
+
+func (r *LoginRecord) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// LoginFamiliarity tells whether a user signed in successfully before, and
+// whether from the same IP address and device.
+type LoginFamiliarity struct {
+	HasHistory  bool
+	KnownIP     bool
+	KnownDevice bool
+}
+
+// Unfamiliar reports whether a sign-in comes from an IP address or a device
+// the user never signed in from. The first sign-in of an account is not.
+func (f LoginFamiliarity) Unfamiliar() bool {
+	return f.HasHistory && (!f.KnownIP || !f.KnownDevice)
+}
+
+// LoginHistoryPage selects a page of a user's login history, newest first.
+type LoginHistoryPage struct {
+	Limit  int
+	Offset int
+}
+
+// WithDefaults returns the page with the default page size when Limit is not
+// set, and with Limit capped at MaxLoginPageSize.
+func (p LoginHistoryPage) WithDefaults() LoginHistoryPage {
+	if p.Limit <= 0 {
+		p.Limit = DefaultLoginPageSize
+	}
+	p.Limit = min(p.Limit, MaxLoginPageSize)
+	p.Offset = max(p.Offset, 0)
+	return p
+}
+
+// LoginSecurityRepository defines the interface for account lockout and
+// login history persistence.
+type LoginSecurityRepository interface {
+	// GetAccountLockoutForUpdate returns the lockout of an account, one
+	// without failed sign-ins when there is none, and locks it.
+	GetAccountLockoutForUpdate(ctx context.Context, userID uuid.UUID) (*AccountLockout, error)
+	SaveAccountLockout(ctx context.Context, lockout *AccountLockout) error
+	DeleteAccountLockout(ctx context.Context, userID uuid.UUID) error
+	// CreateLoginRecord stores a sign-in attempt and drops the user's
+	// attempts older than LoginHistoryRetention.
+	CreateLoginRecord(ctx context.Context, record *LoginRecord) error
+	// GetLoginFamiliarity looks up the successful sign-ins of a user.
+	GetLoginFamiliarity(ctx context.Context, userID uuid.UUID, ipAddress, userAgent string) (LoginFamiliarity, error)
+	ListLoginRecords(ctx context.Context, userID uuid.UUID, page LoginHistoryPage) ([]*LoginRecord, error)
+}
@@ -0,0 +1,76 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestAccountLockout_LocksOutProgressively(t *testing.T) {
+	now := time.Now()
+	lockout := domain.NewAccountLockout(uuid.New())
+	for range domain.LockoutThreshold - 1 {
+		lockout.Fail(now)
+	}
+	if err := lockout.Check(now); err != nil {
+		t.Fatalf("Check() before the threshold error = %v", err)
+	}
+
+	wantLockouts := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute}
+	for _, want := range wantLockouts {
+		lockout.Fail(now)
+		if got := lockout.LockedUntil().Sub(now); got != want {
+			t.Errorf("lockout after %d failures = %v, want %v", lockout.FailedAttempts(), got, want)
+		}
+		if err := lockout.Check(now); !errors.Is(err, domain.ErrAccountLocked) {
+			t.Errorf("Check() error = %v, want %v", err, domain.ErrAccountLocked)
+		}
+	}
+	for range 20 {
+		lockout.Fail(now)
+	}
+	if got := lockout.LockedUntil().Sub(now); got != domain.LockoutMaxDuration {
+		t.Errorf("lockout after %d failures = %v, want %v", lockout.FailedAttempts(), got, domain.LockoutMaxDuration)
+	}
+	if err := lockout.Check(now.Add(domain.LockoutMaxDuration)); err != nil {
+		t.Errorf("Check() after the lockout error = %v", err)
+	}
+}
+
+func TestAccountLockout_ForgetsOldFailures(t *testing.T) {
+	now := time.Now()
+	lockout := domain.NewAccountLockout(uuid.New())
+	for range domain.LockoutThreshold - 1 {
+		lockout.Fail(now)
+	}
+	lockout.Fail(now.Add(domain.LockoutResetAfter))
+	if lockout.FailedAttempts() != 1 {
+		t.Errorf("FailedAttempts() = %d, want 1", lockout.FailedAttempts())
+	}
+	if err := lockout.Check(now.Add(domain.LockoutResetAfter)); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+}
+
+func TestLoginFamiliarity_Unfamiliar(t *testing.T) {
+	tests := []struct {
+		name        string
+		familiarity domain.LoginFamiliarity
+		want        bool
+	}{
+		{"first sign-in", domain.LoginFamiliarity{}, false},
+		{"known", domain.LoginFamiliarity{HasHistory: true, KnownIP: true, KnownDevice: true}, false},
+		{"new IP", domain.LoginFamiliarity{HasHistory: true, KnownDevice: true}, true},
+		{"new device", domain.LoginFamiliarity{HasHistory: true, KnownIP: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.familiarity.Unfamiliar(); got != tt.want {
+				t.Errorf("Unfamiliar() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationRole is the role of a member within one organization,
+// independent of their global UserRole.
+type OrganizationRole string
+
+const (
+	OrganizationRoleOwner     OrganizationRole = "owner"
+	OrganizationRoleManager   OrganizationRole = "manager"
+	OrganizationRoleBoxOffice OrganizationRole = "box_office"
+	OrganizationRoleScanner   OrganizationRole = "scanner"
+)
+
+// OrganizationAction is something a member may do within their organization.
+type OrganizationAction string
+
+const (
+	OrganizationActionManageMembers  OrganizationAction = "manage_members"
+	OrganizationActionManageEvents   OrganizationAction = "manage_events"
+	OrganizationActionManageBookings OrganizationAction = "manage_bookings"
+	OrganizationActionViewBookings   OrganizationAction = "view_bookings"
+	OrganizationActionManageAPIKeys  OrganizationAction = "manage_api_keys"
+)
+
+var organizationRoleActions = map[OrganizationRole][]OrganizationAction{
+	OrganizationRoleOwner: {
+		OrganizationActionManageMembers,
+		OrganizationActionManageEvents,
+		OrganizationActionManageBookings,
+		OrganizationActionViewBookings,
+		OrganizationActionManageAPIKeys,
+	},
+	OrganizationRoleManager: {
+		OrganizationActionManageMembers,
+		OrganizationActionManageEvents,
+		OrganizationActionManageBookings,
+		OrganizationActionViewBookings,
+		OrganizationActionManageAPIKeys,
+	},
+	OrganizationRoleBoxOffice: {
+		OrganizationActionManageBookings,
+		OrganizationActionViewBookings,
+	},
+	OrganizationRoleScanner: {
+		OrganizationActionViewBookings,
+	},
+}
+
+// ParseOrganizationRole validates a role name.
+func ParseOrganizationRole(role string) (OrganizationRole, error) {
+	if _, ok := organizationRoleActions[OrganizationRole(role)]; !ok {
+		return "", ErrOrganizationRoleInvalid
+	}
+	return OrganizationRole(role), nil
+}
+
+// Can reports whether members with the role may take an action.
+func (r OrganizationRole) Can(action OrganizationAction) bool {
+	return slices.Contains(organizationRoleActions[r], action)
+}
+
+// CanAssign reports whether members with the role may add or remove members
+// with the target role. Owners manage every other role, managers the box
+// office and scanner staff. The owner role comes with the organization and
+// is never assigned.
+func (r OrganizationRole) CanAssign(target OrganizationRole) bool {
+	switch r {
+	case OrganizationRoleOwner:
+		return target != OrganizationRoleOwner
+	case OrganizationRoleManager:
+		return target == OrganizationRoleBoxOffice || target == OrganizationRoleScanner
+	default:
+		return false
+	}
+}
+
+// Membership makes a user a member of an organization.
+type Membership struct {
+	organizationID uuid.UUID
+	userID         uuid.UUID
+	userEmail      string
+	role           OrganizationRole
+	createdAt      time.Time
+}
+
+func NewMembership(organizationID uuid.UUID, user *User, role OrganizationRole, now time.Time) (*Membership, error) {
+	if _, err := ParseOrganizationRole(string(role)); err != nil {
+		return nil, err
+	}
+	return &Membership{
+		organizationID: organizationID,
+		userID:         user.ID(),
+		userEmail:      user.Email(),
+		role:           role,
+		createdAt:      now,
+	}, nil
+}
+
+// UnmarshalMembership rebuilds a Membership from persisted values.
+func UnmarshalMembership(
+	organizationID, userID uuid.UUID,
+	userEmail string,
+	role OrganizationRole,
+	createdAt time.Time,
+) *Membership {
+	return &Membership{
+		organizationID: organizationID,
+		userID:         userID,
+		userEmail:      userEmail,
+		role:           role,
+		createdAt:      createdAt,
+	}
+}
+
+func (m *Membership) OrganizationID() uuid.UUID {
+	return m.organizationID
+}
+
+func (m *Membership) UserID() uuid.UUID {
+	return m.userID
+}
+
+func (m *Membership) UserEmail() string {
+	return m.userEmail
+}
+
+func (m *Membership) Role() OrganizationRole {
+	return m.role
+}
+
+// Can reports whether the member may take an action in the organization.
+func (m *Membership) Can(action OrganizationAction) bool {
+	return m.role.Can(action)
+}
+
+func (m *Membership) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+// MembershipRepository defines the interface for membership persistence.
+type MembershipRepository interface {
+	// CreateMembership returns ErrMembershipExists when the user is already a
+	// member of the organization.
+	CreateMembership(ctx context.Context, membership *Membership) error
+	// GetMembership returns ErrMembershipNotFound for non-members.
+	GetMembership(ctx context.Context, organizationID, userID uuid.UUID) (*Membership, error)
+	ListOrganizationMembers(ctx context.Context, organizationID uuid.UUID) ([]*Membership, error)
+	ListUserMemberships(ctx context.Context, userID uuid.UUID) ([]*Membership, error)
+	// DeleteMembership returns ErrMembershipNotFound for non-members.
+	DeleteMembership(ctx context.Context, organizationID, userID uuid.UUID) error
+}
@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestOrganizationRole_Permissions(t *testing.T) {
+	tests := []struct {
+		role           domain.OrganizationRole
+		manageMembers  bool
+		manageEvents   bool
+		manageBookings bool
+		viewBookings   bool
+		assignsManager bool
+		assignsScanner bool
+	}{
+		{domain.OrganizationRoleOwner, true, true, true, true, true, true},
+		{domain.OrganizationRoleManager, true, true, true, true, false, true},
+		{domain.OrganizationRoleBoxOffice, false, false, true, true, false, false},
+		{domain.OrganizationRoleScanner, false, false, false, true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.role), func(t *testing.T) {
+			got := []bool{
+				tt.role.Can(domain.OrganizationActionManageMembers),
+				tt.role.Can(domain.OrganizationActionManageEvents),
+				tt.role.Can(domain.OrganizationActionManageBookings),
+				tt.role.Can(domain.OrganizationActionViewBookings),
+				tt.role.CanAssign(domain.OrganizationRoleManager),
+				tt.role.CanAssign(domain.OrganizationRoleScanner),
+			}
+			want := []bool{
+				tt.manageMembers, tt.manageEvents, tt.manageBookings, tt.viewBookings,
+				tt.assignsManager, tt.assignsScanner,
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("permissions = %v, want %v", got, want)
+					break
+				}
+			}
+			if tt.role.CanAssign(domain.OrganizationRoleOwner) {
+				t.Errorf("CanAssign(owner) = true, want false")
+			}
+		})
+	}
+
+	if _, err := domain.ParseOrganizationRole("admin"); !errors.Is(err, domain.ErrOrganizationRoleInvalid) {
+		t.Errorf("ParseOrganizationRole(admin) error = %v, want %v", err, domain.ErrOrganizationRoleInvalid)
+	}
+}
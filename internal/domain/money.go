@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Currency is an ISO 4217 alphabetic currency code.
+type Currency string
+
+// DefaultCurrency is used for events and bookings created without an explicit currency.
+const DefaultCurrency Currency = "EUR"
+
+// minorUnits lists currencies whose minor unit is not cents. Every other
+// currency uses two decimal places.
+var minorUnits = map[Currency]int{
+	"BHD": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JOD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"VND": 0,
+}
+
+// ParseCurrency normalizes and validates a currency code.
+func ParseCurrency(code string) (Currency, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 || !isUpperASCII(code) {
+		return "", ErrCurrencyInvalid
+	}
+	return Currency(code), nil
+}
+
+func isUpperASCII(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// MinorUnits returns the number of decimal places of the currency's minor unit.
+func (c Currency) MinorUnits() int {
+	if units, ok := minorUnits[c]; ok {
+		return units
+	}
+	return 2
+}
+
+// Money is an amount in the minor unit of its currency, e.g. cents for EUR.
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+// NewMoney creates a validated Money value.
+func NewMoney(amount int64, currency Currency) (Money, error) {
+	parsed, err := ParseCurrency(string(currency))
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: amount, currency: parsed}, nil
+}
+
+// UnmarshalMoney rebuilds Money from persisted values without validation.
+func UnmarshalMoney(amount int64, currency string) Money {
+	return Money{amount: amount, currency: Currency(currency)}
+}
+
+// Amount returns the amount in minor units.
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency returns the currency of the amount.
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// IsNegative reports whether the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// WithAmount returns a value in the same currency with a different amount.
+func (m Money) WithAmount(amount int64) Money {
+	return Money{amount: amount, currency: m.currency}
+}
+
+// Add sums two amounts of the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return m.WithAmount(m.amount + other.amount), nil
+}
+
+// String formats the amount in major units followed by the currency code, e.g. "25.00 EUR".
+func (m Money) String() string {
+	units := m.currency.MinorUnits()
+	sign := ""
+	amount := m.amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	if units == 0 {
+		return fmt.Sprintf("%s%d %s", sign, amount, m.currency)
+	}
+	scale := int64(1)
+	for range units {
+		scale *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/scale, units, amount%scale, m.currency)
+}
+
+type moneyJSON struct {
+	Amount   int64    `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+// MarshalJSON encodes the value as {"amount": ..., "currency": ...}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.amount, Currency: m.currency})
+}
+
+// UnmarshalJSON decodes the object form. Payloads written before prices
+// carried a currency hold a bare integer, which is read in DefaultCurrency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var amount int64
+	if err := json.Unmarshal(data, &amount); err == nil {
+		*m = Money{amount: amount, currency: DefaultCurrency}
+		return nil
+	}
+	var raw moneyJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*m = Money{amount: raw.Amount, currency: raw.Currency}
+	return nil
+}
@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestNewMoney(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency domain.Currency
+		want     domain.Currency
+		wantErr  error
+	}{
+		{name: "valid", currency: "PLN", want: "PLN"},
+		{name: "lower case is normalized", currency: "usd", want: "USD"},
+		{name: "empty", currency: "", wantErr: domain.ErrCurrencyInvalid},
+		{name: "too long", currency: "EURO", wantErr: domain.ErrCurrencyInvalid},
+		{name: "digits", currency: "E1R", wantErr: domain.ErrCurrencyInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.NewMoney(100, tt.currency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewMoney() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.Currency() != tt.want {
+				t.Errorf("NewMoney() currency = %v, want %v", got.Currency(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		money domain.Money
+		want  string
+	}{
+		{domain.UnmarshalMoney(2500, "EUR"), "25.00 EUR"},
+		{domain.UnmarshalMoney(-5, "USD"), "-0.05 USD"},
+		{domain.UnmarshalMoney(1500, "JPY"), "1500 JPY"},
+		{domain.UnmarshalMoney(12345, "KWD"), "12.345 KWD"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestMoney_Add(t *testing.T) {
+	sum, err := domain.UnmarshalMoney(100, "EUR").Add(domain.UnmarshalMoney(250, "EUR"))
+	if err != nil || sum != domain.UnmarshalMoney(350, "EUR") {
+		t.Errorf("Add() = %v, %v", sum, err)
+	}
+
+	if _, err := domain.UnmarshalMoney(100, "EUR").Add(domain.UnmarshalMoney(100, "USD")); !errors.Is(
+		err, domain.ErrCurrencyMismatch,
+	) {
+		t.Errorf("Add() error = %v, want %v", err, domain.ErrCurrencyMismatch)
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(domain.UnmarshalMoney(2500, "PLN"))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(data) != `{"amount":2500,"currency":"PLN"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+
+	var legacy domain.Money
+	if err := json.Unmarshal([]byte(`1200`), &legacy); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if legacy != domain.UnmarshalMoney(1200, string(domain.DefaultCurrency)) {
+		t.Errorf("Unmarshal() legacy = %v", legacy)
+	}
+}
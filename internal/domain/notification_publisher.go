@@ -17,3 +17,33 @@ type BookingNotification struct {
 type NotificationPublisher interface {
 	Publish(ctx context.Context, payload *BookingNotification) error
 }
+
+// AccountNotificationKind tells the email worker which account email to send.
+type AccountNotificationKind string
+
+const (
+	AccountNotificationPasswordReset     AccountNotificationKind = "password_reset"
+	AccountNotificationEmailVerification AccountNotificationKind = "email_verification"
+	AccountNotificationNewSignIn         AccountNotificationKind = "new_sign_in"
+	AccountNotificationAccountLocked     AccountNotificationKind = "account_locked"
+)
+
+// AccountNotification is an email about the account itself, sent through the
+// same queue as booking notifications. ID makes delivery idempotent.
+type AccountNotification struct {
+	ID        uuid.UUID               `json:"id"`
+	Kind      AccountNotificationKind `json:"kind"`
+	UserEmail string                  `json:"userEmail"`
+	// Link is the action the email asks for, such as a reset link. It
+	// carries a secret and must not be logged.
+	Link      string    `json:"link"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	CreatedAt time.Time `json:"createdAt"`
+	// IPAddress and UserAgent tell where a new sign-in came from.
+	IPAddress string `json:"ipAddress,omitempty"`
+	UserAgent string `json:"userAgent,omitempty"`
+}
+
+type AccountNotificationPublisher interface {
+	PublishAccountNotification(ctx context.Context, notification *AccountNotification) error
+}
@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusAwaitingInvoicePayment OrderStatus = "awaiting_invoice_payment"
+	OrderStatusPaid                   OrderStatus = "paid"
+	OrderStatusExpired                OrderStatus = "expired"
+)
+
+const (
+	// MaxOrderSeats caps how many bookings one order may hold.
+	MaxOrderSeats = 100
+	// DefaultInvoicePaymentTerm is how long a purchaser has to pay an invoice.
+	DefaultInvoicePaymentTerm = 14 * 24 * time.Hour
+)
+
+// Order groups the bookings of several attendees under one purchaser, e.g. a
+// company paying by invoice. Its spots stay reserved until the invoice is
+// paid or falls due.
+type Order struct {
+	id             uuid.UUID
+	eventID        uuid.UUID
+	purchaserEmail string
+	companyName    string
+	status         OrderStatus
+	dueAt          time.Time
+	bookings       []*Booking
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// NewInvoiceOrder creates an order awaiting invoice payment with a pending
+// booking per attendee. Attendees without an email are booked under the
+// purchaser's.
+func NewInvoiceOrder(
+	id uuid.UUID,
+	eventID uuid.UUID,
+	purchaserEmail string,
+	companyName string,
+	attendees []string,
+	dueAt time.Time,
+) (*Order, error) {
+	if purchaserEmail == "" {
+		return nil, ErrBookingUserEmailEmpty
+	}
+	if len(attendees) == 0 || len(attendees) > MaxOrderSeats {
+		return nil, ErrOrderSeatsInvalid
+	}
+	now := time.Now()
+	if !dueAt.After(now) {
+		return nil, ErrOrderDueDateInvalid
+	}
+
+	bookings := make([]*Booking, 0, len(attendees))
+	for _, email := range attendees {
+		if email == "" {
+			email = purchaserEmail
+		}
+		booking, err := NewBooking(uuid.New(), eventID, email, BookingStatusPending)
+		if err != nil {
+			return nil, err
+		}
+		booking.AssignOrder(id)
+		bookings = append(bookings, booking)
+	}
+
+	return &Order{
+		id:             id,
+		eventID:        eventID,
+		purchaserEmail: purchaserEmail,
+		companyName:    companyName,
+		status:         OrderStatusAwaitingInvoicePayment,
+		dueAt:          dueAt,
+		bookings:       bookings,
+		createdAt:      now,
+		updatedAt:      now,
+	}, nil
+}
+
+// UnmarshalOrder rebuilds an Order from persisted values.
+func UnmarshalOrder(
+	id uuid.UUID,
+	eventID uuid.UUID,
+	purchaserEmail string,
+	companyName string,
+	status OrderStatus,
+	dueAt time.Time,
+	bookings []*Booking,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Order {
+	return &Order{
+		id:             id,
+		eventID:        eventID,
+		purchaserEmail: purchaserEmail,
+		companyName:    companyName,
+		status:         status,
+		dueAt:          dueAt,
+		bookings:       bookings,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}
+}
+
+// MarkPaid records that the invoice was paid. The caller confirms the bookings.
+func (o *Order) MarkPaid() error {
+	if o.status != OrderStatusAwaitingInvoicePayment {
+		return ErrOrderNotAwaitingPayment
+	}
+	o.status = OrderStatusPaid
+	o.updatedAt = time.Now()
+	return nil
+}
+
+// Expire gives up on an invoice that was not paid by its due date. The caller
+// cancels the bookings and releases their spots.
+func (o *Order) Expire(now time.Time) error {
+	if o.status != OrderStatusAwaitingInvoicePayment {
+		return ErrOrderNotAwaitingPayment
+	}
+	if now.Before(o.dueAt) {
+		return ErrOrderDueDateInvalid
+	}
+	o.status = OrderStatusExpired
+	o.updatedAt = now
+	return nil
+}
+
+func (o *Order) ID() uuid.UUID {
+	return o.id
+}
+
+func (o *Order) EventID() uuid.UUID {
+	return o.eventID
+}
+
+func (o *Order) PurchaserEmail() string {
+	return o.purchaserEmail
+}
+
+func (o *Order) CompanyName() string {
+	return o.companyName
+}
+
+func (o *Order) Status() OrderStatus {
+	return o.status
+}
+
+// DueAt returns when the invoice must be paid.
+func (o *Order) DueAt() time.Time {
+	return o.dueAt
+}
+
+func (o *Order) Bookings() []*Booking {
+	return o.bookings
+}
+
+// Total returns the sum of the booking totals.
+func (o *Order) Total() Money {
+	var total Money
+	for i, booking := range o.bookings {
+		if i == 0 {
+			total = booking.Total()
+			continue
+		}
+		total = total.WithAmount(total.Amount() + booking.Total().Amount())
+	}
+	return total
+}
+
+func (o *Order) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+func (o *Order) UpdatedAt() time.Time {
+	return o.updatedAt
+}
+
+// OrderRepository defines the interface for order persistence. Orders are
+// loaded with their bookings; the bookings themselves are stored through the
+// BookingRepository.
+type OrderRepository interface {
+	CreateOrder(ctx context.Context, order *Order) error
+	GetOrder(ctx context.Context, id uuid.UUID) (*Order, error)
+	// GetOrderForUpdate locks the order until the transaction ends.
+	GetOrderForUpdate(ctx context.Context, id uuid.UUID) (*Order, error)
+	// ListOverdueOrders locks unpaid orders past their due date, skipping
+	// those locked by another instance.
+	ListOverdueOrders(ctx context.Context, now time.Time, limit int) ([]*Order, error)
+	UpdateOrderStatus(ctx context.Context, order *Order) error
+}
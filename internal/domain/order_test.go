@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestNewInvoiceOrder(t *testing.T) {
+	orderID := uuid.New()
+	order, err := domain.NewInvoiceOrder(
+		orderID,
+		uuid.New(),
+		"buyer@example.com",
+		"Acme Corp",
+		[]string{"alice@example.com", ""},
+		time.Now().Add(domain.DefaultInvoicePaymentTerm),
+	)
+	if err != nil {
+		t.Fatalf("NewInvoiceOrder() error = %v", err)
+	}
+
+	if order.Status() != domain.OrderStatusAwaitingInvoicePayment {
+		t.Errorf("Status() = %v, want %v", order.Status(), domain.OrderStatusAwaitingInvoicePayment)
+	}
+	bookings := order.Bookings()
+	if len(bookings) != 2 {
+		t.Fatalf("Bookings() = %d, want 2", len(bookings))
+	}
+	for i, want := range []string{"alice@example.com", "buyer@example.com"} {
+		if bookings[i].UserEmail() != want {
+			t.Errorf("Bookings()[%d].UserEmail() = %q, want %q", i, bookings[i].UserEmail(), want)
+		}
+		if bookings[i].OrderID() != orderID {
+			t.Errorf("Bookings()[%d].OrderID() = %v, want %v", i, bookings[i].OrderID(), orderID)
+		}
+		if bookings[i].Status() != domain.BookingStatusPending {
+			t.Errorf("Bookings()[%d].Status() = %v, want pending", i, bookings[i].Status())
+		}
+	}
+}
+
+func TestNewInvoiceOrder_Invalid(t *testing.T) {
+	dueAt := time.Now().Add(time.Hour)
+	tests := []struct {
+		name      string
+		attendees []string
+		dueAt     time.Time
+		want      error
+	}{
+		{"no seats", nil, dueAt, domain.ErrOrderSeatsInvalid},
+		{"too many seats", make([]string, domain.MaxOrderSeats+1), dueAt, domain.ErrOrderSeatsInvalid},
+		{"due in the past", []string{""}, time.Now().Add(-time.Minute), domain.ErrOrderDueDateInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.NewInvoiceOrder(uuid.New(), uuid.New(), "buyer@example.com", "", tt.attendees, tt.dueAt)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("NewInvoiceOrder() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrder_Transitions(t *testing.T) {
+	dueAt := time.Now().Add(time.Hour)
+	newOrder := func() *domain.Order {
+		return domain.UnmarshalOrder(
+			uuid.New(), uuid.New(), "buyer@example.com", "",
+			domain.OrderStatusAwaitingInvoicePayment, dueAt, nil, time.Now(), time.Now(),
+		)
+	}
+
+	order := newOrder()
+	if err := order.Expire(time.Now()); !errors.Is(err, domain.ErrOrderDueDateInvalid) {
+		t.Errorf("Expire() before due date error = %v, want %v", err, domain.ErrOrderDueDateInvalid)
+	}
+	if err := order.Expire(dueAt); err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+	if err := order.MarkPaid(); !errors.Is(err, domain.ErrOrderNotAwaitingPayment) {
+		t.Errorf("MarkPaid() on expired order error = %v, want %v", err, domain.ErrOrderNotAwaitingPayment)
+	}
+
+	order = newOrder()
+	if err := order.MarkPaid(); err != nil {
+		t.Fatalf("MarkPaid() error = %v", err)
+	}
+	if order.Status() != domain.OrderStatusPaid {
+		t.Errorf("Status() = %v, want %v", order.Status(), domain.OrderStatusPaid)
+	}
+	if err := order.MarkPaid(); !errors.Is(err, domain.ErrOrderNotAwaitingPayment) {
+		t.Errorf("MarkPaid() twice error = %v, want %v", err, domain.ErrOrderNotAwaitingPayment)
+	}
+}
@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization is the company or collective behind events. It is created
+// when an organizer application is approved, owned by the applicant.
+type Organization struct {
+	id        uuid.UUID
+	ownerID   uuid.UUID
+	name      string
+	website   string
+	createdAt time.Time
+}
+
+func NewOrganization(ownerID uuid.UUID, name, website string, now time.Time) (*Organization, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrOrganizationNameEmpty
+	}
+	return &Organization{
+		id:        uuid.New(),
+		ownerID:   ownerID,
+		name:      name,
+		website:   strings.TrimSpace(website),
+		createdAt: now,
+	}, nil
+}
+
+// UnmarshalOrganization rebuilds an Organization from persisted values.
+func UnmarshalOrganization(id, ownerID uuid.UUID, name, website string, createdAt time.Time) *Organization {
+	return &Organization{
+		id:        id,
+		ownerID:   ownerID,
+		name:      name,
+		website:   website,
+		createdAt: createdAt,
+	}
+}
+
+func (o *Organization) ID() uuid.UUID {
+	return o.id
+}
+
+func (o *Organization) OwnerID() uuid.UUID {
+	return o.ownerID
+}
+
+func (o *Organization) Name() string {
+	return o.name
+}
+
+func (o *Organization) Website() string {
+	return o.website
+}
+
+func (o *Organization) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+// ApplicationStatus is the review state of an organizer application.
+type ApplicationStatus string
+
+const (
+	ApplicationStatusPending  ApplicationStatus = "pending"
+	ApplicationStatusApproved ApplicationStatus = "approved"
+	ApplicationStatusRejected ApplicationStatus = "rejected"
+)
+
+// ApplicationFilter selects a page of applications in a status, oldest first.
+type ApplicationFilter struct {
+	Status ApplicationStatus
+	Limit  int
+	Offset int
+}
+
+// WithPageDefaults returns the filter for pending applications when Status
+// is not set, with the page size bounded like UserFilter.
+func (f ApplicationFilter) WithPageDefaults() ApplicationFilter {
+	if f.Status == "" {
+		f.Status = ApplicationStatusPending
+	}
+	if f.Limit <= 0 {
+		f.Limit = DefaultUserPageSize
+	}
+	f.Limit = min(f.Limit, MaxUserPageSize)
+	f.Offset = max(f.Offset, 0)
+	return f
+}
+
+// OrganizerApplication is a user's request to organize events. An admin
+// approves it, which creates the organization, or rejects it with a note.
+type OrganizerApplication struct {
+	id               uuid.UUID
+	userID           uuid.UUID
+	organizationName string
+	website          string
+	description      string
+	status           ApplicationStatus
+	reviewerID       uuid.UUID
+	reviewNote       string
+	reviewedAt       time.Time
+	organizationID   uuid.UUID
+	createdAt        time.Time
+}
+
+func NewOrganizerApplication(
+	userID uuid.UUID,
+	organizationName, website, description string,
+	now time.Time,
+) (*OrganizerApplication, error) {
+	organizationName = strings.TrimSpace(organizationName)
+	if organizationName == "" {
+		return nil, ErrOrganizationNameEmpty
+	}
+	return &OrganizerApplication{
+		id:               uuid.New(),
+		userID:           userID,
+		organizationName: organizationName,
+		website:          strings.TrimSpace(website),
+		description:      strings.TrimSpace(description),
+		status:           ApplicationStatusPending,
+		createdAt:        now,
+	}, nil
+}
+
+// UnmarshalOrganizerApplication rebuilds an OrganizerApplication from
+// persisted values. reviewerID, reviewedAt and organizationID are zero until
+// the application is reviewed.
+func UnmarshalOrganizerApplication(
+	id uuid.UUID,
+	userID uuid.UUID,
+	organizationName, website, description string,
+	status ApplicationStatus,
+	reviewerID uuid.UUID,
+	reviewNote string,
+	reviewedAt time.Time,
+	organizationID uuid.UUID,
+	createdAt time.Time,
+) *OrganizerApplication {
+	return &OrganizerApplication{
+		id:               id,
+		userID:           userID,
+		organizationName: organizationName,
+		website:          website,
+		description:      description,
+		status:           status,
+		reviewerID:       reviewerID,
+		reviewNote:       reviewNote,
+		reviewedAt:       reviewedAt,
+		organizationID:   organizationID,
+		createdAt:        createdAt,
+	}
+}
+
+// Approve accepts a pending application and records the organization it
+// created.
+func (a *OrganizerApplication) Approve(reviewerID, organizationID uuid.UUID, now time.Time) error {
+	if a.status != ApplicationStatusPending {
+		return ErrApplicationNotPending
+	}
+	a.status = ApplicationStatusApproved
+	a.reviewerID = reviewerID
+	a.organizationID = organizationID
+	a.reviewedAt = now
+	return nil
+}
+
+// Reject turns a pending application down. The note is shown to the
+// applicant, who may apply again.
+func (a *OrganizerApplication) Reject(reviewerID uuid.UUID, note string, now time.Time) error {
+	if a.status != ApplicationStatusPending {
+		return ErrApplicationNotPending
+	}
+	a.status = ApplicationStatusRejected
+	a.reviewerID = reviewerID
+	a.reviewNote = strings.TrimSpace(note)
+	a.reviewedAt = now
+	return nil
+}
+
+func (a *OrganizerApplication) ID() uuid.UUID {
+	return a.id
+}
+
+func (a *OrganizerApplication) UserID() uuid.UUID {
+	return a.userID
+}
+
+func (a *OrganizerApplication) OrganizationName() string {
+	return a.organizationName
+}
+
+func (a *OrganizerApplication) Website() string {
+	return a.website
+}
+
+func (a *OrganizerApplication) Description() string {
+	return a.description
+}
+
+func (a *OrganizerApplication) Status() ApplicationStatus {
+	return a.status
+}
+
+func (a *OrganizerApplication) IsPending() bool {
+	return a.status == ApplicationStatusPending
+}
+
+func (a *OrganizerApplication) ReviewerID() uuid.UUID {
+	return a.reviewerID
+}
+
+func (a *OrganizerApplication) ReviewNote() string {
+	return a.reviewNote
+}
+
+func (a *OrganizerApplication) ReviewedAt() time.Time {
+	return a.reviewedAt
+}
+
+// OrganizationID returns the organization created on approval, or uuid.Nil.
+func (a *OrganizerApplication) OrganizationID() uuid.UUID {
+	return a.organizationID
+}
+
+func (a *OrganizerApplication) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// OrganizationRepository defines the interface for organization persistence.
+type OrganizationRepository interface {
+	CreateOrganization(ctx context.Context, organization *Organization) error
+	GetOrganization(ctx context.Context, id uuid.UUID) (*Organization, error)
+	// GetOrganizationByOwner returns ErrOrganizationNotFound for users who
+	// own no organization.
+	GetOrganizationByOwner(ctx context.Context, ownerID uuid.UUID) (*Organization, error)
+}
+
+// OrganizerApplicationRepository defines the interface for organizer
+// application persistence.
+type OrganizerApplicationRepository interface {
+	// CreateOrganizerApplication returns ErrApplicationPending when the user
+	// already has an application waiting for review.
+	CreateOrganizerApplication(ctx context.Context, application *OrganizerApplication) error
+	// GetLatestOrganizerApplication returns the newest application of a user.
+	GetLatestOrganizerApplication(ctx context.Context, userID uuid.UUID) (*OrganizerApplication, error)
+	GetOrganizerApplicationForUpdate(ctx context.Context, id uuid.UUID) (*OrganizerApplication, error)
+	ListOrganizerApplications(ctx context.Context, filter ApplicationFilter) ([]*OrganizerApplication, error)
+	UpdateOrganizerApplication(ctx context.Context, application *OrganizerApplication) error
+}
@@ -0,0 +1,41 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestOrganizerApplication_ReviewedOnce(t *testing.T) {
+	now := time.Now()
+	_, err := domain.NewOrganizerApplication(uuid.New(), "  ", "", "", now)
+	if !errors.Is(err, domain.ErrOrganizationNameEmpty) {
+		t.Errorf("NewOrganizerApplication() without name error = %v, want %v", err, domain.ErrOrganizationNameEmpty)
+	}
+
+	application, err := domain.NewOrganizerApplication(uuid.New(), " Riverside Concerts ", "", "", now)
+	if err != nil {
+		t.Fatalf("NewOrganizerApplication() error = %v", err)
+	}
+	if !application.IsPending() || application.OrganizationName() != "Riverside Concerts" {
+		t.Fatalf("NewOrganizerApplication() = %s %q, want pending %q",
+			application.Status(), application.OrganizationName(), "Riverside Concerts")
+	}
+
+	organizationID := uuid.New()
+	if err := application.Approve(uuid.New(), organizationID, now); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if application.Status() != domain.ApplicationStatusApproved || application.OrganizationID() != organizationID {
+		t.Errorf("Approve() status = %s, organization = %s", application.Status(), application.OrganizationID())
+	}
+	if err := application.Reject(uuid.New(), "too late", now); !errors.Is(err, domain.ErrApplicationNotPending) {
+		t.Errorf("Reject() after Approve() error = %v, want %v", err, domain.ErrApplicationNotPending)
+	}
+	if err := application.Approve(uuid.New(), uuid.New(), now); !errors.Is(err, domain.ErrApplicationNotPending) {
+		t.Errorf("Approve() twice error = %v, want %v", err, domain.ErrApplicationNotPending)
+	}
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordResetTokenTTL is how long a password reset link works.
+const PasswordResetTokenTTL = time.Hour
+
+// PasswordResetToken lets the owner of an email address choose a new
+// password once. Only its hash is stored.
+type PasswordResetToken struct {
+	id        uuid.UUID
+	userID    uuid.UUID
+	tokenHash string
+	expiresAt time.Time
+	createdAt time.Time
+	usedAt    time.Time
+}
+
+// NewPasswordResetToken issues a reset token for a user and returns it with
+// its secret value.
+func NewPasswordResetToken(userID uuid.UUID, now time.Time) (*PasswordResetToken, string) {
+	secret := rand.Text()
+	return &PasswordResetToken{
+		id:        uuid.New(),
+		userID:    userID,
+		tokenHash: HashToken(secret),
+		expiresAt: now.Add(PasswordResetTokenTTL),
+		createdAt: now,
+	}, secret
+}
+
+// UnmarshalPasswordResetToken rebuilds a PasswordResetToken from persisted
+// values. A zero usedAt means the token was not used.
+func UnmarshalPasswordResetToken(
+	id uuid.UUID,
+	userID uuid.UUID,
+	tokenHash string,
+	expiresAt time.Time,
+	createdAt time.Time,
+	usedAt time.Time,
+) *PasswordResetToken {
+	return &PasswordResetToken{
+		id:        id,
+		userID:    userID,
+		tokenHash: tokenHash,
+		expiresAt: expiresAt,
+		createdAt: createdAt,
+		usedAt:    usedAt,
+	}
+}
+
+// Use marks the token as used. Used and expired tokens yield
+// ErrResetTokenInvalid.
+func (t *PasswordResetToken) Use(now time.Time) error {
+	if !t.usedAt.IsZero() || !now.Before(t.expiresAt) {
+		return ErrResetTokenInvalid
+	}
+	t.usedAt = now
+	return nil
+}
+
+func (t *PasswordResetToken) ID() uuid.UUID {
+	return t.id
+}
+
+func (t *PasswordResetToken) UserID() uuid.UUID {
+	return t.userID
+}
+
+func (t *PasswordResetToken) TokenHash() string {
+	return t.tokenHash
+}
+
+func (t *PasswordResetToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *PasswordResetToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *PasswordResetToken) UsedAt() time.Time {
+	return t.usedAt
+}
+
+// PasswordResetRepository defines the interface for password reset token persistence.
+type PasswordResetRepository interface {
+	CreatePasswordResetToken(ctx context.Context, token *PasswordResetToken) error
+	// GetPasswordResetTokenForUpdate looks a token up by its secret value and
+	// locks it; it returns ErrResetTokenInvalid for unknown tokens.
+	GetPasswordResetTokenForUpdate(ctx context.Context, secret string) (*PasswordResetToken, error)
+	// UsePasswordResetTokens marks every outstanding token of a user used.
+	UsePasswordResetTokens(ctx context.Context, userID uuid.UUID, now time.Time) error
+}
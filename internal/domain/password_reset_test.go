@@ -0,0 +1,28 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestPasswordResetToken_UseOnceBeforeExpiry(t *testing.T) {
+	now := time.Now()
+	token, secret := domain.NewPasswordResetToken(uuid.New(), now)
+	if token.TokenHash() != domain.HashToken(secret) || token.TokenHash() == secret {
+		t.Error("NewPasswordResetToken() should store the hash of the secret only")
+	}
+
+	if err := token.Use(now.Add(domain.PasswordResetTokenTTL)); !errors.Is(err, domain.ErrResetTokenInvalid) {
+		t.Errorf("Use() after expiry error = %v, want %v", err, domain.ErrResetTokenInvalid)
+	}
+	if err := token.Use(now); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	if err := token.Use(now); !errors.Is(err, domain.ErrResetTokenInvalid) {
+		t.Errorf("Use() twice error = %v, want %v", err, domain.ErrResetTokenInvalid)
+	}
+}
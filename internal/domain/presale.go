@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Presale is a sales window that opens before general sales and is
+// restricted to holders of an access code.
+type Presale struct {
+	id             uuid.UUID
+	eventID        uuid.UUID
+	name           string
+	startAt        time.Time
+	endAt          time.Time
+	accessCodeHash string
+	createdAt      time.Time
+}
+
+// NewPresale creates a new validated Presale. Only a hash of the access code is kept.
+func NewPresale(id, eventID uuid.UUID, name string, startAt, endAt time.Time, accessCode string) (*Presale, error) {
+	if eventID == uuid.Nil {
+		return nil, ErrEventIDNil
+	}
+	if name == "" {
+		return nil, ErrPresaleNameEmpty
+	}
+	if accessCode == "" {
+		return nil, ErrAccessCodeEmpty
+	}
+	if !startAt.Before(endAt) {
+		return nil, ErrSalesWindowInvalid
+	}
+	return &Presale{
+		id:             id,
+		eventID:        eventID,
+		name:           name,
+		startAt:        startAt,
+		endAt:          endAt,
+		accessCodeHash: HashAccessCode(accessCode),
+		createdAt:      time.Now(),
+	}, nil
+}
+
+// NewPresaleFromPersistence creates a Presale from the given parameters.
+func NewPresaleFromPersistence(
+	id, eventID uuid.UUID,
+	name string,
+	startAt, endAt time.Time,
+	accessCodeHash string,
+	createdAt time.Time,
+) *Presale {
+	return &Presale{id, eventID, name, startAt, endAt, accessCodeHash, createdAt}
+}
+
+// HashAccessCode returns the hex encoded SHA-256 of an access code.
+func HashAccessCode(accessCode string) string {
+	sum := sha256.Sum256([]byte(accessCode))
+	return hex.EncodeToString(sum[:])
+}
+
+// ID returns the presale's ID.
+func (p *Presale) ID() uuid.UUID {
+	return p.id
+}
+
+// EventID returns the ID of the event the presale belongs to.
+func (p *Presale) EventID() uuid.UUID {
+	return p.eventID
+}
+
+// Name returns the presale's name.
+func (p *Presale) Name() string {
+	return p.name
+}
+
+// StartAndEndAt returns the presale's start and end times.
+func (p *Presale) StartAndEndAt() (time.Time, time.Time) {
+	return p.startAt, p.endAt
+}
+
+// AccessCodeHash returns the hash of the presale's access code.
+func (p *Presale) AccessCodeHash() string {
+	return p.accessCodeHash
+}
+
+// CreatedAt returns the time the presale was created.
+func (p *Presale) CreatedAt() time.Time {
+	return p.createdAt
+}
+
+// IsActive reports whether the presale window contains the given time.
+func (p *Presale) IsActive(now time.Time) bool {
+	return !now.Before(p.startAt) && now.Before(p.endAt)
+}
+
+// Accepts reports whether the access code matches the presale's code.
+func (p *Presale) Accepts(accessCode string) bool {
+	if accessCode == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(HashAccessCode(accessCode)), []byte(p.accessCodeHash)) == 1
+}
+
+// PresaleRepository defines the interface for presale persistence.
+type PresaleRepository interface {
+	CreatePresale(ctx context.Context, presale *Presale) error
+	ListPresales(ctx context.Context, eventID uuid.UUID) ([]*Presale, error)
+	DeletePresale(ctx context.Context, eventID, presaleID uuid.UUID) error
+}
@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PricingTrigger decides when a pricing rule kicks in.
+type PricingTrigger string
+
+const (
+	// PricingTriggerSoldPercent applies once at least Threshold percent of the capacity is sold.
+	PricingTriggerSoldPercent PricingTrigger = "sold_percent"
+	// PricingTriggerHoursBeforeStart applies within Threshold hours of the event start.
+	PricingTriggerHoursBeforeStart PricingTrigger = "hours_before_start"
+)
+
+// PriceAdjustment decides how a pricing rule changes the price.
+type PriceAdjustment string
+
+const (
+	// PriceAdjustmentPercent changes the base price by Adjustment percent.
+	PriceAdjustmentPercent PriceAdjustment = "percent"
+	// PriceAdjustmentFixed adds Adjustment minor units of the event's currency to the price.
+	PriceAdjustmentFixed PriceAdjustment = "fixed"
+)
+
+// PricingRule adjusts an event's price when its trigger condition holds,
+// e.g. "after 80% sold, +20%" or "last 48h, +1000".
+type PricingRule struct {
+	id             uuid.UUID
+	eventID        uuid.UUID
+	trigger        PricingTrigger
+	threshold      int
+	adjustmentKind PriceAdjustment
+	adjustment     int64
+	createdAt      time.Time
+}
+
+// NewPricingRule creates a new validated PricingRule.
+func NewPricingRule(
+	id, eventID uuid.UUID,
+	trigger PricingTrigger,
+	threshold int,
+	adjustmentKind PriceAdjustment,
+	adjustment int64,
+) (*PricingRule, error) {
+	if eventID == uuid.Nil {
+		return nil, ErrEventIDNil
+	}
+	switch trigger {
+	case PricingTriggerSoldPercent:
+		if threshold < 1 || threshold > 100 {
+			return nil, ErrPricingRuleInvalid
+		}
+	case PricingTriggerHoursBeforeStart:
+		if threshold < 1 {
+			return nil, ErrPricingRuleInvalid
+		}
+	default:
+		return nil, ErrPricingRuleInvalid
+	}
+	switch adjustmentKind {
+	case PriceAdjustmentPercent:
+		if adjustment <= -100 {
+			return nil, ErrPricingRuleInvalid
+		}
+	case PriceAdjustmentFixed:
+	default:
+		return nil, ErrPricingRuleInvalid
+	}
+	return &PricingRule{
+		id:             id,
+		eventID:        eventID,
+		trigger:        trigger,
+		threshold:      threshold,
+		adjustmentKind: adjustmentKind,
+		adjustment:     adjustment,
+		createdAt:      time.Now(),
+	}, nil
+}
+
+// UnmarshalPricingRule creates a PricingRule from the given parameters.
+func UnmarshalPricingRule(
+	id, eventID uuid.UUID,
+	trigger PricingTrigger,
+	threshold int,
+	adjustmentKind PriceAdjustment,
+	adjustment int64,
+	createdAt time.Time,
+) *PricingRule {
+	return &PricingRule{id, eventID, trigger, threshold, adjustmentKind, adjustment, createdAt}
+}
+
+func (r *PricingRule) ID() uuid.UUID {
+	return r.id
+}
+
+func (r *PricingRule) EventID() uuid.UUID {
+	return r.eventID
+}
+
+func (r *PricingRule) Trigger() PricingTrigger {
+	return r.trigger
+}
+
+func (r *PricingRule) Threshold() int {
+	return r.threshold
+}
+
+func (r *PricingRule) AdjustmentKind() PriceAdjustment {
+	return r.adjustmentKind
+}
+
+func (r *PricingRule) Adjustment() int64 {
+	return r.adjustment
+}
+
+func (r *PricingRule) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// Applies reports whether the rule's trigger holds for the event at the given time.
+func (r *PricingRule) Applies(event *Event, now time.Time) bool {
+	switch r.trigger {
+	case PricingTriggerSoldPercent:
+		capacity := event.Capacity()
+		if capacity <= 0 {
+			return false
+		}
+		sold := capacity - event.AvailableSpots()
+		return sold*100 >= r.threshold*capacity
+	case PricingTriggerHoursBeforeStart:
+		startAt, _ := event.StartAndEndAt()
+		return startAt.Sub(now) <= time.Duration(r.threshold)*time.Hour
+	default:
+		return false
+	}
+}
+
+// QuotePrice computes the current price of an event from its base price and
+// the rules that apply at the given time. Percentage adjustments are summed
+// and applied to the base price, then fixed adjustments are added, so the
+// result does not depend on rule order. The price never drops below zero and
+// is always quoted in the event's currency.
+func QuotePrice(event *Event, rules []*PricingRule, now time.Time) Money {
+	base := event.Price().Amount()
+	var percent, fixed int64
+	for _, rule := range rules {
+		if !rule.Applies(event, now) {
+			continue
+		}
+		switch rule.adjustmentKind {
+		case PriceAdjustmentPercent:
+			percent += rule.adjustment
+		case PriceAdjustmentFixed:
+			fixed += rule.adjustment
+		}
+	}
+
+	price := base + roundDiv(base*percent, 100) + fixed
+	if price < 0 {
+		price = 0
+	}
+	return event.Price().WithAmount(price)
+}
+
+// roundDiv divides rounding half away from zero.
+func roundDiv(a, b int64) int64 {
+	if a < 0 {
+		return -((-a + b/2) / b)
+	}
+	return (a + b/2) / b
+}
+
+// PricingRuleRepository defines the interface for pricing rule persistence.
+type PricingRuleRepository interface {
+	CreatePricingRule(ctx context.Context, rule *PricingRule) error
+	ListPricingRules(ctx context.Context, eventID uuid.UUID) ([]*PricingRule, error)
+	DeletePricingRule(ctx context.Context, eventID, ruleID uuid.UUID) error
+}
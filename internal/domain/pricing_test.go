@@ -0,0 +1,106 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestQuotePrice(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	eventID := uuid.New()
+
+	soldOut80 := mustPricingRule(t, eventID, domain.PricingTriggerSoldPercent, 80, domain.PriceAdjustmentPercent, 20)
+	last48h := mustPricingRule(t, eventID, domain.PricingTriggerHoursBeforeStart, 48, domain.PriceAdjustmentFixed, 1000)
+	rules := []*domain.PricingRule{soldOut80, last48h}
+
+	tests := []struct {
+		name      string
+		available int
+		startIn   time.Duration
+		want      int64
+	}{
+		{name: "no rule applies", available: 50, startIn: 72 * time.Hour, want: 5000},
+		{name: "80% sold", available: 20, startIn: 72 * time.Hour, want: 6000},
+		{name: "last 48 hours", available: 50, startIn: 48 * time.Hour, want: 6000},
+		{name: "both apply", available: 10, startIn: time.Hour, want: 7000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := domain.NewEventFromPersistence(
+				eventID, "Hot Event", domain.UnmarshalMoney(5000, "PLN"),
+				now.Add(tt.startIn), now.Add(tt.startIn+3*time.Hour), now, now,
+				100, tt.available, 0, time.Time{}, time.Time{}, uuid.Nil, "", uuid.Nil, "UTC", 0,
+			)
+
+			want := domain.UnmarshalMoney(tt.want, "PLN")
+			if got := domain.QuotePrice(event, rules, now); got != want {
+				t.Errorf("QuotePrice() = %v, want %v", got, want)
+			}
+			// Rule order must not change the quote.
+			reversed := []*domain.PricingRule{last48h, soldOut80}
+			if got := domain.QuotePrice(event, reversed, now); got != want {
+				t.Errorf("QuotePrice() reversed = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestQuotePrice_NeverNegative(t *testing.T) {
+	now := time.Now()
+	eventID := uuid.New()
+	event := domain.NewEventFromPersistence(
+		eventID, "Discounted", domain.UnmarshalMoney(500, "EUR"), now.Add(time.Hour), now.Add(2*time.Hour), now, now,
+		10, 10, 0, time.Time{}, time.Time{}, uuid.Nil, "", uuid.Nil, "UTC", 0,
+	)
+	discount := mustPricingRule(t, eventID, domain.PricingTriggerHoursBeforeStart, 2, domain.PriceAdjustmentFixed, -1000)
+
+	if got := domain.QuotePrice(event, []*domain.PricingRule{discount}, now); got.Amount() != 0 {
+		t.Errorf("QuotePrice() = %v, want 0", got)
+	}
+}
+
+func TestNewPricingRule_Invalid(t *testing.T) {
+	eventID := uuid.New()
+	tests := []struct {
+		name           string
+		trigger        domain.PricingTrigger
+		threshold      int
+		adjustmentKind domain.PriceAdjustment
+		adjustment     int64
+	}{
+		{"unknown trigger", "weekday", 1, domain.PriceAdjustmentFixed, 100},
+		{"sold percent above 100", domain.PricingTriggerSoldPercent, 101, domain.PriceAdjustmentPercent, 10},
+		{"zero hours", domain.PricingTriggerHoursBeforeStart, 0, domain.PriceAdjustmentFixed, 100},
+		{"unknown adjustment", domain.PricingTriggerSoldPercent, 50, "multiply", 2},
+		{"minus 100 percent", domain.PricingTriggerSoldPercent, 50, domain.PriceAdjustmentPercent, -100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.NewPricingRule(uuid.New(), eventID, tt.trigger, tt.threshold, tt.adjustmentKind, tt.adjustment)
+			if err != domain.ErrPricingRuleInvalid {
+				t.Errorf("NewPricingRule() error = %v, wantErr %v", err, domain.ErrPricingRuleInvalid)
+			}
+		})
+	}
+}
+
+func mustPricingRule(
+	t *testing.T,
+	eventID uuid.UUID,
+	trigger domain.PricingTrigger,
+	threshold int,
+	adjustmentKind domain.PriceAdjustment,
+	adjustment int64,
+) *domain.PricingRule {
+	t.Helper()
+	rule, err := domain.NewPricingRule(uuid.New(), eventID, trigger, threshold, adjustmentKind, adjustment)
+	if err != nil {
+		t.Fatalf("NewPricingRule() error = %v", err)
+	}
+	return rule
+}
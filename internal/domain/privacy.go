@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DataExportRetention is how long a ready export can be downloaded before a
+// new one has to be built.
+const DataExportRetention = 7 * 24 * time.Hour
+
+// DataExportStatus tells whether an export can be downloaded yet.
+type DataExportStatus string
+
+const (
+	DataExportPending DataExportStatus = "pending"
+	DataExportReady   DataExportStatus = "ready"
+	DataExportFailed  DataExportStatus = "failed"
+)
+
+// DataExport is a ZIP archive of the data held about a user, built in the
+// background on their request and kept in the BlobStore.
+type DataExport struct {
+	id          uuid.UUID
+	userID      uuid.UUID
+	status      DataExportStatus
+	blobKey     string
+	createdAt   time.Time
+	completedAt time.Time
+}
+
+// NewDataExport requests an export of a user's data.
+func NewDataExport(userID uuid.UUID, now time.Time) *DataExport {
+	id := uuid.New()
+	return &DataExport{
+		id:        id,
+		userID:    userID,
+		status:    DataExportPending,
+		blobKey:   "exports/" + id.String() + ".zip",
+		createdAt: now,
+	}
+}
+
+// UnmarshalDataExport rebuilds a DataExport from persisted values.
+func UnmarshalDataExport(
+	id, userID uuid.UUID,
+	status DataExportStatus,
+	blobKey string,
+	createdAt, completedAt time.Time,
+) *DataExport {
+	return &DataExport{
+		id:          id,
+		userID:      userID,
+		status:      status,
+		blobKey:     blobKey,
+		createdAt:   createdAt,
+		completedAt: completedAt,
+	}
+}
+
+// Complete marks the export ready once its archive is stored.
+func (e *DataExport) Complete(now time.Time) {
+	e.status = DataExportReady
+	e.completedAt = now
+}
+
+// Fail marks an export that could not be built.
+func (e *DataExport) Fail(now time.Time) {
+	e.status = DataExportFailed
+	e.completedAt = now
+}
+
+// Expired reports whether a new export has to be built instead of this one:
+// it failed, or was ready longer than DataExportRetention ago.
+func (e *DataExport) Expired(now time.Time) bool {
+	switch e.status {
+	case DataExportFailed:
+		return true
+	case DataExportReady:
+		return !now.Before(e.completedAt.Add(DataExportRetention))
+	default:
+		return false
+	}
+}
+
+func (e *DataExport) ID() uuid.UUID {
+	return e.id
+}
+
+func (e *DataExport) UserID() uuid.UUID {
+	return e.userID
+}
+
+func (e *DataExport) Status() DataExportStatus {
+	return e.status
+}
+
+// BlobKey returns where the archive is kept in the BlobStore.
+func (e *DataExport) BlobKey() string {
+	return e.blobKey
+}
+
+func (e *DataExport) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// CompletedAt returns when the export became ready or failed, or the zero
+// time while it is pending.
+func (e *DataExport) CompletedAt() time.Time {
+	return e.completedAt
+}
+
+// DataExportRepository defines the interface for data export persistence.
+type DataExportRepository interface {
+	CreateDataExport(ctx context.Context, export *DataExport) error
+	// GetLatestDataExport returns ErrDataExportNotFound when the user never
+	// requested an export.
+	GetLatestDataExport(ctx context.Context, userID uuid.UUID) (*DataExport, error)
+	// ListPendingDataExportsForUpdate locks up to limit pending exports,
+	// oldest first, skipping those locked by another worker.
+	ListPendingDataExportsForUpdate(ctx context.Context, limit int) ([]*DataExport, error)
+	UpdateDataExport(ctx context.Context, export *DataExport) error
+	// DeleteDataExportsCompletedBefore deletes the exports completed before
+	// a time and returns their blob keys.
+	DeleteDataExportsCompletedBefore(ctx context.Context, before time.Time) ([]string, error)
+	// DeleteUserDataExports deletes the exports of a user and returns their
+	// blob keys.
+	DeleteUserDataExports(ctx context.Context, userID uuid.UUID) ([]string, error)
+}
+
+// ErasedEmail is the address an erased account, and the bookings and orders
+// it made, are left with. It cannot receive email.
+func ErasedEmail(userID uuid.UUID) string {
+	return "erased-" + userID.String() + "@erased.invalid"
+}
+
+// RecipientHash identifies an email recipient without storing their address,
+// so what was sent to them can be found and purged when they are erased.
+func RecipientHash(email string) string {
+	return HashToken(strings.ToLower(strings.TrimSpace(email)))
+}
+
+// UserErasure is the payload of the UserErased outbox event, which tells
+// downstream consumers to purge what they keep about the user.
+type UserErasure struct {
+	UserID uuid.UUID `json:"userID"`
+	// RecipientHash is the RecipientHash of the erased email address.
+	RecipientHash string    `json:"recipientHash"`
+	ErasedAt      time.Time `json:"erasedAt"`
+}
+
+// ErasureRepository removes the personal data of a user kept outside the
+// user row.
+type ErasureRepository interface {
+	// EraseUserData deletes the credentials, sessions, login history,
+	// profile, memberships and API keys of a user, and moves the bookings
+	// and orders made with email to anonymizedEmail. Financial records stay
+	// intact.
+	EraseUserData(ctx context.Context, userID uuid.UUID, email, anonymizedEmail string) error
+}
@@ -0,0 +1,59 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestDataExport_Expired(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		finish func(export *domain.DataExport)
+		at     time.Time
+		want   bool
+	}{
+		{"pending", func(*domain.DataExport) {}, now.Add(30 * 24 * time.Hour), false},
+		{"ready", func(e *domain.DataExport) { e.Complete(now) }, now.Add(domain.DataExportRetention - time.Second), false},
+		{"ready past retention", func(e *domain.DataExport) { e.Complete(now) }, now.Add(domain.DataExportRetention), true},
+		{"failed", func(e *domain.DataExport) { e.Fail(now) }, now, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			export := domain.NewDataExport(uuid.New(), now)
+			tt.finish(export)
+			if got := export.Expired(tt.at); got != tt.want {
+				t.Errorf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUser_Erase(t *testing.T) {
+	user, err := domain.NewUser(uuid.New(), "erase@example.com", "hash", domain.UserRoleUser)
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	now := time.Now()
+	user.Erase(now)
+
+	if !user.IsErased() || !user.IsSuspended() {
+		t.Errorf("IsErased() = %v, IsSuspended() = %v, want both true", user.IsErased(), user.IsSuspended())
+	}
+	if user.Email() != domain.ErasedEmail(user.ID()) || strings.Contains(user.Email(), "erase@example.com") {
+		t.Errorf("Email() = %q, want %q", user.Email(), domain.ErasedEmail(user.ID()))
+	}
+	if user.PasswordHash() != "" {
+		t.Error("PasswordHash() is kept after erasure")
+	}
+}
+
+func TestRecipientHash_IgnoresCaseAndSpace(t *testing.T) {
+	if domain.RecipientHash(" Jane@Example.com") != domain.RecipientHash("jane@example.com") {
+		t.Error("RecipientHash() differs for the same address")
+	}
+}
@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const maxDisplayNameLength = 100
+
+var (
+	// phonePattern matches E.164 numbers, such as +48123456789.
+	phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	// localePattern matches a language, optionally with a region, such as
+	// en or pt-BR.
+	localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+)
+
+// NotificationChannel is a way of reaching a user.
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail   NotificationChannel = "email"
+	NotificationChannelSMS     NotificationChannel = "sms"
+	NotificationChannelWebhook NotificationChannel = "webhook"
+)
+
+// NotificationCategory tells what a notification is about, which decides
+// whether the preferences of its recipient let it through.
+type NotificationCategory string
+
+const (
+	// NotificationAccount covers email verification, password resets and
+	// security alerts. They are always emailed.
+	NotificationAccount NotificationCategory = "account"
+	// NotificationBooking covers booking confirmations and other changes to
+	// bookings.
+	NotificationBooking   NotificationCategory = "booking"
+	NotificationReminder  NotificationCategory = "reminder"
+	NotificationMarketing NotificationCategory = "marketing"
+)
+
+// ChannelPreference tells what a user wants to receive on a channel.
+// Reminders and marketing are opt-in on top of the channel being enabled.
+type ChannelPreference struct {
+	Enabled   bool `json:"enabled"`
+	Reminders bool `json:"reminders"`
+	Marketing bool `json:"marketing"`
+}
+
+// NotificationPreferences tells how a user wants to be notified.
+type NotificationPreferences struct {
+	Channels map[NotificationChannel]ChannelPreference `json:"channels"`
+	// WebhookURL is where webhook notifications are posted.
+	WebhookURL string `json:"webhookUrl,omitempty"`
+}
+
+// DefaultNotificationPreferences emails bookings and reminders, and sends
+// nothing on the other channels or about marketing until the user opts in.
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{Channels: map[NotificationChannel]ChannelPreference{
+		NotificationChannelEmail:   {Enabled: true, Reminders: true},
+		NotificationChannelSMS:     {},
+		NotificationChannelWebhook: {},
+	}}
+}
+
+// Allows reports whether a notification of the category may be sent on the
+// channel.
+func (p NotificationPreferences) Allows(channel NotificationChannel, category NotificationCategory) bool {
+	preference := p.Channels[channel]
+	switch category {
+	case NotificationAccount:
+		return channel == NotificationChannelEmail || preference.Enabled
+	case NotificationBooking:
+		return preference.Enabled
+	case NotificationReminder:
+		return preference.Enabled && preference.Reminders
+	case NotificationMarketing:
+		return preference.Enabled && preference.Marketing
+	default:
+		return false
+	}
+}
+
+// ProfileUpdate changes the fields of a profile that are set. Channels
+// replaces the preferences of the channels it names.
+type ProfileUpdate struct {
+	DisplayName *string
+	Phone       *string
+	Locale      *string
+	TimeZone    *string
+	Channels    map[NotificationChannel]ChannelPreference
+	WebhookURL  *string
+}
+
+// Profile holds how a user presents themselves and wants to be notified.
+type Profile struct {
+	userID        uuid.UUID
+	displayName   string
+	phone         string
+	locale        string
+	timeZone      string
+	notifications NotificationPreferences
+	updatedAt     time.Time
+}
+
+// NewProfile returns the profile of a user who never edited it.
+func NewProfile(userID uuid.UUID) *Profile {
+	return &Profile{userID: userID, notifications: DefaultNotificationPreferences()}
+}
+
+// UnmarshalProfile rebuilds a Profile from persisted values. Channels missing
+// from the stored preferences get their default.
+func UnmarshalProfile(
+	userID uuid.UUID,
+	displayName, phone, locale, timeZone string,
+	notifications NotificationPreferences,
+	updatedAt time.Time,
+) *Profile {
+	channels := DefaultNotificationPreferences().Channels
+	for channel, preference := range notifications.Channels {
+		channels[channel] = preference
+	}
+	notifications.Channels = channels
+	return &Profile{
+		userID:        userID,
+		displayName:   displayName,
+		phone:         phone,
+		locale:        locale,
+		timeZone:      timeZone,
+		notifications: notifications,
+		updatedAt:     updatedAt,
+	}
+}
+
+// Update validates and applies an update. SMS needs a phone number and
+// webhooks a URL, also when the update clears them.
+func (p *Profile) Update(update ProfileUpdate, now time.Time) error {
+	next := *p
+	next.notifications.Channels = make(map[NotificationChannel]ChannelPreference, len(p.notifications.Channels))
+	for channel, preference := range p.notifications.Channels {
+		next.notifications.Channels[channel] = preference
+	}
+
+	if update.DisplayName != nil {
+		next.displayName = strings.TrimSpace(*update.DisplayName)
+		if utf8.RuneCountInString(next.displayName) > maxDisplayNameLength {
+			return ErrDisplayNameTooLong
+		}
+	}
+	if update.Phone != nil {
+		next.phone = strings.TrimSpace(*update.Phone)
+		if next.phone != "" && !phonePattern.MatchString(next.phone) {
+			return ErrPhoneInvalid
+		}
+	}
+	if update.Locale != nil {
+		next.locale = strings.TrimSpace(*update.Locale)
+		if next.locale != "" && !localePattern.MatchString(next.locale) {
+			return ErrLocaleInvalid
+		}
+	}
+	if update.TimeZone != nil {
+		next.timeZone = strings.TrimSpace(*update.TimeZone)
+		if next.timeZone != "" && !isTimeZone(next.timeZone) {
+			return ErrTimeZoneInvalid
+		}
+	}
+	if update.WebhookURL != nil {
+		next.notifications.WebhookURL = strings.TrimSpace(*update.WebhookURL)
+		if next.notifications.WebhookURL != "" && !validWebhookURL(next.notifications.WebhookURL) {
+			return ErrWebhookURLInvalid
+		}
+	}
+	for channel, preference := range update.Channels {
+		if _, ok := next.notifications.Channels[channel]; !ok {
+			return ErrChannelInvalid
+		}
+		next.notifications.Channels[channel] = preference
+	}
+
+	if next.notifications.Channels[NotificationChannelSMS].Enabled && next.phone == "" {
+		return ErrPhoneRequired
+	}
+	if next.notifications.Channels[NotificationChannelWebhook].Enabled && next.notifications.WebhookURL == "" {
+		return ErrWebhookURLRequired
+	}
+	next.updatedAt = now
+	*p = next
+	return nil
+}
+
+func validWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme == "https" && u.Host != "" && u.User == nil
+}
+
+func (p *Profile) UserID() uuid.UUID {
+	return p.userID
+}
+
+func (p *Profile) DisplayName() string {
+	return p.displayName
+}
+
+// Phone returns the E.164 phone number of the user, or "".
+func (p *Profile) Phone() string {
+	return p.phone
+}
+
+// Locale returns the language the user reads, such as en or pt-BR, or "".
+func (p *Profile) Locale() string {
+	return p.locale
+}
+
+// TimeZone returns the IANA time zone of the user, or "" for UTC.
+func (p *Profile) TimeZone() string {
+	return p.timeZone
+}
+
+func (p *Profile) Notifications() NotificationPreferences {
+	return p.notifications
+}
+
+// UpdatedAt returns when the profile was last edited, or the zero time.
+func (p *Profile) UpdatedAt() time.Time {
+	return p.updatedAt
+}
+
+// ProfileRepository defines the interface for profile persistence.
+type ProfileRepository interface {
+	// GetProfile returns NewProfile for users who never edited theirs.
+	GetProfile(ctx context.Context, userID uuid.UUID) (*Profile, error)
+	SaveProfile(ctx context.Context, profile *Profile) error
+}
+
+// NotificationPreferenceChecker tells senders whether the recipient of a
+// notification wants it.
+type NotificationPreferenceChecker interface {
+	AllowsNotification(
+		ctx context.Context,
+		email string,
+		channel NotificationChannel,
+		category NotificationCategory,
+	) (bool, error)
+}
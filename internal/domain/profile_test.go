@@ -0,0 +1,138 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func ptr(s string) *string {
+	return &s
+}
+
+func TestProfile_Update(t *testing.T) {
+	tests := []struct {
+		name    string
+		update  domain.ProfileUpdate
+		wantErr error
+	}{
+		{"display name", domain.ProfileUpdate{DisplayName: ptr("  Jane  ")}, nil},
+		{
+			"display name too long",
+			domain.ProfileUpdate{DisplayName: ptr(strings.Repeat("a", 101))},
+			domain.ErrDisplayNameTooLong,
+		},
+		{"phone", domain.ProfileUpdate{Phone: ptr("+48123456789")}, nil},
+		{"phone without country code", domain.ProfileUpdate{Phone: ptr("123456789")}, domain.ErrPhoneInvalid},
+		{"locale", domain.ProfileUpdate{Locale: ptr("pt-BR")}, nil},
+		{"locale invalid", domain.ProfileUpdate{Locale: ptr("english")}, domain.ErrLocaleInvalid},
+		{"time zone", domain.ProfileUpdate{TimeZone: ptr("Europe/Warsaw")}, nil},
+		{"time zone unknown", domain.ProfileUpdate{TimeZone: ptr("Mars/Olympus")}, domain.ErrTimeZoneInvalid},
+		{"time zone local", domain.ProfileUpdate{TimeZone: ptr("Local")}, domain.ErrTimeZoneInvalid},
+		{"webhook over http", domain.ProfileUpdate{WebhookURL: ptr("http://example.com/hook")}, domain.ErrWebhookURLInvalid},
+		{
+			"unknown channel",
+			domain.ProfileUpdate{Channels: map[domain.NotificationChannel]domain.ChannelPreference{"pigeon": {}}},
+			domain.ErrChannelInvalid,
+		},
+		{
+			"sms without phone",
+			domain.ProfileUpdate{Channels: map[domain.NotificationChannel]domain.ChannelPreference{
+				domain.NotificationChannelSMS: {Enabled: true},
+			}},
+			domain.ErrPhoneRequired,
+		},
+		{
+			"sms with phone",
+			domain.ProfileUpdate{Phone: ptr("+48123456789"), Channels: map[domain.NotificationChannel]domain.ChannelPreference{
+				domain.NotificationChannelSMS: {Enabled: true},
+			}},
+			nil,
+		},
+		{
+			"webhook without url",
+			domain.ProfileUpdate{Channels: map[domain.NotificationChannel]domain.ChannelPreference{
+				domain.NotificationChannelWebhook: {Enabled: true},
+			}},
+			domain.ErrWebhookURLRequired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := domain.NewProfile(uuid.New())
+			err := profile.Update(tt.update, time.Now())
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Update() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil && !profile.UpdatedAt().IsZero() {
+				t.Error("Update() changed the profile despite failing")
+			}
+		})
+	}
+}
+
+func TestProfile_UpdateKeepsProfileOnError(t *testing.T) {
+	profile := domain.NewProfile(uuid.New())
+	err := profile.Update(domain.ProfileUpdate{
+		DisplayName: ptr("Jane"),
+		Channels: map[domain.NotificationChannel]domain.ChannelPreference{
+			domain.NotificationChannelEmail: {Enabled: false},
+			domain.NotificationChannelSMS:   {Enabled: true},
+		},
+	}, time.Now())
+	if !errors.Is(err, domain.ErrPhoneRequired) {
+		t.Fatalf("Update() error = %v, want %v", err, domain.ErrPhoneRequired)
+	}
+	if profile.DisplayName() != "" || !profile.Notifications().Channels[domain.NotificationChannelEmail].Enabled {
+		t.Error("Update() changed the profile despite failing")
+	}
+}
+
+func TestNotificationPreferences_Allows(t *testing.T) {
+	preferences := domain.DefaultNotificationPreferences()
+	preferences.Channels[domain.NotificationChannelEmail] = domain.ChannelPreference{}
+
+	tests := []struct {
+		channel  domain.NotificationChannel
+		category domain.NotificationCategory
+		want     bool
+	}{
+		{domain.NotificationChannelEmail, domain.NotificationAccount, true},
+		{domain.NotificationChannelEmail, domain.NotificationBooking, false},
+		{domain.NotificationChannelEmail, domain.NotificationReminder, false},
+		{domain.NotificationChannelSMS, domain.NotificationAccount, false},
+	}
+	for _, tt := range tests {
+		if got := preferences.Allows(tt.channel, tt.category); got != tt.want {
+			t.Errorf("Allows(%s, %s) = %v, want %v", tt.channel, tt.category, got, tt.want)
+		}
+	}
+
+	defaults := domain.DefaultNotificationPreferences()
+	if !defaults.Allows(domain.NotificationChannelEmail, domain.NotificationReminder) {
+		t.Error("default preferences turn down email reminders")
+	}
+	if defaults.Allows(domain.NotificationChannelEmail, domain.NotificationMarketing) {
+		t.Error("default preferences allow marketing without opt-in")
+	}
+}
+
+func TestUnmarshalProfile_FillsMissingChannels(t *testing.T) {
+	profile := domain.UnmarshalProfile(uuid.New(), "Jane", "", "", "", domain.NotificationPreferences{
+		Channels: map[domain.NotificationChannel]domain.ChannelPreference{
+			domain.NotificationChannelEmail: {Enabled: true, Marketing: true},
+		},
+	}, time.Now())
+
+	channels := profile.Notifications().Channels
+	if len(channels) != 3 {
+		t.Fatalf("Channels has %d entries, want 3", len(channels))
+	}
+	if !channels[domain.NotificationChannelEmail].Marketing || channels[domain.NotificationChannelEmail].Reminders {
+		t.Errorf("email preference = %+v, want the stored one", channels[domain.NotificationChannelEmail])
+	}
+}
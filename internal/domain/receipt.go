@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Receipt is the invoice issued for a confirmed booking. Invoice numbers are
+// sequential per organizer.
+type Receipt struct {
+	bookingID     uuid.UUID
+	organizerID   uuid.UUID
+	sequence      int64
+	invoiceNumber string
+	blobKey       string
+	createdAt     time.Time
+}
+
+// NewReceipt creates a receipt for the given invoice sequence number.
+// Bookings of events without an organizer are numbered on the platform
+// sequence, keyed by uuid.Nil.
+func NewReceipt(bookingID, organizerID uuid.UUID, sequence int64, issuedAt time.Time) *Receipt {
+	return &Receipt{
+		bookingID:     bookingID,
+		organizerID:   organizerID,
+		sequence:      sequence,
+		invoiceNumber: InvoiceNumber(organizerID, issuedAt, sequence),
+		blobKey:       "receipts/" + bookingID.String() + ".pdf",
+		createdAt:     issuedAt,
+	}
+}
+
+// UnmarshalReceipt rebuilds a Receipt from persisted values.
+func UnmarshalReceipt(
+	bookingID, organizerID uuid.UUID,
+	sequence int64,
+	invoiceNumber, blobKey string,
+	createdAt time.Time,
+) *Receipt {
+	return &Receipt{
+		bookingID:     bookingID,
+		organizerID:   organizerID,
+		sequence:      sequence,
+		invoiceNumber: invoiceNumber,
+		blobKey:       blobKey,
+		createdAt:     createdAt,
+	}
+}
+
+// InvoiceNumber formats a sequence number as e.g. "INV-1A2B3C4D-2026-000042".
+func InvoiceNumber(organizerID uuid.UUID, issuedAt time.Time, sequence int64) string {
+	prefix := "PLATFORM"
+	if organizerID != uuid.Nil {
+		prefix = strings.ToUpper(organizerID.String()[:8])
+	}
+	return fmt.Sprintf("INV-%s-%d-%06d", prefix, issuedAt.Year(), sequence)
+}
+
+func (r *Receipt) BookingID() uuid.UUID {
+	return r.bookingID
+}
+
+func (r *Receipt) OrganizerID() uuid.UUID {
+	return r.organizerID
+}
+
+func (r *Receipt) Sequence() int64 {
+	return r.sequence
+}
+
+func (r *Receipt) InvoiceNumber() string {
+	return r.invoiceNumber
+}
+
+// BlobKey returns where the PDF is kept in the BlobStore.
+func (r *Receipt) BlobKey() string {
+	return r.blobKey
+}
+
+func (r *Receipt) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// ReceiptRepository defines the interface for receipt persistence.
+type ReceiptRepository interface {
+	// NextInvoiceSequence allocates the organizer's next invoice number. It
+	// must run in the transaction that stores the receipt to stay gapless.
+	NextInvoiceSequence(ctx context.Context, organizerID uuid.UUID) (int64, error)
+	CreateReceipt(ctx context.Context, receipt *Receipt) error
+	// GetReceipt returns ErrReceiptNotFound when no receipt was issued for the booking yet.
+	GetReceipt(ctx context.Context, bookingID uuid.UUID) (*Receipt, error)
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshTokenTTL is how long a session can go without being refreshed.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+// RefreshToken is a single-use credential exchanged for a new access token
+// and the next refresh token of the same family. Only its hash is stored.
+type RefreshToken struct {
+	id            uuid.UUID
+	userID        uuid.UUID
+	familyID      uuid.UUID
+	tokenHash     string
+	accessTokenID uuid.UUID
+	expiresAt     time.Time
+	createdAt     time.Time
+	usedAt        time.Time
+	revokedAt     time.Time
+}
+
+// NewRefreshToken issues a refresh token paired with the access token of the
+// given ID and returns it with its secret value. familyID is uuid.Nil for a
+// new session.
+func NewRefreshToken(userID, familyID, accessTokenID uuid.UUID, now time.Time) (*RefreshToken, string) {
+	if familyID == uuid.Nil {
+		familyID = uuid.New()
+	}
+	secret := rand.Text()
+	return &RefreshToken{
+		id:            uuid.New(),
+		userID:        userID,
+		familyID:      familyID,
+		tokenHash:     HashToken(secret),
+		accessTokenID: accessTokenID,
+		expiresAt:     now.Add(RefreshTokenTTL),
+		createdAt:     now,
+	}, secret
+}
+
+// UnmarshalRefreshToken rebuilds a RefreshToken from persisted values. Zero
+// times mean the token was not used or revoked.
+func UnmarshalRefreshToken(
+	id uuid.UUID,
+	userID uuid.UUID,
+	familyID uuid.UUID,
+	tokenHash string,
+	accessTokenID uuid.UUID,
+	expiresAt time.Time,
+	createdAt time.Time,
+	usedAt time.Time,
+	revokedAt time.Time,
+) *RefreshToken {
+	return &RefreshToken{
+		id:            id,
+		userID:        userID,
+		familyID:      familyID,
+		tokenHash:     tokenHash,
+		accessTokenID: accessTokenID,
+		expiresAt:     expiresAt,
+		createdAt:     createdAt,
+		usedAt:        usedAt,
+		revokedAt:     revokedAt,
+	}
+}
+
+// HashToken returns the stored form of an opaque single-use token, such as a
+// refresh or password reset token.
+func HashToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// Use marks the token as exchanged. A token that was already used yields
+// ErrRefreshTokenReused; a revoked or expired one ErrRefreshTokenInvalid.
+func (t *RefreshToken) Use(now time.Time) error {
+	if !t.revokedAt.IsZero() || !now.Before(t.expiresAt) {
+		return ErrRefreshTokenInvalid
+	}
+	if !t.usedAt.IsZero() {
+		return ErrRefreshTokenReused
+	}
+	t.usedAt = now
+	return nil
+}
+
+func (t *RefreshToken) ID() uuid.UUID {
+	return t.id
+}
+
+func (t *RefreshToken) UserID() uuid.UUID {
+	return t.userID
+}
+
+// FamilyID identifies the session the token belongs to.
+func (t *RefreshToken) FamilyID() uuid.UUID {
+	return t.familyID
+}
+
+func (t *RefreshToken) TokenHash() string {
+	return t.tokenHash
+}
+
+// AccessTokenID returns the jti of the access token issued with this token.
+func (t *RefreshToken) AccessTokenID() uuid.UUID {
+	return t.accessTokenID
+}
+
+func (t *RefreshToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+func (t *RefreshToken) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+func (t *RefreshToken) UsedAt() time.Time {
+	return t.usedAt
+}
+
+// RefreshTokenRepository defines the interface for refresh token persistence.
+type RefreshTokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token *RefreshToken) error
+	// GetRefreshTokenForUpdate looks a token up by its secret value and locks
+	// it; it returns ErrRefreshTokenInvalid for unknown tokens.
+	GetRefreshTokenForUpdate(ctx context.Context, secret string) (*RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, token *RefreshToken) error
+	// RevokeFamily revokes every live token of a session and returns the IDs
+	// of the access tokens issued with them since issuedSince, which may still
+	// be valid.
+	RevokeFamily(ctx context.Context, familyID uuid.UUID, now, issuedSince time.Time) ([]uuid.UUID, error)
+	// RevokeUserTokens revokes every session of a user and returns the IDs of
+	// the access tokens issued with them since issuedSince.
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID, now, issuedSince time.Time) ([]uuid.UUID, error)
+	// ListUserAccessTokens returns the IDs of the access tokens issued to the
+	// live sessions of a user since issuedSince, leaving the sessions intact.
+	ListUserAccessTokens(ctx context.Context, userID uuid.UUID, issuedSince time.Time) ([]uuid.UUID, error)
+}
@@ -0,0 +1,44 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestRefreshToken_UseOnce(t *testing.T) {
+	now := time.Now()
+	token, secret := domain.NewRefreshToken(uuid.New(), uuid.Nil, uuid.New(), now)
+	if token.FamilyID() == uuid.Nil {
+		t.Error("NewRefreshToken() should start a new family")
+	}
+	if token.TokenHash() != domain.HashToken(secret) || token.TokenHash() == secret {
+		t.Error("NewRefreshToken() should store the hash of the secret only")
+	}
+
+	if err := token.Use(now); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	if err := token.Use(now); !errors.Is(err, domain.ErrRefreshTokenReused) {
+		t.Errorf("Use() error = %v, want %v", err, domain.ErrRefreshTokenReused)
+	}
+}
+
+func TestRefreshToken_UseExpiredOrRevoked(t *testing.T) {
+	now := time.Now()
+	token, _ := domain.NewRefreshToken(uuid.New(), uuid.New(), uuid.New(), now)
+	if err := token.Use(now.Add(domain.RefreshTokenTTL)); !errors.Is(err, domain.ErrRefreshTokenInvalid) {
+		t.Errorf("Use() error = %v, want %v", err, domain.ErrRefreshTokenInvalid)
+	}
+
+	revoked := domain.UnmarshalRefreshToken(
+		uuid.New(), uuid.New(), uuid.New(), "hash", uuid.New(),
+		now.Add(time.Hour), now, now, now,
+	)
+	if err := revoked.Use(now); !errors.Is(err, domain.ErrRefreshTokenInvalid) {
+		t.Errorf("Use() error = %v, want %v", err, domain.ErrRefreshTokenInvalid)
+	}
+}
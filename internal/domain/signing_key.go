@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type SigningAlgorithm string
+
+const (
+	SigningAlgorithmRS256 SigningAlgorithm = "RS256"
+	SigningAlgorithmEdDSA SigningAlgorithm = "EdDSA"
+	// SigningAlgorithmHS256 signs with the shared JWT_SECRET_KEY. It is meant
+	// for local development: other services cannot verify its tokens.
+	SigningAlgorithmHS256 SigningAlgorithm = "HS256"
+)
+
+func (a SigningAlgorithm) IsValid() bool {
+	switch a {
+	case SigningAlgorithmRS256, SigningAlgorithmEdDSA, SigningAlgorithmHS256:
+		return true
+	}
+	return false
+}
+
+// SigningKey is a stored asymmetric token signing key. The private key is
+// encrypted by the auth package; the domain only schedules its lifetime.
+type SigningKey struct {
+	id          string
+	algorithm   SigningAlgorithm
+	privateKey  []byte
+	createdAt   time.Time
+	activatesAt time.Time
+	retiresAt   time.Time
+}
+
+// NewSigningKey creates a key that starts signing at activatesAt.
+func NewSigningKey(
+	id string,
+	algorithm SigningAlgorithm,
+	privateKey []byte,
+	now time.Time,
+	activatesAt time.Time,
+) (*SigningKey, error) {
+	if id == "" || len(privateKey) == 0 || !algorithm.IsValid() || algorithm == SigningAlgorithmHS256 {
+		return nil, ErrSigningKeyInvalid
+	}
+	return &SigningKey{
+		id:          id,
+		algorithm:   algorithm,
+		privateKey:  privateKey,
+		createdAt:   now,
+		activatesAt: activatesAt,
+	}, nil
+}
+
+// UnmarshalSigningKey rebuilds a SigningKey from persisted values. A zero
+// retiresAt means the key has not been replaced.
+func UnmarshalSigningKey(
+	id string,
+	algorithm SigningAlgorithm,
+	privateKey []byte,
+	createdAt time.Time,
+	activatesAt time.Time,
+	retiresAt time.Time,
+) *SigningKey {
+	return &SigningKey{
+		id:          id,
+		algorithm:   algorithm,
+		privateKey:  privateKey,
+		createdAt:   createdAt,
+		activatesAt: activatesAt,
+		retiresAt:   retiresAt,
+	}
+}
+
+// ID is the key ID published as "kid".
+func (k *SigningKey) ID() string {
+	return k.id
+}
+
+func (k *SigningKey) Algorithm() SigningAlgorithm {
+	return k.algorithm
+}
+
+func (k *SigningKey) PrivateKey() []byte {
+	return k.privateKey
+}
+
+func (k *SigningKey) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+// ActivatesAt is when the key starts signing. Until then it is only
+// published, so verifiers caching the key set learn it in advance.
+func (k *SigningKey) ActivatesAt() time.Time {
+	return k.activatesAt
+}
+
+// RetiresAt is when the key stops verifying, zero until it is replaced.
+func (k *SigningKey) RetiresAt() time.Time {
+	return k.retiresAt
+}
+
+// SigningKeyRepository defines the interface for signing key persistence.
+type SigningKeyRepository interface {
+	// LockSigningKeys serializes key rotation across instances until the
+	// transaction ends.
+	LockSigningKeys(ctx context.Context) error
+	// ListSigningKeys returns the keys not retired at now, newest first.
+	ListSigningKeys(ctx context.Context, now time.Time) ([]*SigningKey, error)
+	CreateSigningKey(ctx context.Context, key *SigningKey) error
+	// RetireSigningKeys schedules every key but keepID to stop verifying at
+	// retiresAt, unless it is already scheduled.
+	RetireSigningKeys(ctx context.Context, keepID string, retiresAt time.Time) error
+	DeleteRetiredSigningKeys(ctx context.Context, now time.Time) (int64, error)
+}
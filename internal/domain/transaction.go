@@ -5,3 +5,18 @@ import "context"
 type TransactionManager interface {
 	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
+
+type repeatableReadKey struct{}
+
+// WithRepeatableRead asks RunInTx to start its transaction with repeatable
+// read isolation, so every statement in it reads the same snapshot. It has
+// no effect when ctx already carries a transaction.
+func WithRepeatableRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, repeatableReadKey{}, true)
+}
+
+// IsRepeatableRead reports whether ctx asks for repeatable read isolation.
+func IsRepeatableRead(ctx context.Context) bool {
+	repeatable, _ := ctx.Value(repeatableReadKey{}).(bool)
+	return repeatable
+}
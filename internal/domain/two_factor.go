@@ -0,0 +1,283 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// LoginChallengeTTL is how long a user has to enter their second factor
+	// after their password.
+	LoginChallengeTTL = 5 * time.Minute
+	// MaxLoginChallengeAttempts is how many wrong codes a login challenge
+	// takes before it stops working.
+	MaxLoginChallengeAttempts = 5
+	// RecoveryCodeCount is how many recovery codes a user gets at a time.
+	RecoveryCodeCount = 10
+)
+
+// TwoFactor is the TOTP authenticator of a user. It is pending from
+// enrollment until the user confirms it with a first code, and only counts
+// once enabled.
+type TwoFactor struct {
+	userID       uuid.UUID
+	secret       string
+	enabledAt    time.Time
+	lastUsedStep int64
+	createdAt    time.Time
+}
+
+// NewTwoFactor enrolls a pending authenticator with a base32 TOTP secret.
+func NewTwoFactor(userID uuid.UUID, secret string, now time.Time) *TwoFactor {
+	return &TwoFactor{
+		userID:    userID,
+		secret:    secret,
+		createdAt: now,
+	}
+}
+
+// UnmarshalTwoFactor rebuilds a TwoFactor from persisted values. A zero
+// enabledAt means the authenticator is pending.
+func UnmarshalTwoFactor(
+	userID uuid.UUID,
+	secret string,
+	enabledAt time.Time,
+	lastUsedStep int64,
+	createdAt time.Time,
+) *TwoFactor {
+	return &TwoFactor{
+		userID:       userID,
+		secret:       secret,
+		enabledAt:    enabledAt,
+		lastUsedStep: lastUsedStep,
+		createdAt:    createdAt,
+	}
+}
+
+// Enable turns a confirmed authenticator on.
+func (f *TwoFactor) Enable(now time.Time) error {
+	if f.IsEnabled() {
+		return ErrTwoFactorEnabled
+	}
+	f.enabledAt = now
+	return nil
+}
+
+// UseStep records the TOTP period of an accepted code. Codes of the period
+// or earlier ones yield ErrTwoFactorCodeInvalid from then on, so a code
+// overheard cannot be replayed.
+func (f *TwoFactor) UseStep(step int64) error {
+	if step <= f.lastUsedStep {
+		return ErrTwoFactorCodeInvalid
+	}
+	f.lastUsedStep = step
+	return nil
+}
+
+func (f *TwoFactor) UserID() uuid.UUID {
+	return f.userID
+}
+
+func (f *TwoFactor) Secret() string {
+	return f.secret
+}
+
+func (f *TwoFactor) IsEnabled() bool {
+	return !f.enabledAt.IsZero()
+}
+
+// EnabledAt returns when the authenticator was confirmed, or the zero time.
+func (f *TwoFactor) EnabledAt() time.Time {
+	return f.enabledAt
+}
+
+func (f *TwoFactor) LastUsedStep() int64 {
+	return f.lastUsedStep
+}
+
+func (f *TwoFactor) CreatedAt() time.Time {
+	return f.createdAt
+}
+
+// RecoveryCode signs a user in once when they lost their authenticator.
+// Only its hash is stored.
+type RecoveryCode struct {
+	id        uuid.UUID
+	userID    uuid.UUID
+	codeHash  string
+	createdAt time.Time
+}
+
+// NewRecoveryCodes issues a set of recovery codes and returns them with
+// their secret values, formatted as XXXX-XXXX-XXXX-XXXX.
+func NewRecoveryCodes(userID uuid.UUID, now time.Time) ([]*RecoveryCode, []string) {
+	codes := make([]*RecoveryCode, RecoveryCodeCount)
+	secrets := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		text := rand.Text()
+		secrets[i] = text[0:4] + "-" + text[4:8] + "-" + text[8:12] + "-" + text[12:16]
+		codes[i] = &RecoveryCode{
+			id:        uuid.New(),
+			userID:    userID,
+			codeHash:  HashRecoveryCode(secrets[i]),
+			createdAt: now,
+		}
+	}
+	return codes, secrets
+}
+
+// HashRecoveryCode hashes a recovery code the way it is stored, ignoring
+// case, spaces and dashes.
+func HashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+	return HashToken(normalized)
+}
+
+func (c *RecoveryCode) ID() uuid.UUID {
+	return c.id
+}
+
+func (c *RecoveryCode) UserID() uuid.UUID {
+	return c.userID
+}
+
+func (c *RecoveryCode) CodeHash() string {
+	return c.codeHash
+}
+
+func (c *RecoveryCode) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// LoginChallenge stands for a sign-in whose password checked out and that
+// waits for the second factor. Only the hash of its token is stored.
+type LoginChallenge struct {
+	tokenHash string
+	userID    uuid.UUID
+	attempts  int
+	expiresAt time.Time
+	createdAt time.Time
+}
+
+// NewLoginChallenge challenges a user for their second factor and returns
+// the challenge with its secret token.
+func NewLoginChallenge(userID uuid.UUID, now time.Time) (*LoginChallenge, string) {
+	token := rand.Text()
+	return &LoginChallenge{
+		tokenHash: HashToken(token),
+		userID:    userID,
+		expiresAt: now.Add(LoginChallengeTTL),
+		createdAt: now,
+	}, token
+}
+
+// UnmarshalLoginChallenge rebuilds a LoginChallenge from persisted values.
+func UnmarshalLoginChallenge(
+	tokenHash string,
+	userID uuid.UUID,
+	attempts int,
+	expiresAt time.Time,
+	createdAt time.Time,
+) *LoginChallenge {
+	return &LoginChallenge{
+		tokenHash: tokenHash,
+		userID:    userID,
+		attempts:  attempts,
+		expiresAt: expiresAt,
+		createdAt: createdAt,
+	}
+}
+
+// Check yields ErrLoginChallengeInvalid once the challenge expired or took
+// MaxLoginChallengeAttempts wrong codes.
+func (c *LoginChallenge) Check(now time.Time) error {
+	if c.attempts >= MaxLoginChallengeAttempts || !now.Before(c.expiresAt) {
+		return ErrLoginChallengeInvalid
+	}
+	return nil
+}
+
+// Fail counts a wrong code.
+func (c *LoginChallenge) Fail() {
+	c.attempts++
+}
+
+func (c *LoginChallenge) TokenHash() string {
+	return c.tokenHash
+}
+
+func (c *LoginChallenge) UserID() uuid.UUID {
+	return c.userID
+}
+
+func (c *LoginChallenge) Attempts() int {
+	return c.attempts
+}
+
+func (c *LoginChallenge) ExpiresAt() time.Time {
+	return c.expiresAt
+}
+
+func (c *LoginChallenge) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// ValidateTwoFactorRoles checks that two-factor authentication is only
+// required for elevated roles.
+func ValidateTwoFactorRoles(roles []UserRole) error {
+	for _, role := range roles {
+		if role != UserRoleOrganizer && role != UserRoleAdmin {
+			return ErrTwoFactorRoleInvalid
+		}
+	}
+	return nil
+}
+
+// RequiresTwoFactor reports whether a role is among the roles required to
+// use two-factor authentication.
+func RequiresTwoFactor(requiredRoles []UserRole, role UserRole) bool {
+	return slices.Contains(requiredRoles, role)
+}
+
+// TwoFactorRepository defines the interface for persisting authenticators,
+// recovery codes, login challenges and the roles required to use them.
+type TwoFactorRepository interface {
+	// GetTwoFactorForUpdate returns the authenticator of a user and locks
+	// it; it returns ErrTwoFactorNotEnrolled for users without one.
+	GetTwoFactorForUpdate(ctx context.Context, userID uuid.UUID) (*TwoFactor, error)
+	// SaveTwoFactor stores an authenticator, replacing the user's previous one.
+	SaveTwoFactor(ctx context.Context, twoFactor *TwoFactor) error
+	// DeleteTwoFactor removes the authenticator and recovery codes of a user.
+	DeleteTwoFactor(ctx context.Context, userID uuid.UUID) error
+
+	// ReplaceRecoveryCodes stores a new set of recovery codes; earlier codes
+	// stop working.
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codes []*RecoveryCode) error
+	// UseRecoveryCode marks a recovery code used; it returns
+	// ErrTwoFactorCodeInvalid for unknown and used codes.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, code string, now time.Time) error
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+
+	// CreateLoginChallenge stores a challenge and deletes expired ones.
+	CreateLoginChallenge(ctx context.Context, challenge *LoginChallenge) error
+	// GetLoginChallengeForUpdate looks a challenge up by its secret token and
+	// locks it; it returns ErrLoginChallengeInvalid for unknown tokens.
+	GetLoginChallengeForUpdate(ctx context.Context, token string) (*LoginChallenge, error)
+	UpdateLoginChallenge(ctx context.Context, challenge *LoginChallenge) error
+	DeleteLoginChallenge(ctx context.Context, challenge *LoginChallenge) error
+
+	ListTwoFactorRequiredRoles(ctx context.Context) ([]UserRole, error)
+	// SetTwoFactorRequiredRoles replaces the roles required to use two-factor
+	// authentication.
+	SetTwoFactorRequiredRoles(ctx context.Context, roles []UserRole, actorID uuid.UUID, now time.Time) error
+}
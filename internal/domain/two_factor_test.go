@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestTwoFactor_UseStepRefusesReplays(t *testing.T) {
+	twoFactor := domain.NewTwoFactor(uuid.New(), "JBSWY3DPEHPK3PXP", time.Now())
+	if err := twoFactor.UseStep(100); err != nil {
+		t.Fatalf("UseStep() error = %v", err)
+	}
+	for _, step := range []int64{100, 99} {
+		if err := twoFactor.UseStep(step); !errors.Is(err, domain.ErrTwoFactorCodeInvalid) {
+			t.Errorf("UseStep(%d) error = %v, want %v", step, err, domain.ErrTwoFactorCodeInvalid)
+		}
+	}
+	if err := twoFactor.UseStep(101); err != nil {
+		t.Errorf("UseStep(101) error = %v", err)
+	}
+}
+
+func TestRecoveryCodes_HashIgnoresFormatting(t *testing.T) {
+	codes, secrets := domain.NewRecoveryCodes(uuid.New(), time.Now())
+	if len(codes) != domain.RecoveryCodeCount || len(secrets) != domain.RecoveryCodeCount {
+		t.Fatalf("NewRecoveryCodes() returned %d codes, want %d", len(codes), domain.RecoveryCodeCount)
+	}
+	typed := strings.ToLower(strings.ReplaceAll(secrets[0], "-", " "))
+	if domain.HashRecoveryCode(typed) != codes[0].CodeHash() {
+		t.Errorf("HashRecoveryCode(%q) does not match the code %q", typed, secrets[0])
+	}
+}
+
+func TestLoginChallenge_ExpiresAndLimitsAttempts(t *testing.T) {
+	now := time.Now()
+	challenge, token := domain.NewLoginChallenge(uuid.New(), now)
+	if challenge.TokenHash() != domain.HashToken(token) {
+		t.Error("NewLoginChallenge() should store the hash of the token")
+	}
+	if err := challenge.Check(now.Add(domain.LoginChallengeTTL)); !errors.Is(err, domain.ErrLoginChallengeInvalid) {
+		t.Errorf("Check() after expiry error = %v, want %v", err, domain.ErrLoginChallengeInvalid)
+	}
+	for range domain.MaxLoginChallengeAttempts {
+		if err := challenge.Check(now); err != nil {
+			t.Fatalf("Check() error = %v", err)
+		}
+		challenge.Fail()
+	}
+	if err := challenge.Check(now); !errors.Is(err, domain.ErrLoginChallengeInvalid) {
+		t.Errorf("Check() after %d failures error = %v, want %v",
+			domain.MaxLoginChallengeAttempts, err, domain.ErrLoginChallengeInvalid)
+	}
+}
+
+func TestValidateTwoFactorRoles_OnlyElevatedRoles(t *testing.T) {
+	elevated := []domain.UserRole{domain.UserRoleAdmin, domain.UserRoleOrganizer}
+	if err := domain.ValidateTwoFactorRoles(elevated); err != nil {
+		t.Errorf("ValidateTwoFactorRoles() error = %v", err)
+	}
+	err := domain.ValidateTwoFactorRoles([]domain.UserRole{domain.UserRoleUser})
+	if !errors.Is(err, domain.ErrTwoFactorRoleInvalid) {
+		t.Errorf("ValidateTwoFactorRoles(user) error = %v, want %v", err, domain.ErrTwoFactorRoleInvalid)
+	}
+}
@@ -12,6 +12,34 @@ type UserRepository interface {
 	CreateUser(ctx context.Context, user *User) error
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (*User, error)
+	UpdateUser(ctx context.Context, user *User) error
+	ListUsers(ctx context.Context, filter UserFilter) ([]*User, error)
+}
+
+// UserFilter selects a page of users, newest first. Empty fields match every
+// user.
+type UserFilter struct {
+	// Email matches users whose address contains it, ignoring case.
+	Email  string
+	Role   UserRole
+	Limit  int
+	Offset int
+}
+
+const (
+	DefaultUserPageSize = 50
+	MaxUserPageSize     = 100
+)
+
+// WithPageDefaults returns the filter with the default page size when Limit
+// is not set, and with Limit capped at MaxUserPageSize.
+func (f UserFilter) WithPageDefaults() UserFilter {
+	if f.Limit <= 0 {
+		f.Limit = DefaultUserPageSize
+	}
+	f.Limit = min(f.Limit, MaxUserPageSize)
+	f.Offset = max(f.Offset, 0)
+	return f
 }
 
 type UserRole string
@@ -22,13 +50,25 @@ const (
 	UserRoleOrganizer UserRole = "organizer"
 )
 
+// UserStatus tells whether a user has verified their email address, or
+// whether their account was erased.
+type UserStatus string
+
+const (
+	UserStatusUnverified UserStatus = "unverified"
+	UserStatusActive     UserStatus = "active"
+	UserStatusErased     UserStatus = "erased"
+)
+
 type User struct {
 	id           uuid.UUID
 	email        string
 	passwordHash string
 	role         UserRole
+	status       UserStatus
 	createdAt    time.Time
 	updatedAt    time.Time
+	suspendedAt  time.Time
 }
 
 func NewUser(id uuid.UUID, email string, passwordHash string, role UserRole) (*User, error) {
@@ -55,6 +95,7 @@ func NewUser(id uuid.UUID, email string, passwordHash string, role UserRole) (*U
 		email:        email,
 		passwordHash: passwordHash,
 		role:         role,
+		status:       UserStatusUnverified,
 		createdAt:    time.Now(),
 		updatedAt:    time.Now(),
 	}, nil
@@ -92,6 +133,46 @@ func (u *User) UpdateRole(role UserRole) error {
 	return nil
 }
 
+// Verify activates a user once their email address is confirmed.
+func (u *User) Verify() error {
+	if u.status == UserStatusActive {
+		return ErrUserAlreadyVerified
+	}
+	u.status = UserStatusActive
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// Suspend disables the account. Suspending a suspended user keeps the
+// original suspension time.
+func (u *User) Suspend(now time.Time) {
+	if u.IsSuspended() {
+		return
+	}
+	u.suspendedAt = now
+	u.updatedAt = now
+}
+
+// Unsuspend enables a suspended account again.
+func (u *User) Unsuspend(now time.Time) {
+	if !u.IsSuspended() {
+		return
+	}
+	u.suspendedAt = time.Time{}
+	u.updatedAt = now
+}
+
+// Erase anonymizes the account on its owner's request. The row stays, as
+// wallets and organizations refer to it, but it no longer holds the email
+// address or a password and stays suspended for good.
+func (u *User) Erase(now time.Time) {
+	u.email = ErasedEmail(u.id)
+	u.passwordHash = ""
+	u.status = UserStatusErased
+	u.Suspend(now)
+	u.updatedAt = now
+}
+
 func (u *User) ID() uuid.UUID {
 	return u.id
 }
@@ -108,6 +189,30 @@ func (u *User) Role() UserRole {
 	return u.role
 }
 
+func (u *User) Status() UserStatus {
+	return u.status
+}
+
+// IsVerified reports whether the user confirmed their email address.
+func (u *User) IsVerified() bool {
+	return u.status == UserStatusActive
+}
+
+// IsSuspended reports whether an admin disabled the account.
+func (u *User) IsSuspended() bool {
+	return !u.suspendedAt.IsZero()
+}
+
+// IsErased reports whether the account was erased.
+func (u *User) IsErased() bool {
+	return u.status == UserStatusErased
+}
+
+// SuspendedAt returns when the account was suspended, or the zero time.
+func (u *User) SuspendedAt() time.Time {
+	return u.suspendedAt
+}
+
 func (u *User) CreatedAt() time.Time {
 	return u.createdAt
 }
@@ -121,16 +226,20 @@ func NewUserFromPersistence(
 	email string,
 	passwordHash string,
 	role UserRole,
+	status UserStatus,
 	createdAt time.Time,
 	updatedAt time.Time,
+	suspendedAt time.Time,
 ) (*User, error) {
 	return &User{
 		id:           id,
 		email:        email,
 		passwordHash: passwordHash,
 		role:         role,
+		status:       status,
 		createdAt:    createdAt,
 		updatedAt:    updatedAt,
+		suspendedAt:  suspendedAt,
 	}, nil
 }
 
@@ -0,0 +1,270 @@
+package domain
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WalletTransactionKind names why a wallet balance changed.
+type WalletTransactionKind string
+
+const (
+	WalletGiftCardRedeemed WalletTransactionKind = "gift_card_redeemed"
+	WalletBookingPayment   WalletTransactionKind = "booking_payment"
+	WalletBookingRefund    WalletTransactionKind = "booking_refund"
+)
+
+// Wallet is the stored-value balance of a user in one currency.
+type Wallet struct {
+	userID    uuid.UUID
+	balance   Money
+	updatedAt time.Time
+}
+
+// UnmarshalWallet rebuilds a Wallet from persisted values.
+func UnmarshalWallet(userID uuid.UUID, balance Money, updatedAt time.Time) *Wallet {
+	return &Wallet{userID: userID, balance: balance, updatedAt: updatedAt}
+}
+
+// Credit adds a positive amount to the balance and returns the transaction
+// recording it.
+func (w *Wallet) Credit(kind WalletTransactionKind, reference uuid.UUID, amount Money) (*WalletTransaction, error) {
+	if amount.Currency() != w.balance.Currency() {
+		return nil, ErrCurrencyMismatch
+	}
+	if amount.Amount() <= 0 {
+		return nil, ErrWalletAmountInvalid
+	}
+	return w.apply(kind, reference, amount.Amount()), nil
+}
+
+// Debit takes a positive amount from the balance and returns the transaction
+// recording it. The balance never goes negative.
+func (w *Wallet) Debit(kind WalletTransactionKind, reference uuid.UUID, amount Money) (*WalletTransaction, error) {
+	if amount.Currency() != w.balance.Currency() {
+		return nil, ErrCurrencyMismatch
+	}
+	if amount.IsNegative() || amount.Amount() > w.balance.Amount() {
+		return nil, ErrWalletInsufficientFunds
+	}
+	return w.apply(kind, reference, -amount.Amount()), nil
+}
+
+func (w *Wallet) apply(kind WalletTransactionKind, reference uuid.UUID, amount int64) *WalletTransaction {
+	w.balance = w.balance.WithAmount(w.balance.Amount() + amount)
+	w.updatedAt = time.Now()
+	return &WalletTransaction{
+		id:           uuid.New(),
+		userID:       w.userID,
+		kind:         kind,
+		reference:    reference,
+		amount:       w.balance.WithAmount(amount),
+		balanceAfter: w.balance,
+		createdAt:    w.updatedAt,
+	}
+}
+
+func (w *Wallet) UserID() uuid.UUID {
+	return w.userID
+}
+
+func (w *Wallet) Balance() Money {
+	return w.balance
+}
+
+func (w *Wallet) UpdatedAt() time.Time {
+	return w.updatedAt
+}
+
+// WalletTransaction records one change of a wallet balance. Credits are
+// positive, debits negative.
+type WalletTransaction struct {
+	id           uuid.UUID
+	userID       uuid.UUID
+	kind         WalletTransactionKind
+	reference    uuid.UUID
+	amount       Money
+	balanceAfter Money
+	createdAt    time.Time
+}
+
+// UnmarshalWalletTransaction rebuilds a WalletTransaction from persisted values.
+func UnmarshalWalletTransaction(
+	id uuid.UUID,
+	userID uuid.UUID,
+	kind WalletTransactionKind,
+	reference uuid.UUID,
+	amount Money,
+	balanceAfter Money,
+	createdAt time.Time,
+) *WalletTransaction {
+	return &WalletTransaction{
+		id:           id,
+		userID:       userID,
+		kind:         kind,
+		reference:    reference,
+		amount:       amount,
+		balanceAfter: balanceAfter,
+		createdAt:    createdAt,
+	}
+}
+
+func (t *WalletTransaction) ID() uuid.UUID {
+	return t.id
+}
+
+func (t *WalletTransaction) UserID() uuid.UUID {
+	return t.userID
+}
+
+func (t *WalletTransaction) Kind() WalletTransactionKind {
+	return t.kind
+}
+
+// Reference returns the gift card or booking that moved the balance.
+func (t *WalletTransaction) Reference() uuid.UUID {
+	return t.reference
+}
+
+func (t *WalletTransaction) Amount() Money {
+	return t.amount
+}
+
+func (t *WalletTransaction) BalanceAfter() Money {
+	return t.balanceAfter
+}
+
+func (t *WalletTransaction) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// GiftCard is a code worth a fixed amount that a user redeems into their
+// wallet once. Only a hash of the code is kept.
+type GiftCard struct {
+	id         uuid.UUID
+	codeHash   string
+	amount     Money
+	issuedBy   uuid.UUID
+	createdAt  time.Time
+	redeemedBy uuid.UUID
+	redeemedAt time.Time
+}
+
+// NewGiftCard issues a gift card and returns it with its code, which cannot
+// be recovered later.
+func NewGiftCard(amount Money, issuedBy uuid.UUID) (*GiftCard, string, error) {
+	if amount.Amount() <= 0 {
+		return nil, "", ErrWalletAmountInvalid
+	}
+	if _, err := ParseCurrency(string(amount.Currency())); err != nil {
+		return nil, "", err
+	}
+
+	// 16 base32 characters carry 80 bits of entropy.
+	raw := rand.Text()[:16]
+	code := strings.Join([]string{raw[0:4], raw[4:8], raw[8:12], raw[12:16]}, "-")
+	return &GiftCard{
+		id:        uuid.New(),
+		codeHash:  HashGiftCardCode(code),
+		amount:    amount,
+		issuedBy:  issuedBy,
+		createdAt: time.Now(),
+	}, code, nil
+}
+
+// UnmarshalGiftCard rebuilds a GiftCard from persisted values. redeemedBy is
+// uuid.Nil while the card is unredeemed.
+func UnmarshalGiftCard(
+	id uuid.UUID,
+	codeHash string,
+	amount Money,
+	issuedBy uuid.UUID,
+	createdAt time.Time,
+	redeemedBy uuid.UUID,
+	redeemedAt time.Time,
+) *GiftCard {
+	return &GiftCard{
+		id:         id,
+		codeHash:   codeHash,
+		amount:     amount,
+		issuedBy:   issuedBy,
+		createdAt:  createdAt,
+		redeemedBy: redeemedBy,
+		redeemedAt: redeemedAt,
+	}
+}
+
+// HashGiftCardCode returns the stored form of a code. Case, spaces and dashes
+// are ignored so codes can be typed loosely.
+func HashGiftCardCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// Redeem marks the card as redeemed by a user.
+func (g *GiftCard) Redeem(userID uuid.UUID, now time.Time) error {
+	if g.redeemedBy != uuid.Nil {
+		return ErrGiftCardRedeemed
+	}
+	g.redeemedBy = userID
+	g.redeemedAt = now
+	return nil
+}
+
+func (g *GiftCard) ID() uuid.UUID {
+	return g.id
+}
+
+func (g *GiftCard) CodeHash() string {
+	return g.codeHash
+}
+
+func (g *GiftCard) Amount() Money {
+	return g.amount
+}
+
+func (g *GiftCard) IssuedBy() uuid.UUID {
+	return g.issuedBy
+}
+
+func (g *GiftCard) CreatedAt() time.Time {
+	return g.createdAt
+}
+
+func (g *GiftCard) RedeemedBy() uuid.UUID {
+	return g.redeemedBy
+}
+
+func (g *GiftCard) RedeemedAt() time.Time {
+	return g.redeemedAt
+}
+
+// WalletRepository defines the interface for wallet and gift card persistence.
+type WalletRepository interface {
+	// GetWalletForUpdate returns the wallet of a user in a currency, opening an
+	// empty one on first use, and locks it until the transaction ends so
+	// concurrent debits and credits of one wallet are serialized.
+	GetWalletForUpdate(ctx context.Context, userID uuid.UUID, currency Currency) (*Wallet, error)
+	ListWallets(ctx context.Context, userID uuid.UUID) ([]*Wallet, error)
+	// SaveTransaction stores the new balance of a locked wallet with the
+	// transaction that changed it.
+	SaveTransaction(ctx context.Context, wallet *Wallet, transaction *WalletTransaction) error
+	ListTransactions(ctx context.Context, userID uuid.UUID, limit int) ([]*WalletTransaction, error)
+	CreateGiftCard(ctx context.Context, card *GiftCard) error
+	// GetGiftCardForUpdate looks a card up by its code and locks it; it
+	// returns ErrGiftCardNotFound for unknown codes.
+	GetGiftCardForUpdate(ctx context.Context, code string) (*GiftCard, error)
+	RedeemGiftCard(ctx context.Context, card *GiftCard) error
+}
@@ -0,0 +1,97 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+func TestWallet_DebitNeverGoesNegative(t *testing.T) {
+	wallet := domain.UnmarshalWallet(uuid.New(), domain.UnmarshalMoney(1000, "EUR"), time.Now())
+
+	_, err := wallet.Debit(domain.WalletBookingPayment, uuid.New(), domain.UnmarshalMoney(1001, "EUR"))
+	if !errors.Is(err, domain.ErrWalletInsufficientFunds) {
+		t.Errorf("Debit() error = %v, want %v", err, domain.ErrWalletInsufficientFunds)
+	}
+	_, err = wallet.Debit(domain.WalletBookingPayment, uuid.New(), domain.UnmarshalMoney(1, "USD"))
+	if !errors.Is(err, domain.ErrCurrencyMismatch) {
+		t.Errorf("Debit() error = %v, want %v", err, domain.ErrCurrencyMismatch)
+	}
+
+	transaction, err := wallet.Debit(domain.WalletBookingPayment, uuid.New(), domain.UnmarshalMoney(400, "EUR"))
+	if err != nil {
+		t.Fatalf("Debit() error = %v", err)
+	}
+	if transaction.Amount().Amount() != -400 || transaction.BalanceAfter().Amount() != 600 {
+		t.Errorf("Debit() = %s, balance %s, want -400 and 600", transaction.Amount(), transaction.BalanceAfter())
+	}
+	if wallet.Balance().Amount() != 600 {
+		t.Errorf("Balance() = %s, want 6.00 EUR", wallet.Balance())
+	}
+}
+
+func TestGiftCard_RedeemOnce(t *testing.T) {
+	card, code, err := domain.NewGiftCard(domain.UnmarshalMoney(5000, "EUR"), uuid.New())
+	if err != nil {
+		t.Fatalf("NewGiftCard() error = %v", err)
+	}
+	if len(code) != 19 {
+		t.Errorf("code = %q, want four groups of four characters", code)
+	}
+	if domain.HashGiftCardCode(" "+code[:4]+code[5:]+" ") != card.CodeHash() {
+		t.Error("HashGiftCardCode() should ignore spaces and dashes")
+	}
+
+	if err := card.Redeem(uuid.New(), time.Now()); err != nil {
+		t.Fatalf("Redeem() error = %v", err)
+	}
+	if err := card.Redeem(uuid.New(), time.Now()); !errors.Is(err, domain.ErrGiftCardRedeemed) {
+		t.Errorf("Redeem() twice error = %v, want %v", err, domain.ErrGiftCardRedeemed)
+	}
+
+	_, _, err = domain.NewGiftCard(domain.UnmarshalMoney(0, "EUR"), uuid.New())
+	if !errors.Is(err, domain.ErrWalletAmountInvalid) {
+		t.Errorf("NewGiftCard(0) error = %v, want %v", err, domain.ErrWalletAmountInvalid)
+	}
+}
+
+func TestBookingJournalEntry_WalletCredit(t *testing.T) {
+	booking, err := domain.NewBooking(uuid.New(), uuid.New(), "buyer@example.com", domain.BookingStatusConfirmed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	breakdown := domain.PriceOrder(domain.UnmarshalMoney(10000, "EUR"), domain.FeeSchedule{}, 0)
+	if err := booking.LockPrice(breakdown); err != nil {
+		t.Fatal(err)
+	}
+	err = booking.ApplyCredit(domain.UnmarshalMoney(10001, "EUR"))
+	if !errors.Is(err, domain.ErrWalletInsufficientFunds) {
+		t.Errorf("ApplyCredit() above total error = %v, want %v", err, domain.ErrWalletInsufficientFunds)
+	}
+	if err := booking.ApplyCredit(domain.UnmarshalMoney(2500, "EUR")); err != nil {
+		t.Fatalf("ApplyCredit() error = %v", err)
+	}
+	if booking.Total().Amount() != 7500 || booking.CreditApplied().Amount() != 2500 {
+		t.Errorf("Total() = %s, CreditApplied() = %s, want 75.00 and 25.00", booking.Total(), booking.CreditApplied())
+	}
+
+	entry, err := domain.BookingJournalEntry(booking, uuid.New())
+	if err != nil {
+		t.Fatalf("BookingJournalEntry() error = %v", err)
+	}
+	var cash, credit int64
+	for _, line := range entry.Lines() {
+		switch line.Account.Kind {
+		case domain.LedgerAccountCash:
+			cash += line.Amount
+		case domain.LedgerAccountCustomerCredit:
+			credit += line.Amount
+		}
+	}
+	if cash != 7500 || credit != 2500 {
+		t.Errorf("cash = %d, customer credit = %d, want 7500 and 2500", cash, credit)
+	}
+}
@@ -0,0 +1,41 @@
+package event_handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// AccountEventHandler forwards account emails written to the outbox, such as
+// email verification and password reset links, to the email queue.
+type AccountEventHandler struct {
+	logger    *slog.Logger
+	publisher domain.AccountNotificationPublisher
+}
+
+func NewAccountEventHandler(logger *slog.Logger, publisher domain.AccountNotificationPublisher) *AccountEventHandler {
+	return &AccountEventHandler{
+		logger:    logger,
+		publisher: publisher,
+	}
+}
+
+func (eh *AccountEventHandler) Handle(ctx context.Context, payload []byte) error {
+	var notification domain.AccountNotification
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return fmt.Errorf("failed unmarshal account event: %w", err)
+	}
+
+	if err := eh.publisher.PublishAccountNotification(ctx, &notification); err != nil {
+		return fmt.Errorf("failed publish account notification: %w", err)
+	}
+	eh.logger.Info("account event received",
+		"notification_id", notification.ID,
+		"kind", notification.Kind,
+		"user_email", notification.UserEmail,
+	)
+	return nil
+}
@@ -0,0 +1,47 @@
+package event_handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAccountPublisher struct {
+	published []*domain.AccountNotification
+}
+
+func (f *fakeAccountPublisher) PublishAccountNotification(_ context.Context, n *domain.AccountNotification) error {
+	f.published = append(f.published, n)
+	return nil
+}
+
+func TestAccountEventHandler_ForwardsNotification(t *testing.T) {
+	publisher := &fakeAccountPublisher{}
+	handler := NewAccountEventHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), publisher)
+
+	notification := domain.AccountNotification{
+		ID:        uuid.New(),
+		Kind:      domain.AccountNotificationEmailVerification,
+		UserEmail: "new@example.com",
+		Link:      "https://tickets.example.com/auth/verify?token=secret",
+		ExpiresAt: time.Now().Add(domain.EmailVerificationTokenTTL).UTC(),
+		CreatedAt: time.Now().UTC(),
+	}
+	data, err := json.Marshal(notification)
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(context.Background(), data))
+
+	require.Len(t, publisher.published, 1)
+	assert.Equal(t, notification.ID, publisher.published[0].ID)
+	assert.Equal(t, notification.Kind, publisher.published[0].Kind)
+	assert.Equal(t, notification.Link, publisher.published[0].Link)
+	assert.Error(t, handler.Handle(context.Background(), []byte("not json")))
+}
@@ -9,17 +9,22 @@ import (
 	"github.com/mati/go-ticket/internal/domain"
 )
 
+// BookingEventHandler queues an email about every booking event, unless the
+// notification preferences of the customer turn it down.
 type BookingEventHandler struct {
 	logger                *slog.Logger
 	notificationPublisher domain.NotificationPublisher
+	preferences           domain.NotificationPreferenceChecker
 }
 
 func NewBookingEventHandler(
 	logger *slog.Logger,
-	notificationPublisher domain.NotificationPublisher) *BookingEventHandler {
+	notificationPublisher domain.NotificationPublisher,
+	preferences domain.NotificationPreferenceChecker) *BookingEventHandler {
 	return &BookingEventHandler{
 		logger:                logger,
 		notificationPublisher: notificationPublisher,
+		preferences:           preferences,
 	}
 }
 
@@ -30,6 +35,20 @@ func (eh *BookingEventHandler) Handle(ctx context.Context, payload []byte) error
 		return fmt.Errorf("failed unmarshal booking event: %w", err)
 	}
 
+	allowed, err := eh.preferences.AllowsNotification(
+		ctx,
+		booking.UserEmail,
+		domain.NotificationChannelEmail,
+		domain.NotificationBooking,
+	)
+	if err != nil {
+		return fmt.Errorf("failed check notification preferences: %w", err)
+	}
+	if !allowed {
+		eh.logger.Info("booking event skipped due to notification preferences", "booking_id", booking.ID)
+		return nil
+	}
+
 	bookingNotification := &domain.BookingNotification{
 		ID:        booking.ID,
 		EventID:   booking.EventID,
@@ -0,0 +1,58 @@
+package event_handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeBookingPublisher struct {
+	published []*domain.BookingNotification
+}
+
+func (f *fakeBookingPublisher) Publish(_ context.Context, n *domain.BookingNotification) error {
+	f.published = append(f.published, n)
+	return nil
+}
+
+type fakePreferenceChecker struct {
+	optedOut map[string]bool
+}
+
+func (f *fakePreferenceChecker) AllowsNotification(
+	_ context.Context,
+	email string,
+	_ domain.NotificationChannel,
+	_ domain.NotificationCategory,
+) (bool, error) {
+	return !f.optedOut[email], nil
+}
+
+func TestBookingEventHandler_HonorsPreferences(t *testing.T) {
+	publisher := &fakeBookingPublisher{}
+	preferences := &fakePreferenceChecker{optedOut: map[string]bool{"quiet@example.com": true}}
+	handler := NewBookingEventHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), publisher, preferences)
+
+	for _, email := range []string{"quiet@example.com", "fan@example.com"} {
+		data, err := json.Marshal(domain.BookingEventPayload{
+			ID:        uuid.New(),
+			EventID:   uuid.New(),
+			UserEmail: email,
+			CreatedAt: time.Now().UTC(),
+			Status:    string(domain.BookingStatusConfirmed),
+		})
+		require.NoError(t, err)
+		require.NoError(t, handler.Handle(context.Background(), data))
+	}
+
+	require.Len(t, publisher.published, 1)
+	assert.Equal(t, "fan@example.com", publisher.published[0].UserEmail)
+}
@@ -0,0 +1,50 @@
+package event_handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// ReceiptIssuer produces the receipt of a confirmed booking.
+type ReceiptIssuer interface {
+	IssueReceipt(ctx context.Context, bookingID uuid.UUID) (*domain.Receipt, error)
+}
+
+// ReceiptEventHandler issues receipts for bookings published with the
+// confirmed status and ignores every other booking event.
+type ReceiptEventHandler struct {
+	logger   *slog.Logger
+	receipts ReceiptIssuer
+}
+
+func NewReceiptEventHandler(logger *slog.Logger, receipts ReceiptIssuer) *ReceiptEventHandler {
+	return &ReceiptEventHandler{
+		logger:   logger,
+		receipts: receipts,
+	}
+}
+
+func (eh *ReceiptEventHandler) Handle(ctx context.Context, payload []byte) error {
+	var booking domain.BookingEventPayload
+	if err := json.Unmarshal(payload, &booking); err != nil {
+		return fmt.Errorf("failed unmarshal booking event: %w", err)
+	}
+	if booking.Status != string(domain.BookingStatusConfirmed) {
+		return nil
+	}
+
+	receipt, err := eh.receipts.IssueReceipt(ctx, booking.ID)
+	if err != nil {
+		return fmt.Errorf("failed issue receipt: %w", err)
+	}
+	eh.logger.Info("receipt issued",
+		"booking_id", booking.ID,
+		"invoice_number", receipt.InvoiceNumber(),
+	)
+	return nil
+}
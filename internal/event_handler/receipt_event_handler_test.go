@@ -0,0 +1,41 @@
+package event_handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReceiptIssuer struct {
+	issued []uuid.UUID
+}
+
+func (f *fakeReceiptIssuer) IssueReceipt(_ context.Context, bookingID uuid.UUID) (*domain.Receipt, error) {
+	f.issued = append(f.issued, bookingID)
+	return domain.NewReceipt(bookingID, uuid.Nil, int64(len(f.issued)), time.Now()), nil
+}
+
+func TestReceiptEventHandler_IssuesOnlyForConfirmedBookings(t *testing.T) {
+	issuer := &fakeReceiptIssuer{}
+	handler := NewReceiptEventHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), issuer)
+
+	pending := domain.BookingEventPayload{ID: uuid.New(), Status: string(domain.BookingStatusPending)}
+	confirmed := domain.BookingEventPayload{ID: uuid.New(), Status: string(domain.BookingStatusConfirmed)}
+
+	for _, payload := range []domain.BookingEventPayload{pending, confirmed} {
+		data, err := json.Marshal(payload)
+		require.NoError(t, err)
+		require.NoError(t, handler.Handle(context.Background(), data))
+	}
+
+	assert.Equal(t, []uuid.UUID{confirmed.ID}, issuer.issued)
+	assert.Error(t, handler.Handle(context.Background(), []byte("not json")))
+}
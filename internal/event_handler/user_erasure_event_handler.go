@@ -0,0 +1,45 @@
+package event_handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// RecipientPurger forgets what was sent to an email recipient, identified by
+// their domain.RecipientHash.
+type RecipientPurger interface {
+	PurgeRecipient(ctx context.Context, recipientHash string) error
+}
+
+// UserErasureEventHandler purges the data kept outside the database about
+// users who erased their account, such as the email idempotency keys.
+type UserErasureEventHandler struct {
+	logger  *slog.Logger
+	purgers []RecipientPurger
+}
+
+func NewUserErasureEventHandler(logger *slog.Logger, purgers ...RecipientPurger) *UserErasureEventHandler {
+	return &UserErasureEventHandler{
+		logger:  logger,
+		purgers: purgers,
+	}
+}
+
+func (eh *UserErasureEventHandler) Handle(ctx context.Context, payload []byte) error {
+	var erasure domain.UserErasure
+	if err := json.Unmarshal(payload, &erasure); err != nil {
+		return fmt.Errorf("failed unmarshal user erasure event: %w", err)
+	}
+
+	for _, purger := range eh.purgers {
+		if err := purger.PurgeRecipient(ctx, erasure.RecipientHash); err != nil {
+			return fmt.Errorf("failed purge erased recipient: %w", err)
+		}
+	}
+	eh.logger.Info("user erasure event received", "user_id", erasure.UserID)
+	return nil
+}
@@ -0,0 +1,41 @@
+package event_handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRecipientPurger struct {
+	purged []string
+}
+
+func (f *fakeRecipientPurger) PurgeRecipient(_ context.Context, recipientHash string) error {
+	f.purged = append(f.purged, recipientHash)
+	return nil
+}
+
+func TestUserErasureEventHandler_PurgesRecipient(t *testing.T) {
+	purger := &fakeRecipientPurger{}
+	handler := NewUserErasureEventHandler(slog.New(slog.NewTextHandler(io.Discard, nil)), purger)
+
+	erasure := domain.UserErasure{
+		UserID:        uuid.New(),
+		RecipientHash: domain.RecipientHash("Gone@Example.com"),
+		ErasedAt:      time.Now().UTC(),
+	}
+	data, err := json.Marshal(erasure)
+	require.NoError(t, err)
+	require.NoError(t, handler.Handle(context.Background(), data))
+
+	assert.Equal(t, []string{domain.RecipientHash("gone@example.com")}, purger.purged)
+	assert.Error(t, handler.Handle(context.Background(), []byte("not json")))
+}
@@ -0,0 +1,157 @@
+// Package ical renders RFC 5545 iCalendar documents.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Status is the STATUS property of a VEVENT.
+type Status string
+
+const (
+	StatusConfirmed Status = "CONFIRMED"
+	StatusTentative Status = "TENTATIVE"
+	StatusCancelled Status = "CANCELLED"
+)
+
+const (
+	// ContentType is the media type of an iCalendar document.
+	ContentType = "text/calendar; charset=utf-8"
+
+	dateTimeLayout      = "20060102T150405Z"
+	localDateTimeLayout = "20060102T150405"
+	maxLineOctets       = 75
+)
+
+// Event is a single VEVENT component.
+type Event struct {
+	UID          string
+	Summary      string
+	Description  string
+	Start        time.Time
+	End          time.Time
+	Stamp        time.Time
+	LastModified time.Time
+	Sequence     int
+	Status       Status
+	// Location, when set, has Start and End written as local times of that
+	// time zone, which the calendar describes in a VTIMEZONE. They are
+	// written in UTC otherwise.
+	Location *time.Location
+}
+
+// Calendar is a VCALENDAR object holding a list of events.
+type Calendar struct {
+	ProdID string
+	Name   string
+	Method string
+	Events []Event
+}
+
+// Encode writes the calendar to w using CRLF line endings and
+// folding lines longer than 75 octets.
+func (c *Calendar) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	e := &encoder{w: bw}
+
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", c.ProdID)
+	e.line("CALSCALE", "GREGORIAN")
+	if c.Method != "" {
+		e.line("METHOD", c.Method)
+	}
+	if c.Name != "" {
+		e.line("X-WR-CALNAME", escapeText(c.Name))
+	}
+	for _, zone := range c.timeZones() {
+		e.timeZone(zone)
+	}
+	for _, event := range c.Events {
+		e.event(event)
+	}
+	e.line("END", "VCALENDAR")
+
+	if e.err != nil {
+		return e.err
+	}
+	return bw.Flush()
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *encoder) event(event Event) {
+	e.line("BEGIN", "VEVENT")
+	e.line("UID", event.UID)
+	e.line("DTSTAMP", formatTime(event.Stamp))
+	if event.Location != nil {
+		tzid := ";TZID=" + event.Location.String()
+		e.line("DTSTART"+tzid, event.Start.In(event.Location).Format(localDateTimeLayout))
+		e.line("DTEND"+tzid, event.End.In(event.Location).Format(localDateTimeLayout))
+	} else {
+		e.line("DTSTART", formatTime(event.Start))
+		e.line("DTEND", formatTime(event.End))
+	}
+	if !event.LastModified.IsZero() {
+		e.line("LAST-MODIFIED", formatTime(event.LastModified))
+	}
+	e.line("SEQUENCE", fmt.Sprintf("%d", event.Sequence))
+	e.line("SUMMARY", escapeText(event.Summary))
+	if event.Description != "" {
+		e.line("DESCRIPTION", escapeText(event.Description))
+	}
+	if event.Status != "" {
+		e.line("STATUS", string(event.Status))
+	}
+	e.line("END", "VEVENT")
+}
+
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+	_, e.err = e.w.WriteString(fold(name + ":" + value))
+}
+
+// fold splits a content line into chunks of at most 75 octets, continuing
+// each chunk with CRLF followed by a single space. It never splits a
+// multi-byte UTF-8 sequence.
+func fold(line string) string {
+	var b strings.Builder
+	limit := maxLineOctets
+	count := 0
+	for _, r := range line {
+		size := len(string(r))
+		if count+size > limit {
+			b.WriteString("\r\n ")
+			count = 0
+			limit = maxLineOctets - 1
+		}
+		b.WriteRune(r)
+		count += size
+	}
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+func escapeText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
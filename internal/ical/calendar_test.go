@@ -0,0 +1,92 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendar_Encode(t *testing.T) {
+	start := time.Date(2026, 12, 1, 20, 0, 0, 0, time.FixedZone("CET", 3600))
+	calendar := &Calendar{
+		ProdID: "-//go-ticket//EN",
+		Name:   "My bookings",
+		Events: []Event{
+			{
+				UID:      "booking-1@go-ticket",
+				Summary:  "Metallica; Live, Warsaw",
+				Start:    start,
+				End:      start.Add(3 * time.Hour),
+				Stamp:    start,
+				Sequence: 2,
+				Status:   StatusCancelled,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	err := calendar.Encode(&buf)
+	assert.NoError(t, err)
+
+	out := buf.String()
+	assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+	assert.Contains(t, out, "DTSTART:20261201T190000Z\r\n")
+	assert.Contains(t, out, "DTEND:20261201T220000Z\r\n")
+	assert.Contains(t, out, "SUMMARY:Metallica\\; Live\\, Warsaw\r\n")
+	assert.Contains(t, out, "SEQUENCE:2\r\n")
+	assert.Contains(t, out, "STATUS:CANCELLED\r\n")
+}
+
+func TestFold(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("ą", 60)
+
+	folded := fold(line)
+
+	for _, part := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(part), maxLineOctets)
+	}
+	unfolded := strings.ReplaceAll(strings.TrimSuffix(folded, "\r\n"), "\r\n ", "")
+	assert.Equal(t, line, unfolded)
+}
+
+func TestCalendar_EncodeTimeZone(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	assert.NoError(t, err)
+	winter := time.Date(2026, 12, 1, 20, 0, 0, 0, warsaw)
+	spring := time.Date(2027, 4, 10, 19, 0, 0, 0, warsaw)
+	calendar := &Calendar{
+		ProdID: "-//go-ticket//EN",
+		Events: []Event{
+			{UID: "event-1@go-ticket", Start: winter, End: winter.Add(3 * time.Hour), Location: warsaw},
+			{UID: "event-2@go-ticket", Start: spring, End: spring.Add(3 * time.Hour), Location: warsaw},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, calendar.Encode(&buf))
+
+	out := buf.String()
+	assert.Equal(t, 1, strings.Count(out, "BEGIN:VTIMEZONE\r\n"))
+	assert.Contains(t, out, "TZID:Europe/Warsaw\r\n")
+	assert.Contains(t, out, "DTSTART;TZID=Europe/Warsaw:20261201T200000\r\n")
+	assert.Contains(t, out, "DTEND;TZID=Europe/Warsaw:20270410T220000\r\n")
+	// Summer time starts on the last Sunday of March at 02:00 local time.
+	assert.Contains(t, out, "BEGIN:DAYLIGHT\r\n"+
+		"DTSTART:20270328T020000\r\n"+
+		"TZOFFSETFROM:+0100\r\n"+
+		"TZOFFSETTO:+0200\r\n"+
+		"TZNAME:CEST\r\n"+
+		"END:DAYLIGHT\r\n")
+	assert.Less(t, strings.Index(out, "END:VTIMEZONE"), strings.Index(out, "BEGIN:VEVENT"))
+}
+
+func TestFormatOffset(t *testing.T) {
+	assert.Equal(t, "+0530", formatOffset(5*3600+30*60))
+	assert.Equal(t, "-0800", formatOffset(-8*3600))
+	// Local mean time of Amsterdam.
+	assert.Equal(t, "+001932", formatOffset(19*60+32))
+}
@@ -0,0 +1,132 @@
+package ical
+
+import (
+	"fmt"
+	"time"
+)
+
+// zoneSearchStep is how far apart offsets are sampled when looking for the
+// transitions of a time zone. Zones never change their offset twice a day.
+const zoneSearchStep = 24 * time.Hour
+
+// timeZone is a location used by the events of a calendar, with the span of
+// time its VTIMEZONE has to cover.
+type timeZone struct {
+	location *time.Location
+	from, to time.Time
+}
+
+// observance is a STANDARD or DAYLIGHT component of a VTIMEZONE: the offset
+// in use from start on.
+type observance struct {
+	start      time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+	daylight   bool
+}
+
+// timeZones returns the locations of the events, in order of first use.
+func (c *Calendar) timeZones() []timeZone {
+	var zones []timeZone
+	index := make(map[string]int)
+	for _, event := range c.Events {
+		if event.Location == nil {
+			continue
+		}
+		name := event.Location.String()
+		i, ok := index[name]
+		if !ok {
+			index[name] = len(zones)
+			zones = append(zones, timeZone{location: event.Location, from: event.Start, to: event.End})
+			continue
+		}
+		if event.Start.Before(zones[i].from) {
+			zones[i].from = event.Start
+		}
+		if event.End.After(zones[i].to) {
+			zones[i].to = event.End
+		}
+	}
+	return zones
+}
+
+// observances returns the offset in use at the start of the span, followed
+// by every transition up to its end.
+func (z timeZone) observances() []observance {
+	from := z.from.Unix()
+	to := z.to.Unix()
+	name, offset := time.Unix(from, 0).In(z.location).Zone()
+	observances := []observance{{
+		start:      time.Unix(from, 0),
+		offsetFrom: offset,
+		offsetTo:   offset,
+		name:       name,
+		daylight:   time.Unix(from, 0).In(z.location).IsDST(),
+	}}
+
+	step := int64(zoneSearchStep / time.Second)
+	for at := from; at < to; at += step {
+		next := min(at+step, to)
+		if _, nextOffset := time.Unix(next, 0).In(z.location).Zone(); nextOffset == offset {
+			continue
+		}
+		// Bisect down to the first second of the new offset.
+		low, high := at, next
+		for high-low > 1 {
+			middle := low + (high-low)/2
+			if _, middleOffset := time.Unix(middle, 0).In(z.location).Zone(); middleOffset == offset {
+				low = middle
+			} else {
+				high = middle
+			}
+		}
+		transition := time.Unix(high, 0).In(z.location)
+		name, nextOffset := transition.Zone()
+		observances = append(observances, observance{
+			start:      transition,
+			offsetFrom: offset,
+			offsetTo:   nextOffset,
+			name:       name,
+			daylight:   transition.IsDST(),
+		})
+		offset = nextOffset
+	}
+	return observances
+}
+
+func (e *encoder) timeZone(zone timeZone) {
+	e.line("BEGIN", "VTIMEZONE")
+	e.line("TZID", zone.location.String())
+	for _, observance := range zone.observances() {
+		component := "STANDARD"
+		if observance.daylight {
+			component = "DAYLIGHT"
+		}
+		e.line("BEGIN", component)
+		// DTSTART is the local time the offset starts at, read on the clock
+		// of the offset before it.
+		start := observance.start.UTC().Add(time.Duration(observance.offsetFrom) * time.Second)
+		e.line("DTSTART", start.Format(localDateTimeLayout))
+		e.line("TZOFFSETFROM", formatOffset(observance.offsetFrom))
+		e.line("TZOFFSETTO", formatOffset(observance.offsetTo))
+		e.line("TZNAME", escapeText(observance.name))
+		e.line("END", component)
+	}
+	e.line("END", "VTIMEZONE")
+}
+
+// formatOffset writes a UTC offset in seconds as +HHMM, or +HHMMSS when it
+// is not a whole number of minutes.
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	hours, minutes, rest := seconds/3600, seconds/60%60, seconds%60
+	if rest != 0 {
+		return fmt.Sprintf("%c%02d%02d%02d", sign, hours, minutes, rest)
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, hours, minutes)
+}
@@ -2,36 +2,58 @@ package kafka
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"strconv"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/mati/go-ticket/internal/domain"
 )
 
+const (
+	// maxHandleAttempts is how often a message is handed to the event
+	// handler before it is moved to the dead-letter topic.
+	maxHandleAttempts   = 5
+	initialRetryBackoff = 500 * time.Millisecond
+)
+
+// DeadLetterTopic is the topic that receives the messages of topic that kept
+// failing. They carry the original partition, offset and the last error as
+// headers.
+func DeadLetterTopic(topic string) string {
+	return topic + ".dead-letter"
+}
+
 type KafkaConsumer struct {
 	consumerGroup sarama.ConsumerGroup
 	logger        *slog.Logger
 	topics        []string
 	msgHandler    domain.EventHandler
+	deadLetters   sarama.SyncProducer
 }
 
 func NewKafkaConsumer(
 	consumer sarama.ConsumerGroup,
 	topics []string,
 	logger *slog.Logger,
-	event domain.EventHandler) *KafkaConsumer {
+	event domain.EventHandler,
+	deadLetters sarama.SyncProducer) *KafkaConsumer {
 	return &KafkaConsumer{
 		consumerGroup: consumer,
 		topics:        topics,
 		logger:        logger,
 		msgHandler:    event,
+		deadLetters:   deadLetters,
 	}
 }
 
 func (k *KafkaConsumer) Consume(ctx context.Context) error {
 	handler := &consumerHandler{
-		logger: k.logger,
-		event:  k.msgHandler,
+		logger:       k.logger,
+		event:        k.msgHandler,
+		deadLetters:  k.deadLetters,
+		retryBackoff: initialRetryBackoff,
 	}
 
 	err := k.consumerGroup.Consume(ctx, k.topics, handler)
@@ -43,8 +65,10 @@ func (k *KafkaConsumer) Consume(ctx context.Context) error {
 }
 
 type consumerHandler struct {
-	logger *slog.Logger
-	event  domain.EventHandler
+	logger       *slog.Logger
+	event        domain.EventHandler
+	deadLetters  sarama.SyncProducer
+	retryBackoff time.Duration
 }
 
 func (h *consumerHandler) Setup(session sarama.ConsumerGroupSession) error {
@@ -67,13 +91,65 @@ func (h *consumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession, clai
 				return nil
 			}
 
-			err := h.event.Handle(session.Context(), message.Value)
-			session.MarkMessage(message, "")
-
+			err := h.handle(session.Context(), message)
 			if err != nil {
-				h.logger.Error("Error on kafka consumer", "error", err)
-				continue
+				if session.Context().Err() != nil {
+					// Left unmarked, the message is redelivered to the
+					// next owner of the partition.
+					return nil
+				}
+				if err := h.deadLetter(message, err); err != nil {
+					return fmt.Errorf("failed dead-letter message: %w", err)
+				}
 			}
+			session.MarkMessage(message, "")
+		}
+	}
+}
+
+// handle passes message to the event handler, retrying with exponential
+// backoff. Handlers return nil for messages they skip on purpose, so every
+// error is worth another attempt.
+func (h *consumerHandler) handle(ctx context.Context, message *sarama.ConsumerMessage) error {
+	backoff := h.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := h.event.Handle(ctx, message.Value)
+		if err == nil || attempt == maxHandleAttempts {
+			return err
+		}
+		h.logger.Warn("Error on kafka consumer, retrying",
+			"topic", message.Topic,
+			"offset", message.Offset,
+			"attempt", attempt,
+			"error", err,
+		)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
 		}
+		backoff *= 2
 	}
 }
+
+// deadLetter moves a message that kept failing out of the way of its
+// partition without losing it.
+func (h *consumerHandler) deadLetter(message *sarama.ConsumerMessage, cause error) error {
+	h.logger.Error("Error on kafka consumer, moving message to dead-letter topic",
+		"topic", message.Topic,
+		"offset", message.Offset,
+		"error", cause,
+	)
+	_, _, err := h.deadLetters.SendMessage(&sarama.ProducerMessage{
+		Topic: DeadLetterTopic(message.Topic),
+		Key:   sarama.ByteEncoder(message.Key),
+		Value: sarama.ByteEncoder(message.Value),
+		Headers: []sarama.RecordHeader{
+			{Key: []byte("partition"), Value: []byte(strconv.FormatInt(int64(message.Partition), 10))},
+			{Key: []byte("offset"), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+			{Key: []byte("error"), Value: []byte(cause.Error())},
+		},
+	})
+	return err
+}
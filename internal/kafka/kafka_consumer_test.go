@@ -0,0 +1,116 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []int64
+}
+
+func (s *fakeSession) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c *fakeClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return c.messages
+}
+
+// failingHandler fails the first failures calls of each message.
+type failingHandler struct {
+	failures int
+	calls    map[string]int
+}
+
+func (h *failingHandler) Handle(_ context.Context, payload []byte) error {
+	h.calls[string(payload)]++
+	if h.calls[string(payload)] <= h.failures {
+		return errors.New("receipt store unavailable")
+	}
+	return nil
+}
+
+func consume(t *testing.T, handler *consumerHandler, messages ...*sarama.ConsumerMessage) *fakeSession {
+	t.Helper()
+	claim := &fakeClaim{messages: make(chan *sarama.ConsumerMessage, len(messages))}
+	for _, message := range messages {
+		claim.messages <- message
+	}
+	close(claim.messages)
+
+	session := &fakeSession{ctx: context.Background()}
+	require.NoError(t, handler.ConsumeClaim(session, claim))
+	return session
+}
+
+func newTestHandler(t *testing.T, event *failingHandler) (*consumerHandler, *mocks.SyncProducer) {
+	t.Helper()
+	producer := mocks.NewSyncProducer(t, nil)
+	t.Cleanup(func() { assert.NoError(t, producer.Close()) })
+	return &consumerHandler{
+		logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		event:        event,
+		deadLetters:  producer,
+		retryBackoff: time.Millisecond,
+	}, producer
+}
+
+func TestConsumeClaim_RetriesFailedMessages(t *testing.T) {
+	event := &failingHandler{failures: maxHandleAttempts - 1, calls: map[string]int{}}
+	handler, _ := newTestHandler(t, event)
+
+	session := consume(t, handler, &sarama.ConsumerMessage{Topic: "bookings", Offset: 7, Value: []byte("confirmed")})
+
+	assert.Equal(t, maxHandleAttempts, event.calls["confirmed"])
+	assert.Equal(t, []int64{7}, session.marked)
+}
+
+func TestConsumeClaim_DeadLettersMessagesThatKeepFailing(t *testing.T) {
+	event := &failingHandler{failures: maxHandleAttempts, calls: map[string]int{}}
+	handler, producer := newTestHandler(t, event)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		if msg.Topic != DeadLetterTopic("bookings") {
+			return errors.New("unexpected topic " + msg.Topic)
+		}
+		return nil
+	})
+
+	session := consume(t, handler, &sarama.ConsumerMessage{Topic: "bookings", Offset: 3, Value: []byte("confirmed")})
+
+	assert.Equal(t, maxHandleAttempts, event.calls["confirmed"])
+	assert.Equal(t, []int64{3}, session.marked)
+}
+
+func TestConsumeClaim_KeepsMessageWhenDeadLetteringFails(t *testing.T) {
+	event := &failingHandler{failures: maxHandleAttempts, calls: map[string]int{}}
+	handler, producer := newTestHandler(t, event)
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+
+	claim := &fakeClaim{messages: make(chan *sarama.ConsumerMessage, 1)}
+	claim.messages <- &sarama.ConsumerMessage{Topic: "bookings", Offset: 3, Value: []byte("confirmed")}
+	session := &fakeSession{ctx: context.Background()}
+
+	assert.ErrorIs(t, handler.ConsumeClaim(session, claim), sarama.ErrOutOfBrokers)
+	assert.Empty(t, session.marked)
+}
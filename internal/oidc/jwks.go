@@ -0,0 +1,47 @@
+package oidc
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"math/big"
+
+	"github.com/mati/go-ticket/internal/auth"
+)
+
+var errKeyUnsupported = errors.New("oidc: unsupported key")
+
+// publicKey decodes an RSA or Ed25519 signing key of a JWK set.
+func publicKey(jwk auth.JWK) (any, error) {
+	if jwk.Use != "" && jwk.Use != "sig" {
+		return nil, errKeyUnsupported
+	}
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, errKeyUnsupported
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "OKP":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if jwk.Crv != "Ed25519" || len(x) != ed25519.PublicKeySize {
+			return nil, errKeyUnsupported
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, errKeyUnsupported
+	}
+}
@@ -0,0 +1,83 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// signIn runs the browser part of the flow against the mock provider and
+// returns the query of the redirect back to us.
+func signIn(t *testing.T, provider *Provider, state, nonce, verifier, loginHint string) url.Values {
+	t.Helper()
+	authURL, err := provider.AuthCodeURL(context.Background(), state, nonce, verifier, loginHint)
+	if err != nil {
+		t.Fatalf("AuthCodeURL() error = %v", err)
+	}
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	if err != nil {
+		t.Fatalf("GET authorize error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("GET authorize status = %d, want %d", resp.StatusCode, http.StatusFound)
+	}
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("invalid redirect: %v", err)
+	}
+	return location.Query()
+}
+
+func TestProvider_AuthorizationCodeFlowWithPKCE(t *testing.T) {
+	var mock *MockProvider
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mock.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	var err error
+	if mock, err = NewMockProvider(server.URL); err != nil {
+		t.Fatalf("NewMockProvider() error = %v", err)
+	}
+	provider := NewProvider(ProviderConfig{
+		Name:        "mock",
+		Issuer:      server.URL,
+		ClientID:    "go-ticket",
+		RedirectURL: "http://localhost:8080/auth/oidc/mock/callback",
+	}, server.Client())
+	ctx := context.Background()
+
+	callback := signIn(t, provider, "state-1", "nonce-1", "verifier-1", "ada@example.com")
+	if callback.Get("state") != "state-1" {
+		t.Errorf("state = %q, want %q", callback.Get("state"), "state-1")
+	}
+	identity, err := provider.Exchange(ctx, callback.Get("code"), "verifier-1", "nonce-1")
+	if err != nil {
+		t.Fatalf("Exchange() error = %v", err)
+	}
+	if identity.Email != "ada@example.com" || !identity.EmailVerified || identity.Subject == "" {
+		t.Errorf("Exchange() = %+v, want verified ada@example.com", identity)
+	}
+	_, err = provider.Exchange(ctx, callback.Get("code"), "verifier-1", "nonce-1")
+	if !errors.Is(err, ErrExchangeFailed) {
+		t.Errorf("Exchange() with a used code error = %v, want %v", err, ErrExchangeFailed)
+	}
+
+	callback = signIn(t, provider, "state-2", "nonce-2", "verifier-2", "")
+	_, err = provider.Exchange(ctx, callback.Get("code"), "other-verifier", "nonce-2")
+	if !errors.Is(err, ErrExchangeFailed) {
+		t.Errorf("Exchange() with the wrong verifier error = %v, want %v", err, ErrExchangeFailed)
+	}
+
+	callback = signIn(t, provider, "state-3", "nonce-3", "verifier-3", "")
+	_, err = provider.Exchange(ctx, callback.Get("code"), "verifier-3", "other-nonce")
+	if !errors.Is(err, ErrIDTokenInvalid) {
+		t.Errorf("Exchange() with the wrong nonce error = %v, want %v", err, ErrIDTokenInvalid)
+	}
+}
@@ -0,0 +1,147 @@
+// Package pdf writes simple text-only PDF 1.4 documents using the standard
+// Helvetica fonts, which every PDF reader ships, so no fonts are embedded.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	// PageWidth and PageHeight are the A4 page size in points.
+	PageWidth  = 595.0
+	PageHeight = 842.0
+
+	// ContentType is the media type of a PDF document.
+	ContentType = "application/pdf"
+)
+
+// Font selects one of the standard fonts.
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+// Document is a PDF under construction.
+type Document struct {
+	pages []*Page
+}
+
+// New creates an empty document.
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage appends an A4 page and returns it.
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// Page holds the content stream of a single page. Coordinates are in points
+// from the bottom-left corner.
+type Page struct {
+	content bytes.Buffer
+}
+
+// Text draws a single line of text with its baseline starting at (x, y).
+// Characters outside Latin-1 are replaced with '?'.
+func (p *Page) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(&p.content, "BT /F%d %s Tf %s %s Td (%s) Tj ET\n",
+		font+1, formatNumber(size), formatNumber(x), formatNumber(y), escape(text))
+}
+
+// Line draws a straight 0.5pt line.
+func (p *Page) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&p.content, "0.5 w %s %s m %s %s l S\n",
+		formatNumber(x1), formatNumber(y1), formatNumber(x2), formatNumber(y2))
+}
+
+// WriteTo serializes the document. A document without pages gets one blank page.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*Page{{}}
+	}
+
+	// Objects: 1 catalog, 2 page tree, 3-4 fonts, then a page and a content
+	// stream per page.
+	var out bytes.Buffer
+	offsets := []int{}
+	begin := func() int {
+		offsets = append(offsets, out.Len())
+		return len(offsets)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	begin()
+	out.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	begin()
+	fmt.Fprintf(&out, "2 0 obj\n<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n",
+		strings.Join(kids, " "), len(pages))
+
+	for i, name := range []string{"Helvetica", "Helvetica-Bold"} {
+		begin()
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>\nendobj\n",
+			3+i, name)
+	}
+
+	for _, page := range pages {
+		pageObj := begin()
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>\nendobj\n",
+			pageObj, formatNumber(PageWidth), formatNumber(PageHeight), pageObj+1)
+
+		contentObj := begin()
+		fmt.Fprintf(&out, "%d 0 obj\n<< /Length %d >>\nstream\n", contentObj, page.content.Len())
+		out.Write(page.content.Bytes())
+		out.WriteString("endstream\nendobj\n")
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.WriteTo(w)
+}
+
+// escape encodes text as the body of a PDF literal string in WinAnsiEncoding.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r < 0x20 || r > 0xff:
+			b.WriteByte('?')
+		case r >= 0x80:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func formatNumber(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// APIKeyRepository implements the APIKeyRepository interface using PostgreSQL.
+type APIKeyRepository struct {
+	queries *Queries
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository.
+func NewAPIKeyRepository(queries *Queries) *APIKeyRepository {
+	return &APIKeyRepository{queries: queries}
+}
+
+func (r *APIKeyRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	return r.getQueries(ctx).CreateAPIKey(ctx, CreateAPIKeyParams{
+		ID:             pgtype.UUID{Bytes: key.ID(), Valid: true},
+		OrganizationID: pgtype.UUID{Bytes: key.OrganizationID(), Valid: true},
+		CreatedBy:      pgtype.UUID{Bytes: key.CreatedBy(), Valid: true},
+		Name:           key.Name(),
+		Prefix:         key.Prefix(),
+		SecretHash:     key.SecretHash(),
+		Scopes:         scopeNames(key.Scopes()),
+		ExpiresAt:      pgtype.Timestamptz{Time: key.ExpiresAt(), Valid: !key.ExpiresAt().IsZero()},
+		CreatedAt:      pgtype.Timestamptz{Time: key.CreatedAt(), Valid: true},
+	})
+}
+
+func (r *APIKeyRepository) GetAPIKey(ctx context.Context, id uuid.UUID) (*domain.APIKey, error) {
+	row, err := r.getQueries(ctx).GetAPIKey(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return apiKeyFromRow(row), nil
+}
+
+func (r *APIKeyRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	row, err := r.getQueries(ctx).GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return apiKeyFromRow(row), nil
+}
+
+// ListAPIKeys returns the keys of an organization, newest first.
+func (r *APIKeyRepository) ListAPIKeys(ctx context.Context, organizationID uuid.UUID) ([]*domain.APIKey, error) {
+	rows, err := r.getQueries(ctx).ListOrganizationAPIKeys(ctx, pgtype.UUID{Bytes: organizationID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*domain.APIKey, len(rows))
+	for i, row := range rows {
+		keys[i] = apiKeyFromRow(row)
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepository) UpdateAPIKey(ctx context.Context, key *domain.APIKey) error {
+	updated, err := r.getQueries(ctx).UpdateAPIKey(ctx, UpdateAPIKeyParams{
+		ID:     pgtype.UUID{Bytes: key.ID(), Valid: true},
+		Name:   key.Name(),
+		Scopes: scopeNames(key.Scopes()),
+	})
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// MarkAPIKeyUsed stores the last use of a key.
+func (r *APIKeyRepository) MarkAPIKeyUsed(ctx context.Context, key *domain.APIKey) error {
+	return r.getQueries(ctx).MarkAPIKeyUsed(ctx, MarkAPIKeyUsedParams{
+		ID:         pgtype.UUID{Bytes: key.ID(), Valid: true},
+		LastUsedAt: pgtype.Timestamptz{Time: key.LastUsedAt(), Valid: true},
+	})
+}
+
+func (r *APIKeyRepository) DeleteAPIKey(ctx context.Context, id uuid.UUID) error {
+	deleted, err := r.getQueries(ctx).DeleteAPIKey(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func apiKeyFromRow(row ApiKey) *domain.APIKey {
+	scopes := make([]domain.APIKeyScope, len(row.Scopes))
+	for i, scope := range row.Scopes {
+		scopes[i] = domain.APIKeyScope(scope)
+	}
+	return domain.UnmarshalAPIKey(
+		uuid.UUID(row.ID.Bytes),
+		uuid.UUID(row.OrganizationID.Bytes),
+		uuid.UUID(row.CreatedBy.Bytes),
+		row.Name,
+		row.Prefix,
+		row.SecretHash,
+		scopes,
+		row.ExpiresAt.Time,
+		row.LastUsedAt.Time,
+		row.CreatedAt.Time,
+	)
+}
+
+func scopeNames(scopes []domain.APIKeyScope) []string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	return names
+}
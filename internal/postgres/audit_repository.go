@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// AuditRepository implements the AuditRepository interface using PostgreSQL.
+type AuditRepository struct {
+	queries *Queries
+}
+
+// NewAuditRepository creates a new AuditRepository.
+func NewAuditRepository(queries *Queries) *AuditRepository {
+	return &AuditRepository{queries: queries}
+}
+
+func (r *AuditRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// CreateAuditRecord appends a record to the audit log.
+func (r *AuditRepository) CreateAuditRecord(ctx context.Context, record *domain.AuditRecord) error {
+	details, err := json.Marshal(record.Details())
+	if err != nil {
+		return err
+	}
+	return r.getQueries(ctx).CreateAuditRecord(ctx, CreateAuditRecordParams{
+		ID:         pgtype.UUID{Bytes: record.ID(), Valid: true},
+		ActorID:    pgtype.UUID{Bytes: record.ActorID(), Valid: record.ActorID() != uuid.Nil},
+		Action:     string(record.Action()),
+		TargetType: record.TargetType(),
+		TargetID:   pgtype.UUID{Bytes: record.TargetID(), Valid: true},
+		Details:    details,
+		CreatedAt:  pgtype.Timestamptz{Time: record.CreatedAt(), Valid: true},
+	})
+}
+
+// ListAuditRecords returns the latest records about a target, newest first.
+func (r *AuditRepository) ListAuditRecords(
+	ctx context.Context,
+	targetType string,
+	targetID uuid.UUID,
+	limit int,
+) ([]*domain.AuditRecord, error) {
+	rows, err := r.getQueries(ctx).ListAuditRecords(ctx, ListAuditRecordsParams{
+		TargetType: targetType,
+		TargetID:   pgtype.UUID{Bytes: targetID, Valid: true},
+		Limit:      int32(limit), //nolint:gosec // G115: integer overflow conversion int -> int32
+	})
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*domain.AuditRecord, 0, len(rows))
+	for _, row := range rows {
+		var details map[string]string
+		if err := json.Unmarshal(row.Details, &details); err != nil {
+			return nil, err
+		}
+		records = append(records, domain.UnmarshalAuditRecord(
+			uuid.UUID(row.ID.Bytes),
+			uuid.UUID(row.ActorID.Bytes),
+			domain.AuditAction(row.Action),
+			row.TargetType,
+			uuid.UUID(row.TargetID.Bytes),
+			details,
+			row.CreatedAt.Time,
+		))
+	}
+	return records, nil
+}
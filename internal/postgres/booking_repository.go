@@ -36,9 +36,28 @@ func (br *BookingRepository) CreateBooking(ctx context.Context, booking *domain.
 		Status:    string(booking.Status()),
 		CreatedAt: pgtype.Timestamptz{Time: booking.CreatedAt(), Valid: true},
 		UpdatedAt: pgtype.Timestamptz{Time: booking.UpdatedAt(), Valid: true},
+		Price:     booking.Price().Amount(),
+		Currency:  string(booking.Price().Currency()),
+		OrderID:   pgtype.UUID{Bytes: booking.OrderID(), Valid: booking.OrderID() != uuid.Nil},
 	}
-	_, err := br.getQueries(ctx).CreateBooking(ctx, params)
-	return err
+	if _, err := br.getQueries(ctx).CreateBooking(ctx, params); err != nil {
+		return err
+	}
+
+	for position, item := range booking.LineItems() {
+		err := br.getQueries(ctx).CreateBookingLineItem(ctx, CreateBookingLineItemParams{
+			BookingID: pgtype.UUID{Bytes: booking.ID(), Valid: true},
+			Position:  int32(position), //nolint:gosec // G115: a booking has a handful of line items
+			Kind:      string(item.Kind),
+			Amount:    item.Amount.Amount(),
+			Currency:  string(item.Amount.Currency()),
+			RateBps:   int32(item.RateBasisPoints), //nolint:gosec // G115: bounded by domain.MaxBasisPoints
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (br *BookingRepository) GetBookingByID(ctx context.Context, id uuid.UUID) (*domain.Booking, error) {
@@ -49,14 +68,56 @@ func (br *BookingRepository) GetBookingByID(ctx context.Context, id uuid.UUID) (
 		}
 		return nil, err
 	}
-	return domain.UnmarshalBooking(
+	return br.withLineItems(ctx, row)
+}
+
+// ListBookingsByOrder returns the bookings of a group order with their line
+// items, oldest first.
+func (br *BookingRepository) ListBookingsByOrder(ctx context.Context, orderID uuid.UUID) ([]*domain.Booking, error) {
+	rows, err := br.getQueries(ctx).ListBookingsByOrder(ctx, pgtype.UUID{Bytes: orderID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	bookings := make([]*domain.Booking, 0, len(rows))
+	for _, row := range rows {
+		booking, err := br.withLineItems(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, booking)
+	}
+	return bookings, nil
+}
+
+// withLineItems rebuilds a booking from its row and loads its line items.
+func (br *BookingRepository) withLineItems(ctx context.Context, row Booking) (*domain.Booking, error) {
+	booking := domain.UnmarshalBooking(
 		uuid.UUID(row.ID.Bytes),
 		uuid.UUID(row.EventID.Bytes),
 		row.UserEmail,
 		domain.BookingStatus(row.Status),
 		row.CreatedAt.Time,
 		row.UpdatedAt.Time,
-	), nil
+		domain.UnmarshalMoney(row.Price, row.Currency),
+	)
+	if row.OrderID.Valid {
+		booking.AssignOrder(uuid.UUID(row.OrderID.Bytes))
+	}
+
+	items, err := br.getQueries(ctx).ListBookingLineItems(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+	lineItems := make([]domain.LineItem, 0, len(items))
+	for _, item := range items {
+		lineItems = append(lineItems, domain.LineItem{
+			Kind:            domain.LineItemKind(item.Kind),
+			Amount:          domain.UnmarshalMoney(item.Amount, item.Currency),
+			RateBasisPoints: int(item.RateBps),
+		})
+	}
+	booking.RestoreLineItems(lineItems)
+	return booking, nil
 }
 
 func (br *BookingRepository) UpdateBooking(ctx context.Context, booking *domain.Booking) error {
@@ -86,6 +147,16 @@ func (br *BookingRepository) CancelBooking(ctx context.Context, id uuid.UUID) er
 	return err
 }
 
+// RefundBooking cancels a confirmed booking. A booking that is no longer
+// confirmed, e.g. refunded concurrently, yields ErrBookingNotRefundable.
+func (br *BookingRepository) RefundBooking(ctx context.Context, id uuid.UUID) error {
+	_, err := br.getQueries(ctx).RefundBooking(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrBookingNotRefundable
+	}
+	return err
+}
+
 func (br *BookingRepository) ListBookings(ctx context.Context) ([]domain.Booking, error) {
 	rows, err := br.getQueries(ctx).ListBookings(ctx, ListBookingsParams{
 		Limit:  10,
@@ -103,12 +174,33 @@ func (br *BookingRepository) ListBookings(ctx context.Context) ([]domain.Booking
 			domain.BookingStatus(row.Status),
 			row.CreatedAt.Time,
 			row.UpdatedAt.Time,
+			domain.UnmarshalMoney(row.Price, row.Currency),
 		)
 		bookings = append(bookings, *booking)
 	}
 	return bookings, nil
 }
 
+func (br *BookingRepository) ListBookingsByUserEmail(ctx context.Context, userEmail string) ([]*domain.Booking, error) {
+	rows, err := br.getQueries(ctx).ListBookingsByUserEmail(ctx, userEmail)
+	if err != nil {
+		return nil, err
+	}
+	bookings := make([]*domain.Booking, 0, len(rows))
+	for _, row := range rows {
+		bookings = append(bookings, domain.UnmarshalBooking(
+			uuid.UUID(row.ID.Bytes),
+			uuid.UUID(row.EventID.Bytes),
+			row.UserEmail,
+			domain.BookingStatus(row.Status),
+			row.CreatedAt.Time,
+			row.UpdatedAt.Time,
+			domain.UnmarshalMoney(row.Price, row.Currency),
+		))
+	}
+	return bookings, nil
+}
+
 func (r *BookingRepository) WithTx(tx pgx.Tx) *BookingRepository {
 	return &BookingRepository{Queries: r.Queries.WithTx(tx)}
 }
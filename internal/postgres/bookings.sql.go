@@ -15,7 +15,7 @@ const cancelBooking = `-- name: CancelBooking :one
 UPDATE bookings
 SET status = 'cancelled', updated_at = NOW()
 WHERE id = $1 AND status = 'pending'
-RETURNING id, event_id, user_email, status, created_at, updated_at
+RETURNING id, event_id, user_email, status, created_at, updated_at, price, currency, order_id
 `
 
 func (q *Queries) CancelBooking(ctx context.Context, id pgtype.UUID) (Booking, error) {
@@ -28,6 +28,9 @@ func (q *Queries) CancelBooking(ctx context.Context, id pgtype.UUID) (Booking, e
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Price,
+		&i.Currency,
+		&i.OrderID,
 	)
 	return i, err
 }
@@ -36,7 +39,7 @@ const confirmBooking = `-- name: ConfirmBooking :one
 UPDATE bookings
 SET status = 'confirmed', updated_at = NOW()
 WHERE id = $1 AND status = 'pending'
-RETURNING id, event_id, user_email, status, created_at, updated_at
+RETURNING id, event_id, user_email, status, created_at, updated_at, price, currency, order_id
 `
 
 func (q *Queries) ConfirmBooking(ctx context.Context, id pgtype.UUID) (Booking, error) {
@@ -49,14 +52,29 @@ func (q *Queries) ConfirmBooking(ctx context.Context, id pgtype.UUID) (Booking,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Price,
+		&i.Currency,
+		&i.OrderID,
 	)
 	return i, err
 }
 
+const countActiveBookingsByEvent = `-- name: CountActiveBookingsByEvent :one
+SELECT COUNT(*) FROM bookings
+WHERE event_id = $1 AND status <> 'cancelled'
+`
+
+func (q *Queries) CountActiveBookingsByEvent(ctx context.Context, eventID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countActiveBookingsByEvent, eventID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createBooking = `-- name: CreateBooking :one
-INSERT INTO bookings (id, event_id, user_email, status, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, event_id, user_email, status, created_at, updated_at
+INSERT INTO bookings (id, event_id, user_email, status, created_at, updated_at, price, currency, order_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id, event_id, user_email, status, created_at, updated_at, price, currency, order_id
 `
 
 type CreateBookingParams struct {
@@ -66,6 +84,9 @@ type CreateBookingParams struct {
 	Status    string             `json:"status"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Price     int64              `json:"price"`
+	Currency  string             `json:"currency"`
+	OrderID   pgtype.UUID        `json:"order_id"`
 }
 
 func (q *Queries) CreateBooking(ctx context.Context, arg CreateBookingParams) (Booking, error) {
@@ -76,6 +97,9 @@ func (q *Queries) CreateBooking(ctx context.Context, arg CreateBookingParams) (B
 		arg.Status,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.Price,
+		arg.Currency,
+		arg.OrderID,
 	)
 	var i Booking
 	err := row.Scan(
@@ -85,6 +109,9 @@ func (q *Queries) CreateBooking(ctx context.Context, arg CreateBookingParams) (B
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Price,
+		&i.Currency,
+		&i.OrderID,
 	)
 	return i, err
 }
@@ -100,7 +127,7 @@ func (q *Queries) DeleteBooking(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getBookingByID = `-- name: GetBookingByID :one
-SELECT id, event_id, user_email, status, created_at, updated_at FROM bookings
+SELECT id, event_id, user_email, status, created_at, updated_at, price, currency, order_id FROM bookings
 WHERE id = $1
 `
 
@@ -114,12 +141,15 @@ func (q *Queries) GetBookingByID(ctx context.Context, id pgtype.UUID) (Booking,
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Price,
+		&i.Currency,
+		&i.OrderID,
 	)
 	return i, err
 }
 
 const listBookings = `-- name: ListBookings :many
-SELECT id, event_id, user_email, status, created_at, updated_at FROM bookings
+SELECT id, event_id, user_email, status, created_at, updated_at, price, currency, order_id FROM bookings
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
 `
@@ -145,6 +175,81 @@ func (q *Queries) ListBookings(ctx context.Context, arg ListBookingsParams) ([]B
 			&i.Status,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Price,
+			&i.Currency,
+			&i.OrderID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBookingsByOrder = `-- name: ListBookingsByOrder :many
+SELECT id, event_id, user_email, status, created_at, updated_at, price, currency, order_id FROM bookings
+WHERE order_id = $1
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) ListBookingsByOrder(ctx context.Context, orderID pgtype.UUID) ([]Booking, error) {
+	rows, err := q.db.Query(ctx, listBookingsByOrder, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Booking
+	for rows.Next() {
+		var i Booking
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.UserEmail,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Price,
+			&i.Currency,
+			&i.OrderID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBookingsByUserEmail = `-- name: ListBookingsByUserEmail :many
+SELECT id, event_id, user_email, status, created_at, updated_at, price, currency, order_id FROM bookings
+WHERE user_email = $1
+ORDER BY created_at ASC
+`
+
+func (q *Queries) ListBookingsByUserEmail(ctx context.Context, userEmail string) ([]Booking, error) {
+	rows, err := q.db.Query(ctx, listBookingsByUserEmail, userEmail)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Booking
+	for rows.Next() {
+		var i Booking
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.UserEmail,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Price,
+			&i.Currency,
+			&i.OrderID,
 		); err != nil {
 			return nil, err
 		}
@@ -156,11 +261,35 @@ func (q *Queries) ListBookings(ctx context.Context, arg ListBookingsParams) ([]B
 	return items, nil
 }
 
+const refundBooking = `-- name: RefundBooking :one
+UPDATE bookings
+SET status = 'cancelled', updated_at = NOW()
+WHERE id = $1 AND status = 'confirmed'
+RETURNING id, event_id, user_email, status, created_at, updated_at, price, currency, order_id
+`
+
+func (q *Queries) RefundBooking(ctx context.Context, id pgtype.UUID) (Booking, error) {
+	row := q.db.QueryRow(ctx, refundBooking, id)
+	var i Booking
+	err := row.Scan(
+		&i.ID,
+		&i.EventID,
+		&i.UserEmail,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Price,
+		&i.Currency,
+		&i.OrderID,
+	)
+	return i, err
+}
+
 const updateBooking = `-- name: UpdateBooking :one
 UPDATE bookings
 SET event_id = $2, user_email = $3, status = $4, updated_at = $5
 WHERE id = $1
-RETURNING id, event_id, user_email, status, created_at, updated_at
+RETURNING id, event_id, user_email, status, created_at, updated_at, price, currency, order_id
 `
 
 type UpdateBookingParams struct {
@@ -187,6 +316,9 @@ func (q *Queries) UpdateBooking(ctx context.Context, arg UpdateBookingParams) (B
 		&i.Status,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Price,
+		&i.Currency,
+		&i.OrderID,
 	)
 	return i, err
 }
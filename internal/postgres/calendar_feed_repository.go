@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// CalendarFeedRepository implements the CalendarFeedRepository interface using PostgreSQL.
+type CalendarFeedRepository struct {
+	queries *Queries
+}
+
+// NewCalendarFeedRepository creates a new CalendarFeedRepository.
+func NewCalendarFeedRepository(queries *Queries) *CalendarFeedRepository {
+	return &CalendarFeedRepository{queries: queries}
+}
+
+func (r *CalendarFeedRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+func (r *CalendarFeedRepository) GetCalendarFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	row, err := r.getQueries(ctx).GetCalendarFeed(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCalendarFeedNotFound
+		}
+		return nil, err
+	}
+	return domain.UnmarshalCalendarFeed(uuid.UUID(row.UserID.Bytes), row.Secret, row.CreatedAt.Time), nil
+}
+
+func (r *CalendarFeedRepository) CreateCalendarFeed(ctx context.Context, feed *domain.CalendarFeed) error {
+	return r.getQueries(ctx).CreateCalendarFeed(ctx, CreateCalendarFeedParams{
+		UserID:    pgtype.UUID{Bytes: feed.UserID(), Valid: true},
+		Secret:    feed.Secret(),
+		CreatedAt: pgtype.Timestamptz{Time: feed.CreatedAt(), Valid: true},
+	})
+}
+
+func (r *CalendarFeedRepository) SaveCalendarFeed(ctx context.Context, feed *domain.CalendarFeed) error {
+	return r.getQueries(ctx).UpsertCalendarFeed(ctx, UpsertCalendarFeedParams{
+		UserID:    pgtype.UUID{Bytes: feed.UserID(), Valid: true},
+		Secret:    feed.Secret(),
+		CreatedAt: pgtype.Timestamptz{Time: feed.CreatedAt(), Valid: true},
+	})
+}
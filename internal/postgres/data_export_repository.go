@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// DataExportRepository implements the DataExportRepository interface using PostgreSQL.
+type DataExportRepository struct {
+	queries *Queries
+}
+
+// NewDataExportRepository creates a new DataExportRepository.
+func NewDataExportRepository(queries *Queries) *DataExportRepository {
+	return &DataExportRepository{queries: queries}
+}
+
+func (r *DataExportRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+func (r *DataExportRepository) CreateDataExport(ctx context.Context, export *domain.DataExport) error {
+	return r.getQueries(ctx).CreateDataExport(ctx, CreateDataExportParams{
+		ID:        pgtype.UUID{Bytes: export.ID(), Valid: true},
+		UserID:    pgtype.UUID{Bytes: export.UserID(), Valid: true},
+		Status:    string(export.Status()),
+		BlobKey:   export.BlobKey(),
+		CreatedAt: pgtype.Timestamptz{Time: export.CreatedAt(), Valid: true},
+	})
+}
+
+func (r *DataExportRepository) GetLatestDataExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	row, err := r.getQueries(ctx).GetLatestDataExport(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrDataExportNotFound
+		}
+		return nil, err
+	}
+	return dataExportFromRow(row), nil
+}
+
+func (r *DataExportRepository) ListPendingDataExportsForUpdate(
+	ctx context.Context,
+	limit int,
+) ([]*domain.DataExport, error) {
+	rows, err := r.getQueries(ctx).ListPendingDataExportsForUpdate(
+		ctx,
+		int32(limit), //nolint:gosec // G115: integer overflow conversion int -> int32
+	)
+	if err != nil {
+		return nil, err
+	}
+	exports := make([]*domain.DataExport, len(rows))
+	for i, row := range rows {
+		exports[i] = dataExportFromRow(row)
+	}
+	return exports, nil
+}
+
+func (r *DataExportRepository) UpdateDataExport(ctx context.Context, export *domain.DataExport) error {
+	updated, err := r.getQueries(ctx).UpdateDataExport(ctx, UpdateDataExportParams{
+		ID:          pgtype.UUID{Bytes: export.ID(), Valid: true},
+		Status:      string(export.Status()),
+		CompletedAt: pgtype.Timestamptz{Time: export.CompletedAt(), Valid: !export.CompletedAt().IsZero()},
+	})
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return domain.ErrDataExportNotFound
+	}
+	return nil
+}
+
+func (r *DataExportRepository) DeleteDataExportsCompletedBefore(
+	ctx context.Context,
+	before time.Time,
+) ([]string, error) {
+	return r.getQueries(ctx).DeleteDataExportsCompletedBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true})
+}
+
+func (r *DataExportRepository) DeleteUserDataExports(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	return r.getQueries(ctx).DeleteUserDataExports(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+}
+
+func dataExportFromRow(row DataExport) *domain.DataExport {
+	return domain.UnmarshalDataExport(
+		uuid.UUID(row.ID.Bytes),
+		uuid.UUID(row.UserID.Bytes),
+		domain.DataExportStatus(row.Status),
+		row.BlobKey,
+		row.CreatedAt.Time,
+		row.CompletedAt.Time,
+	)
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// EmailVerificationRepository implements the EmailVerificationRepository interface using PostgreSQL.
+type EmailVerificationRepository struct {
+	queries *Queries
+}
+
+// NewEmailVerificationRepository creates a new EmailVerificationRepository.
+func NewEmailVerificationRepository(queries *Queries) *EmailVerificationRepository {
+	return &EmailVerificationRepository{queries: queries}
+}
+
+func (r *EmailVerificationRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// CreateEmailVerificationToken stores the hash of a new verification token.
+func (r *EmailVerificationRepository) CreateEmailVerificationToken(
+	ctx context.Context,
+	token *domain.EmailVerificationToken,
+) error {
+	return r.getQueries(ctx).CreateEmailVerificationToken(ctx, CreateEmailVerificationTokenParams{
+		ID:        pgtype.UUID{Bytes: token.ID(), Valid: true},
+		UserID:    pgtype.UUID{Bytes: token.UserID(), Valid: true},
+		TokenHash: token.TokenHash(),
+		ExpiresAt: pgtype.Timestamptz{Time: token.ExpiresAt(), Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: token.CreatedAt(), Valid: true},
+	})
+}
+
+// GetEmailVerificationTokenForUpdate returns the token matching a secret and
+// locks it, so a link verifies once under concurrent clicks.
+func (r *EmailVerificationRepository) GetEmailVerificationTokenForUpdate(
+	ctx context.Context,
+	secret string,
+) (*domain.EmailVerificationToken, error) {
+	row, err := r.getQueries(ctx).GetEmailVerificationTokenForUpdate(ctx, domain.HashToken(secret))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrVerifyTokenInvalid
+		}
+		return nil, err
+	}
+	return domain.UnmarshalEmailVerificationToken(
+		uuid.UUID(row.ID.Bytes),
+		uuid.UUID(row.UserID.Bytes),
+		row.TokenHash,
+		row.ExpiresAt.Time,
+		row.CreatedAt.Time,
+		row.UsedAt.Time,
+	), nil
+}
+
+// UseEmailVerificationTokens marks every outstanding token of a user used.
+func (r *EmailVerificationRepository) UseEmailVerificationTokens(
+	ctx context.Context,
+	userID uuid.UUID,
+	now time.Time,
+) error {
+	return r.getQueries(ctx).UseUserEmailVerificationTokens(ctx, UseUserEmailVerificationTokensParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		UsedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+}
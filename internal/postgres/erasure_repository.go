@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErasureRepository implements the ErasureRepository interface using PostgreSQL.
+type ErasureRepository struct {
+	queries *Queries
+}
+
+// NewErasureRepository creates a new ErasureRepository.
+func NewErasureRepository(queries *Queries) *ErasureRepository {
+	return &ErasureRepository{queries: queries}
+}
+
+func (r *ErasureRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// EraseUserData should run in a transaction so a user is never left half
+// erased.
+func (r *ErasureRepository) EraseUserData(ctx context.Context, userID uuid.UUID, email, anonymizedEmail string) error {
+	queries := r.getQueries(ctx)
+	if err := queries.DeleteUserCredentials(ctx, pgtype.UUID{Bytes: userID, Valid: true}); err != nil {
+		return err
+	}
+	if err := queries.AnonymizeUserBookings(ctx, AnonymizeUserBookingsParams{
+		AnonymizedEmail: anonymizedEmail,
+		Email:           email,
+	}); err != nil {
+		return err
+	}
+	return queries.AnonymizeUserOrders(ctx, AnonymizeUserOrdersParams{
+		AnonymizedEmail: anonymizedEmail,
+		Email:           email,
+	})
+}
@@ -4,6 +4,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"math/rand/v2"
 	"time"
 
 	"github.com/google/uuid"
@@ -33,11 +34,12 @@ func (r *EventRepository) getQueries(ctx context.Context) *Queries {
 // CreateEvent creates a new event in the database.
 func (r *EventRepository) CreateEvent(ctx context.Context, event *domain.Event) error {
 	startAt, endAt := event.StartAndEndAt()
+	salesStartAt, salesEndAt := event.SalesWindow()
 
 	params := CreateEventParams{
 		ID:        pgtype.UUID{Bytes: event.ID(), Valid: true},
 		Name:      event.Name(),
-		Price:     event.Price(),
+		Price:     event.Price().Amount(),
 		StartAt:   pgtype.Timestamptz{Time: startAt, Valid: true},
 		EndAt:     pgtype.Timestamptz{Time: endAt, Valid: true},
 		CreatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
@@ -46,6 +48,14 @@ func (r *EventRepository) CreateEvent(ctx context.Context, event *domain.Event)
 		// G115: integer overflow conversion int -> int32 handled by domain
 		AvailableSpots: int32(event.AvailableSpots()), //nolint:gosec
 		// G115: integer overflow conversion int -> int32 handled by domain
+		SalesStartAt:   optionalTimestamptz(salesStartAt),
+		SalesEndAt:     optionalTimestamptz(salesEndAt),
+		Currency:       string(event.Price().Currency()),
+		OrganizerID:    optionalUUID(event.OrganizerID()),
+		VenueCountry:   event.VenueCountry(),
+		OrganizationID: optionalUUID(event.OrganizationID()),
+		TimeZone:       event.TimeZone(),
+		Revision:       int32(event.Revision()), //nolint:gosec // G115: integer overflow conversion int -> int32
 	}
 
 	_, err := r.getQueries(ctx).CreateEvent(ctx, params)
@@ -55,15 +65,22 @@ func (r *EventRepository) CreateEvent(ctx context.Context, event *domain.Event)
 // UpdateEvent updates an event in the database.
 func (r *EventRepository) UpdateEvent(ctx context.Context, event *domain.Event) error {
 	startAt, endAt := event.StartAndEndAt()
+	salesStartAt, salesEndAt := event.SalesWindow()
 
 	params := UpdateEventParams{
-		ID:        pgtype.UUID{Bytes: event.ID(), Valid: true},
-		Name:      event.Name(),
-		Price:     event.Price(),
-		StartAt:   pgtype.Timestamptz{Time: startAt, Valid: true},
-		EndAt:     pgtype.Timestamptz{Time: endAt, Valid: true},
-		UpdatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
-		Capacity:  int32(event.Capacity()), //nolint:gosec // G115: integer overflow conversion int -> int32 handled by domain
+		ID:           pgtype.UUID{Bytes: event.ID(), Valid: true},
+		Name:         event.Name(),
+		Price:        event.Price().Amount(),
+		StartAt:      pgtype.Timestamptz{Time: startAt, Valid: true},
+		EndAt:        pgtype.Timestamptz{Time: endAt, Valid: true},
+		UpdatedAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		Capacity:     int32(event.Capacity()), //nolint:gosec // G115: integer overflow conversion int -> int32
+		SalesStartAt: optionalTimestamptz(salesStartAt),
+		SalesEndAt:   optionalTimestamptz(salesEndAt),
+		Currency:     string(event.Price().Currency()),
+		VenueCountry: event.VenueCountry(),
+		TimeZone:     event.TimeZone(),
+		Revision:     int32(event.Revision()), //nolint:gosec // G115: integer overflow conversion int -> int32
 	}
 
 	_, err := r.getQueries(ctx).UpdateEvent(ctx, params)
@@ -84,17 +101,13 @@ func (r *EventRepository) GetEvent(ctx context.Context, id uuid.UUID) (*domain.E
 		return nil, domain.ErrEventNotFound
 	}
 
-	return domain.NewEventFromPersistence(
-		uuid.UUID(row.ID.Bytes),
-		row.Name,
-		row.Price,
-		row.StartAt.Time,
-		row.EndAt.Time,
-		row.CreatedAt.Time,
-		row.UpdatedAt.Time,
-		int(row.Capacity),
-		int(row.AvailableSpots),
-	), nil
+	if row.InventoryShards > 0 {
+		if row.AvailableSpots, err = r.sumShards(ctx, row.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return eventFromRow(row), nil
 }
 
 // ListEvents retrieves a list of events from the database.
@@ -109,22 +122,19 @@ func (r *EventRepository) ListEvents(ctx context.Context) ([]*domain.Event, erro
 
 	var events []*domain.Event
 	for _, row := range rows {
-		event := domain.NewEventFromPersistence(
-			uuid.UUID(row.ID.Bytes),
-			row.Name,
-			row.Price,
-			row.StartAt.Time,
-			row.EndAt.Time,
-			row.CreatedAt.Time,
-			row.UpdatedAt.Time,
-			int(row.Capacity),
-			int(row.AvailableSpots),
-		)
-		events = append(events, event)
+		if row.InventoryShards > 0 {
+			if row.AvailableSpots, err = r.sumShards(ctx, row.ID); err != nil {
+				return nil, err
+			}
+		}
+		events = append(events, eventFromRow(row))
 	}
 	return events, nil
 }
 
+// ReserveSpots decrements the available spots of an event. Events with
+// sharded inventory are served from their counter rows instead of the
+// event row, so concurrent bookings do not serialize on a single lock.
 func (r *EventRepository) ReserveSpots(ctx context.Context, eventID uuid.UUID, spots int) error {
 	_, err := r.getQueries(ctx).ReserveSpots(ctx, ReserveSpotsParams{
 		ID:             pgtype.UUID{Bytes: eventID, Valid: true},
@@ -132,10 +142,13 @@ func (r *EventRepository) ReserveSpots(ctx context.Context, eventID uuid.UUID, s
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			_, errGet := r.getQueries(ctx).GetEvent(ctx, pgtype.UUID{Bytes: eventID, Valid: true})
+			row, errGet := r.getQueries(ctx).GetEvent(ctx, pgtype.UUID{Bytes: eventID, Valid: true})
 			if errGet != nil {
 				return domain.ErrEventNotFound
 			}
+			if row.InventoryShards > 0 {
+				return r.reserveFromShards(ctx, eventID, spots, int(row.InventoryShards))
+			}
 			return domain.ErrEventIsFull
 		}
 		return err
@@ -143,6 +156,152 @@ func (r *EventRepository) ReserveSpots(ctx context.Context, eventID uuid.UUID, s
 	return nil
 }
 
+// reserveFromShards starts at a random shard and falls back across the
+// remaining ones until a shard with enough stock is found. A reservation is
+// never split between shards.
+func (r *EventRepository) reserveFromShards(ctx context.Context, eventID uuid.UUID, spots int, shards int) error {
+	start := rand.IntN(shards) //nolint:gosec // G404: shard selection does not need a secure source
+	for i := 0; i < shards; i++ {
+		_, err := r.getQueries(ctx).ReserveShardSpots(ctx, ReserveShardSpotsParams{
+			EventID:        pgtype.UUID{Bytes: eventID, Valid: true},
+			Shard:          int32((start + i) % shards), //nolint:gosec // G115: bounded by MaxInventoryShards
+			AvailableSpots: int32(spots),                //nolint:gosec // G115: integer overflow conversion int -> int32
+		})
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+	}
+	return domain.ErrEventIsFull
+}
+
+// ReleaseSpots returns spots of cancelled bookings to an event. Spots of a
+// sharded event go back to a random shard.
+func (r *EventRepository) ReleaseSpots(ctx context.Context, eventID uuid.UUID, spots int) error {
+	affected, err := r.getQueries(ctx).ReleaseSpots(ctx, ReleaseSpotsParams{
+		ID:             pgtype.UUID{Bytes: eventID, Valid: true},
+		AvailableSpots: int32(spots), //nolint:gosec // G115: integer overflow conversion int -> int32
+	})
+	if err != nil || affected > 0 {
+		return err
+	}
+
+	row, err := r.getQueries(ctx).GetEvent(ctx, pgtype.UUID{Bytes: eventID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrEventNotFound
+		}
+		return err
+	}
+	if row.InventoryShards == 0 {
+		return nil
+	}
+	shard := rand.Int32N(row.InventoryShards) //nolint:gosec // G404: shard selection does not need a secure source
+	return r.getQueries(ctx).ReleaseShardSpots(ctx, ReleaseShardSpotsParams{
+		EventID:        pgtype.UUID{Bytes: eventID, Valid: true},
+		Shard:          shard,
+		AvailableSpots: int32(spots), //nolint:gosec // G115: integer overflow conversion int -> int32
+	})
+}
+
+func (r *EventRepository) sumShards(ctx context.Context, eventID pgtype.UUID) (int32, error) {
+	shards, err := r.getQueries(ctx).ListInventoryShards(ctx, eventID)
+	if err != nil {
+		return 0, err
+	}
+	var total int32
+	for _, shard := range shards {
+		total += shard.AvailableSpots
+	}
+	return total, nil
+}
+
 func (r *EventRepository) WithTx(tx pgx.Tx) *EventRepository {
 	return &EventRepository{queries: r.queries.WithTx(tx)}
 }
+
+// ListEventsDueForSalesOpen locks and returns events whose sales opened
+// before now and whose SalesOpened event has not been published yet.
+func (r *EventRepository) ListEventsDueForSalesOpen(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*domain.Event, error) {
+	rows, err := r.getQueries(ctx).ListEventsDueForSalesOpen(ctx, ListEventsDueForSalesOpenParams{
+		SalesStartAt: pgtype.Timestamptz{Time: now, Valid: true},
+		Limit:        int32(limit), //nolint:gosec // G115: integer overflow conversion int -> int32
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*domain.Event, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, eventFromRow(row))
+	}
+	return events, nil
+}
+
+// ListEventsDueForSalesClose locks and returns events whose sales closed
+// before now and whose SalesClosed event has not been published yet.
+func (r *EventRepository) ListEventsDueForSalesClose(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*domain.Event, error) {
+	rows, err := r.getQueries(ctx).ListEventsDueForSalesClose(ctx, ListEventsDueForSalesCloseParams{
+		SalesEndAt: pgtype.Timestamptz{Time: now, Valid: true},
+		Limit:      int32(limit), //nolint:gosec // G115: integer overflow conversion int -> int32
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*domain.Event, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, eventFromRow(row))
+	}
+	return events, nil
+}
+
+// MarkSalesOpenedEmitted records that the SalesOpened event was published.
+func (r *EventRepository) MarkSalesOpenedEmitted(ctx context.Context, eventID uuid.UUID) error {
+	return r.getQueries(ctx).MarkSalesOpenedEmitted(ctx, pgtype.UUID{Bytes: eventID, Valid: true})
+}
+
+// MarkSalesClosedEmitted records that the SalesClosed event was published.
+func (r *EventRepository) MarkSalesClosedEmitted(ctx context.Context, eventID uuid.UUID) error {
+	return r.getQueries(ctx).MarkSalesClosedEmitted(ctx, pgtype.UUID{Bytes: eventID, Valid: true})
+}
+
+func eventFromRow(row Event) *domain.Event {
+	return domain.NewEventFromPersistence(
+		uuid.UUID(row.ID.Bytes),
+		row.Name,
+		domain.UnmarshalMoney(row.Price, row.Currency),
+		row.StartAt.Time,
+		row.EndAt.Time,
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+		int(row.Capacity),
+		int(row.AvailableSpots),
+		int(row.InventoryShards),
+		row.SalesStartAt.Time,
+		row.SalesEndAt.Time,
+		uuid.UUID(row.OrganizerID.Bytes),
+		row.VenueCountry,
+		uuid.UUID(row.OrganizationID.Bytes),
+		row.TimeZone,
+		int(row.Revision),
+	)
+}
+
+// optionalUUID maps uuid.Nil to NULL.
+func optionalUUID(id uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: id, Valid: id != uuid.Nil}
+}
+
+// optionalTimestamptz maps the zero time to NULL.
+func optionalTimestamptz(t time.Time) pgtype.Timestamptz {
+	return pgtype.Timestamptz{Time: t, Valid: !t.IsZero()}
+}
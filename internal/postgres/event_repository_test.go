@@ -2,11 +2,13 @@ package postgres
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mati/go-ticket/internal/domain"
 	"github.com/stretchr/testify/assert"
 )
@@ -114,3 +116,110 @@ func TestEventRepository_ReserveSpots_Concurrent(t *testing.T) {
 
 	assert.Equal(t, retrieved.AvailableSpots(), 0)
 }
+
+func reshardTestEvent(ctx context.Context, t testing.TB, pool *pgxpool.Pool, eventID uuid.UUID, shards int) {
+	t.Helper()
+
+	inventoryRepository := NewInventoryRepository(New(pool))
+	err := NewPgxTxManager(pool).RunInTx(ctx, func(ctx context.Context) error {
+		return inventoryRepository.ReshardInventory(ctx, eventID, shards)
+	})
+	if err != nil {
+		t.Fatalf("failed to reshard test event: %v", err)
+	}
+}
+
+func TestEventRepository_ReserveSpots_ShardedConcurrent(t *testing.T) {
+	ctx := context.Background()
+	pool := SetupDb(ctx, t)
+
+	//Create sharded event
+	event := CreateTestEvent(ctx, t, pool, WithCapacity(100))
+	reshardTestEvent(ctx, t, pool, event.ID(), 8)
+
+	queries := New(pool)
+	eventRepository := NewEventRepository(queries)
+
+	numGorutines := 200
+	var wg sync.WaitGroup
+	successCount := atomic.Int32{}
+
+	for i := 0; i < numGorutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := eventRepository.ReserveSpots(ctx, event.ID(), 1)
+			if err == nil {
+				successCount.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(100), successCount.Load())
+
+	retrieved := GetEventFromDB(ctx, t, pool, event.ID())
+
+	assert.Equal(t, 0, retrieved.AvailableSpots())
+	assert.Equal(t, 8, retrieved.InventoryShards())
+}
+
+func TestInventoryRepository_ReshardInventory_KeepsTotal(t *testing.T) {
+	ctx := context.Background()
+	pool := SetupDb(ctx, t)
+
+	event := CreateTestEvent(ctx, t, pool, WithCapacity(10))
+	eventRepository := NewEventRepository(New(pool))
+	inventoryRepository := NewInventoryRepository(New(pool))
+
+	assert.NoError(t, eventRepository.ReserveSpots(ctx, event.ID(), 3))
+
+	reshardTestEvent(ctx, t, pool, event.ID(), 3)
+
+	shards, err := inventoryRepository.ListInventoryShards(ctx, event.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.InventoryShard{
+		{Shard: 0, AvailableSpots: 3},
+		{Shard: 1, AvailableSpots: 2},
+		{Shard: 2, AvailableSpots: 2},
+	}, shards)
+
+	// A reservation larger than any single shard is rejected.
+	assert.ErrorIs(t, eventRepository.ReserveSpots(ctx, event.ID(), 4), domain.ErrEventIsFull)
+
+	reshardTestEvent(ctx, t, pool, event.ID(), 0)
+
+	retrieved := GetEventFromDB(ctx, t, pool, event.ID())
+	assert.Equal(t, 7, retrieved.AvailableSpots())
+	assert.False(t, retrieved.IsInventorySharded())
+}
+
+// BenchmarkReserveSpots_Contention compares reservation throughput on a
+// single event row against the same event split across counter shards.
+// Run with: go test ./internal/postgres -run '^$' -bench Contention -cpu 32
+func BenchmarkReserveSpots_Contention(b *testing.B) {
+	ctx := context.Background()
+	pool := SetupDb(ctx, b)
+	eventRepository := NewEventRepository(New(pool))
+
+	for _, shards := range []int{0, 16} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			event := CreateTestEvent(ctx, b, pool, WithCapacity(1_000_000))
+			reshardTestEvent(ctx, b, pool, event.ID(), shards)
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					err := NewPgxTxManager(pool).RunInTx(ctx, func(ctx context.Context) error {
+						return eventRepository.ReserveSpots(ctx, event.ID(), 1)
+					})
+					if err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
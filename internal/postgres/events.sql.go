@@ -12,9 +12,9 @@ import (
 )
 
 const createEvent = `-- name: CreateEvent :one
-INSERT INTO events (id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-RETURNING id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots
+INSERT INTO events (id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots, sales_start_at, sales_end_at, currency, organizer_id, venue_country, organization_id, time_zone, revision)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+RETURNING id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots, inventory_shards, sales_start_at, sales_end_at, sales_opened_emitted_at, sales_closed_emitted_at, currency, organizer_id, venue_country, organization_id, time_zone, revision
 `
 
 type CreateEventParams struct {
@@ -27,6 +27,14 @@ type CreateEventParams struct {
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
 	Capacity       int32              `json:"capacity"`
 	AvailableSpots int32              `json:"available_spots"`
+	SalesStartAt   pgtype.Timestamptz `json:"sales_start_at"`
+	SalesEndAt     pgtype.Timestamptz `json:"sales_end_at"`
+	Currency       string             `json:"currency"`
+	OrganizerID    pgtype.UUID        `json:"organizer_id"`
+	VenueCountry   string             `json:"venue_country"`
+	OrganizationID pgtype.UUID        `json:"organization_id"`
+	TimeZone       string             `json:"time_zone"`
+	Revision       int32              `json:"revision"`
 }
 
 func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error) {
@@ -40,6 +48,14 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event
 		arg.UpdatedAt,
 		arg.Capacity,
 		arg.AvailableSpots,
+		arg.SalesStartAt,
+		arg.SalesEndAt,
+		arg.Currency,
+		arg.OrganizerID,
+		arg.VenueCountry,
+		arg.OrganizationID,
+		arg.TimeZone,
+		arg.Revision,
 	)
 	var i Event
 	err := row.Scan(
@@ -52,6 +68,17 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event
 		&i.UpdatedAt,
 		&i.Capacity,
 		&i.AvailableSpots,
+		&i.InventoryShards,
+		&i.SalesStartAt,
+		&i.SalesEndAt,
+		&i.SalesOpenedEmittedAt,
+		&i.SalesClosedEmittedAt,
+		&i.Currency,
+		&i.OrganizerID,
+		&i.VenueCountry,
+		&i.OrganizationID,
+		&i.TimeZone,
+		&i.Revision,
 	)
 	return i, err
 }
@@ -67,7 +94,7 @@ func (q *Queries) DeleteEvent(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getEvent = `-- name: GetEvent :one
-SELECT id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots FROM events
+SELECT id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots, inventory_shards, sales_start_at, sales_end_at, sales_opened_emitted_at, sales_closed_emitted_at, currency, organizer_id, venue_country, organization_id, time_zone, revision FROM events
 WHERE id = $1
 `
 
@@ -84,12 +111,57 @@ func (q *Queries) GetEvent(ctx context.Context, id pgtype.UUID) (Event, error) {
 		&i.UpdatedAt,
 		&i.Capacity,
 		&i.AvailableSpots,
+		&i.InventoryShards,
+		&i.SalesStartAt,
+		&i.SalesEndAt,
+		&i.SalesOpenedEmittedAt,
+		&i.SalesClosedEmittedAt,
+		&i.Currency,
+		&i.OrganizerID,
+		&i.VenueCountry,
+		&i.OrganizationID,
+		&i.TimeZone,
+		&i.Revision,
+	)
+	return i, err
+}
+
+const getEventForUpdate = `-- name: GetEventForUpdate :one
+SELECT id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots, inventory_shards, sales_start_at, sales_end_at, sales_opened_emitted_at, sales_closed_emitted_at, currency, organizer_id, venue_country, organization_id, time_zone, revision FROM events
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetEventForUpdate(ctx context.Context, id pgtype.UUID) (Event, error) {
+	row := q.db.QueryRow(ctx, getEventForUpdate, id)
+	var i Event
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Price,
+		&i.StartAt,
+		&i.EndAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Capacity,
+		&i.AvailableSpots,
+		&i.InventoryShards,
+		&i.SalesStartAt,
+		&i.SalesEndAt,
+		&i.SalesOpenedEmittedAt,
+		&i.SalesClosedEmittedAt,
+		&i.Currency,
+		&i.OrganizerID,
+		&i.VenueCountry,
+		&i.OrganizationID,
+		&i.TimeZone,
+		&i.Revision,
 	)
 	return i, err
 }
 
 const listEvents = `-- name: ListEvents :many
-SELECT id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots FROM events
+SELECT id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots, inventory_shards, sales_start_at, sales_end_at, sales_opened_emitted_at, sales_closed_emitted_at, currency, organizer_id, venue_country, organization_id, time_zone, revision FROM events
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
 `
@@ -118,6 +190,17 @@ func (q *Queries) ListEvents(ctx context.Context, arg ListEventsParams) ([]Event
 			&i.UpdatedAt,
 			&i.Capacity,
 			&i.AvailableSpots,
+			&i.InventoryShards,
+			&i.SalesStartAt,
+			&i.SalesEndAt,
+			&i.SalesOpenedEmittedAt,
+			&i.SalesClosedEmittedAt,
+			&i.Currency,
+			&i.OrganizerID,
+			&i.VenueCountry,
+			&i.OrganizationID,
+			&i.TimeZone,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -129,11 +212,206 @@ func (q *Queries) ListEvents(ctx context.Context, arg ListEventsParams) ([]Event
 	return items, nil
 }
 
+const listEventsDueForSalesClose = `-- name: ListEventsDueForSalesClose :many
+SELECT id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots, inventory_shards, sales_start_at, sales_end_at, sales_opened_emitted_at, sales_closed_emitted_at, currency, organizer_id, venue_country, organization_id, time_zone, revision FROM events
+WHERE sales_end_at <= $1 AND sales_closed_emitted_at IS NULL
+ORDER BY sales_end_at
+LIMIT $2
+FOR UPDATE SKIP LOCKED
+`
+
+type ListEventsDueForSalesCloseParams struct {
+	SalesEndAt pgtype.Timestamptz `json:"sales_end_at"`
+	Limit      int32              `json:"limit"`
+}
+
+func (q *Queries) ListEventsDueForSalesClose(ctx context.Context, arg ListEventsDueForSalesCloseParams) ([]Event, error) {
+	rows, err := q.db.Query(ctx, listEventsDueForSalesClose, arg.SalesEndAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Price,
+			&i.StartAt,
+			&i.EndAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Capacity,
+			&i.AvailableSpots,
+			&i.InventoryShards,
+			&i.SalesStartAt,
+			&i.SalesEndAt,
+			&i.SalesOpenedEmittedAt,
+			&i.SalesClosedEmittedAt,
+			&i.Currency,
+			&i.OrganizerID,
+			&i.VenueCountry,
+			&i.OrganizationID,
+			&i.TimeZone,
+			&i.Revision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listEventsDueForSalesOpen = `-- name: ListEventsDueForSalesOpen :many
+SELECT id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots, inventory_shards, sales_start_at, sales_end_at, sales_opened_emitted_at, sales_closed_emitted_at, currency, organizer_id, venue_country, organization_id, time_zone, revision FROM events
+WHERE sales_start_at <= $1 AND sales_opened_emitted_at IS NULL
+ORDER BY sales_start_at
+LIMIT $2
+FOR UPDATE SKIP LOCKED
+`
+
+type ListEventsDueForSalesOpenParams struct {
+	SalesStartAt pgtype.Timestamptz `json:"sales_start_at"`
+	Limit        int32              `json:"limit"`
+}
+
+func (q *Queries) ListEventsDueForSalesOpen(ctx context.Context, arg ListEventsDueForSalesOpenParams) ([]Event, error) {
+	rows, err := q.db.Query(ctx, listEventsDueForSalesOpen, arg.SalesStartAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Price,
+			&i.StartAt,
+			&i.EndAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Capacity,
+			&i.AvailableSpots,
+			&i.InventoryShards,
+			&i.SalesStartAt,
+			&i.SalesEndAt,
+			&i.SalesOpenedEmittedAt,
+			&i.SalesClosedEmittedAt,
+			&i.Currency,
+			&i.OrganizerID,
+			&i.VenueCountry,
+			&i.OrganizationID,
+			&i.TimeZone,
+			&i.Revision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listShardedEvents = `-- name: ListShardedEvents :many
+SELECT id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots, inventory_shards, sales_start_at, sales_end_at, sales_opened_emitted_at, sales_closed_emitted_at, currency, organizer_id, venue_country, organization_id, time_zone, revision FROM events
+WHERE inventory_shards > 0
+`
+
+func (q *Queries) ListShardedEvents(ctx context.Context) ([]Event, error) {
+	rows, err := q.db.Query(ctx, listShardedEvents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Price,
+			&i.StartAt,
+			&i.EndAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Capacity,
+			&i.AvailableSpots,
+			&i.InventoryShards,
+			&i.SalesStartAt,
+			&i.SalesEndAt,
+			&i.SalesOpenedEmittedAt,
+			&i.SalesClosedEmittedAt,
+			&i.Currency,
+			&i.OrganizerID,
+			&i.VenueCountry,
+			&i.OrganizationID,
+			&i.TimeZone,
+			&i.Revision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markSalesClosedEmitted = `-- name: MarkSalesClosedEmitted :exec
+UPDATE events
+SET sales_closed_emitted_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkSalesClosedEmitted(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markSalesClosedEmitted, id)
+	return err
+}
+
+const markSalesOpenedEmitted = `-- name: MarkSalesOpenedEmitted :exec
+UPDATE events
+SET sales_opened_emitted_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkSalesOpenedEmitted(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markSalesOpenedEmitted, id)
+	return err
+}
+
+const releaseSpots = `-- name: ReleaseSpots :execrows
+UPDATE events
+SET available_spots = available_spots + $2
+WHERE id = $1 AND inventory_shards = 0
+`
+
+type ReleaseSpotsParams struct {
+	ID             pgtype.UUID `json:"id"`
+	AvailableSpots int32       `json:"available_spots"`
+}
+
+func (q *Queries) ReleaseSpots(ctx context.Context, arg ReleaseSpotsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, releaseSpots, arg.ID, arg.AvailableSpots)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const reserveSpots = `-- name: ReserveSpots :one
 UPDATE events
 SET available_spots = available_spots - $2
-WHERE id = $1 AND available_spots >= $2
-RETURNING id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots
+WHERE id = $1 AND available_spots >= $2 AND inventory_shards = 0
+RETURNING id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots, inventory_shards, sales_start_at, sales_end_at, sales_opened_emitted_at, sales_closed_emitted_at, currency, organizer_id, venue_country, organization_id, time_zone, revision
 `
 
 type ReserveSpotsParams struct {
@@ -154,25 +432,78 @@ func (q *Queries) ReserveSpots(ctx context.Context, arg ReserveSpotsParams) (Eve
 		&i.UpdatedAt,
 		&i.Capacity,
 		&i.AvailableSpots,
+		&i.InventoryShards,
+		&i.SalesStartAt,
+		&i.SalesEndAt,
+		&i.SalesOpenedEmittedAt,
+		&i.SalesClosedEmittedAt,
+		&i.Currency,
+		&i.OrganizerID,
+		&i.VenueCountry,
+		&i.OrganizationID,
+		&i.TimeZone,
+		&i.Revision,
 	)
 	return i, err
 }
 
+const setInventoryShards = `-- name: SetInventoryShards :exec
+UPDATE events
+SET inventory_shards = $2, available_spots = $3, updated_at = NOW()
+WHERE id = $1
+`
+
+type SetInventoryShardsParams struct {
+	ID              pgtype.UUID `json:"id"`
+	InventoryShards int32       `json:"inventory_shards"`
+	AvailableSpots  int32       `json:"available_spots"`
+}
+
+func (q *Queries) SetInventoryShards(ctx context.Context, arg SetInventoryShardsParams) error {
+	_, err := q.db.Exec(ctx, setInventoryShards, arg.ID, arg.InventoryShards, arg.AvailableSpots)
+	return err
+}
+
+const syncAvailableSpots = `-- name: SyncAvailableSpots :exec
+UPDATE events
+SET available_spots = $2
+WHERE id = $1
+`
+
+type SyncAvailableSpotsParams struct {
+	ID             pgtype.UUID `json:"id"`
+	AvailableSpots int32       `json:"available_spots"`
+}
+
+func (q *Queries) SyncAvailableSpots(ctx context.Context, arg SyncAvailableSpotsParams) error {
+	_, err := q.db.Exec(ctx, syncAvailableSpots, arg.ID, arg.AvailableSpots)
+	return err
+}
+
 const updateEvent = `-- name: UpdateEvent :one
 UPDATE events
-SET name = $2, price = $3, start_at = $4, end_at = $5, updated_at = $6, capacity = $7
+SET name = $2, price = $3, start_at = $4, end_at = $5, updated_at = $6, capacity = $7,
+    sales_opened_emitted_at = CASE WHEN sales_start_at IS DISTINCT FROM $8 THEN NULL ELSE sales_opened_emitted_at END,
+    sales_closed_emitted_at = CASE WHEN sales_end_at IS DISTINCT FROM $9 THEN NULL ELSE sales_closed_emitted_at END,
+    sales_start_at = $8, sales_end_at = $9, currency = $10, venue_country = $11, time_zone = $12, revision = $13
 WHERE id = $1
-RETURNING id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots
+RETURNING id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots, inventory_shards, sales_start_at, sales_end_at, sales_opened_emitted_at, sales_closed_emitted_at, currency, organizer_id, venue_country, organization_id, time_zone, revision
 `
 
 type UpdateEventParams struct {
-	ID        pgtype.UUID        `json:"id"`
-	Name      string             `json:"name"`
-	Price     int64              `json:"price"`
-	StartAt   pgtype.Timestamptz `json:"start_at"`
-	EndAt     pgtype.Timestamptz `json:"end_at"`
-	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
-	Capacity  int32              `json:"capacity"`
+	ID           pgtype.UUID        `json:"id"`
+	Name         string             `json:"name"`
+	Price        int64              `json:"price"`
+	StartAt      pgtype.Timestamptz `json:"start_at"`
+	EndAt        pgtype.Timestamptz `json:"end_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	Capacity     int32              `json:"capacity"`
+	SalesStartAt pgtype.Timestamptz `json:"sales_start_at"`
+	SalesEndAt   pgtype.Timestamptz `json:"sales_end_at"`
+	Currency     string             `json:"currency"`
+	VenueCountry string             `json:"venue_country"`
+	TimeZone     string             `json:"time_zone"`
+	Revision     int32              `json:"revision"`
 }
 
 func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event, error) {
@@ -184,6 +515,12 @@ func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event
 		arg.EndAt,
 		arg.UpdatedAt,
 		arg.Capacity,
+		arg.SalesStartAt,
+		arg.SalesEndAt,
+		arg.Currency,
+		arg.VenueCountry,
+		arg.TimeZone,
+		arg.Revision,
 	)
 	var i Event
 	err := row.Scan(
@@ -196,6 +533,17 @@ func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event
 		&i.UpdatedAt,
 		&i.Capacity,
 		&i.AvailableSpots,
+		&i.InventoryShards,
+		&i.SalesStartAt,
+		&i.SalesEndAt,
+		&i.SalesOpenedEmittedAt,
+		&i.SalesClosedEmittedAt,
+		&i.Currency,
+		&i.OrganizerID,
+		&i.VenueCountry,
+		&i.OrganizationID,
+		&i.TimeZone,
+		&i.Revision,
 	)
 	return i, err
 }
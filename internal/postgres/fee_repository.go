@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// FeeRepository implements the FeeRepository interface using PostgreSQL.
+type FeeRepository struct {
+	queries *Queries
+}
+
+// NewFeeRepository creates a new FeeRepository.
+func NewFeeRepository(queries *Queries) *FeeRepository {
+	return &FeeRepository{queries: queries}
+}
+
+func (r *FeeRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// GetFeeSchedule returns the service fee schedule of an organizer.
+func (r *FeeRepository) GetFeeSchedule(ctx context.Context, organizerID uuid.UUID) (domain.FeeSchedule, error) {
+	row, err := r.getQueries(ctx).GetOrganizerFees(ctx, pgtype.UUID{Bytes: organizerID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.FeeSchedule{}, domain.ErrFeeScheduleNotFound
+		}
+		return domain.FeeSchedule{}, err
+	}
+	return domain.UnmarshalFeeSchedule(int(row.PercentBps), row.FixedAmount), nil
+}
+
+// SaveFeeSchedule creates or replaces the service fee schedule of an organizer.
+func (r *FeeRepository) SaveFeeSchedule(
+	ctx context.Context,
+	organizerID uuid.UUID,
+	schedule domain.FeeSchedule,
+) error {
+	_, err := r.getQueries(ctx).UpsertOrganizerFees(ctx, UpsertOrganizerFeesParams{
+		OrganizerID: pgtype.UUID{Bytes: organizerID, Valid: true},
+		PercentBps:  int32(schedule.PercentBasisPoints()), //nolint:gosec // G115: bounded by domain validation
+		FixedAmount: schedule.Fixed(),
+		UpdatedAt:   pgtype.Timestamptz{Time: time.Now(), Valid: true},
+	})
+	return err
+}
+
+// GetVATRate returns the VAT rate of a country in basis points. Countries
+// without a configured rate are not taxed.
+func (r *FeeRepository) GetVATRate(ctx context.Context, country string) (int, error) {
+	rate, err := r.getQueries(ctx).GetVatRate(ctx, country)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return int(rate), nil
+}
@@ -13,11 +13,17 @@ import (
 type EventOptions func(*EventConfig)
 
 type EventConfig struct {
-	Name     string
-	Price    int64
-	StartAt  time.Time
-	EndAt    time.Time
-	Capacity int
+	Name           string
+	Price          int64
+	Currency       domain.Currency
+	StartAt        time.Time
+	EndAt          time.Time
+	Capacity       int
+	SalesStartAt   time.Time
+	SalesEndAt     time.Time
+	OrganizerID    uuid.UUID
+	OrganizationID uuid.UUID
+	VenueCountry   string
 }
 
 func WithName(name string) EventOptions {
@@ -32,6 +38,12 @@ func WithPrice(price int64) EventOptions {
 	}
 }
 
+func WithCurrency(currency domain.Currency) EventOptions {
+	return func(config *EventConfig) {
+		config.Currency = currency
+	}
+}
+
 func WithStartAt(startAt time.Time) EventOptions {
 	return func(config *EventConfig) {
 		config.StartAt = startAt
@@ -50,12 +62,38 @@ func WithCapacity(capacity int) EventOptions {
 	}
 }
 
-func CreateTestEvent(ctx context.Context, t *testing.T, pool *pgxpool.Pool, options ...EventOptions) *domain.Event {
+func WithSalesWindow(salesStartAt, salesEndAt time.Time) EventOptions {
+	return func(config *EventConfig) {
+		config.SalesStartAt = salesStartAt
+		config.SalesEndAt = salesEndAt
+	}
+}
+
+func WithOrganizer(organizerID uuid.UUID) EventOptions {
+	return func(config *EventConfig) {
+		config.OrganizerID = organizerID
+	}
+}
+
+func WithOrganization(organizationID uuid.UUID) EventOptions {
+	return func(config *EventConfig) {
+		config.OrganizationID = organizationID
+	}
+}
+
+func WithVenueCountry(country string) EventOptions {
+	return func(config *EventConfig) {
+		config.VenueCountry = country
+	}
+}
+
+func CreateTestEvent(ctx context.Context, t testing.TB, pool *pgxpool.Pool, options ...EventOptions) *domain.Event {
 	t.Helper()
 
 	config := &EventConfig{
 		Name:     "Test Event",
 		Price:    1000,
+		Currency: domain.DefaultCurrency,
 		StartAt:  time.Now().Add(1 * time.Hour),
 		EndAt:    time.Now().Add(2 * time.Hour),
 		Capacity: 10,
@@ -65,12 +103,31 @@ func CreateTestEvent(ctx context.Context, t *testing.T, pool *pgxpool.Pool, opti
 		option(config)
 	}
 
-	newEvent, err := domain.NewEvent(uuid.New(), config.Name, config.Price, config.StartAt, config.EndAt, config.Capacity)
+	price, err := domain.NewMoney(config.Price, config.Currency)
+	if err != nil {
+		t.Fatalf("failed to create test event price: %v", err)
+	}
+
+	newEvent, err := domain.NewEvent(uuid.New(), config.Name, price, config.StartAt, config.EndAt, config.Capacity)
 
 	if err != nil {
 		t.Fatalf("failed to create test event: %v", err)
 	}
 
+	if err := newEvent.ScheduleSales(config.SalesStartAt, config.SalesEndAt); err != nil {
+		t.Fatalf("failed to schedule test event sales: %v", err)
+	}
+
+	if config.OrganizerID != uuid.Nil {
+		newEvent.AssignOrganizer(config.OrganizerID)
+	}
+	if config.OrganizationID != uuid.Nil {
+		newEvent.AssignOrganization(config.OrganizationID)
+	}
+	if err := newEvent.LocateVenue(config.VenueCountry); err != nil {
+		t.Fatalf("failed to locate test event venue: %v", err)
+	}
+
 	queries := New(pool)
 
 	eventRepositry := NewEventRepository(queries)
@@ -83,6 +140,25 @@ func CreateTestEvent(ctx context.Context, t *testing.T, pool *pgxpool.Pool, opti
 	return newEvent
 }
 
+func CreateTestUser(ctx context.Context, t testing.TB, pool *pgxpool.Pool, role domain.UserRole) *domain.User {
+	t.Helper()
+
+	id := uuid.New()
+	user, err := domain.NewUser(id, id.String()+"@example.com", "password-hash", role)
+	if err != nil {
+		t.Fatalf("failed to create test user: %v", err)
+	}
+	if err := user.Verify(); err != nil {
+		t.Fatalf("failed to verify test user: %v", err)
+	}
+
+	if err := NewUserRepository(New(pool)).CreateUser(ctx, user); err != nil {
+		t.Fatalf("failed to create test user: %v", err)
+	}
+
+	return user
+}
+
 func GetBookingFromDB(ctx context.Context, t *testing.T, pool *pgxpool.Pool, eventID uuid.UUID) *domain.Booking {
 	t.Helper()
 
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// IdentityRepository implements the IdentityRepository interface using PostgreSQL.
+type IdentityRepository struct {
+	queries *Queries
+}
+
+// NewIdentityRepository creates a new IdentityRepository.
+func NewIdentityRepository(queries *Queries) *IdentityRepository {
+	return &IdentityRepository{queries: queries}
+}
+
+func (r *IdentityRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// CreateOIDCLogin stores a new sign-in. Sign-ins the user never returned
+// from are deleted along the way.
+func (r *IdentityRepository) CreateOIDCLogin(ctx context.Context, login *domain.OIDCLogin) error {
+	queries := r.getQueries(ctx)
+	now := pgtype.Timestamptz{Time: login.CreatedAt(), Valid: true}
+	if err := queries.DeleteExpiredOIDCLogins(ctx, now); err != nil {
+		return err
+	}
+	return queries.CreateOIDCLogin(ctx, CreateOIDCLoginParams{
+		StateHash:    login.StateHash(),
+		Provider:     login.Provider(),
+		CodeVerifier: login.CodeVerifier(),
+		Nonce:        login.Nonce(),
+		ExpiresAt:    pgtype.Timestamptz{Time: login.ExpiresAt(), Valid: true},
+		CreatedAt:    now,
+	})
+}
+
+// TakeOIDCLogin deletes and returns the sign-in matching a state.
+func (r *IdentityRepository) TakeOIDCLogin(ctx context.Context, state string) (*domain.OIDCLogin, error) {
+	row, err := r.getQueries(ctx).TakeOIDCLogin(ctx, domain.HashToken(state))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOIDCStateInvalid
+		}
+		return nil, err
+	}
+	return domain.UnmarshalOIDCLogin(
+		row.StateHash,
+		row.Provider,
+		row.CodeVerifier,
+		row.Nonce,
+		row.ExpiresAt.Time,
+		row.CreatedAt.Time,
+	), nil
+}
+
+// CreateUserIdentity links an account at a provider to a user.
+func (r *IdentityRepository) CreateUserIdentity(ctx context.Context, identity *domain.UserIdentity) error {
+	return r.getQueries(ctx).CreateUserIdentity(ctx, CreateUserIdentityParams{
+		ID:        pgtype.UUID{Bytes: identity.ID(), Valid: true},
+		UserID:    pgtype.UUID{Bytes: identity.UserID(), Valid: true},
+		Provider:  identity.Provider(),
+		Subject:   identity.Subject(),
+		Email:     identity.Email(),
+		CreatedAt: pgtype.Timestamptz{Time: identity.CreatedAt(), Valid: true},
+	})
+}
+
+// GetUserIdentity returns the identity of an account at a provider.
+func (r *IdentityRepository) GetUserIdentity(
+	ctx context.Context,
+	provider, subject string,
+) (*domain.UserIdentity, error) {
+	row, err := r.getQueries(ctx).GetUserIdentity(ctx, GetUserIdentityParams{
+		Provider: provider,
+		Subject:  subject,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrIdentityNotFound
+		}
+		return nil, err
+	}
+	return domain.UnmarshalUserIdentity(
+		uuid.UUID(row.ID.Bytes),
+		uuid.UUID(row.UserID.Bytes),
+		row.Provider,
+		row.Subject,
+		row.Email,
+		row.CreatedAt.Time,
+	), nil
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// InventoryRepository manages the sharded inventory counters of events.
+type InventoryRepository struct {
+	queries *Queries
+}
+
+// NewInventoryRepository creates a new InventoryRepository.
+func NewInventoryRepository(queries *Queries) *InventoryRepository {
+	return &InventoryRepository{queries: queries}
+}
+
+func (r *InventoryRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// ReshardInventory redistributes the event's remaining spots evenly across
+// the given number of shards (0 moves them back onto the event row).
+// It locks the event row and its shards, so reservations on the shards wait
+// for it, and must run inside a transaction.
+func (r *InventoryRepository) ReshardInventory(ctx context.Context, eventID uuid.UUID, shards int) error {
+	id := pgtype.UUID{Bytes: eventID, Valid: true}
+	queries := r.getQueries(ctx)
+
+	row, err := queries.GetEventForUpdate(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrEventNotFound
+		}
+		return err
+	}
+
+	available := row.AvailableSpots
+	if row.InventoryShards > 0 {
+		current, err := queries.ListInventoryShardsForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		available = 0
+		for _, shard := range current {
+			available += shard.AvailableSpots
+		}
+	}
+
+	if err := queries.DeleteInventoryShards(ctx, id); err != nil {
+		return err
+	}
+
+	count := int32(shards) //nolint:gosec // G115: bounded by domain.MaxInventoryShards
+	for shard := int32(0); shard < count; shard++ {
+		spots := available / count
+		if shard < available%count {
+			spots++
+		}
+		err := queries.CreateInventoryShard(ctx, CreateInventoryShardParams{
+			EventID:        id,
+			Shard:          shard,
+			AvailableSpots: spots,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return queries.SetInventoryShards(ctx, SetInventoryShardsParams{
+		ID:              id,
+		InventoryShards: count,
+		AvailableSpots:  available,
+	})
+}
+
+// ListInventoryShards returns the counter rows of an event ordered by shard number.
+func (r *InventoryRepository) ListInventoryShards(
+	ctx context.Context,
+	eventID uuid.UUID,
+) ([]domain.InventoryShard, error) {
+	rows, err := r.getQueries(ctx).ListInventoryShards(ctx, pgtype.UUID{Bytes: eventID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	shards := make([]domain.InventoryShard, 0, len(rows))
+	for _, row := range rows {
+		shards = append(shards, domain.InventoryShard{
+			Shard:          int(row.Shard),
+			AvailableSpots: int(row.AvailableSpots),
+		})
+	}
+	return shards, nil
+}
+
+// ListShardedEvents returns every event that uses sharded inventory.
+func (r *InventoryRepository) ListShardedEvents(ctx context.Context) ([]*domain.Event, error) {
+	rows, err := r.getQueries(ctx).ListShardedEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*domain.Event, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, eventFromRow(row))
+	}
+	return events, nil
+}
+
+// CountActiveBookings returns the number of bookings of an event that hold a spot.
+func (r *InventoryRepository) CountActiveBookings(ctx context.Context, eventID uuid.UUID) (int, error) {
+	count, err := r.getQueries(ctx).CountActiveBookingsByEvent(ctx, pgtype.UUID{Bytes: eventID, Valid: true})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// SyncAvailableSpots writes the aggregated shard total back to the event row.
+func (r *InventoryRepository) SyncAvailableSpots(ctx context.Context, eventID uuid.UUID, availableSpots int) error {
+	return r.getQueries(ctx).SyncAvailableSpots(ctx, SyncAvailableSpotsParams{
+		ID:             pgtype.UUID{Bytes: eventID, Valid: true},
+		AvailableSpots: int32(availableSpots), //nolint:gosec // G115: integer overflow conversion int -> int32
+	})
+}
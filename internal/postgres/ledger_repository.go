@@ -0,0 +1,256 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// LedgerRepository implements the LedgerRepository interface using PostgreSQL.
+type LedgerRepository struct {
+	queries *Queries
+}
+
+// NewLedgerRepository creates a new LedgerRepository.
+func NewLedgerRepository(queries *Queries) *LedgerRepository {
+	return &LedgerRepository{queries: queries}
+}
+
+func (r *LedgerRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// PostEntry stores a journal entry and its lines, opening accounts on first
+// use. It should run in a transaction so the entry is stored whole.
+func (r *LedgerRepository) PostEntry(ctx context.Context, entry *domain.JournalEntry) error {
+	q := r.getQueries(ctx)
+	_, err := q.CreateJournalEntry(ctx, CreateJournalEntryParams{
+		ID:          pgtype.UUID{Bytes: entry.ID(), Valid: true},
+		Kind:        string(entry.Kind()),
+		ReferenceID: pgtype.UUID{Bytes: entry.Reference(), Valid: true},
+		CreatedAt:   pgtype.Timestamptz{Time: entry.CreatedAt(), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrJournalEntryExists
+		}
+		return err
+	}
+
+	for i, line := range entry.Lines() {
+		accountID, err := r.accountID(ctx, q, line.Account)
+		if err != nil {
+			return err
+		}
+		if err := q.CreateJournalLine(ctx, CreateJournalLineParams{
+			EntryID:   pgtype.UUID{Bytes: entry.ID(), Valid: true},
+			Position:  int32(i), //nolint:gosec // G115: a handful of lines per entry
+			AccountID: accountID,
+			Amount:    line.Amount,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accountID returns the id of an account, creating it if needed. Existing
+// accounts are not locked, so concurrent postings do not serialize on the
+// shared platform accounts.
+func (r *LedgerRepository) accountID(
+	ctx context.Context,
+	q *Queries,
+	account domain.LedgerAccount,
+) (pgtype.UUID, error) {
+	owner := pgtype.UUID{Bytes: account.OwnerID, Valid: true}
+	if err := q.CreateLedgerAccount(ctx, CreateLedgerAccountParams{
+		OwnerID:  owner,
+		Kind:     string(account.Kind),
+		Currency: string(account.Currency),
+	}); err != nil {
+		return pgtype.UUID{}, err
+	}
+	return q.GetLedgerAccountID(ctx, GetLedgerAccountIDParams{
+		OwnerID:  owner,
+		Kind:     string(account.Kind),
+		Currency: string(account.Currency),
+	})
+}
+
+// GetEntry returns the entry of the given kind posted for a reference.
+func (r *LedgerRepository) GetEntry(
+	ctx context.Context,
+	kind domain.JournalEntryKind,
+	reference uuid.UUID,
+) (*domain.JournalEntry, error) {
+	q := r.getQueries(ctx)
+	row, err := q.GetJournalEntryByReference(ctx, GetJournalEntryByReferenceParams{
+		Kind:        string(kind),
+		ReferenceID: pgtype.UUID{Bytes: reference, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrJournalEntryNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := q.ListJournalLines(ctx, row.ID)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]domain.JournalLine, len(rows))
+	for i, line := range rows {
+		lines[i] = domain.JournalLine{
+			Account: domain.LedgerAccount{
+				OwnerID:  uuid.UUID(line.OwnerID.Bytes),
+				Kind:     domain.LedgerAccountKind(line.Kind),
+				Currency: domain.Currency(line.Currency),
+			},
+			Amount: line.Amount,
+		}
+	}
+
+	return domain.UnmarshalJournalEntry(
+		uuid.UUID(row.ID.Bytes),
+		domain.JournalEntryKind(row.Kind),
+		uuid.UUID(row.ReferenceID.Bytes),
+		lines,
+		row.CreatedAt.Time,
+	), nil
+}
+
+// OrganizerBalances returns the available and paid out amounts of an
+// organizer per currency.
+func (r *LedgerRepository) OrganizerBalances(
+	ctx context.Context,
+	organizerID uuid.UUID,
+) ([]domain.OrganizerBalance, error) {
+	rows, err := r.getQueries(ctx).ListOrganizerBalances(ctx, pgtype.UUID{Bytes: organizerID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	balances := make([]domain.OrganizerBalance, len(rows))
+	for i, row := range rows {
+		balances[i] = domain.OrganizerBalance{
+			Available: domain.UnmarshalMoney(row.Available, row.Currency),
+			PaidOut:   domain.UnmarshalMoney(row.PaidOut, row.Currency),
+		}
+	}
+	return balances, nil
+}
+
+// LockPayableBalances locks the organizer payable accounts and then reads
+// their balances, so concurrent payout runs cannot pay the same amount twice.
+// It must run in a transaction.
+func (r *LedgerRepository) LockPayableBalances(ctx context.Context) ([]domain.OrganizerPayable, error) {
+	q := r.getQueries(ctx)
+	accounts, err := q.LockOrganizerPayableAccounts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payables := make([]domain.OrganizerPayable, 0, len(accounts))
+	for _, account := range accounts {
+		balance, err := q.GetLedgerAccountBalance(ctx, account.ID)
+		if err != nil {
+			return nil, err
+		}
+		payables = append(payables, domain.OrganizerPayable{
+			OrganizerID: uuid.UUID(account.OwnerID.Bytes),
+			// Payables are credit accounts: what is owed is the negated balance.
+			Amount: domain.UnmarshalMoney(-balance, account.Currency),
+		})
+	}
+	return payables, nil
+}
+
+// CreateSettlement posts the payout entry and stores the settlement record.
+func (r *LedgerRepository) CreateSettlement(
+	ctx context.Context,
+	settlement *domain.Settlement,
+	entry *domain.JournalEntry,
+) error {
+	if err := r.PostEntry(ctx, entry); err != nil {
+		return err
+	}
+	_, err := r.getQueries(ctx).CreateSettlement(ctx, CreateSettlementParams{
+		ID:             pgtype.UUID{Bytes: settlement.ID(), Valid: true},
+		OrganizerID:    pgtype.UUID{Bytes: settlement.OrganizerID(), Valid: true},
+		Amount:         settlement.Amount().Amount(),
+		Currency:       string(settlement.Amount().Currency()),
+		JournalEntryID: pgtype.UUID{Bytes: entry.ID(), Valid: true},
+		CreatedAt:      pgtype.Timestamptz{Time: settlement.CreatedAt(), Valid: true},
+	})
+	return err
+}
+
+// ListSettlements returns the latest settlements of an organizer, newest first.
+func (r *LedgerRepository) ListSettlements(
+	ctx context.Context,
+	organizerID uuid.UUID,
+	limit int,
+) ([]*domain.Settlement, error) {
+	rows, err := r.getQueries(ctx).ListSettlementsByOrganizer(ctx, ListSettlementsByOrganizerParams{
+		OrganizerID: pgtype.UUID{Bytes: organizerID, Valid: true},
+		Limit:       int32(limit), //nolint:gosec // G115: limit is a small constant
+	})
+	if err != nil {
+		return nil, err
+	}
+	settlements := make([]*domain.Settlement, len(rows))
+	for i, row := range rows {
+		settlements[i] = domain.UnmarshalSettlement(
+			uuid.UUID(row.ID.Bytes),
+			uuid.UUID(row.OrganizerID.Bytes),
+			domain.UnmarshalMoney(row.Amount, row.Currency),
+			row.CreatedAt.Time,
+		)
+	}
+	return settlements, nil
+}
+
+// Discrepancies checks that every journal entry balances and that the cash
+// posted for each booking equals its total while confirmed and nets to zero
+// otherwise.
+func (r *LedgerRepository) Discrepancies(ctx context.Context) ([]domain.LedgerDiscrepancy, error) {
+	q := r.getQueries(ctx)
+	var discrepancies []domain.LedgerDiscrepancy
+
+	unbalanced, err := q.ListUnbalancedJournalEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range unbalanced {
+		discrepancies = append(discrepancies, domain.LedgerDiscrepancy{
+			Reference: uuid.UUID(row.EntryID.Bytes),
+			Reason:    fmt.Sprintf("journal entry is off by %s", domain.UnmarshalMoney(row.Total, row.Currency)),
+		})
+	}
+
+	mismatches, err := q.ListBookingLedgerMismatches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range mismatches {
+		discrepancies = append(discrepancies, domain.LedgerDiscrepancy{
+			Reference: uuid.UUID(row.ID.Bytes),
+			Reason: fmt.Sprintf("%s booking posted %s to cash, expected %s",
+				row.Status,
+				domain.UnmarshalMoney(row.Posted, row.Currency),
+				domain.UnmarshalMoney(row.Expected, row.Currency),
+			),
+		})
+	}
+
+	return discrepancies, nil
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// LoginSecurityRepository implements the LoginSecurityRepository interface using PostgreSQL.
+type LoginSecurityRepository struct {
+	queries *Queries
+}
+
+// NewLoginSecurityRepository creates a new LoginSecurityRepository.
+func NewLoginSecurityRepository(queries *Queries) *LoginSecurityRepository {
+	return &LoginSecurityRepository{queries: queries}
+}
+
+func (r *LoginSecurityRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// GetAccountLockoutForUpdate returns the lockout of an account and locks it,
+// so concurrent failed sign-ins are counted one by one.
+func (r *LoginSecurityRepository) GetAccountLockoutForUpdate(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*domain.AccountLockout, error) {
+	row, err := r.getQueries(ctx).GetAccountLockoutForUpdate(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.NewAccountLockout(userID), nil
+		}
+		return nil, err
+	}
+	return domain.UnmarshalAccountLockout(
+		uuid.UUID(row.UserID.Bytes),
+		int(row.FailedAttempts),
+		row.LastFailedAt.Time,
+		row.LockedUntil.Time,
+	), nil
+}
+
+func (r *LoginSecurityRepository) SaveAccountLockout(ctx context.Context, lockout *domain.AccountLockout) error {
+	return r.getQueries(ctx).UpsertAccountLockout(ctx, UpsertAccountLockoutParams{
+		UserID:         pgtype.UUID{Bytes: lockout.UserID(), Valid: true},
+		FailedAttempts: int32(lockout.FailedAttempts()), //nolint:gosec // G115: failures within a day fit
+		LastFailedAt:   pgtype.Timestamptz{Time: lockout.LastFailedAt(), Valid: true},
+		LockedUntil:    pgtype.Timestamptz{Time: lockout.LockedUntil(), Valid: !lockout.LockedUntil().IsZero()},
+	})
+}
+
+func (r *LoginSecurityRepository) DeleteAccountLockout(ctx context.Context, userID uuid.UUID) error {
+	return r.getQueries(ctx).DeleteAccountLockout(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+}
+
+// CreateLoginRecord stores a sign-in attempt and drops the user's attempts
+// older than domain.LoginHistoryRetention.
+func (r *LoginSecurityRepository) CreateLoginRecord(ctx context.Context, record *domain.LoginRecord) error {
+	queries := r.getQueries(ctx)
+	userID := pgtype.UUID{Bytes: record.UserID(), Valid: true}
+	err := queries.DeleteUserLoginRecordsBefore(ctx, DeleteUserLoginRecordsBeforeParams{
+		UserID:    userID,
+		CreatedAt: pgtype.Timestamptz{Time: record.CreatedAt().Add(-domain.LoginHistoryRetention), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	return queries.CreateLoginRecord(ctx, CreateLoginRecordParams{
+		ID:        pgtype.UUID{Bytes: record.ID(), Valid: true},
+		UserID:    userID,
+		IpAddress: record.IPAddress(),
+		UserAgent: record.UserAgent(),
+		Outcome:   string(record.Outcome()),
+		CreatedAt: pgtype.Timestamptz{Time: record.CreatedAt(), Valid: true},
+	})
+}
+
+// GetLoginFamiliarity tells whether a user signed in successfully before,
+// and whether from ipAddress and userAgent.
+func (r *LoginSecurityRepository) GetLoginFamiliarity(
+	ctx context.Context,
+	userID uuid.UUID,
+	ipAddress, userAgent string,
+) (domain.LoginFamiliarity, error) {
+	row, err := r.getQueries(ctx).GetLoginFamiliarity(ctx, GetLoginFamiliarityParams{
+		IpAddress: ipAddress,
+		UserAgent: userAgent,
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+	})
+	if err != nil {
+		return domain.LoginFamiliarity{}, err
+	}
+	return domain.LoginFamiliarity{
+		HasHistory:  row.HasHistory,
+		KnownIP:     row.KnownIp,
+		KnownDevice: row.KnownDevice,
+	}, nil
+}
+
+// ListLoginRecords returns a page of a user's sign-in attempts, newest first.
+func (r *LoginSecurityRepository) ListLoginRecords(
+	ctx context.Context,
+	userID uuid.UUID,
+	page domain.LoginHistoryPage,
+) ([]*domain.LoginRecord, error) {
+	rows, err := r.getQueries(ctx).ListUserLoginRecords(ctx, ListUserLoginRecordsParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		Limit:  int32(page.Limit),  //nolint:gosec // G115: integer overflow conversion int -> int32
+		Offset: int32(page.Offset), //nolint:gosec // G115: integer overflow conversion int -> int32
+	})
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*domain.LoginRecord, 0, len(rows))
+	for _, row := range rows {
+		records = append(records, domain.UnmarshalLoginRecord(
+			uuid.UUID(row.ID.Bytes),
+			uuid.UUID(row.UserID.Bytes),
+			row.IpAddress,
+			row.UserAgent,
+			domain.LoginOutcome(row.Outcome),
+			row.CreatedAt.Time,
+		))
+	}
+	return records, nil
+}
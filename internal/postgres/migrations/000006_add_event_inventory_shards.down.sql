@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_inventory_shards;
+ALTER TABLE events DROP COLUMN inventory_shards;
@@ -0,0 +1,8 @@
+ALTER TABLE events ADD COLUMN inventory_shards INT NOT NULL DEFAULT 0;
+
+CREATE TABLE event_inventory_shards (
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    shard INT NOT NULL,
+    available_spots INT NOT NULL CHECK (available_spots >= 0),
+    PRIMARY KEY (event_id, shard)
+);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS event_presales;
+DROP INDEX IF EXISTS idx_events_sales_start_at;
+DROP INDEX IF EXISTS idx_events_sales_end_at;
+ALTER TABLE events DROP COLUMN sales_closed_emitted_at;
+ALTER TABLE events DROP COLUMN sales_opened_emitted_at;
+ALTER TABLE events DROP COLUMN sales_end_at;
+ALTER TABLE events DROP COLUMN sales_start_at;
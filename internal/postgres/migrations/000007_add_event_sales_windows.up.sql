@@ -0,0 +1,18 @@
+ALTER TABLE events ADD COLUMN sales_start_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE events ADD COLUMN sales_end_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE events ADD COLUMN sales_opened_emitted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE events ADD COLUMN sales_closed_emitted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE event_presales (
+    id UUID PRIMARY KEY NOT NULL,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    start_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    end_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    access_code_hash TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_presales_event_id ON event_presales(event_id);
+CREATE INDEX idx_events_sales_start_at ON events(sales_start_at) WHERE sales_opened_emitted_at IS NULL;
+CREATE INDEX idx_events_sales_end_at ON events(sales_end_at) WHERE sales_closed_emitted_at IS NULL;
@@ -0,0 +1,2 @@
+ALTER TABLE bookings DROP COLUMN price;
+DROP TABLE IF EXISTS event_pricing_rules;
@@ -0,0 +1,16 @@
+CREATE TABLE event_pricing_rules (
+    id UUID PRIMARY KEY NOT NULL,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    trigger_kind VARCHAR(50) NOT NULL,
+    threshold INT NOT NULL,
+    adjustment_kind VARCHAR(50) NOT NULL,
+    adjustment BIGINT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_event_pricing_rules_event_id ON event_pricing_rules(event_id);
+
+ALTER TABLE bookings ADD COLUMN price BIGINT NOT NULL DEFAULT 0;
+
+-- Bookings made before dynamic pricing paid the static event price.
+UPDATE bookings SET price = events.price FROM events WHERE bookings.event_id = events.id;
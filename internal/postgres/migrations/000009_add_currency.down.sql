@@ -0,0 +1,2 @@
+ALTER TABLE bookings DROP COLUMN currency;
+ALTER TABLE events DROP COLUMN currency;
@@ -0,0 +1,4 @@
+ALTER TABLE events ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'EUR';
+ALTER TABLE bookings ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'EUR';
+
+UPDATE bookings SET currency = events.currency FROM events WHERE bookings.event_id = events.id;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS booking_line_items;
+DROP TABLE IF EXISTS vat_rates;
+DROP TABLE IF EXISTS organizer_fees;
+ALTER TABLE events DROP COLUMN venue_country;
+ALTER TABLE events DROP COLUMN organizer_id;
@@ -0,0 +1,39 @@
+ALTER TABLE events ADD COLUMN organizer_id UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE events ADD COLUMN venue_country VARCHAR(2) NOT NULL DEFAULT '';
+
+CREATE TABLE organizer_fees (
+    organizer_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    percent_bps INT NOT NULL CHECK (percent_bps >= 0),
+    fixed_amount BIGINT NOT NULL CHECK (fixed_amount >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE vat_rates (
+    country VARCHAR(2) PRIMARY KEY,
+    rate_bps INT NOT NULL CHECK (rate_bps >= 0)
+);
+
+INSERT INTO vat_rates (country, rate_bps) VALUES
+    ('AT', 2000),
+    ('BE', 2100),
+    ('CZ', 2100),
+    ('DE', 1900),
+    ('ES', 2100),
+    ('FR', 2000),
+    ('GB', 2000),
+    ('IE', 2300),
+    ('IT', 2200),
+    ('NL', 2100),
+    ('PL', 2300),
+    ('PT', 2300),
+    ('SE', 2500);
+
+CREATE TABLE booking_line_items (
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    rate_bps INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (booking_id, position)
+);
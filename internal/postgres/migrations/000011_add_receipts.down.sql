@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS receipts;
+DROP TABLE IF EXISTS invoice_sequences;
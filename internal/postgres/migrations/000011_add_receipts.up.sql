@@ -0,0 +1,14 @@
+CREATE TABLE invoice_sequences (
+    organizer_id UUID PRIMARY KEY,
+    last_number BIGINT NOT NULL
+);
+
+CREATE TABLE receipts (
+    booking_id UUID PRIMARY KEY REFERENCES bookings(id) ON DELETE CASCADE,
+    organizer_id UUID NOT NULL,
+    sequence BIGINT NOT NULL,
+    invoice_number TEXT NOT NULL,
+    blob_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (organizer_id, sequence)
+);
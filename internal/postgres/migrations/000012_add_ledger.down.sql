@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS settlements;
+DROP TABLE IF EXISTS journal_lines;
+DROP TABLE IF EXISTS journal_entries;
+DROP FUNCTION IF EXISTS reject_journal_changes();
+DROP TABLE IF EXISTS ledger_accounts;
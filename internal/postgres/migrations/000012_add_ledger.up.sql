@@ -0,0 +1,53 @@
+-- Platform-owned accounts use the nil UUID as owner.
+CREATE TABLE ledger_accounts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (owner_id, kind, currency)
+);
+
+CREATE TABLE journal_entries (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(32) NOT NULL,
+    reference_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (kind, reference_id)
+);
+
+-- Debits are positive, credits negative; the lines of an entry sum to zero.
+CREATE TABLE journal_lines (
+    entry_id UUID NOT NULL REFERENCES journal_entries(id),
+    position INT NOT NULL,
+    account_id UUID NOT NULL REFERENCES ledger_accounts(id),
+    amount BIGINT NOT NULL,
+    PRIMARY KEY (entry_id, position)
+);
+
+CREATE INDEX idx_journal_lines_account_id ON journal_lines(account_id);
+
+CREATE FUNCTION reject_journal_changes() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'journal is append-only: % on % is not allowed', TG_OP, TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER journal_entries_append_only
+    BEFORE UPDATE OR DELETE ON journal_entries
+    FOR EACH ROW EXECUTE FUNCTION reject_journal_changes();
+
+CREATE TRIGGER journal_lines_append_only
+    BEFORE UPDATE OR DELETE ON journal_lines
+    FOR EACH ROW EXECUTE FUNCTION reject_journal_changes();
+
+CREATE TABLE settlements (
+    id UUID PRIMARY KEY,
+    organizer_id UUID NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    journal_entry_id UUID NOT NULL REFERENCES journal_entries(id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_settlements_organizer_id ON settlements(organizer_id, created_at);
@@ -0,0 +1,17 @@
+CREATE TABLE orders (
+    id UUID PRIMARY KEY,
+    event_id UUID NOT NULL REFERENCES events(id),
+    purchaser_email VARCHAR(255) NOT NULL,
+    company_name VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(32) NOT NULL,
+    due_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_orders_purchaser_email ON orders(purchaser_email);
+CREATE INDEX idx_orders_awaiting_due_at ON orders(due_at) WHERE status = 'awaiting_invoice_payment';
+
+ALTER TABLE bookings ADD COLUMN order_id UUID REFERENCES orders(id);
+
+CREATE INDEX idx_bookings_order_id ON bookings(order_id) WHERE order_id IS NOT NULL;
@@ -0,0 +1,38 @@
+CREATE TABLE wallets (
+    user_id UUID NOT NULL REFERENCES users(id),
+    currency VARCHAR(3) NOT NULL,
+    balance BIGINT NOT NULL DEFAULT 0 CHECK (balance >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, currency)
+);
+
+-- Every balance change is recorded; like the journal, the history cannot be rewritten.
+CREATE TABLE wallet_transactions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    reference_id UUID NOT NULL,
+    amount BIGINT NOT NULL,
+    balance_after BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id, currency) REFERENCES wallets(user_id, currency)
+);
+
+CREATE INDEX idx_wallet_transactions_user ON wallet_transactions(user_id, created_at DESC);
+
+CREATE TRIGGER wallet_transactions_append_only
+    BEFORE UPDATE OR DELETE ON wallet_transactions
+    FOR EACH ROW EXECUTE FUNCTION reject_journal_changes();
+
+-- Only a hash of the code is stored: the code itself is shown once, when issued.
+CREATE TABLE gift_cards (
+    id UUID PRIMARY KEY,
+    code_hash VARCHAR(64) NOT NULL UNIQUE,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    issued_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    redeemed_by UUID REFERENCES users(id),
+    redeemed_at TIMESTAMPTZ
+);
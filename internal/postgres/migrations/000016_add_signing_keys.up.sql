@@ -0,0 +1,11 @@
+-- Asymmetric JWT signing keys. Private keys are stored as PKCS #8 encrypted
+-- with AES-GCM. A new key is published before it starts signing, and a
+-- replaced key keeps verifying until the last token it signed has expired.
+CREATE TABLE signing_keys (
+    kid VARCHAR(64) PRIMARY KEY,
+    algorithm VARCHAR(10) NOT NULL,
+    private_key BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    activates_at TIMESTAMPTZ NOT NULL,
+    retires_at TIMESTAMPTZ
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS audit_log;
+DROP INDEX IF EXISTS idx_users_created_at;
+ALTER TABLE users DROP COLUMN IF EXISTS suspended_at;
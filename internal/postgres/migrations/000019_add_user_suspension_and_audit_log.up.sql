@@ -0,0 +1,19 @@
+-- Suspended users cannot sign in or refresh; the auth middleware rejects their
+-- tokens through a Redis flag set alongside this column.
+ALTER TABLE users ADD COLUMN suspended_at TIMESTAMPTZ;
+
+CREATE INDEX idx_users_created_at ON users(created_at);
+
+-- The audit log records every administrative change. Rows are never updated;
+-- actor_id is kept as NULL when the acting account is deleted.
+CREATE TABLE audit_log (
+    id UUID PRIMARY KEY,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(50) NOT NULL,
+    target_type VARCHAR(50) NOT NULL,
+    target_id UUID NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_target ON audit_log(target_type, target_id, created_at);
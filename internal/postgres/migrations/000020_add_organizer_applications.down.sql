@@ -0,0 +1,3 @@
+ALTER TABLE events DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organizer_applications;
+DROP TABLE IF EXISTS organizations;
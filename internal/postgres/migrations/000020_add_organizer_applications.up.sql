@@ -0,0 +1,29 @@
+-- An organization is created when an organizer application is approved and
+-- owns the events its organizers create.
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY,
+    owner_id UUID NOT NULL UNIQUE REFERENCES users(id),
+    name VARCHAR(255) NOT NULL,
+    website TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE organizer_applications (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    organization_name VARCHAR(255) NOT NULL,
+    website TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMPTZ,
+    organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A user has at most one application waiting for review.
+CREATE UNIQUE INDEX idx_organizer_applications_pending ON organizer_applications(user_id) WHERE status = 'pending';
+CREATE INDEX idx_organizer_applications_status ON organizer_applications(status, created_at);
+
+ALTER TABLE events ADD COLUMN organization_id UUID REFERENCES organizations(id) ON DELETE SET NULL;
@@ -0,0 +1,15 @@
+-- Members act for an organization with a role scoped to it: owner, manager,
+-- box_office or scanner. The global user role no longer decides who may
+-- manage an organization's events and bookings.
+CREATE TABLE organization_memberships (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_memberships_user_id ON organization_memberships(user_id);
+
+INSERT INTO organization_memberships (organization_id, user_id, role, created_at)
+SELECT id, owner_id, 'owner', created_at FROM organizations;
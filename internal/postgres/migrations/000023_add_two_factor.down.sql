@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS two_factor_required_roles;
+DROP TABLE IF EXISTS login_challenges;
+DROP TABLE IF EXISTS two_factor_recovery_codes;
+DROP TABLE IF EXISTS user_two_factor;
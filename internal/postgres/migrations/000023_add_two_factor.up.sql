@@ -0,0 +1,39 @@
+-- TOTP authenticators. An authenticator is pending until its user confirms
+-- it with a first code (enabled_at). last_used_step is the TOTP period of
+-- the last accepted code, so a code works once.
+CREATE TABLE user_two_factor (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    enabled_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Single-use recovery codes, stored as SHA-256 hashes.
+CREATE TABLE two_factor_recovery_codes (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    used_at TIMESTAMPTZ,
+    UNIQUE (user_id, code_hash)
+);
+
+-- Sign-ins whose password checked out, waiting for the second factor. Keyed
+-- by the SHA-256 hash of the challenge token.
+CREATE TABLE login_challenges (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_challenges_expires_at ON login_challenges(expires_at);
+
+-- Roles whose users must use two-factor authentication, set by admins.
+CREATE TABLE two_factor_required_roles (
+    role user_role PRIMARY KEY,
+    required_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    required_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
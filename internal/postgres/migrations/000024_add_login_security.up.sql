@@ -0,0 +1,21 @@
+-- Failed sign-ins of an account since its last successful one. Accounts are
+-- locked out until locked_until after repeated failures; a successful
+-- sign-in deletes the row.
+CREATE TABLE account_lockouts (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    failed_attempts INT NOT NULL,
+    last_failed_at TIMESTAMPTZ NOT NULL,
+    locked_until TIMESTAMPTZ
+);
+
+-- Every sign-in attempt on an existing account, with where it came from.
+CREATE TABLE login_history (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    ip_address TEXT NOT NULL,
+    user_agent TEXT NOT NULL,
+    outcome VARCHAR(30) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_history_user ON login_history(user_id, created_at);
@@ -0,0 +1,17 @@
+-- Keys that let machines call the API on behalf of an organization. A key
+-- is handed out as gtk_<prefix>_<secret>; only the hash of the secret is
+-- stored, and the prefix finds the key.
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    prefix VARCHAR(16) NOT NULL UNIQUE,
+    secret_hash VARCHAR(64) NOT NULL,
+    scopes TEXT[] NOT NULL,
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_api_keys_organization ON api_keys(organization_id, created_at);
@@ -0,0 +1,13 @@
+-- Exports of the data held about a user, built in the background. Once
+-- ready, the ZIP archive is kept in the blob store under blob_key.
+CREATE TABLE data_exports (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    blob_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_data_exports_user ON data_exports(user_id, created_at);
+CREATE INDEX idx_data_exports_pending ON data_exports(created_at) WHERE status = 'pending';
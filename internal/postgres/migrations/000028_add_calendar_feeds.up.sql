@@ -0,0 +1,7 @@
+-- The secret each booking calendar feed URL is bound to. Replacing it turns
+-- away the URLs handed out before.
+CREATE TABLE calendar_feeds (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
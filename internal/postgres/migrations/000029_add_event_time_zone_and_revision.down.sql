@@ -0,0 +1,3 @@
+ALTER TABLE events
+    DROP COLUMN IF EXISTS revision,
+    DROP COLUMN IF EXISTS time_zone;
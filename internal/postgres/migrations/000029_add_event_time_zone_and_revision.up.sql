@@ -0,0 +1,5 @@
+-- time_zone is the IANA time zone calendars show the event in. revision
+-- counts the changes made to the event and becomes its iCalendar SEQUENCE.
+ALTER TABLE events
+    ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    ADD COLUMN revision INTEGER NOT NULL DEFAULT 0;
@@ -54,6 +54,36 @@ func (ns NullUserRole) Value() (driver.Value, error) {
 	return string(ns.UserRole), nil
 }
 
+type AccountLockout struct {
+	UserID         pgtype.UUID        `json:"user_id"`
+	FailedAttempts int32              `json:"failed_attempts"`
+	LastFailedAt   pgtype.Timestamptz `json:"last_failed_at"`
+	LockedUntil    pgtype.Timestamptz `json:"locked_until"`
+}
+
+type ApiKey struct {
+	ID             pgtype.UUID        `json:"id"`
+	OrganizationID pgtype.UUID        `json:"organization_id"`
+	CreatedBy      pgtype.UUID        `json:"created_by"`
+	Name           string             `json:"name"`
+	Prefix         string             `json:"prefix"`
+	SecretHash     string             `json:"secret_hash"`
+	Scopes         []string           `json:"scopes"`
+	ExpiresAt      pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt     pgtype.Timestamptz `json:"last_used_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type AuditLog struct {
+	ID         pgtype.UUID        `json:"id"`
+	ActorID    pgtype.UUID        `json:"actor_id"`
+	Action     string             `json:"action"`
+	TargetType string             `json:"target_type"`
+	TargetID   pgtype.UUID        `json:"target_id"`
+	Details    []byte             `json:"details"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
 type Booking struct {
 	ID        pgtype.UUID        `json:"id"`
 	EventID   pgtype.UUID        `json:"event_id"`
@@ -61,18 +91,202 @@ type Booking struct {
 	Status    string             `json:"status"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Price     int64              `json:"price"`
+	Currency  string             `json:"currency"`
+	OrderID   pgtype.UUID        `json:"order_id"`
+}
+
+type BookingLineItem struct {
+	BookingID pgtype.UUID `json:"booking_id"`
+	Position  int32       `json:"position"`
+	Kind      string      `json:"kind"`
+	Amount    int64       `json:"amount"`
+	Currency  string      `json:"currency"`
+	RateBps   int32       `json:"rate_bps"`
+}
+
+type CalendarFeed struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	Secret    string             `json:"secret"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type DataExport struct {
+	ID          pgtype.UUID        `json:"id"`
+	UserID      pgtype.UUID        `json:"user_id"`
+	Status      string             `json:"status"`
+	BlobKey     string             `json:"blob_key"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+}
+
+type EmailVerificationToken struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
 }
 
 type Event struct {
+	ID                   pgtype.UUID        `json:"id"`
+	Name                 string             `json:"name"`
+	Price                int64              `json:"price"`
+	StartAt              pgtype.Timestamptz `json:"start_at"`
+	EndAt                pgtype.Timestamptz `json:"end_at"`
+	CreatedAt            pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
+	Capacity             int32              `json:"capacity"`
+	AvailableSpots       int32              `json:"available_spots"`
+	InventoryShards      int32              `json:"inventory_shards"`
+	SalesStartAt         pgtype.Timestamptz `json:"sales_start_at"`
+	SalesEndAt           pgtype.Timestamptz `json:"sales_end_at"`
+	SalesOpenedEmittedAt pgtype.Timestamptz `json:"sales_opened_emitted_at"`
+	SalesClosedEmittedAt pgtype.Timestamptz `json:"sales_closed_emitted_at"`
+	Currency             string             `json:"currency"`
+	OrganizerID          pgtype.UUID        `json:"organizer_id"`
+	VenueCountry         string             `json:"venue_country"`
+	OrganizationID       pgtype.UUID        `json:"organization_id"`
+	TimeZone             string             `json:"time_zone"`
+	Revision             int32              `json:"revision"`
+}
+
+type EventInventoryShard struct {
+	EventID        pgtype.UUID `json:"event_id"`
+	Shard          int32       `json:"shard"`
+	AvailableSpots int32       `json:"available_spots"`
+}
+
+type EventPricingRule struct {
+	ID             pgtype.UUID        `json:"id"`
+	EventID        pgtype.UUID        `json:"event_id"`
+	TriggerKind    string             `json:"trigger_kind"`
+	Threshold      int32              `json:"threshold"`
+	AdjustmentKind string             `json:"adjustment_kind"`
+	Adjustment     int64              `json:"adjustment"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type EventPresale struct {
 	ID             pgtype.UUID        `json:"id"`
+	EventID        pgtype.UUID        `json:"event_id"`
 	Name           string             `json:"name"`
-	Price          int64              `json:"price"`
 	StartAt        pgtype.Timestamptz `json:"start_at"`
 	EndAt          pgtype.Timestamptz `json:"end_at"`
+	AccessCodeHash string             `json:"access_code_hash"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type GiftCard struct {
+	ID         pgtype.UUID        `json:"id"`
+	CodeHash   string             `json:"code_hash"`
+	Amount     int64              `json:"amount"`
+	Currency   string             `json:"currency"`
+	IssuedBy   pgtype.UUID        `json:"issued_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	RedeemedBy pgtype.UUID        `json:"redeemed_by"`
+	RedeemedAt pgtype.Timestamptz `json:"redeemed_at"`
+}
+
+type InvoiceSequence struct {
+	OrganizerID pgtype.UUID `json:"organizer_id"`
+	LastNumber  int64       `json:"last_number"`
+}
+
+type JournalEntry struct {
+	ID          pgtype.UUID        `json:"id"`
+	Kind        string             `json:"kind"`
+	ReferenceID pgtype.UUID        `json:"reference_id"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type JournalLine struct {
+	EntryID   pgtype.UUID `json:"entry_id"`
+	Position  int32       `json:"position"`
+	AccountID pgtype.UUID `json:"account_id"`
+	Amount    int64       `json:"amount"`
+}
+
+type LedgerAccount struct {
+	ID        pgtype.UUID        `json:"id"`
+	OwnerID   pgtype.UUID        `json:"owner_id"`
+	Kind      string             `json:"kind"`
+	Currency  string             `json:"currency"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type LoginChallenge struct {
+	TokenHash string             `json:"token_hash"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Attempts  int32              `json:"attempts"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type LoginHistory struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	IpAddress string             `json:"ip_address"`
+	UserAgent string             `json:"user_agent"`
+	Outcome   string             `json:"outcome"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type OidcLogin struct {
+	StateHash    string             `json:"state_hash"`
+	Provider     string             `json:"provider"`
+	CodeVerifier string             `json:"code_verifier"`
+	Nonce        string             `json:"nonce"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type Order struct {
+	ID             pgtype.UUID        `json:"id"`
+	EventID        pgtype.UUID        `json:"event_id"`
+	PurchaserEmail string             `json:"purchaser_email"`
+	CompanyName    string             `json:"company_name"`
+	Status         string             `json:"status"`
+	DueAt          pgtype.Timestamptz `json:"due_at"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
-	Capacity       int32              `json:"capacity"`
-	AvailableSpots int32              `json:"available_spots"`
+}
+
+type Organization struct {
+	ID        pgtype.UUID        `json:"id"`
+	OwnerID   pgtype.UUID        `json:"owner_id"`
+	Name      string             `json:"name"`
+	Website   string             `json:"website"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type OrganizationMembership struct {
+	OrganizationID pgtype.UUID        `json:"organization_id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	Role           string             `json:"role"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type OrganizerApplication struct {
+	ID               pgtype.UUID        `json:"id"`
+	UserID           pgtype.UUID        `json:"user_id"`
+	OrganizationName string             `json:"organization_name"`
+	Website          string             `json:"website"`
+	Description      string             `json:"description"`
+	Status           string             `json:"status"`
+	ReviewerID       pgtype.UUID        `json:"reviewer_id"`
+	ReviewNote       string             `json:"review_note"`
+	ReviewedAt       pgtype.Timestamptz `json:"reviewed_at"`
+	OrganizationID   pgtype.UUID        `json:"organization_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+type OrganizerFee struct {
+	OrganizerID pgtype.UUID        `json:"organizer_id"`
+	PercentBps  int32              `json:"percent_bps"`
+	FixedAmount int64              `json:"fixed_amount"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
 
 type OutboxEvent struct {
@@ -86,6 +300,68 @@ type OutboxEvent struct {
 	AggregateID pgtype.UUID      `json:"aggregate_id"`
 }
 
+type PasswordResetToken struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	TokenHash string             `json:"token_hash"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+}
+
+type Receipt struct {
+	BookingID     pgtype.UUID        `json:"booking_id"`
+	OrganizerID   pgtype.UUID        `json:"organizer_id"`
+	Sequence      int64              `json:"sequence"`
+	InvoiceNumber string             `json:"invoice_number"`
+	BlobKey       string             `json:"blob_key"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+type RefreshToken struct {
+	ID            pgtype.UUID        `json:"id"`
+	UserID        pgtype.UUID        `json:"user_id"`
+	FamilyID      pgtype.UUID        `json:"family_id"`
+	TokenHash     string             `json:"token_hash"`
+	AccessTokenID pgtype.UUID        `json:"access_token_id"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UsedAt        pgtype.Timestamptz `json:"used_at"`
+	RevokedAt     pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type Settlement struct {
+	ID             pgtype.UUID        `json:"id"`
+	OrganizerID    pgtype.UUID        `json:"organizer_id"`
+	Amount         int64              `json:"amount"`
+	Currency       string             `json:"currency"`
+	JournalEntryID pgtype.UUID        `json:"journal_entry_id"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+}
+
+type SigningKey struct {
+	Kid         string             `json:"kid"`
+	Algorithm   string             `json:"algorithm"`
+	PrivateKey  []byte             `json:"private_key"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	ActivatesAt pgtype.Timestamptz `json:"activates_at"`
+	RetiresAt   pgtype.Timestamptz `json:"retires_at"`
+}
+
+type TwoFactorRecoveryCode struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	CodeHash  string             `json:"code_hash"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+}
+
+type TwoFactorRequiredRole struct {
+	Role       UserRole           `json:"role"`
+	RequiredBy pgtype.UUID        `json:"required_by"`
+	RequiredAt pgtype.Timestamptz `json:"required_at"`
+}
+
 type User struct {
 	ID           pgtype.UUID        `json:"id"`
 	Email        string             `json:"email"`
@@ -93,4 +369,56 @@ type User struct {
 	Role         UserRole           `json:"role"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	Status       string             `json:"status"`
+	SuspendedAt  pgtype.Timestamptz `json:"suspended_at"`
+}
+
+type UserIdentity struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Provider  string             `json:"provider"`
+	Subject   string             `json:"subject"`
+	Email     string             `json:"email"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserProfile struct {
+	UserID                  pgtype.UUID        `json:"user_id"`
+	DisplayName             string             `json:"display_name"`
+	Phone                   string             `json:"phone"`
+	Locale                  string             `json:"locale"`
+	TimeZone                string             `json:"time_zone"`
+	NotificationPreferences []byte             `json:"notification_preferences"`
+	UpdatedAt               pgtype.Timestamptz `json:"updated_at"`
+}
+
+type UserTwoFactor struct {
+	UserID       pgtype.UUID        `json:"user_id"`
+	Secret       string             `json:"secret"`
+	EnabledAt    pgtype.Timestamptz `json:"enabled_at"`
+	LastUsedStep int64              `json:"last_used_step"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type VatRate struct {
+	Country string `json:"country"`
+	RateBps int32  `json:"rate_bps"`
+}
+
+type Wallet struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	Currency  string             `json:"currency"`
+	Balance   int64              `json:"balance"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type WalletTransaction struct {
+	ID           pgtype.UUID        `json:"id"`
+	UserID       pgtype.UUID        `json:"user_id"`
+	Currency     string             `json:"currency"`
+	Kind         string             `json:"kind"`
+	ReferenceID  pgtype.UUID        `json:"reference_id"`
+	Amount       int64              `json:"amount"`
+	BalanceAfter int64              `json:"balance_after"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 }
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// OrderRepository implements the OrderRepository interface using PostgreSQL.
+type OrderRepository struct {
+	queries  *Queries
+	bookings *BookingRepository
+}
+
+// NewOrderRepository creates a new OrderRepository.
+func NewOrderRepository(queries *Queries) *OrderRepository {
+	return &OrderRepository{queries: queries, bookings: NewBookingRepository(queries)}
+}
+
+func (r *OrderRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// CreateOrder stores the order row. Its bookings are created through the
+// booking service so they reserve spots and get priced.
+func (r *OrderRepository) CreateOrder(ctx context.Context, order *domain.Order) error {
+	_, err := r.getQueries(ctx).CreateOrder(ctx, CreateOrderParams{
+		ID:             pgtype.UUID{Bytes: order.ID(), Valid: true},
+		EventID:        pgtype.UUID{Bytes: order.EventID(), Valid: true},
+		PurchaserEmail: order.PurchaserEmail(),
+		CompanyName:    order.CompanyName(),
+		Status:         string(order.Status()),
+		DueAt:          pgtype.Timestamptz{Time: order.DueAt(), Valid: true},
+		CreatedAt:      pgtype.Timestamptz{Time: order.CreatedAt(), Valid: true},
+		UpdatedAt:      pgtype.Timestamptz{Time: order.UpdatedAt(), Valid: true},
+	})
+	return err
+}
+
+// GetOrder returns an order with its bookings.
+func (r *OrderRepository) GetOrder(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
+	row, err := r.getQueries(ctx).GetOrder(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return r.withBookings(ctx, row)
+}
+
+// GetOrderForUpdate returns an order with its bookings and locks the order
+// row until the surrounding transaction ends.
+func (r *OrderRepository) GetOrderForUpdate(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
+	row, err := r.getQueries(ctx).GetOrderForUpdate(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return r.withBookings(ctx, row)
+}
+
+// ListOverdueOrders locks up to limit unpaid orders that were due by now.
+func (r *OrderRepository) ListOverdueOrders(ctx context.Context, now time.Time, limit int) ([]*domain.Order, error) {
+	rows, err := r.getQueries(ctx).ListOverdueOrders(ctx, ListOverdueOrdersParams{
+		DueAt: pgtype.Timestamptz{Time: now, Valid: true},
+		Limit: int32(limit), //nolint:gosec // G115: limit is a small constant
+	})
+	if err != nil {
+		return nil, err
+	}
+	orders := make([]*domain.Order, 0, len(rows))
+	for _, row := range rows {
+		order, err := r.withBookings(ctx, row)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// UpdateOrderStatus stores the status of an order.
+func (r *OrderRepository) UpdateOrderStatus(ctx context.Context, order *domain.Order) error {
+	return r.getQueries(ctx).UpdateOrderStatus(ctx, UpdateOrderStatusParams{
+		ID:        pgtype.UUID{Bytes: order.ID(), Valid: true},
+		Status:    string(order.Status()),
+		UpdatedAt: pgtype.Timestamptz{Time: order.UpdatedAt(), Valid: true},
+	})
+}
+
+func (r *OrderRepository) withBookings(ctx context.Context, row Order) (*domain.Order, error) {
+	bookings, err := r.bookings.ListBookingsByOrder(ctx, uuid.UUID(row.ID.Bytes))
+	if err != nil {
+		return nil, err
+	}
+	return domain.UnmarshalOrder(
+		uuid.UUID(row.ID.Bytes),
+		uuid.UUID(row.EventID.Bytes),
+		row.PurchaserEmail,
+		row.CompanyName,
+		domain.OrderStatus(row.Status),
+		row.DueAt.Time,
+		bookings,
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+	), nil
+}
@@ -0,0 +1,279 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// OrganizationRepository implements the OrganizationRepository,
+// MembershipRepository and OrganizerApplicationRepository interfaces using
+// PostgreSQL.
+type OrganizationRepository struct {
+	queries *Queries
+}
+
+// NewOrganizationRepository creates a new OrganizationRepository.
+func NewOrganizationRepository(queries *Queries) *OrganizationRepository {
+	return &OrganizationRepository{queries: queries}
+}
+
+func (r *OrganizationRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// CreateOrganization stores a new organization.
+func (r *OrganizationRepository) CreateOrganization(ctx context.Context, organization *domain.Organization) error {
+	return r.getQueries(ctx).CreateOrganization(ctx, CreateOrganizationParams{
+		ID:        pgtype.UUID{Bytes: organization.ID(), Valid: true},
+		OwnerID:   pgtype.UUID{Bytes: organization.OwnerID(), Valid: true},
+		Name:      organization.Name(),
+		Website:   organization.Website(),
+		CreatedAt: pgtype.Timestamptz{Time: organization.CreatedAt(), Valid: true},
+	})
+}
+
+// GetOrganization returns an organization by its ID.
+func (r *OrganizationRepository) GetOrganization(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	row, err := r.getQueries(ctx).GetOrganization(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return organizationFromRow(row), nil
+}
+
+// GetOrganizationByOwner returns the organization a user owns.
+func (r *OrganizationRepository) GetOrganizationByOwner(
+	ctx context.Context,
+	ownerID uuid.UUID,
+) (*domain.Organization, error) {
+	row, err := r.getQueries(ctx).GetOrganizationByOwner(ctx, pgtype.UUID{Bytes: ownerID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return organizationFromRow(row), nil
+}
+
+// CreateMembership adds a user to an organization.
+func (r *OrganizationRepository) CreateMembership(ctx context.Context, membership *domain.Membership) error {
+	err := r.getQueries(ctx).CreateMembership(ctx, CreateMembershipParams{
+		OrganizationID: pgtype.UUID{Bytes: membership.OrganizationID(), Valid: true},
+		UserID:         pgtype.UUID{Bytes: membership.UserID(), Valid: true},
+		Role:           string(membership.Role()),
+		CreatedAt:      pgtype.Timestamptz{Time: membership.CreatedAt(), Valid: true},
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "unique constraint") {
+			return domain.ErrMembershipExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetMembership returns the membership of a user in an organization.
+func (r *OrganizationRepository) GetMembership(
+	ctx context.Context,
+	organizationID, userID uuid.UUID,
+) (*domain.Membership, error) {
+	row, err := r.getQueries(ctx).GetMembership(ctx, GetMembershipParams{
+		OrganizationID: pgtype.UUID{Bytes: organizationID, Valid: true},
+		UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrMembershipNotFound
+		}
+		return nil, err
+	}
+	return membershipFromRow(ListOrganizationMembersRow(row)), nil
+}
+
+// ListOrganizationMembers returns the members of an organization, oldest first.
+func (r *OrganizationRepository) ListOrganizationMembers(
+	ctx context.Context,
+	organizationID uuid.UUID,
+) ([]*domain.Membership, error) {
+	rows, err := r.getQueries(ctx).ListOrganizationMembers(ctx, pgtype.UUID{Bytes: organizationID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	memberships := make([]*domain.Membership, len(rows))
+	for i, row := range rows {
+		memberships[i] = membershipFromRow(row)
+	}
+	return memberships, nil
+}
+
+// ListUserMemberships returns the organizations a user belongs to.
+func (r *OrganizationRepository) ListUserMemberships(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*domain.Membership, error) {
+	rows, err := r.getQueries(ctx).ListUserMemberships(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	memberships := make([]*domain.Membership, len(rows))
+	for i, row := range rows {
+		memberships[i] = membershipFromRow(ListOrganizationMembersRow(row))
+	}
+	return memberships, nil
+}
+
+// DeleteMembership removes a user from an organization.
+func (r *OrganizationRepository) DeleteMembership(ctx context.Context, organizationID, userID uuid.UUID) error {
+	deleted, err := r.getQueries(ctx).DeleteMembership(ctx, DeleteMembershipParams{
+		OrganizationID: pgtype.UUID{Bytes: organizationID, Valid: true},
+		UserID:         pgtype.UUID{Bytes: userID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrMembershipNotFound
+	}
+	return nil
+}
+
+// CreateOrganizerApplication stores a new application. A unique index allows
+// one pending application per user.
+func (r *OrganizationRepository) CreateOrganizerApplication(
+	ctx context.Context,
+	application *domain.OrganizerApplication,
+) error {
+	err := r.getQueries(ctx).CreateOrganizerApplication(ctx, CreateOrganizerApplicationParams{
+		ID:               pgtype.UUID{Bytes: application.ID(), Valid: true},
+		UserID:           pgtype.UUID{Bytes: application.UserID(), Valid: true},
+		OrganizationName: application.OrganizationName(),
+		Website:          application.Website(),
+		Description:      application.Description(),
+		Status:           string(application.Status()),
+		CreatedAt:        pgtype.Timestamptz{Time: application.CreatedAt(), Valid: true},
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "unique constraint") {
+			return domain.ErrApplicationPending
+		}
+		return err
+	}
+	return nil
+}
+
+// GetLatestOrganizerApplication returns the newest application of a user.
+func (r *OrganizationRepository) GetLatestOrganizerApplication(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*domain.OrganizerApplication, error) {
+	row, err := r.getQueries(ctx).GetLatestOrganizerApplication(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrApplicationNotFound
+		}
+		return nil, err
+	}
+	return organizerApplicationFromRow(row), nil
+}
+
+// GetOrganizerApplicationForUpdate returns an application and locks it, so
+// concurrent reviews of one application succeed once.
+func (r *OrganizationRepository) GetOrganizerApplicationForUpdate(
+	ctx context.Context,
+	id uuid.UUID,
+) (*domain.OrganizerApplication, error) {
+	row, err := r.getQueries(ctx).GetOrganizerApplicationForUpdate(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrApplicationNotFound
+		}
+		return nil, err
+	}
+	return organizerApplicationFromRow(row), nil
+}
+
+// ListOrganizerApplications returns a page of applications in a status,
+// oldest first.
+func (r *OrganizationRepository) ListOrganizerApplications(
+	ctx context.Context,
+	filter domain.ApplicationFilter,
+) ([]*domain.OrganizerApplication, error) {
+	rows, err := r.getQueries(ctx).ListOrganizerApplications(ctx, ListOrganizerApplicationsParams{
+		Status: string(filter.Status),
+		Limit:  int32(filter.Limit),  //nolint:gosec // G115: integer overflow conversion int -> int32
+		Offset: int32(filter.Offset), //nolint:gosec // G115: integer overflow conversion int -> int32
+	})
+	if err != nil {
+		return nil, err
+	}
+	applications := make([]*domain.OrganizerApplication, len(rows))
+	for i, row := range rows {
+		applications[i] = organizerApplicationFromRow(row)
+	}
+	return applications, nil
+}
+
+// UpdateOrganizerApplication stores the review of an application.
+func (r *OrganizationRepository) UpdateOrganizerApplication(
+	ctx context.Context,
+	application *domain.OrganizerApplication,
+) error {
+	return r.getQueries(ctx).UpdateOrganizerApplication(ctx, UpdateOrganizerApplicationParams{
+		ID:             pgtype.UUID{Bytes: application.ID(), Valid: true},
+		Status:         string(application.Status()),
+		ReviewerID:     optionalUUID(application.ReviewerID()),
+		ReviewNote:     application.ReviewNote(),
+		ReviewedAt:     optionalTimestamptz(application.ReviewedAt()),
+		OrganizationID: optionalUUID(application.OrganizationID()),
+	})
+}
+
+func organizationFromRow(row Organization) *domain.Organization {
+	return domain.UnmarshalOrganization(
+		uuid.UUID(row.ID.Bytes),
+		uuid.UUID(row.OwnerID.Bytes),
+		row.Name,
+		row.Website,
+		row.CreatedAt.Time,
+	)
+}
+
+func membershipFromRow(row ListOrganizationMembersRow) *domain.Membership {
+	return domain.UnmarshalMembership(
+		uuid.UUID(row.OrganizationID.Bytes),
+		uuid.UUID(row.UserID.Bytes),
+		row.Email,
+		domain.OrganizationRole(row.Role),
+		row.CreatedAt.Time,
+	)
+}
+
+func organizerApplicationFromRow(row OrganizerApplication) *domain.OrganizerApplication {
+	return domain.UnmarshalOrganizerApplication(
+		uuid.UUID(row.ID.Bytes),
+		uuid.UUID(row.UserID.Bytes),
+		row.OrganizationName,
+		row.Website,
+		row.Description,
+		domain.ApplicationStatus(row.Status),
+		uuid.UUID(row.ReviewerID.Bytes),
+		row.ReviewNote,
+		row.ReviewedAt.Time,
+		uuid.UUID(row.OrganizationID.Bytes),
+		row.CreatedAt.Time,
+	)
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// PasswordResetRepository implements the PasswordResetRepository interface using PostgreSQL.
+type PasswordResetRepository struct {
+	queries *Queries
+}
+
+// NewPasswordResetRepository creates a new PasswordResetRepository.
+func NewPasswordResetRepository(queries *Queries) *PasswordResetRepository {
+	return &PasswordResetRepository{queries: queries}
+}
+
+func (r *PasswordResetRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// CreatePasswordResetToken stores the hash of a new reset token.
+func (r *PasswordResetRepository) CreatePasswordResetToken(
+	ctx context.Context,
+	token *domain.PasswordResetToken,
+) error {
+	return r.getQueries(ctx).CreatePasswordResetToken(ctx, CreatePasswordResetTokenParams{
+		ID:        pgtype.UUID{Bytes: token.ID(), Valid: true},
+		UserID:    pgtype.UUID{Bytes: token.UserID(), Valid: true},
+		TokenHash: token.TokenHash(),
+		ExpiresAt: pgtype.Timestamptz{Time: token.ExpiresAt(), Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: token.CreatedAt(), Valid: true},
+	})
+}
+
+// GetPasswordResetTokenForUpdate returns the token matching a secret and
+// locks it, so concurrent resets with one token succeed once.
+func (r *PasswordResetRepository) GetPasswordResetTokenForUpdate(
+	ctx context.Context,
+	secret string,
+) (*domain.PasswordResetToken, error) {
+	row, err := r.getQueries(ctx).GetPasswordResetTokenForUpdate(ctx, domain.HashToken(secret))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrResetTokenInvalid
+		}
+		return nil, err
+	}
+	return domain.UnmarshalPasswordResetToken(
+		uuid.UUID(row.ID.Bytes),
+		uuid.UUID(row.UserID.Bytes),
+		row.TokenHash,
+		row.ExpiresAt.Time,
+		row.CreatedAt.Time,
+		row.UsedAt.Time,
+	), nil
+}
+
+// UsePasswordResetTokens marks every outstanding token of a user used.
+func (r *PasswordResetRepository) UsePasswordResetTokens(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	return r.getQueries(ctx).UseUserPasswordResetTokens(ctx, UseUserPasswordResetTokensParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		UsedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// PresaleRepository implements the PresaleRepository interface using PostgreSQL.
+type PresaleRepository struct {
+	queries *Queries
+}
+
+// NewPresaleRepository creates a new PresaleRepository.
+func NewPresaleRepository(queries *Queries) *PresaleRepository {
+	return &PresaleRepository{queries: queries}
+}
+
+func (r *PresaleRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// CreatePresale creates a new presale window in the database.
+func (r *PresaleRepository) CreatePresale(ctx context.Context, presale *domain.Presale) error {
+	startAt, endAt := presale.StartAndEndAt()
+	_, err := r.getQueries(ctx).CreatePresale(ctx, CreatePresaleParams{
+		ID:             pgtype.UUID{Bytes: presale.ID(), Valid: true},
+		EventID:        pgtype.UUID{Bytes: presale.EventID(), Valid: true},
+		Name:           presale.Name(),
+		StartAt:        pgtype.Timestamptz{Time: startAt, Valid: true},
+		EndAt:          pgtype.Timestamptz{Time: endAt, Valid: true},
+		AccessCodeHash: presale.AccessCodeHash(),
+		CreatedAt:      pgtype.Timestamptz{Time: presale.CreatedAt(), Valid: true},
+	})
+	return err
+}
+
+// ListPresales returns the presale windows of an event ordered by start time.
+func (r *PresaleRepository) ListPresales(ctx context.Context, eventID uuid.UUID) ([]*domain.Presale, error) {
+	rows, err := r.getQueries(ctx).ListPresalesByEvent(ctx, pgtype.UUID{Bytes: eventID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	presales := make([]*domain.Presale, 0, len(rows))
+	for _, row := range rows {
+		presales = append(presales, domain.NewPresaleFromPersistence(
+			uuid.UUID(row.ID.Bytes),
+			uuid.UUID(row.EventID.Bytes),
+			row.Name,
+			row.StartAt.Time,
+			row.EndAt.Time,
+			row.AccessCodeHash,
+			row.CreatedAt.Time,
+		))
+	}
+	return presales, nil
+}
+
+// DeletePresale deletes a presale window of an event.
+func (r *PresaleRepository) DeletePresale(ctx context.Context, eventID, presaleID uuid.UUID) error {
+	deleted, err := r.getQueries(ctx).DeletePresale(ctx, DeletePresaleParams{
+		ID:      pgtype.UUID{Bytes: presaleID, Valid: true},
+		EventID: pgtype.UUID{Bytes: eventID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrPresaleNotFound
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// PricingRuleRepository implements the PricingRuleRepository interface using PostgreSQL.
+type PricingRuleRepository struct {
+	queries *Queries
+}
+
+// NewPricingRuleRepository creates a new PricingRuleRepository.
+func NewPricingRuleRepository(queries *Queries) *PricingRuleRepository {
+	return &PricingRuleRepository{queries: queries}
+}
+
+func (r *PricingRuleRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// CreatePricingRule creates a new pricing rule in the database.
+func (r *PricingRuleRepository) CreatePricingRule(ctx context.Context, rule *domain.PricingRule) error {
+	_, err := r.getQueries(ctx).CreatePricingRule(ctx, CreatePricingRuleParams{
+		ID:             pgtype.UUID{Bytes: rule.ID(), Valid: true},
+		EventID:        pgtype.UUID{Bytes: rule.EventID(), Valid: true},
+		TriggerKind:    string(rule.Trigger()),
+		Threshold:      int32(rule.Threshold()), //nolint:gosec // G115: bounded by domain validation
+		AdjustmentKind: string(rule.AdjustmentKind()),
+		Adjustment:     rule.Adjustment(),
+		CreatedAt:      pgtype.Timestamptz{Time: rule.CreatedAt(), Valid: true},
+	})
+	return err
+}
+
+// ListPricingRules returns the pricing rules of an event in creation order.
+func (r *PricingRuleRepository) ListPricingRules(
+	ctx context.Context,
+	eventID uuid.UUID,
+) ([]*domain.PricingRule, error) {
+	rows, err := r.getQueries(ctx).ListPricingRulesByEvent(ctx, pgtype.UUID{Bytes: eventID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]*domain.PricingRule, 0, len(rows))
+	for _, row := range rows {
+		rules = append(rules, domain.UnmarshalPricingRule(
+			uuid.UUID(row.ID.Bytes),
+			uuid.UUID(row.EventID.Bytes),
+			domain.PricingTrigger(row.TriggerKind),
+			int(row.Threshold),
+			domain.PriceAdjustment(row.AdjustmentKind),
+			row.Adjustment,
+			row.CreatedAt.Time,
+		))
+	}
+	return rules, nil
+}
+
+// DeletePricingRule deletes a pricing rule of an event.
+func (r *PricingRuleRepository) DeletePricingRule(ctx context.Context, eventID, ruleID uuid.UUID) error {
+	deleted, err := r.getQueries(ctx).DeletePricingRule(ctx, DeletePricingRuleParams{
+		ID:      pgtype.UUID{Bytes: ruleID, Valid: true},
+		EventID: pgtype.UUID{Bytes: eventID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrPricingRuleNotFound
+	}
+	return nil
+}
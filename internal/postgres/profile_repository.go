@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// ProfileRepository implements the ProfileRepository interface using PostgreSQL.
+type ProfileRepository struct {
+	queries *Queries
+}
+
+// NewProfileRepository creates a new ProfileRepository.
+func NewProfileRepository(queries *Queries) *ProfileRepository {
+	return &ProfileRepository{queries: queries}
+}
+
+func (r *ProfileRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+func (r *ProfileRepository) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.Profile, error) {
+	row, err := r.getQueries(ctx).GetUserProfile(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.NewProfile(userID), nil
+		}
+		return nil, err
+	}
+	var notifications domain.NotificationPreferences
+	if err := json.Unmarshal(row.NotificationPreferences, &notifications); err != nil {
+		return nil, err
+	}
+	return domain.UnmarshalProfile(
+		uuid.UUID(row.UserID.Bytes),
+		row.DisplayName,
+		row.Phone,
+		row.Locale,
+		row.TimeZone,
+		notifications,
+		row.UpdatedAt.Time,
+	), nil
+}
+
+func (r *ProfileRepository) SaveProfile(ctx context.Context, profile *domain.Profile) error {
+	notifications, err := json.Marshal(profile.Notifications())
+	if err != nil {
+		return err
+	}
+	return r.getQueries(ctx).UpsertUserProfile(ctx, UpsertUserProfileParams{
+		UserID:                  pgtype.UUID{Bytes: profile.UserID(), Valid: true},
+		DisplayName:             profile.DisplayName(),
+		Phone:                   profile.Phone(),
+		Locale:                  profile.Locale(),
+		TimeZone:                profile.TimeZone(),
+		NotificationPreferences: notifications,
+		UpdatedAt:               pgtype.Timestamptz{Time: profile.UpdatedAt(), Valid: true},
+	})
+}
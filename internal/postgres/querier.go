@@ -11,28 +11,168 @@ import (
 )
 
 type Querier interface {
+	AnonymizeUserBookings(ctx context.Context, arg AnonymizeUserBookingsParams) error
+	AnonymizeUserOrders(ctx context.Context, arg AnonymizeUserOrdersParams) error
 	CancelBooking(ctx context.Context, id pgtype.UUID) (Booking, error)
 	ConfirmBooking(ctx context.Context, id pgtype.UUID) (Booking, error)
+	CountActiveBookingsByEvent(ctx context.Context, eventID pgtype.UUID) (int64, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID pgtype.UUID) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) error
+	CreateAuditRecord(ctx context.Context, arg CreateAuditRecordParams) error
 	CreateBooking(ctx context.Context, arg CreateBookingParams) (Booking, error)
+	CreateBookingLineItem(ctx context.Context, arg CreateBookingLineItemParams) error
+	CreateCalendarFeed(ctx context.Context, arg CreateCalendarFeedParams) error
+	CreateDataExport(ctx context.Context, arg CreateDataExportParams) error
+	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) error
 	CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error)
+	CreateGiftCard(ctx context.Context, arg CreateGiftCardParams) error
+	CreateInventoryShard(ctx context.Context, arg CreateInventoryShardParams) error
+	CreateJournalEntry(ctx context.Context, arg CreateJournalEntryParams) (JournalEntry, error)
+	CreateJournalLine(ctx context.Context, arg CreateJournalLineParams) error
+	CreateLedgerAccount(ctx context.Context, arg CreateLedgerAccountParams) error
+	CreateLoginChallenge(ctx context.Context, arg CreateLoginChallengeParams) error
+	CreateLoginRecord(ctx context.Context, arg CreateLoginRecordParams) error
+	CreateMembership(ctx context.Context, arg CreateMembershipParams) error
+	CreateOIDCLogin(ctx context.Context, arg CreateOIDCLoginParams) error
+	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) error
+	CreateOrganizerApplication(ctx context.Context, arg CreateOrganizerApplicationParams) error
 	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (OutboxEvent, error)
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error
+	CreatePresale(ctx context.Context, arg CreatePresaleParams) (EventPresale, error)
+	CreatePricingRule(ctx context.Context, arg CreatePricingRuleParams) (EventPricingRule, error)
+	CreateReceipt(ctx context.Context, arg CreateReceiptParams) (Receipt, error)
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error
+	CreateSettlement(ctx context.Context, arg CreateSettlementParams) (Settlement, error)
+	CreateSigningKey(ctx context.Context, arg CreateSigningKeyParams) error
+	CreateTwoFactorRequiredRole(ctx context.Context, arg CreateTwoFactorRequiredRoleParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) error
+	CreateWallet(ctx context.Context, arg CreateWalletParams) error
+	CreateWalletTransaction(ctx context.Context, arg CreateWalletTransactionParams) error
+	DeleteAPIKey(ctx context.Context, id pgtype.UUID) (int64, error)
+	DeleteAccountLockout(ctx context.Context, userID pgtype.UUID) error
 	DeleteBooking(ctx context.Context, id pgtype.UUID) error
+	DeleteDataExportsCompletedBefore(ctx context.Context, completedAt pgtype.Timestamptz) ([]string, error)
 	DeleteEvent(ctx context.Context, id pgtype.UUID) error
+	DeleteExpiredLoginChallenges(ctx context.Context, expiresAt pgtype.Timestamptz) error
+	DeleteExpiredOIDCLogins(ctx context.Context, expiresAt pgtype.Timestamptz) error
+	DeleteInventoryShards(ctx context.Context, eventID pgtype.UUID) error
+	DeleteLoginChallenge(ctx context.Context, tokenHash string) error
+	DeleteMembership(ctx context.Context, arg DeleteMembershipParams) (int64, error)
+	DeletePresale(ctx context.Context, arg DeletePresaleParams) (int64, error)
+	DeletePricingRule(ctx context.Context, arg DeletePricingRuleParams) (int64, error)
+	DeleteRetiredSigningKeys(ctx context.Context, retiresAt pgtype.Timestamptz) (int64, error)
+	DeleteTwoFactorRequiredRoles(ctx context.Context) error
 	DeleteUser(ctx context.Context, id pgtype.UUID) error
+	DeleteUserCredentials(ctx context.Context, userID pgtype.UUID) error
+	DeleteUserDataExports(ctx context.Context, userID pgtype.UUID) ([]string, error)
+	DeleteUserLoginRecordsBefore(ctx context.Context, arg DeleteUserLoginRecordsBeforeParams) error
+	DeleteUserRecoveryCodes(ctx context.Context, userID pgtype.UUID) error
+	DeleteUserTwoFactor(ctx context.Context, userID pgtype.UUID) error
+	GetAPIKey(ctx context.Context, id pgtype.UUID) (ApiKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error)
+	GetAccountLockoutForUpdate(ctx context.Context, userID pgtype.UUID) (AccountLockout, error)
 	GetBookingByID(ctx context.Context, id pgtype.UUID) (Booking, error)
+	GetCalendarFeed(ctx context.Context, userID pgtype.UUID) (CalendarFeed, error)
+	GetEmailVerificationTokenForUpdate(ctx context.Context, tokenHash string) (EmailVerificationToken, error)
 	GetEvent(ctx context.Context, id pgtype.UUID) (Event, error)
+	GetEventForUpdate(ctx context.Context, id pgtype.UUID) (Event, error)
+	GetGiftCardForUpdate(ctx context.Context, codeHash string) (GiftCard, error)
+	GetJournalEntryByReference(ctx context.Context, arg GetJournalEntryByReferenceParams) (JournalEntry, error)
+	GetLatestDataExport(ctx context.Context, userID pgtype.UUID) (DataExport, error)
+	GetLatestOrganizerApplication(ctx context.Context, userID pgtype.UUID) (OrganizerApplication, error)
+	GetLedgerAccountBalance(ctx context.Context, accountID pgtype.UUID) (int64, error)
+	GetLedgerAccountID(ctx context.Context, arg GetLedgerAccountIDParams) (pgtype.UUID, error)
+	GetLoginChallengeForUpdate(ctx context.Context, tokenHash string) (LoginChallenge, error)
+	GetLoginFamiliarity(ctx context.Context, arg GetLoginFamiliarityParams) (GetLoginFamiliarityRow, error)
+	GetMembership(ctx context.Context, arg GetMembershipParams) (GetMembershipRow, error)
+	GetOrder(ctx context.Context, id pgtype.UUID) (Order, error)
+	GetOrderForUpdate(ctx context.Context, id pgtype.UUID) (Order, error)
+	GetOrganization(ctx context.Context, id pgtype.UUID) (Organization, error)
+	GetOrganizationByOwner(ctx context.Context, ownerID pgtype.UUID) (Organization, error)
+	GetOrganizerApplicationForUpdate(ctx context.Context, id pgtype.UUID) (OrganizerApplication, error)
+	GetOrganizerFees(ctx context.Context, organizerID pgtype.UUID) (OrganizerFee, error)
+	GetPasswordResetTokenForUpdate(ctx context.Context, tokenHash string) (PasswordResetToken, error)
 	GetPendingOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error)
+	GetReceiptByBookingID(ctx context.Context, bookingID pgtype.UUID) (Receipt, error)
+	GetRefreshTokenForUpdate(ctx context.Context, tokenHash string) (RefreshToken, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (UserIdentity, error)
+	GetUserProfile(ctx context.Context, userID pgtype.UUID) (UserProfile, error)
+	GetUserTwoFactorForUpdate(ctx context.Context, userID pgtype.UUID) (UserTwoFactor, error)
+	GetVatRate(ctx context.Context, country string) (int32, error)
+	GetWalletForUpdate(ctx context.Context, arg GetWalletForUpdateParams) (Wallet, error)
+	ListAuditRecords(ctx context.Context, arg ListAuditRecordsParams) ([]AuditLog, error)
+	ListBookingLedgerMismatches(ctx context.Context) ([]ListBookingLedgerMismatchesRow, error)
+	ListBookingLineItems(ctx context.Context, bookingID pgtype.UUID) ([]BookingLineItem, error)
 	ListBookings(ctx context.Context, arg ListBookingsParams) ([]Booking, error)
+	ListBookingsByOrder(ctx context.Context, orderID pgtype.UUID) ([]Booking, error)
+	ListBookingsByUserEmail(ctx context.Context, userEmail string) ([]Booking, error)
 	ListEvents(ctx context.Context, arg ListEventsParams) ([]Event, error)
-	ListUsers(ctx context.Context) ([]User, error)
+	ListEventsDueForSalesClose(ctx context.Context, arg ListEventsDueForSalesCloseParams) ([]Event, error)
+	ListEventsDueForSalesOpen(ctx context.Context, arg ListEventsDueForSalesOpenParams) ([]Event, error)
+	ListInventoryShards(ctx context.Context, eventID pgtype.UUID) ([]EventInventoryShard, error)
+	ListInventoryShardsForUpdate(ctx context.Context, eventID pgtype.UUID) ([]EventInventoryShard, error)
+	ListJournalLines(ctx context.Context, entryID pgtype.UUID) ([]ListJournalLinesRow, error)
+	ListOrganizationAPIKeys(ctx context.Context, organizationID pgtype.UUID) ([]ApiKey, error)
+	ListOrganizationMembers(ctx context.Context, organizationID pgtype.UUID) ([]ListOrganizationMembersRow, error)
+	ListOrganizerApplications(ctx context.Context, arg ListOrganizerApplicationsParams) ([]OrganizerApplication, error)
+	ListOrganizerBalances(ctx context.Context, ownerID pgtype.UUID) ([]ListOrganizerBalancesRow, error)
+	ListOverdueOrders(ctx context.Context, arg ListOverdueOrdersParams) ([]Order, error)
+	ListPendingDataExportsForUpdate(ctx context.Context, limit int32) ([]DataExport, error)
+	ListPresalesByEvent(ctx context.Context, eventID pgtype.UUID) ([]EventPresale, error)
+	ListPricingRulesByEvent(ctx context.Context, eventID pgtype.UUID) ([]EventPricingRule, error)
+	ListSettlementsByOrganizer(ctx context.Context, arg ListSettlementsByOrganizerParams) ([]Settlement, error)
+	ListShardedEvents(ctx context.Context) ([]Event, error)
+	ListSigningKeys(ctx context.Context, retiresAt pgtype.Timestamptz) ([]SigningKey, error)
+	ListTwoFactorRequiredRoles(ctx context.Context) ([]UserRole, error)
+	ListUnbalancedJournalEntries(ctx context.Context) ([]ListUnbalancedJournalEntriesRow, error)
+	ListUserAccessTokenIDs(ctx context.Context, arg ListUserAccessTokenIDsParams) ([]pgtype.UUID, error)
+	ListUserLoginRecords(ctx context.Context, arg ListUserLoginRecordsParams) ([]LoginHistory, error)
+	ListUserMemberships(ctx context.Context, userID pgtype.UUID) ([]ListUserMembershipsRow, error)
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListWalletTransactions(ctx context.Context, arg ListWalletTransactionsParams) ([]WalletTransaction, error)
+	ListWallets(ctx context.Context, userID pgtype.UUID) ([]Wallet, error)
+	LockOrganizerPayableAccounts(ctx context.Context) ([]LockOrganizerPayableAccountsRow, error)
+	LockSigningKeys(ctx context.Context) error
+	MarkAPIKeyUsed(ctx context.Context, arg MarkAPIKeyUsedParams) error
 	MarkOutBoxEventAsProcessed(ctx context.Context, id pgtype.UUID) error
+	MarkRefreshTokenUsed(ctx context.Context, arg MarkRefreshTokenUsedParams) error
+	MarkSalesClosedEmitted(ctx context.Context, id pgtype.UUID) error
+	MarkSalesOpenedEmitted(ctx context.Context, id pgtype.UUID) error
+	NextInvoiceSequence(ctx context.Context, organizerID pgtype.UUID) (int64, error)
+	RedeemGiftCard(ctx context.Context, arg RedeemGiftCardParams) error
+	RefundBooking(ctx context.Context, id pgtype.UUID) (Booking, error)
+	ReleaseShardSpots(ctx context.Context, arg ReleaseShardSpotsParams) error
+	ReleaseSpots(ctx context.Context, arg ReleaseSpotsParams) (int64, error)
+	ReserveShardSpots(ctx context.Context, arg ReserveShardSpotsParams) (EventInventoryShard, error)
 	ReserveSpots(ctx context.Context, arg ReserveSpotsParams) (Event, error)
+	RetireSigningKeys(ctx context.Context, arg RetireSigningKeysParams) error
+	RevokeRefreshTokenFamily(ctx context.Context, arg RevokeRefreshTokenFamilyParams) ([]RevokeRefreshTokenFamilyRow, error)
+	RevokeUserRefreshTokens(ctx context.Context, arg RevokeUserRefreshTokensParams) ([]RevokeUserRefreshTokensRow, error)
+	SetInventoryShards(ctx context.Context, arg SetInventoryShardsParams) error
+	SyncAvailableSpots(ctx context.Context, arg SyncAvailableSpotsParams) error
+	TakeOIDCLogin(ctx context.Context, stateHash string) (OidcLogin, error)
+	UpdateAPIKey(ctx context.Context, arg UpdateAPIKeyParams) (int64, error)
 	UpdateBooking(ctx context.Context, arg UpdateBookingParams) (Booking, error)
+	UpdateDataExport(ctx context.Context, arg UpdateDataExportParams) (int64, error)
 	UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event, error)
+	UpdateLoginChallengeAttempts(ctx context.Context, arg UpdateLoginChallengeAttemptsParams) error
+	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error
+	UpdateOrganizerApplication(ctx context.Context, arg UpdateOrganizerApplicationParams) error
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateWalletBalance(ctx context.Context, arg UpdateWalletBalanceParams) error
+	UpsertAccountLockout(ctx context.Context, arg UpsertAccountLockoutParams) error
+	UpsertCalendarFeed(ctx context.Context, arg UpsertCalendarFeedParams) error
+	UpsertOrganizerFees(ctx context.Context, arg UpsertOrganizerFeesParams) (OrganizerFee, error)
+	UpsertUserProfile(ctx context.Context, arg UpsertUserProfileParams) error
+	UpsertUserTwoFactor(ctx context.Context, arg UpsertUserTwoFactorParams) error
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
+	UseUserEmailVerificationTokens(ctx context.Context, arg UseUserEmailVerificationTokensParams) error
+	UseUserPasswordResetTokens(ctx context.Context, arg UseUserPasswordResetTokensParams) error
 }
 
 var _ Querier = (*Queries)(nil)
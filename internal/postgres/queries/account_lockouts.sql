@@ -0,0 +1,16 @@
+-- name: DeleteAccountLockout :exec
+DELETE FROM account_lockouts
+WHERE user_id = $1;
+
+-- name: GetAccountLockoutForUpdate :one
+SELECT user_id, failed_attempts, last_failed_at, locked_until FROM account_lockouts
+WHERE user_id = $1
+FOR UPDATE;
+
+-- name: UpsertAccountLockout :exec
+INSERT INTO account_lockouts (user_id, failed_attempts, last_failed_at, locked_until)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id) DO UPDATE
+SET failed_attempts = EXCLUDED.failed_attempts,
+    last_failed_at = EXCLUDED.last_failed_at,
+    locked_until = EXCLUDED.locked_until;
@@ -0,0 +1,30 @@
+-- name: CreateAPIKey :exec
+INSERT INTO api_keys (id, organization_id, created_by, name, prefix, secret_hash, scopes, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: DeleteAPIKey :execrows
+DELETE FROM api_keys
+WHERE id = $1;
+
+-- name: GetAPIKey :one
+SELECT * FROM api_keys
+WHERE id = $1;
+
+-- name: GetAPIKeyByPrefix :one
+SELECT * FROM api_keys
+WHERE prefix = $1;
+
+-- name: ListOrganizationAPIKeys :many
+SELECT * FROM api_keys
+WHERE organization_id = $1
+ORDER BY created_at DESC;
+
+-- name: MarkAPIKeyUsed :exec
+UPDATE api_keys
+SET last_used_at = $2
+WHERE id = $1;
+
+-- name: UpdateAPIKey :execrows
+UPDATE api_keys
+SET name = $2, scopes = $3
+WHERE id = $1;
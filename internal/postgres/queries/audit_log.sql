@@ -0,0 +1,9 @@
+-- name: CreateAuditRecord :exec
+INSERT INTO audit_log (id, actor_id, action, target_type, target_id, details, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ListAuditRecords :many
+SELECT id, actor_id, action, target_type, target_id, details, created_at FROM audit_log
+WHERE target_type = $1 AND target_id = $2
+ORDER BY created_at DESC
+LIMIT $3;
@@ -0,0 +1,8 @@
+-- name: CreateBookingLineItem :exec
+INSERT INTO booking_line_items (booking_id, position, kind, amount, currency, rate_bps)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListBookingLineItems :many
+SELECT * FROM booking_line_items
+WHERE booking_id = $1
+ORDER BY position;
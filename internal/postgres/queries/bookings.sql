@@ -1,6 +1,6 @@
 -- name: CreateBooking :one
-INSERT INTO bookings (id, event_id, user_email, status, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO bookings (id, event_id, user_email, status, created_at, updated_at, price, currency, order_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING *;
 
 -- name: UpdateBooking :one
@@ -34,3 +34,23 @@ UPDATE bookings
 SET status = 'cancelled', updated_at = NOW()
 WHERE id = $1 AND status = 'pending'
 RETURNING *;
+
+-- name: ListBookingsByUserEmail :many
+SELECT * FROM bookings
+WHERE user_email = $1
+ORDER BY created_at ASC;
+
+-- name: ListBookingsByOrder :many
+SELECT * FROM bookings
+WHERE order_id = $1
+ORDER BY created_at ASC, id ASC;
+
+-- name: CountActiveBookingsByEvent :one
+SELECT COUNT(*) FROM bookings
+WHERE event_id = $1 AND status <> 'cancelled';
+
+-- name: RefundBooking :one
+UPDATE bookings
+SET status = 'cancelled', updated_at = NOW()
+WHERE id = $1 AND status = 'confirmed'
+RETURNING *;
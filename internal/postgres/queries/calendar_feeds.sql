@@ -0,0 +1,15 @@
+-- name: GetCalendarFeed :one
+SELECT * FROM calendar_feeds
+WHERE user_id = $1;
+
+-- name: CreateCalendarFeed :exec
+INSERT INTO calendar_feeds (user_id, secret, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO NOTHING;
+
+-- name: UpsertCalendarFeed :exec
+INSERT INTO calendar_feeds (user_id, secret, created_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret,
+    created_at = EXCLUDED.created_at;
@@ -0,0 +1,31 @@
+-- name: CreateDataExport :exec
+INSERT INTO data_exports (id, user_id, status, blob_key, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: DeleteDataExportsCompletedBefore :many
+DELETE FROM data_exports
+WHERE completed_at < $1
+RETURNING blob_key;
+
+-- name: DeleteUserDataExports :many
+DELETE FROM data_exports
+WHERE user_id = $1
+RETURNING blob_key;
+
+-- name: GetLatestDataExport :one
+SELECT * FROM data_exports
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListPendingDataExportsForUpdate :many
+SELECT * FROM data_exports
+WHERE status = 'pending'
+ORDER BY created_at
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: UpdateDataExport :execrows
+UPDATE data_exports
+SET status = $2, completed_at = $3
+WHERE id = $1;
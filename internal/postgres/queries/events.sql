@@ -1,11 +1,14 @@
 -- name: CreateEvent :one
-INSERT INTO events (id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+INSERT INTO events (id, name, price, start_at, end_at, created_at, updated_at, capacity, available_spots, sales_start_at, sales_end_at, currency, organizer_id, venue_country, organization_id, time_zone, revision)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
 RETURNING *;
 
 -- name: UpdateEvent :one
 UPDATE events
-SET name = $2, price = $3, start_at = $4, end_at = $5, updated_at = $6, capacity = $7
+SET name = $2, price = $3, start_at = $4, end_at = $5, updated_at = $6, capacity = $7,
+    sales_opened_emitted_at = CASE WHEN sales_start_at IS DISTINCT FROM $8 THEN NULL ELSE sales_opened_emitted_at END,
+    sales_closed_emitted_at = CASE WHEN sales_end_at IS DISTINCT FROM $9 THEN NULL ELSE sales_closed_emitted_at END,
+    sales_start_at = $8, sales_end_at = $9, currency = $10, venue_country = $11, time_zone = $12, revision = $13
 WHERE id = $1
 RETURNING *;
 
@@ -26,5 +29,53 @@ LIMIT $1 OFFSET $2;
 -- name: ReserveSpots :one
 UPDATE events
 SET available_spots = available_spots - $2
-WHERE id = $1 AND available_spots >= $2
+WHERE id = $1 AND available_spots >= $2 AND inventory_shards = 0
 RETURNING *;
+
+-- name: GetEventForUpdate :one
+SELECT * FROM events
+WHERE id = $1
+FOR UPDATE;
+
+-- name: SetInventoryShards :exec
+UPDATE events
+SET inventory_shards = $2, available_spots = $3, updated_at = NOW()
+WHERE id = $1;
+
+-- name: ListShardedEvents :many
+SELECT * FROM events
+WHERE inventory_shards > 0;
+
+-- name: SyncAvailableSpots :exec
+UPDATE events
+SET available_spots = $2
+WHERE id = $1;
+
+-- name: ListEventsDueForSalesOpen :many
+SELECT * FROM events
+WHERE sales_start_at <= $1 AND sales_opened_emitted_at IS NULL
+ORDER BY sales_start_at
+LIMIT $2
+FOR UPDATE SKIP LOCKED;
+
+-- name: ListEventsDueForSalesClose :many
+SELECT * FROM events
+WHERE sales_end_at <= $1 AND sales_closed_emitted_at IS NULL
+ORDER BY sales_end_at
+LIMIT $2
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkSalesOpenedEmitted :exec
+UPDATE events
+SET sales_opened_emitted_at = NOW()
+WHERE id = $1;
+
+-- name: MarkSalesClosedEmitted :exec
+UPDATE events
+SET sales_closed_emitted_at = NOW()
+WHERE id = $1;
+
+-- name: ReleaseSpots :execrows
+UPDATE events
+SET available_spots = available_spots + $2
+WHERE id = $1 AND inventory_shards = 0;
@@ -0,0 +1,14 @@
+-- name: GetOrganizerFees :one
+SELECT * FROM organizer_fees
+WHERE organizer_id = $1;
+
+-- name: UpsertOrganizerFees :one
+INSERT INTO organizer_fees (organizer_id, percent_bps, fixed_amount, updated_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (organizer_id) DO UPDATE
+SET percent_bps = EXCLUDED.percent_bps, fixed_amount = EXCLUDED.fixed_amount, updated_at = EXCLUDED.updated_at
+RETURNING *;
+
+-- name: GetVatRate :one
+SELECT rate_bps FROM vat_rates
+WHERE country = $1;
@@ -0,0 +1,29 @@
+-- name: CreateInventoryShard :exec
+INSERT INTO event_inventory_shards (event_id, shard, available_spots)
+VALUES ($1, $2, $3);
+
+-- name: DeleteInventoryShards :exec
+DELETE FROM event_inventory_shards
+WHERE event_id = $1;
+
+-- name: ReserveShardSpots :one
+UPDATE event_inventory_shards
+SET available_spots = available_spots - $3
+WHERE event_id = $1 AND shard = $2 AND available_spots >= $3
+RETURNING *;
+
+-- name: ListInventoryShards :many
+SELECT * FROM event_inventory_shards
+WHERE event_id = $1
+ORDER BY shard;
+
+-- name: ListInventoryShardsForUpdate :many
+SELECT * FROM event_inventory_shards
+WHERE event_id = $1
+ORDER BY shard
+FOR UPDATE;
+
+-- name: ReleaseShardSpots :exec
+UPDATE event_inventory_shards
+SET available_spots = available_spots + $3
+WHERE event_id = $1 AND shard = $2;
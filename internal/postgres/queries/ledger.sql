@@ -0,0 +1,87 @@
+-- name: CreateLedgerAccount :exec
+INSERT INTO ledger_accounts (owner_id, kind, currency)
+VALUES ($1, $2, $3)
+ON CONFLICT (owner_id, kind, currency) DO NOTHING;
+
+-- name: GetLedgerAccountID :one
+SELECT id FROM ledger_accounts
+WHERE owner_id = $1 AND kind = $2 AND currency = $3;
+
+-- name: CreateJournalEntry :one
+INSERT INTO journal_entries (id, kind, reference_id, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (kind, reference_id) DO NOTHING
+RETURNING *;
+
+-- name: CreateJournalLine :exec
+INSERT INTO journal_lines (entry_id, position, account_id, amount)
+VALUES ($1, $2, $3, $4);
+
+-- name: GetJournalEntryByReference :one
+SELECT * FROM journal_entries
+WHERE kind = $1 AND reference_id = $2;
+
+-- name: ListJournalLines :many
+SELECT l.position, l.amount, a.owner_id, a.kind, a.currency
+FROM journal_lines l
+JOIN ledger_accounts a ON a.id = l.account_id
+WHERE l.entry_id = $1
+ORDER BY l.position;
+
+-- name: GetLedgerAccountBalance :one
+SELECT COALESCE(SUM(amount), 0)::BIGINT AS balance FROM journal_lines
+WHERE account_id = $1;
+
+-- name: ListOrganizerBalances :many
+SELECT a.currency,
+    (-COALESCE(SUM(l.amount), 0))::BIGINT AS available,
+    (SELECT COALESCE(SUM(s.amount), 0) FROM settlements s
+        WHERE s.organizer_id = a.owner_id AND s.currency = a.currency)::BIGINT AS paid_out
+FROM ledger_accounts a
+LEFT JOIN journal_lines l ON l.account_id = a.id
+WHERE a.owner_id = $1 AND a.kind = 'organizer_payable'
+GROUP BY a.id, a.owner_id, a.currency
+ORDER BY a.currency;
+
+-- name: LockOrganizerPayableAccounts :many
+SELECT id, owner_id, currency FROM ledger_accounts
+WHERE kind = 'organizer_payable'
+ORDER BY id
+FOR UPDATE;
+
+-- name: CreateSettlement :one
+INSERT INTO settlements (id, organizer_id, amount, currency, journal_entry_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListSettlementsByOrganizer :many
+SELECT * FROM settlements
+WHERE organizer_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: ListUnbalancedJournalEntries :many
+SELECT l.entry_id, a.currency, SUM(l.amount)::BIGINT AS total
+FROM journal_lines l
+JOIN ledger_accounts a ON a.id = l.account_id
+GROUP BY l.entry_id, a.currency
+HAVING SUM(l.amount) <> 0;
+
+-- name: ListBookingLedgerMismatches :many
+SELECT id, status, currency, expected, posted FROM (
+    SELECT b.id, b.status, b.currency,
+        (CASE WHEN b.status = 'confirmed'
+            THEN COALESCE((SELECT SUM(i.amount) FROM booking_line_items i WHERE i.booking_id = b.id), b.price)
+            ELSE 0
+        END)::BIGINT AS expected,
+        (SELECT COALESCE(SUM(l.amount), 0)
+            FROM journal_entries e
+            JOIN journal_lines l ON l.entry_id = e.id
+            JOIN ledger_accounts a ON a.id = l.account_id
+            WHERE e.reference_id = b.id
+                AND e.kind IN ('booking_confirmed', 'booking_refunded')
+                AND a.kind = 'cash')::BIGINT AS posted
+    FROM bookings b
+) AS reconciliation
+WHERE expected <> posted
+ORDER BY id;
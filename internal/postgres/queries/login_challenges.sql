@@ -0,0 +1,21 @@
+-- name: CreateLoginChallenge :exec
+INSERT INTO login_challenges (token_hash, user_id, attempts, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: DeleteExpiredLoginChallenges :exec
+DELETE FROM login_challenges
+WHERE expires_at <= $1;
+
+-- name: DeleteLoginChallenge :exec
+DELETE FROM login_challenges
+WHERE token_hash = $1;
+
+-- name: GetLoginChallengeForUpdate :one
+SELECT token_hash, user_id, attempts, expires_at, created_at FROM login_challenges
+WHERE token_hash = $1
+FOR UPDATE;
+
+-- name: UpdateLoginChallengeAttempts :exec
+UPDATE login_challenges
+SET attempts = $2
+WHERE token_hash = $1;
@@ -0,0 +1,21 @@
+-- name: CreateLoginRecord :exec
+INSERT INTO login_history (id, user_id, ip_address, user_agent, outcome, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: DeleteUserLoginRecordsBefore :exec
+DELETE FROM login_history
+WHERE user_id = $1 AND created_at < $2;
+
+-- name: GetLoginFamiliarity :one
+SELECT
+    COUNT(*) > 0 AS has_history,
+    COALESCE(BOOL_OR(ip_address = sqlc.arg(ip_address)::text), false)::boolean AS known_ip,
+    COALESCE(BOOL_OR(user_agent = sqlc.arg(user_agent)::text), false)::boolean AS known_device
+FROM login_history
+WHERE user_id = sqlc.arg(user_id) AND outcome = 'success';
+
+-- name: ListUserLoginRecords :many
+SELECT id, user_id, ip_address, user_agent, outcome, created_at FROM login_history
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
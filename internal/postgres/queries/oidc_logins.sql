@@ -0,0 +1,12 @@
+-- name: CreateOIDCLogin :exec
+INSERT INTO oidc_logins (state_hash, provider, code_verifier, nonce, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: DeleteExpiredOIDCLogins :exec
+DELETE FROM oidc_logins
+WHERE expires_at <= $1;
+
+-- name: TakeOIDCLogin :one
+DELETE FROM oidc_logins
+WHERE state_hash = $1
+RETURNING state_hash, provider, code_verifier, nonce, expires_at, created_at;
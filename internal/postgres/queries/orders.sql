@@ -0,0 +1,25 @@
+-- name: CreateOrder :one
+INSERT INTO orders (id, event_id, purchaser_email, company_name, status, due_at, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetOrder :one
+SELECT * FROM orders
+WHERE id = $1;
+
+-- name: GetOrderForUpdate :one
+SELECT * FROM orders
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListOverdueOrders :many
+SELECT * FROM orders
+WHERE status = 'awaiting_invoice_payment' AND due_at <= $1
+ORDER BY due_at
+LIMIT $2
+FOR UPDATE SKIP LOCKED;
+
+-- name: UpdateOrderStatus :exec
+UPDATE orders
+SET status = $2, updated_at = $3
+WHERE id = $1;
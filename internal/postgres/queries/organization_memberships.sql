@@ -0,0 +1,24 @@
+-- name: CreateMembership :exec
+INSERT INTO organization_memberships (organization_id, user_id, role, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: DeleteMembership :execrows
+DELETE FROM organization_memberships
+WHERE organization_id = $1 AND user_id = $2;
+
+-- name: GetMembership :one
+SELECT m.organization_id, m.user_id, m.role, m.created_at, u.email FROM organization_memberships m
+JOIN users u ON u.id = m.user_id
+WHERE m.organization_id = $1 AND m.user_id = $2;
+
+-- name: ListOrganizationMembers :many
+SELECT m.organization_id, m.user_id, m.role, m.created_at, u.email FROM organization_memberships m
+JOIN users u ON u.id = m.user_id
+WHERE m.organization_id = $1
+ORDER BY m.created_at;
+
+-- name: ListUserMemberships :many
+SELECT m.organization_id, m.user_id, m.role, m.created_at, u.email FROM organization_memberships m
+JOIN users u ON u.id = m.user_id
+WHERE m.user_id = $1
+ORDER BY m.created_at;
@@ -0,0 +1,11 @@
+-- name: CreateOrganization :exec
+INSERT INTO organizations (id, owner_id, name, website, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetOrganization :one
+SELECT * FROM organizations
+WHERE id = $1;
+
+-- name: GetOrganizationByOwner :one
+SELECT * FROM organizations
+WHERE owner_id = $1;
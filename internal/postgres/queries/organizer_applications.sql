@@ -0,0 +1,25 @@
+-- name: CreateOrganizerApplication :exec
+INSERT INTO organizer_applications (id, user_id, organization_name, website, description, status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetLatestOrganizerApplication :one
+SELECT * FROM organizer_applications
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: GetOrganizerApplicationForUpdate :one
+SELECT * FROM organizer_applications
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListOrganizerApplications :many
+SELECT * FROM organizer_applications
+WHERE status = $1
+ORDER BY created_at
+LIMIT $2 OFFSET $3;
+
+-- name: UpdateOrganizerApplication :exec
+UPDATE organizer_applications
+SET status = $2, reviewer_id = $3, review_note = $4, reviewed_at = $5, organization_id = $6
+WHERE id = $1;
@@ -0,0 +1,13 @@
+-- name: CreatePasswordResetToken :exec
+INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetPasswordResetTokenForUpdate :one
+SELECT id, user_id, token_hash, expires_at, created_at, used_at FROM password_reset_tokens
+WHERE token_hash = $1
+FOR UPDATE;
+
+-- name: UseUserPasswordResetTokens :exec
+UPDATE password_reset_tokens
+SET used_at = $2
+WHERE user_id = $1 AND used_at IS NULL;
@@ -0,0 +1,13 @@
+-- name: CreatePresale :one
+INSERT INTO event_presales (id, event_id, name, start_at, end_at, access_code_hash, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListPresalesByEvent :many
+SELECT * FROM event_presales
+WHERE event_id = $1
+ORDER BY start_at;
+
+-- name: DeletePresale :execrows
+DELETE FROM event_presales
+WHERE id = $1 AND event_id = $2;
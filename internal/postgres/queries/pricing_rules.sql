@@ -0,0 +1,13 @@
+-- name: CreatePricingRule :one
+INSERT INTO event_pricing_rules (id, event_id, trigger_kind, threshold, adjustment_kind, adjustment, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: ListPricingRulesByEvent :many
+SELECT * FROM event_pricing_rules
+WHERE event_id = $1
+ORDER BY created_at, id;
+
+-- name: DeletePricingRule :execrows
+DELETE FROM event_pricing_rules
+WHERE id = $1 AND event_id = $2;
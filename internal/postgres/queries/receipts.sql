@@ -0,0 +1,15 @@
+-- name: NextInvoiceSequence :one
+INSERT INTO invoice_sequences (organizer_id, last_number)
+VALUES ($1, 1)
+ON CONFLICT (organizer_id) DO UPDATE
+SET last_number = invoice_sequences.last_number + 1
+RETURNING last_number;
+
+-- name: CreateReceipt :one
+INSERT INTO receipts (booking_id, organizer_id, sequence, invoice_number, blob_key, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetReceiptByBookingID :one
+SELECT * FROM receipts
+WHERE booking_id = $1;
@@ -0,0 +1,29 @@
+-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, access_token_id, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetRefreshTokenForUpdate :one
+SELECT * FROM refresh_tokens
+WHERE token_hash = $1
+FOR UPDATE;
+
+-- name: ListUserAccessTokenIDs :many
+SELECT access_token_id FROM refresh_tokens
+WHERE user_id = $1 AND revoked_at IS NULL AND created_at >= $2;
+
+-- name: MarkRefreshTokenUsed :exec
+UPDATE refresh_tokens
+SET used_at = $2
+WHERE id = $1;
+
+-- name: RevokeRefreshTokenFamily :many
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE family_id = $1 AND revoked_at IS NULL
+RETURNING access_token_id, created_at;
+
+-- name: RevokeUserRefreshTokens :many
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE user_id = $1 AND revoked_at IS NULL
+RETURNING access_token_id, created_at;
@@ -0,0 +1,20 @@
+-- name: CreateSigningKey :exec
+INSERT INTO signing_keys (kid, algorithm, private_key, created_at, activates_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: DeleteRetiredSigningKeys :execrows
+DELETE FROM signing_keys
+WHERE retires_at <= $1;
+
+-- name: ListSigningKeys :many
+SELECT kid, algorithm, private_key, created_at, activates_at, retires_at FROM signing_keys
+WHERE retires_at IS NULL OR retires_at > $1
+ORDER BY activates_at DESC;
+
+-- name: LockSigningKeys :exec
+SELECT pg_advisory_xact_lock(hashtext('signing_keys'));
+
+-- name: RetireSigningKeys :exec
+UPDATE signing_keys
+SET retires_at = $2
+WHERE kid <> $1 AND retires_at IS NULL;
@@ -0,0 +1,16 @@
+-- name: CountUnusedRecoveryCodes :one
+SELECT COUNT(*) FROM two_factor_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO two_factor_recovery_codes (id, user_id, code_hash, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: DeleteUserRecoveryCodes :exec
+DELETE FROM two_factor_recovery_codes
+WHERE user_id = $1;
+
+-- name: UseRecoveryCode :execrows
+UPDATE two_factor_recovery_codes
+SET used_at = $3
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
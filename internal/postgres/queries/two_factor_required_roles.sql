@@ -0,0 +1,10 @@
+-- name: CreateTwoFactorRequiredRole :exec
+INSERT INTO two_factor_required_roles (role, required_by, required_at)
+VALUES ($1, $2, $3);
+
+-- name: DeleteTwoFactorRequiredRoles :exec
+DELETE FROM two_factor_required_roles;
+
+-- name: ListTwoFactorRequiredRoles :many
+SELECT role FROM two_factor_required_roles
+ORDER BY role;
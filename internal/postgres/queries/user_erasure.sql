@@ -0,0 +1,40 @@
+-- name: AnonymizeUserBookings :exec
+UPDATE bookings
+SET user_email = sqlc.arg('anonymized_email'), updated_at = NOW()
+WHERE user_email = sqlc.arg('email');
+
+-- name: AnonymizeUserOrders :exec
+UPDATE orders
+SET purchaser_email = sqlc.arg('anonymized_email'), updated_at = NOW()
+WHERE purchaser_email = sqlc.arg('email');
+
+-- name: DeleteUserCredentials :exec
+WITH refresh_tokens_deleted AS (
+    DELETE FROM refresh_tokens WHERE refresh_tokens.user_id = $1
+), password_resets_deleted AS (
+    DELETE FROM password_reset_tokens WHERE password_reset_tokens.user_id = $1
+), verifications_deleted AS (
+    DELETE FROM email_verification_tokens WHERE email_verification_tokens.user_id = $1
+), identities_deleted AS (
+    DELETE FROM user_identities WHERE user_identities.user_id = $1
+), two_factor_deleted AS (
+    DELETE FROM user_two_factor WHERE user_two_factor.user_id = $1
+), recovery_codes_deleted AS (
+    DELETE FROM two_factor_recovery_codes WHERE two_factor_recovery_codes.user_id = $1
+), challenges_deleted AS (
+    DELETE FROM login_challenges WHERE login_challenges.user_id = $1
+), lockouts_deleted AS (
+    DELETE FROM account_lockouts WHERE account_lockouts.user_id = $1
+), memberships_deleted AS (
+    DELETE FROM organization_memberships WHERE organization_memberships.user_id = $1
+), calendar_feeds_deleted AS (
+    DELETE FROM calendar_feeds WHERE calendar_feeds.user_id = $1
+), profiles_deleted AS (
+    DELETE FROM user_profiles WHERE user_profiles.user_id = $1
+), applications_deleted AS (
+    DELETE FROM organizer_applications WHERE organizer_applications.user_id = $1
+), api_keys_deleted AS (
+    DELETE FROM api_keys WHERE api_keys.created_by = $1
+)
+DELETE FROM login_history
+WHERE login_history.user_id = $1;
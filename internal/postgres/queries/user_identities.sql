@@ -0,0 +1,7 @@
+-- name: CreateUserIdentity :exec
+INSERT INTO user_identities (id, user_id, provider, subject, email, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetUserIdentity :one
+SELECT id, user_id, provider, subject, email, created_at FROM user_identities
+WHERE provider = $1 AND subject = $2;
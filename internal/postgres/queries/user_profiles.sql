@@ -0,0 +1,14 @@
+-- name: GetUserProfile :one
+SELECT * FROM user_profiles
+WHERE user_id = $1;
+
+-- name: UpsertUserProfile :exec
+INSERT INTO user_profiles (user_id, display_name, phone, locale, time_zone, notification_preferences, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (user_id) DO UPDATE
+SET display_name = EXCLUDED.display_name,
+    phone = EXCLUDED.phone,
+    locale = EXCLUDED.locale,
+    time_zone = EXCLUDED.time_zone,
+    notification_preferences = EXCLUDED.notification_preferences,
+    updated_at = EXCLUDED.updated_at;
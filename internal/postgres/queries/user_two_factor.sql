@@ -0,0 +1,15 @@
+-- name: DeleteUserTwoFactor :exec
+DELETE FROM user_two_factor
+WHERE user_id = $1;
+
+-- name: GetUserTwoFactorForUpdate :one
+SELECT user_id, secret, enabled_at, last_used_step, created_at FROM user_two_factor
+WHERE user_id = $1
+FOR UPDATE;
+
+-- name: UpsertUserTwoFactor :exec
+INSERT INTO user_two_factor (user_id, secret, enabled_at, last_used_step, created_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, enabled_at = EXCLUDED.enabled_at, last_used_step = EXCLUDED.last_used_step,
+    created_at = EXCLUDED.created_at;
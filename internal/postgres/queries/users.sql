@@ -1,17 +1,21 @@
 -- name: CreateUser :one
-INSERT INTO users (id, email, password_hash,role, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO users (id, email, password_hash,role, created_at, updated_at, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: GetUserByEmail :one
 SELECT * FROM users WHERE email = $1;
 
 -- name: ListUsers :many
-SELECT * FROM users;
+SELECT * FROM users
+WHERE (sqlc.narg('email')::text IS NULL OR email ILIKE '%' || sqlc.narg('email')::text || '%')
+  AND (sqlc.narg('role')::user_role IS NULL OR role = sqlc.narg('role')::user_role)
+ORDER BY created_at DESC, id
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: UpdateUser :one
 UPDATE users
-SET email = $2, password_hash = $3, role = $4, updated_at = $5
+SET email = $2, password_hash = $3, role = $4, updated_at = $5, status = $6, suspended_at = $7
 WHERE id = $1
 RETURNING *;
 
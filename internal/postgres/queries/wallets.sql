@@ -0,0 +1,43 @@
+-- name: CreateGiftCard :exec
+INSERT INTO gift_cards (id, code_hash, amount, currency, issued_by, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: CreateWallet :exec
+INSERT INTO wallets (user_id, currency)
+VALUES ($1, $2)
+ON CONFLICT (user_id, currency) DO NOTHING;
+
+-- name: CreateWalletTransaction :exec
+INSERT INTO wallet_transactions (id, user_id, currency, kind, reference_id, amount, balance_after, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetGiftCardForUpdate :one
+SELECT * FROM gift_cards
+WHERE code_hash = $1
+FOR UPDATE;
+
+-- name: GetWalletForUpdate :one
+SELECT * FROM wallets
+WHERE user_id = $1 AND currency = $2
+FOR UPDATE;
+
+-- name: ListWalletTransactions :many
+SELECT * FROM wallet_transactions
+WHERE user_id = $1
+ORDER BY created_at DESC, id
+LIMIT $2;
+
+-- name: ListWallets :many
+SELECT * FROM wallets
+WHERE user_id = $1
+ORDER BY currency;
+
+-- name: RedeemGiftCard :exec
+UPDATE gift_cards
+SET redeemed_by = $2, redeemed_at = $3
+WHERE id = $1;
+
+-- name: UpdateWalletBalance :exec
+UPDATE wallets
+SET balance = $3, updated_at = $4
+WHERE user_id = $1 AND currency = $2;
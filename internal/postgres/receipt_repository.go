@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// ReceiptRepository implements the ReceiptRepository interface using PostgreSQL.
+type ReceiptRepository struct {
+	queries *Queries
+}
+
+// NewReceiptRepository creates a new ReceiptRepository.
+func NewReceiptRepository(queries *Queries) *ReceiptRepository {
+	return &ReceiptRepository{queries: queries}
+}
+
+func (r *ReceiptRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// NextInvoiceSequence increments and returns the organizer's invoice counter.
+// The counter row stays locked until the surrounding transaction ends.
+func (r *ReceiptRepository) NextInvoiceSequence(ctx context.Context, organizerID uuid.UUID) (int64, error) {
+	return r.getQueries(ctx).NextInvoiceSequence(ctx, pgtype.UUID{Bytes: organizerID, Valid: true})
+}
+
+// CreateReceipt stores a receipt.
+func (r *ReceiptRepository) CreateReceipt(ctx context.Context, receipt *domain.Receipt) error {
+	_, err := r.getQueries(ctx).CreateReceipt(ctx, CreateReceiptParams{
+		BookingID:     pgtype.UUID{Bytes: receipt.BookingID(), Valid: true},
+		OrganizerID:   pgtype.UUID{Bytes: receipt.OrganizerID(), Valid: true},
+		Sequence:      receipt.Sequence(),
+		InvoiceNumber: receipt.InvoiceNumber(),
+		BlobKey:       receipt.BlobKey(),
+		CreatedAt:     pgtype.Timestamptz{Time: receipt.CreatedAt(), Valid: true},
+	})
+	return err
+}
+
+// GetReceipt returns the receipt issued for a booking.
+func (r *ReceiptRepository) GetReceipt(ctx context.Context, bookingID uuid.UUID) (*domain.Receipt, error) {
+	row, err := r.getQueries(ctx).GetReceiptByBookingID(ctx, pgtype.UUID{Bytes: bookingID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrReceiptNotFound
+		}
+		return nil, err
+	}
+	return domain.UnmarshalReceipt(
+		uuid.UUID(row.BookingID.Bytes),
+		uuid.UUID(row.OrganizerID.Bytes),
+		row.Sequence,
+		row.InvoiceNumber,
+		row.BlobKey,
+		row.CreatedAt.Time,
+	), nil
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// RefreshTokenRepository implements the RefreshTokenRepository interface using PostgreSQL.
+type RefreshTokenRepository struct {
+	queries *Queries
+}
+
+// NewRefreshTokenRepository creates a new RefreshTokenRepository.
+func NewRefreshTokenRepository(queries *Queries) *RefreshTokenRepository {
+	return &RefreshTokenRepository{queries: queries}
+}
+
+func (r *RefreshTokenRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// CreateRefreshToken stores the hash of a new refresh token.
+func (r *RefreshTokenRepository) CreateRefreshToken(ctx context.Context, token *domain.RefreshToken) error {
+	return r.getQueries(ctx).CreateRefreshToken(ctx, CreateRefreshTokenParams{
+		ID:            pgtype.UUID{Bytes: token.ID(), Valid: true},
+		UserID:        pgtype.UUID{Bytes: token.UserID(), Valid: true},
+		FamilyID:      pgtype.UUID{Bytes: token.FamilyID(), Valid: true},
+		TokenHash:     token.TokenHash(),
+		AccessTokenID: pgtype.UUID{Bytes: token.AccessTokenID(), Valid: true},
+		ExpiresAt:     pgtype.Timestamptz{Time: token.ExpiresAt(), Valid: true},
+		CreatedAt:     pgtype.Timestamptz{Time: token.CreatedAt(), Valid: true},
+	})
+}
+
+// GetRefreshTokenForUpdate returns the token matching a secret and locks it,
+// so concurrent refreshes with one token rotate it once.
+func (r *RefreshTokenRepository) GetRefreshTokenForUpdate(
+	ctx context.Context,
+	secret string,
+) (*domain.RefreshToken, error) {
+	row, err := r.getQueries(ctx).GetRefreshTokenForUpdate(ctx, domain.HashToken(secret))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRefreshTokenInvalid
+		}
+		return nil, err
+	}
+	return domain.UnmarshalRefreshToken(
+		uuid.UUID(row.ID.Bytes),
+		uuid.UUID(row.UserID.Bytes),
+		uuid.UUID(row.FamilyID.Bytes),
+		row.TokenHash,
+		uuid.UUID(row.AccessTokenID.Bytes),
+		row.ExpiresAt.Time,
+		row.CreatedAt.Time,
+		row.UsedAt.Time,
+		row.RevokedAt.Time,
+	), nil
+}
+
+// MarkRefreshTokenUsed records that a token was exchanged.
+func (r *RefreshTokenRepository) MarkRefreshTokenUsed(ctx context.Context, token *domain.RefreshToken) error {
+	return r.getQueries(ctx).MarkRefreshTokenUsed(ctx, MarkRefreshTokenUsedParams{
+		ID:     pgtype.UUID{Bytes: token.ID(), Valid: true},
+		UsedAt: pgtype.Timestamptz{Time: token.UsedAt(), Valid: true},
+	})
+}
+
+// RevokeFamily revokes the live tokens of a session.
+func (r *RefreshTokenRepository) RevokeFamily(
+	ctx context.Context,
+	familyID uuid.UUID,
+	now time.Time,
+	issuedSince time.Time,
+) ([]uuid.UUID, error) {
+	rows, err := r.getQueries(ctx).RevokeRefreshTokenFamily(ctx, RevokeRefreshTokenFamilyParams{
+		FamilyID:  pgtype.UUID{Bytes: familyID, Valid: true},
+		RevokedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var ids []uuid.UUID
+	for _, row := range rows {
+		if !row.CreatedAt.Time.Before(issuedSince) {
+			ids = append(ids, uuid.UUID(row.AccessTokenID.Bytes))
+		}
+	}
+	return ids, nil
+}
+
+// RevokeUserTokens revokes the live tokens of every session of a user.
+func (r *RefreshTokenRepository) RevokeUserTokens(
+	ctx context.Context,
+	userID uuid.UUID,
+	now time.Time,
+	issuedSince time.Time,
+) ([]uuid.UUID, error) {
+	rows, err := r.getQueries(ctx).RevokeUserRefreshTokens(ctx, RevokeUserRefreshTokensParams{
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		RevokedAt: pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	var ids []uuid.UUID
+	for _, row := range rows {
+		if !row.CreatedAt.Time.Before(issuedSince) {
+			ids = append(ids, uuid.UUID(row.AccessTokenID.Bytes))
+		}
+	}
+	return ids, nil
+}
+
+// ListUserAccessTokens returns the access tokens issued to the live sessions
+// of a user since issuedSince.
+func (r *RefreshTokenRepository) ListUserAccessTokens(
+	ctx context.Context,
+	userID uuid.UUID,
+	issuedSince time.Time,
+) ([]uuid.UUID, error) {
+	rows, err := r.getQueries(ctx).ListUserAccessTokenIDs(ctx, ListUserAccessTokenIDsParams{
+		UserID:    pgtype.UUID{Bytes: userID, Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: issuedSince, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = uuid.UUID(row.Bytes)
+	}
+	return ids, nil
+}
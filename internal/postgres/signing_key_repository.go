@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// SigningKeyRepository implements the SigningKeyRepository interface using PostgreSQL.
+type SigningKeyRepository struct {
+	queries *Queries
+}
+
+// NewSigningKeyRepository creates a new SigningKeyRepository.
+func NewSigningKeyRepository(queries *Queries) *SigningKeyRepository {
+	return &SigningKeyRepository{queries: queries}
+}
+
+func (r *SigningKeyRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// LockSigningKeys takes a transaction-scoped advisory lock, as an empty table
+// has no rows to lock.
+func (r *SigningKeyRepository) LockSigningKeys(ctx context.Context) error {
+	return r.getQueries(ctx).LockSigningKeys(ctx)
+}
+
+func (r *SigningKeyRepository) ListSigningKeys(ctx context.Context, now time.Time) ([]*domain.SigningKey, error) {
+	rows, err := r.getQueries(ctx).ListSigningKeys(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*domain.SigningKey, len(rows))
+	for i, row := range rows {
+		keys[i] = domain.UnmarshalSigningKey(
+			row.Kid,
+			domain.SigningAlgorithm(row.Algorithm),
+			row.PrivateKey,
+			row.CreatedAt.Time,
+			row.ActivatesAt.Time,
+			row.RetiresAt.Time,
+		)
+	}
+	return keys, nil
+}
+
+func (r *SigningKeyRepository) CreateSigningKey(ctx context.Context, key *domain.SigningKey) error {
+	return r.getQueries(ctx).CreateSigningKey(ctx, CreateSigningKeyParams{
+		Kid:         key.ID(),
+		Algorithm:   string(key.Algorithm()),
+		PrivateKey:  key.PrivateKey(),
+		CreatedAt:   pgtype.Timestamptz{Time: key.CreatedAt(), Valid: true},
+		ActivatesAt: pgtype.Timestamptz{Time: key.ActivatesAt(), Valid: true},
+	})
+}
+
+func (r *SigningKeyRepository) RetireSigningKeys(ctx context.Context, keepID string, retiresAt time.Time) error {
+	return r.getQueries(ctx).RetireSigningKeys(ctx, RetireSigningKeysParams{
+		Kid:       keepID,
+		RetiresAt: pgtype.Timestamptz{Time: retiresAt, Valid: true},
+	})
+}
+
+// DeleteRetiredSigningKeys removes keys that no longer verify any token.
+func (r *SigningKeyRepository) DeleteRetiredSigningKeys(ctx context.Context, now time.Time) (int64, error) {
+	return r.getQueries(ctx).DeleteRetiredSigningKeys(ctx, pgtype.Timestamptz{Time: now, Valid: true})
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
-func SetupDb(ctx context.Context, t *testing.T) *pgxpool.Pool {
+func SetupDb(ctx context.Context, t testing.TB) *pgxpool.Pool {
 	t.Helper()
 
 	container, err := postgres.Run(ctx,
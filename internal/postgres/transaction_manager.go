@@ -7,6 +7,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mati/go-ticket/internal/domain"
 )
 
 type PgxTxManager struct {
@@ -19,8 +20,20 @@ func NewPgxTxManager(pool *pgxpool.Pool) *PgxTxManager {
 
 type txKey struct{}
 
+// RunInTx runs fn in a transaction. When ctx already carries one, fn joins
+// it, so services can compose each other's transactional operations. New
+// transactions read committed data, or one snapshot when ctx asks for
+// repeatable read with domain.WithRepeatableRead.
 func (t *PgxTxManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
-	tx, err := t.pool.Begin(ctx)
+	if ExtractTx(ctx) != nil {
+		return fn(ctx)
+	}
+
+	var options pgx.TxOptions
+	if domain.IsRepeatableRead(ctx) {
+		options.IsoLevel = pgx.RepeatableRead
+	}
+	tx, err := t.pool.BeginTx(ctx, options)
 
 	if err != nil {
 		return err
@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// TwoFactorRepository implements the TwoFactorRepository interface using PostgreSQL.
+type TwoFactorRepository struct {
+	queries *Queries
+}
+
+// NewTwoFactorRepository creates a new TwoFactorRepository.
+func NewTwoFactorRepository(queries *Queries) *TwoFactorRepository {
+	return &TwoFactorRepository{queries: queries}
+}
+
+func (r *TwoFactorRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// GetTwoFactorForUpdate returns the authenticator of a user and locks it, so
+// concurrent sign-ins accept a code once.
+func (r *TwoFactorRepository) GetTwoFactorForUpdate(ctx context.Context, userID uuid.UUID) (*domain.TwoFactor, error) {
+	row, err := r.getQueries(ctx).GetUserTwoFactorForUpdate(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTwoFactorNotEnrolled
+		}
+		return nil, err
+	}
+	return domain.UnmarshalTwoFactor(
+		uuid.UUID(row.UserID.Bytes),
+		row.Secret,
+		row.EnabledAt.Time,
+		row.LastUsedStep,
+		row.CreatedAt.Time,
+	), nil
+}
+
+// SaveTwoFactor stores an authenticator, replacing the user's previous one.
+func (r *TwoFactorRepository) SaveTwoFactor(ctx context.Context, twoFactor *domain.TwoFactor) error {
+	return r.getQueries(ctx).UpsertUserTwoFactor(ctx, UpsertUserTwoFactorParams{
+		UserID:       pgtype.UUID{Bytes: twoFactor.UserID(), Valid: true},
+		Secret:       twoFactor.Secret(),
+		EnabledAt:    pgtype.Timestamptz{Time: twoFactor.EnabledAt(), Valid: twoFactor.IsEnabled()},
+		LastUsedStep: twoFactor.LastUsedStep(),
+		CreatedAt:    pgtype.Timestamptz{Time: twoFactor.CreatedAt(), Valid: true},
+	})
+}
+
+// DeleteTwoFactor removes the authenticator and recovery codes of a user.
+func (r *TwoFactorRepository) DeleteTwoFactor(ctx context.Context, userID uuid.UUID) error {
+	queries := r.getQueries(ctx)
+	id := pgtype.UUID{Bytes: userID, Valid: true}
+	if err := queries.DeleteUserRecoveryCodes(ctx, id); err != nil {
+		return err
+	}
+	return queries.DeleteUserTwoFactor(ctx, id)
+}
+
+// ReplaceRecoveryCodes deletes the recovery codes of a user and stores new
+// ones.
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(
+	ctx context.Context,
+	userID uuid.UUID,
+	codes []*domain.RecoveryCode,
+) error {
+	queries := r.getQueries(ctx)
+	if err := queries.DeleteUserRecoveryCodes(ctx, pgtype.UUID{Bytes: userID, Valid: true}); err != nil {
+		return err
+	}
+	for _, code := range codes {
+		err := queries.CreateRecoveryCode(ctx, CreateRecoveryCodeParams{
+			ID:        pgtype.UUID{Bytes: code.ID(), Valid: true},
+			UserID:    pgtype.UUID{Bytes: code.UserID(), Valid: true},
+			CodeHash:  code.CodeHash(),
+			CreatedAt: pgtype.Timestamptz{Time: code.CreatedAt(), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code of a user used.
+func (r *TwoFactorRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, code string, now time.Time) error {
+	used, err := r.getQueries(ctx).UseRecoveryCode(ctx, UseRecoveryCodeParams{
+		UserID:   pgtype.UUID{Bytes: userID, Valid: true},
+		CodeHash: domain.HashRecoveryCode(code),
+		UsedAt:   pgtype.Timestamptz{Time: now, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if used == 0 {
+		return domain.ErrTwoFactorCodeInvalid
+	}
+	return nil
+}
+
+// CountRecoveryCodes returns how many recovery codes of a user are unused.
+func (r *TwoFactorRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := r.getQueries(ctx).CountUnusedRecoveryCodes(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	return int(count), err
+}
+
+// CreateLoginChallenge stores a new challenge. Challenges never completed
+// are deleted along the way.
+func (r *TwoFactorRepository) CreateLoginChallenge(ctx context.Context, challenge *domain.LoginChallenge) error {
+	queries := r.getQueries(ctx)
+	now := pgtype.Timestamptz{Time: challenge.CreatedAt(), Valid: true}
+	if err := queries.DeleteExpiredLoginChallenges(ctx, now); err != nil {
+		return err
+	}
+	return queries.CreateLoginChallenge(ctx, CreateLoginChallengeParams{
+		TokenHash: challenge.TokenHash(),
+		UserID:    pgtype.UUID{Bytes: challenge.UserID(), Valid: true},
+		Attempts:  int32(challenge.Attempts()), //nolint:gosec // G115: attempts are capped
+		ExpiresAt: pgtype.Timestamptz{Time: challenge.ExpiresAt(), Valid: true},
+		CreatedAt: now,
+	})
+}
+
+// GetLoginChallengeForUpdate returns the challenge matching a token and
+// locks it, so concurrent attempts are counted one by one.
+func (r *TwoFactorRepository) GetLoginChallengeForUpdate(
+	ctx context.Context,
+	token string,
+) (*domain.LoginChallenge, error) {
+	row, err := r.getQueries(ctx).GetLoginChallengeForUpdate(ctx, domain.HashToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrLoginChallengeInvalid
+		}
+		return nil, err
+	}
+	return domain.UnmarshalLoginChallenge(
+		row.TokenHash,
+		uuid.UUID(row.UserID.Bytes),
+		int(row.Attempts),
+		row.ExpiresAt.Time,
+		row.CreatedAt.Time,
+	), nil
+}
+
+// UpdateLoginChallenge stores the attempts of a challenge.
+func (r *TwoFactorRepository) UpdateLoginChallenge(ctx context.Context, challenge *domain.LoginChallenge) error {
+	return r.getQueries(ctx).UpdateLoginChallengeAttempts(ctx, UpdateLoginChallengeAttemptsParams{
+		TokenHash: challenge.TokenHash(),
+		Attempts:  int32(challenge.Attempts()), //nolint:gosec // G115: attempts are capped
+	})
+}
+
+// DeleteLoginChallenge removes a completed challenge.
+func (r *TwoFactorRepository) DeleteLoginChallenge(ctx context.Context, challenge *domain.LoginChallenge) error {
+	return r.getQueries(ctx).DeleteLoginChallenge(ctx, challenge.TokenHash())
+}
+
+// ListTwoFactorRequiredRoles returns the roles required to use two-factor
+// authentication.
+func (r *TwoFactorRepository) ListTwoFactorRequiredRoles(ctx context.Context) ([]domain.UserRole, error) {
+	rows, err := r.getQueries(ctx).ListTwoFactorRequiredRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]domain.UserRole, 0, len(rows))
+	for _, role := range rows {
+		roles = append(roles, domain.UserRole(role))
+	}
+	return roles, nil
+}
+
+// SetTwoFactorRequiredRoles replaces the roles required to use two-factor
+// authentication. Call it in a transaction.
+func (r *TwoFactorRepository) SetTwoFactorRequiredRoles(
+	ctx context.Context,
+	roles []domain.UserRole,
+	actorID uuid.UUID,
+	now time.Time,
+) error {
+	queries := r.getQueries(ctx)
+	if err := queries.DeleteTwoFactorRequiredRoles(ctx); err != nil {
+		return err
+	}
+	for _, role := range roles {
+		err := queries.CreateTwoFactorRequiredRole(ctx, CreateTwoFactorRequiredRoleParams{
+			Role:       UserRole(role),
+			RequiredBy: pgtype.UUID{Bytes: actorID, Valid: true},
+			RequiredAt: pgtype.Timestamptz{Time: now, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
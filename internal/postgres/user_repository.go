@@ -23,6 +23,14 @@ func NewUserRepository(queries *Queries) *UserRepository {
 	}
 }
 
+func (ur *UserRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return ur.Queries.WithTx(tx)
+	}
+	return ur.Queries
+}
+
 func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User) error {
 	params := CreateUserParams{
 		ID:           pgtype.UUID{Bytes: user.ID(), Valid: true},
@@ -31,9 +39,10 @@ func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User) err
 		Role:         UserRole(user.Role()),
 		CreatedAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
 		UpdatedAt:    pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		Status:       string(user.Status()),
 	}
 
-	_, err := ur.Queries.CreateUser(ctx, params)
+	_, err := ur.getQueries(ctx).CreateUser(ctx, params)
 	if err != nil {
 		if strings.Contains(err.Error(), "duplicate key") || strings.Contains(err.Error(), "unique constraint") {
 			return domain.ErrUserEmailAlreadyExists
@@ -45,7 +54,7 @@ func (ur *UserRepository) CreateUser(ctx context.Context, user *domain.User) err
 }
 
 func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*domain.User, error) {
-	user, err := ur.Queries.GetUserByEmail(ctx, email)
+	user, err := ur.getQueries(ctx).GetUserByEmail(ctx, email)
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -54,18 +63,11 @@ func (ur *UserRepository) GetUserByEmail(ctx context.Context, email string) (*do
 		return nil, fmt.Errorf("failed to get user by email from database: %w", err)
 	}
 
-	return domain.NewUserFromPersistence(
-		user.ID.Bytes,
-		user.Email,
-		user.PasswordHash,
-		domain.UserRole(user.Role),
-		user.CreatedAt.Time,
-		user.UpdatedAt.Time,
-	)
+	return userFromRow(user)
 }
 
 func (ur *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
-	user, err := ur.Queries.GetUserByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
+	user, err := ur.getQueries(ctx).GetUserByID(ctx, pgtype.UUID{Bytes: id, Valid: true})
 
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -74,12 +76,61 @@ func (ur *UserRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*domai
 		return nil, fmt.Errorf("failed to get user by ID from database: %w", err)
 	}
 
+	return userFromRow(user)
+}
+
+// UpdateUser stores the email, password hash, role, status and suspension
+// of a user.
+func (ur *UserRepository) UpdateUser(ctx context.Context, user *domain.User) error {
+	_, err := ur.getQueries(ctx).UpdateUser(ctx, UpdateUserParams{
+		ID:           pgtype.UUID{Bytes: user.ID(), Valid: true},
+		Email:        user.Email(),
+		PasswordHash: user.PasswordHash(),
+		Role:         UserRole(user.Role()),
+		UpdatedAt:    pgtype.Timestamptz{Time: user.UpdatedAt(), Valid: true},
+		Status:       string(user.Status()),
+		SuspendedAt:  pgtype.Timestamptz{Time: user.SuspendedAt(), Valid: user.IsSuspended()},
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrUserNotFound
+		}
+		return fmt.Errorf("failed to update user in database: %w", err)
+	}
+	return nil
+}
+
+// ListUsers returns a page of users matching the filter, newest first.
+func (ur *UserRepository) ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	rows, err := ur.getQueries(ctx).ListUsers(ctx, ListUsersParams{
+		Email:  pgtype.Text{String: filter.Email, Valid: filter.Email != ""},
+		Role:   NullUserRole{UserRole: UserRole(filter.Role), Valid: filter.Role != ""},
+		Limit:  int32(filter.Limit),  //nolint:gosec // G115: integer overflow conversion int -> int32
+		Offset: int32(filter.Offset), //nolint:gosec // G115: integer overflow conversion int -> int32
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users from database: %w", err)
+	}
+	users := make([]*domain.User, 0, len(rows))
+	for _, row := range rows {
+		user, err := userFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func userFromRow(row User) (*domain.User, error) {
 	return domain.NewUserFromPersistence(
-		user.ID.Bytes,
-		user.Email,
-		user.PasswordHash,
-		domain.UserRole(user.Role),
-		user.CreatedAt.Time,
-		user.UpdatedAt.Time,
+		row.ID.Bytes,
+		row.Email,
+		row.PasswordHash,
+		domain.UserRole(row.Role),
+		domain.UserStatus(row.Status),
+		row.CreatedAt.Time,
+		row.UpdatedAt.Time,
+		row.SuspendedAt.Time,
 	)
 }
@@ -12,9 +12,9 @@ import (
 )
 
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (id, email, password_hash,role, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, email, password_hash, role, created_at, updated_at
+INSERT INTO users (id, email, password_hash,role, created_at, updated_at, status)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, email, password_hash, role, created_at, updated_at, status, suspended_at
 `
 
 type CreateUserParams struct {
@@ -24,6 +24,7 @@ type CreateUserParams struct {
 	Role         UserRole           `json:"role"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	Status       string             `json:"status"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
@@ -34,6 +35,7 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		arg.Role,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.Status,
 	)
 	var i User
 	err := row.Scan(
@@ -43,6 +45,8 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.SuspendedAt,
 	)
 	return i, err
 }
@@ -57,7 +61,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password_hash, role, created_at, updated_at FROM users WHERE email = $1
+SELECT id, email, password_hash, role, created_at, updated_at, status, suspended_at FROM users WHERE email = $1
 `
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
@@ -70,12 +74,14 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.SuspendedAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, password_hash, role, created_at, updated_at FROM users WHERE id = $1
+SELECT id, email, password_hash, role, created_at, updated_at, status, suspended_at FROM users WHERE id = $1
 `
 
 func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error) {
@@ -88,16 +94,34 @@ func (q *Queries) GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.SuspendedAt,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, password_hash, role, created_at, updated_at FROM users
+SELECT id, email, password_hash, role, created_at, updated_at, status, suspended_at FROM users
+WHERE ($1::text IS NULL OR email ILIKE '%' || $1::text || '%')
+  AND ($2::user_role IS NULL OR role = $2::user_role)
+ORDER BY created_at DESC, id
+LIMIT $3 OFFSET $4
 `
 
-func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
-	rows, err := q.db.Query(ctx, listUsers)
+type ListUsersParams struct {
+	Email  pgtype.Text  `json:"email"`
+	Role   NullUserRole `json:"role"`
+	Limit  int32        `json:"limit"`
+	Offset int32        `json:"offset"`
+}
+
+func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error) {
+	rows, err := q.db.Query(ctx, listUsers,
+		arg.Email,
+		arg.Role,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -112,6 +136,8 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 			&i.Role,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Status,
+			&i.SuspendedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -125,9 +151,9 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 
 const updateUser = `-- name: UpdateUser :one
 UPDATE users
-SET email = $2, password_hash = $3, role = $4, updated_at = $5
+SET email = $2, password_hash = $3, role = $4, updated_at = $5, status = $6, suspended_at = $7
 WHERE id = $1
-RETURNING id, email, password_hash, role, created_at, updated_at
+RETURNING id, email, password_hash, role, created_at, updated_at, status, suspended_at
 `
 
 type UpdateUserParams struct {
@@ -136,6 +162,8 @@ type UpdateUserParams struct {
 	PasswordHash string             `json:"password_hash"`
 	Role         UserRole           `json:"role"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	Status       string             `json:"status"`
+	SuspendedAt  pgtype.Timestamptz `json:"suspended_at"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
@@ -145,6 +173,8 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.PasswordHash,
 		arg.Role,
 		arg.UpdatedAt,
+		arg.Status,
+		arg.SuspendedAt,
 	)
 	var i User
 	err := row.Scan(
@@ -154,6 +184,8 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.SuspendedAt,
 	)
 	return i, err
 }
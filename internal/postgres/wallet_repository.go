@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// WalletRepository implements the WalletRepository interface using PostgreSQL.
+type WalletRepository struct {
+	queries *Queries
+}
+
+// NewWalletRepository creates a new WalletRepository.
+func NewWalletRepository(queries *Queries) *WalletRepository {
+	return &WalletRepository{queries: queries}
+}
+
+func (r *WalletRepository) getQueries(ctx context.Context) *Queries {
+	tx := ExtractTx(ctx)
+	if tx != nil {
+		return r.queries.WithTx(tx)
+	}
+	return r.queries
+}
+
+// GetWalletForUpdate opens the wallet on first use and locks it. It must run
+// in a transaction.
+func (r *WalletRepository) GetWalletForUpdate(
+	ctx context.Context,
+	userID uuid.UUID,
+	currency domain.Currency,
+) (*domain.Wallet, error) {
+	q := r.getQueries(ctx)
+	owner := pgtype.UUID{Bytes: userID, Valid: true}
+	if err := q.CreateWallet(ctx, CreateWalletParams{UserID: owner, Currency: string(currency)}); err != nil {
+		return nil, err
+	}
+	row, err := q.GetWalletForUpdate(ctx, GetWalletForUpdateParams{UserID: owner, Currency: string(currency)})
+	if err != nil {
+		return nil, err
+	}
+	return walletFromRow(row), nil
+}
+
+// ListWallets returns the wallets of a user ordered by currency.
+func (r *WalletRepository) ListWallets(ctx context.Context, userID uuid.UUID) ([]*domain.Wallet, error) {
+	rows, err := r.getQueries(ctx).ListWallets(ctx, pgtype.UUID{Bytes: userID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	wallets := make([]*domain.Wallet, len(rows))
+	for i, row := range rows {
+		wallets[i] = walletFromRow(row)
+	}
+	return wallets, nil
+}
+
+// SaveTransaction records a transaction and the balance it left behind.
+func (r *WalletRepository) SaveTransaction(
+	ctx context.Context,
+	wallet *domain.Wallet,
+	transaction *domain.WalletTransaction,
+) error {
+	q := r.getQueries(ctx)
+	if err := q.UpdateWalletBalance(ctx, UpdateWalletBalanceParams{
+		UserID:    pgtype.UUID{Bytes: wallet.UserID(), Valid: true},
+		Currency:  string(wallet.Balance().Currency()),
+		Balance:   wallet.Balance().Amount(),
+		UpdatedAt: pgtype.Timestamptz{Time: wallet.UpdatedAt(), Valid: true},
+	}); err != nil {
+		return err
+	}
+	return q.CreateWalletTransaction(ctx, CreateWalletTransactionParams{
+		ID:           pgtype.UUID{Bytes: transaction.ID(), Valid: true},
+		UserID:       pgtype.UUID{Bytes: transaction.UserID(), Valid: true},
+		Currency:     string(transaction.Amount().Currency()),
+		Kind:         string(transaction.Kind()),
+		ReferenceID:  pgtype.UUID{Bytes: transaction.Reference(), Valid: true},
+		Amount:       transaction.Amount().Amount(),
+		BalanceAfter: transaction.BalanceAfter().Amount(),
+		CreatedAt:    pgtype.Timestamptz{Time: transaction.CreatedAt(), Valid: true},
+	})
+}
+
+// ListTransactions returns the latest transactions of a user, newest first.
+func (r *WalletRepository) ListTransactions(
+	ctx context.Context,
+	userID uuid.UUID,
+	limit int,
+) ([]*domain.WalletTransaction, error) {
+	rows, err := r.getQueries(ctx).ListWalletTransactions(ctx, ListWalletTransactionsParams{
+		UserID: pgtype.UUID{Bytes: userID, Valid: true},
+		Limit:  int32(limit), //nolint:gosec // G115: limit is a small constant
+	})
+	if err != nil {
+		return nil, err
+	}
+	transactions := make([]*domain.WalletTransaction, len(rows))
+	for i, row := range rows {
+		transactions[i] = domain.UnmarshalWalletTransaction(
+			uuid.UUID(row.ID.Bytes),
+			uuid.UUID(row.UserID.Bytes),
+			domain.WalletTransactionKind(row.Kind),
+			uuid.UUID(row.ReferenceID.Bytes),
+			domain.UnmarshalMoney(row.Amount, row.Currency),
+			domain.UnmarshalMoney(row.BalanceAfter, row.Currency),
+			row.CreatedAt.Time,
+		)
+	}
+	return transactions, nil
+}
+
+// CreateGiftCard stores an unredeemed gift card.
+func (r *WalletRepository) CreateGiftCard(ctx context.Context, card *domain.GiftCard) error {
+	return r.getQueries(ctx).CreateGiftCard(ctx, CreateGiftCardParams{
+		ID:        pgtype.UUID{Bytes: card.ID(), Valid: true},
+		CodeHash:  card.CodeHash(),
+		Amount:    card.Amount().Amount(),
+		Currency:  string(card.Amount().Currency()),
+		IssuedBy:  pgtype.UUID{Bytes: card.IssuedBy(), Valid: true},
+		CreatedAt: pgtype.Timestamptz{Time: card.CreatedAt(), Valid: true},
+	})
+}
+
+// GetGiftCardForUpdate returns the card matching a code and locks it.
+func (r *WalletRepository) GetGiftCardForUpdate(ctx context.Context, code string) (*domain.GiftCard, error) {
+	row, err := r.getQueries(ctx).GetGiftCardForUpdate(ctx, domain.HashGiftCardCode(code))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrGiftCardNotFound
+		}
+		return nil, err
+	}
+	var redeemedAt time.Time
+	if row.RedeemedAt.Valid {
+		redeemedAt = row.RedeemedAt.Time
+	}
+	return domain.UnmarshalGiftCard(
+		uuid.UUID(row.ID.Bytes),
+		row.CodeHash,
+		domain.UnmarshalMoney(row.Amount, row.Currency),
+		uuid.UUID(row.IssuedBy.Bytes),
+		row.CreatedAt.Time,
+		uuid.UUID(row.RedeemedBy.Bytes),
+		redeemedAt,
+	), nil
+}
+
+// RedeemGiftCard stores who redeemed a card and when.
+func (r *WalletRepository) RedeemGiftCard(ctx context.Context, card *domain.GiftCard) error {
+	return r.getQueries(ctx).RedeemGiftCard(ctx, RedeemGiftCardParams{
+		ID:         pgtype.UUID{Bytes: card.ID(), Valid: true},
+		RedeemedBy: pgtype.UUID{Bytes: card.RedeemedBy(), Valid: true},
+		RedeemedAt: pgtype.Timestamptz{Time: card.RedeemedAt(), Valid: true},
+	})
+}
+
+func walletFromRow(row Wallet) *domain.Wallet {
+	return domain.UnmarshalWallet(
+		uuid.UUID(row.UserID.Bytes),
+		domain.UnmarshalMoney(row.Balance, row.Currency),
+		row.UpdatedAt.Time,
+	)
+}
@@ -9,6 +9,10 @@ import (
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
+// accountRoutingKey routes account emails to the notification queue, next
+// to booking notifications.
+const accountRoutingKey = "account.notification"
+
 type RabbitMQPublisher struct {
 	channel    *amqp.Channel
 	exchange   string
@@ -57,6 +61,16 @@ func NewRabbitMQPublisher(conn *amqp.Connection) (*RabbitMQPublisher, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to bind queue: %w", err)
 	}
+	err = channel.QueueBind(
+		queue.Name,
+		accountRoutingKey,
+		exchangeName,
+		false,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind queue: %w", err)
+	}
 
 	return &RabbitMQPublisher{
 		channel:    channel,
@@ -70,11 +84,25 @@ func (r *RabbitMQPublisher) Publish(ctx context.Context, booking *domain.Booking
 	if err != nil {
 		return fmt.Errorf("failed to marshal booking: %w", err)
 	}
+	return r.publish(ctx, r.routingKey, body)
+}
+
+func (r *RabbitMQPublisher) PublishAccountNotification(
+	ctx context.Context,
+	notification *domain.AccountNotification,
+) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal account notification: %w", err)
+	}
+	return r.publish(ctx, accountRoutingKey, body)
+}
 
-	err = r.channel.PublishWithContext(
+func (r *RabbitMQPublisher) publish(ctx context.Context, routingKey string, body []byte) error {
+	err := r.channel.PublishWithContext(
 		ctx,
 		r.exchange,
-		r.routingKey,
+		routingKey,
 		false, false, amqp.Publishing{
 			ContentType: "application/json",
 			Body:        body,
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+const (
+	userAuditPageSize = 50
+
+	// suspensionFlagAttempts is how often writing the suspension flag of a
+	// user is tried before SuspendUser fails, waiting suspensionFlagBackoff
+	// and then twice as long between tries.
+	suspensionFlagAttempts = 3
+	suspensionFlagBackoff  = 100 * time.Millisecond
+)
+
+type AdminUserServiceInterface interface {
+	ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error)
+	GetUser(ctx context.Context, userID uuid.UUID) (*domain.User, []*domain.AuditRecord, error)
+	ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role domain.UserRole) (*domain.User, error)
+	SuspendUser(ctx context.Context, actorID, userID uuid.UUID, reason string) (*domain.User, error)
+	UnsuspendUser(ctx context.Context, actorID, userID uuid.UUID) (*domain.User, error)
+	ForceLogout(ctx context.Context, actorID, userID uuid.UUID) error
+}
+
+// UserSuspender flags suspended users for the auth middleware, on top of
+// denylisting access tokens.
+type UserSuspender interface {
+	TokenRevoker
+	SuspendUser(ctx context.Context, userID uuid.UUID) error
+	UnsuspendUser(ctx context.Context, userID uuid.UUID) error
+}
+
+// AdminUserService lets admins manage user accounts. Every change is written
+// to the audit log in the transaction that makes it.
+type AdminUserService struct {
+	userRepository         domain.UserRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	auditRepository        domain.AuditRepository
+	suspender              UserSuspender
+	tm                     domain.TransactionManager
+}
+
+func NewAdminUserService(
+	userRepository domain.UserRepository,
+	refreshTokenRepository domain.RefreshTokenRepository,
+	auditRepository domain.AuditRepository,
+	suspender UserSuspender,
+	tm domain.TransactionManager,
+) *AdminUserService {
+	return &AdminUserService{
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		auditRepository:        auditRepository,
+		suspender:              suspender,
+		tm:                     tm,
+	}
+}
+
+// ListUsers returns a page of users.
+func (s *AdminUserService) ListUsers(ctx context.Context, filter domain.UserFilter) ([]*domain.User, error) {
+	return s.userRepository.ListUsers(ctx, filter.WithPageDefaults())
+}
+
+// GetUser returns a user with the latest audit records about them.
+func (s *AdminUserService) GetUser(ctx context.Context, userID uuid.UUID) (*domain.User, []*domain.AuditRecord, error) {
+	user, err := s.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	records, err := s.auditRepository.ListAuditRecords(ctx, domain.AuditTargetUser, userID, userAuditPageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, records, nil
+}
+
+// ChangeRole gives a user a new role. Access tokens carry the role, so the
+// sessions of the user end and the new role applies from their next sign-in.
+func (s *AdminUserService) ChangeRole(
+	ctx context.Context,
+	actorID, userID uuid.UUID,
+	role domain.UserRole,
+) (*domain.User, error) {
+	if actorID == userID {
+		return nil, domain.ErrUserSelfModification
+	}
+	var user *domain.User
+	var revoked []uuid.UUID
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		user, err = s.userRepository.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		previous := user.Role()
+		if err := user.UpdateRole(role); err != nil {
+			return err
+		}
+		if previous == role {
+			return nil
+		}
+		if err := s.userRepository.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		if revoked, err = s.revokeSessions(ctx, userID, now); err != nil {
+			return err
+		}
+		return s.audit(ctx, actorID, domain.AuditUserRoleChanged, userID, map[string]string{
+			"from": string(previous),
+			"to":   string(role),
+		}, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return user, revokeAccessTokens(ctx, s.suspender, revoked)
+}
+
+// SuspendUser disables an account and ends its sessions. The Redis flag that
+// rejects its live access tokens is written after the suspension commits;
+// when that fails even after retrying, the error is returned and suspending
+// the user again only writes the flag.
+func (s *AdminUserService) SuspendUser(
+	ctx context.Context,
+	actorID, userID uuid.UUID,
+	reason string,
+) (*domain.User, error) {
+	if actorID == userID {
+		return nil, domain.ErrUserSelfModification
+	}
+	var user *domain.User
+	var revoked []uuid.UUID
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		user, err = s.userRepository.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.IsSuspended() {
+			return nil
+		}
+		user.Suspend(now)
+		if err := s.userRepository.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		if revoked, err = s.revokeSessions(ctx, userID, now); err != nil {
+			return err
+		}
+		return s.audit(ctx, actorID, domain.AuditUserSuspended, userID, map[string]string{"reason": reason}, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.flagSuspension(ctx, userID); err != nil {
+		return nil, err
+	}
+	return user, revokeAccessTokens(ctx, s.suspender, revoked)
+}
+
+// flagSuspension writes the suspension flag of a user, retrying with a
+// backoff.
+func (s *AdminUserService) flagSuspension(ctx context.Context, userID uuid.UUID) error {
+	backoff := suspensionFlagBackoff
+	var err error
+	for attempt := range suspensionFlagAttempts {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("flag suspended user %s: %w", userID, err)
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+		if err = s.suspender.SuspendUser(ctx, userID); err == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("flag suspended user %s: %w", userID, err)
+}
+
+// UnsuspendUser enables a suspended account again. Its user has to sign in
+// anew, as suspension ended every session. Erased accounts stay suspended.
+func (s *AdminUserService) UnsuspendUser(ctx context.Context, actorID, userID uuid.UUID) (*domain.User, error) {
+	var user *domain.User
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		user, err = s.userRepository.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.IsErased() {
+			return domain.ErrUserErased
+		}
+		if !user.IsSuspended() {
+			return nil
+		}
+		user.Unsuspend(now)
+		if err := s.userRepository.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		return s.audit(ctx, actorID, domain.AuditUserUnsuspended, userID, nil, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.suspender.UnsuspendUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ForceLogout ends every session of a user, including their live access
+// tokens.
+func (s *AdminUserService) ForceLogout(ctx context.Context, actorID, userID uuid.UUID) error {
+	var revoked []uuid.UUID
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		if _, err := s.userRepository.GetUserByID(ctx, userID); err != nil {
+			return err
+		}
+		var err error
+		if revoked, err = s.revokeSessions(ctx, userID, now); err != nil {
+			return err
+		}
+		return s.audit(ctx, actorID, domain.AuditUserSessionsRevoked, userID, nil, now)
+	})
+	if err != nil {
+		return err
+	}
+	return revokeAccessTokens(ctx, s.suspender, revoked)
+}
+
+func (s *AdminUserService) revokeSessions(ctx context.Context, userID uuid.UUID, now time.Time) ([]uuid.UUID, error) {
+	return s.refreshTokenRepository.RevokeUserTokens(ctx, userID, now, now.Add(-auth.AccessTokenTTL))
+}
+
+func (s *AdminUserService) audit(
+	ctx context.Context,
+	actorID uuid.UUID,
+	action domain.AuditAction,
+	userID uuid.UUID,
+	details map[string]string,
+	now time.Time,
+) error {
+	record := domain.NewAuditRecord(actorID, action, domain.AuditTargetUser, userID, details, now)
+	return s.auditRepository.CreateAuditRecord(ctx, record)
+}
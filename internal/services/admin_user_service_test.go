@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminUserService_SuspensionAndRoleChangesAreAudited(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	queries := postgres.New(pool)
+	suspender := &recordingSuspender{
+		recordingRevoker: recordingRevoker{revoked: map[uuid.UUID]bool{}},
+		suspended:        map[uuid.UUID]bool{},
+	}
+	userService := newTestUserService(pool, newTestJWTService(t), suspender)
+	adminService := NewAdminUserService(
+		postgres.NewUserRepository(queries),
+		postgres.NewRefreshTokenRepository(queries),
+		postgres.NewAuditRepository(queries),
+		suspender,
+		postgres.NewPgxTxManager(pool),
+	)
+	admin := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleAdmin)
+
+	assert.NoError(t, userService.RegisterUser(ctx, "managed@example.com", "password123"))
+	login, err := userService.LoginUser(ctx, "managed@example.com", "password123", testClient)
+	assert.NoError(t, err)
+	users, err := adminService.ListUsers(ctx, domain.UserFilter{Email: "MANAGED@"})
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	user := users[0]
+
+	_, err = adminService.ChangeRole(ctx, admin.ID(), admin.ID(), domain.UserRoleUser)
+	assert.ErrorIs(t, err, domain.ErrUserSelfModification)
+	user, err = adminService.ChangeRole(ctx, admin.ID(), user.ID(), domain.UserRoleOrganizer)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.UserRoleOrganizer, user.Role())
+	assert.True(t, suspender.revoked[login.Session.AccessToken.ID], "a role change ends the sessions")
+
+	// A suspension whose flag cannot be written is reported; suspending again
+	// writes the flag, retrying a failed write.
+	suspender.failures = suspensionFlagAttempts
+	_, err = adminService.SuspendUser(ctx, admin.ID(), user.ID(), "chargebacks")
+	assert.Error(t, err)
+	assert.False(t, suspender.suspended[user.ID()])
+	_, err = userService.LoginUser(ctx, "managed@example.com", "password123", testClient)
+	assert.ErrorIs(t, err, domain.ErrUserSuspended)
+	suspender.failures = suspensionFlagAttempts - 1
+	user, err = adminService.SuspendUser(ctx, admin.ID(), user.ID(), "chargebacks")
+	assert.NoError(t, err)
+	assert.True(t, user.IsSuspended())
+	assert.True(t, suspender.suspended[user.ID()])
+
+	_, err = adminService.UnsuspendUser(ctx, admin.ID(), user.ID())
+	assert.NoError(t, err)
+	assert.False(t, suspender.suspended[user.ID()])
+	login, err = userService.LoginUser(ctx, "managed@example.com", "password123", testClient)
+	assert.NoError(t, err)
+	assert.NoError(t, adminService.ForceLogout(ctx, admin.ID(), user.ID()))
+	_, err = userService.RefreshSession(ctx, login.Session.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+
+	user, records, err := adminService.GetUser(ctx, user.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.UserRoleOrganizer, user.Role())
+	actions := make([]domain.AuditAction, len(records))
+	for i, record := range records {
+		actions[i] = record.Action()
+		assert.Equal(t, admin.ID(), record.ActorID())
+	}
+	assert.Equal(t, []domain.AuditAction{
+		domain.AuditUserSessionsRevoked,
+		domain.AuditUserUnsuspended,
+		domain.AuditUserSuspended,
+		domain.AuditUserRoleChanged,
+	}, actions)
+	assert.Equal(t, "chargebacks", records[2].Details()["reason"])
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type APIKeyServiceInterface interface {
+	CreateAPIKey(
+		ctx context.Context,
+		actor Actor,
+		organizationID uuid.UUID,
+		name string,
+		scopes []domain.APIKeyScope,
+		expiresAt time.Time,
+	) (*domain.APIKey, string, error)
+	ListAPIKeys(ctx context.Context, actor Actor, organizationID uuid.UUID) ([]*domain.APIKey, error)
+	GetAPIKey(ctx context.Context, actor Actor, organizationID, keyID uuid.UUID) (*domain.APIKey, error)
+	UpdateAPIKey(
+		ctx context.Context,
+		actor Actor,
+		organizationID, keyID uuid.UUID,
+		name string,
+		scopes []domain.APIKeyScope,
+	) (*domain.APIKey, error)
+	RevokeAPIKey(ctx context.Context, actor Actor, organizationID, keyID uuid.UUID) error
+}
+
+// APIKeyService manages the API keys of organizations and authenticates the
+// requests made with them. Owners and managers manage the keys of their
+// organization, admins those of any. A key acts as the member who created
+// it, and stops working once that member leaves the organization.
+type APIKeyService struct {
+	apiKeyRepository       domain.APIKeyRepository
+	userRepository         domain.UserRepository
+	organizationRepository domain.OrganizationRepository
+	membershipRepository   domain.MembershipRepository
+	auditRepository        domain.AuditRepository
+	tm                     domain.TransactionManager
+}
+
+func NewAPIKeyService(
+	apiKeyRepository domain.APIKeyRepository,
+	userRepository domain.UserRepository,
+	organizationRepository domain.OrganizationRepository,
+	membershipRepository domain.MembershipRepository,
+	auditRepository domain.AuditRepository,
+	tm domain.TransactionManager,
+) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepository:       apiKeyRepository,
+		userRepository:         userRepository,
+		organizationRepository: organizationRepository,
+		membershipRepository:   membershipRepository,
+		auditRepository:        auditRepository,
+		tm:                     tm,
+	}
+}
+
+// CreateAPIKey creates a key of an organization and returns it with the key
+// to hand out, which is not stored and cannot be shown again.
+func (s *APIKeyService) CreateAPIKey(
+	ctx context.Context,
+	actor Actor,
+	organizationID uuid.UUID,
+	name string,
+	scopes []domain.APIKeyScope,
+	expiresAt time.Time,
+) (*domain.APIKey, string, error) {
+	var key *domain.APIKey
+	var secret string
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.authorize(ctx, actor, organizationID); err != nil {
+			return err
+		}
+		now := time.Now()
+		var err error
+		key, secret, err = domain.NewAPIKey(organizationID, actor.ID, name, scopes, expiresAt, now)
+		if err != nil {
+			return err
+		}
+		if err := s.apiKeyRepository.CreateAPIKey(ctx, key); err != nil {
+			return err
+		}
+		return s.audit(ctx, actor, domain.AuditAPIKeyCreated, key, now)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+// ListAPIKeys returns the keys of an organization, newest first.
+func (s *APIKeyService) ListAPIKeys(
+	ctx context.Context,
+	actor Actor,
+	organizationID uuid.UUID,
+) ([]*domain.APIKey, error) {
+	if err := s.authorize(ctx, actor, organizationID); err != nil {
+		return nil, err
+	}
+	return s.apiKeyRepository.ListAPIKeys(ctx, organizationID)
+}
+
+// GetAPIKey returns a key of an organization.
+func (s *APIKeyService) GetAPIKey(
+	ctx context.Context,
+	actor Actor,
+	organizationID, keyID uuid.UUID,
+) (*domain.APIKey, error) {
+	if err := s.authorize(ctx, actor, organizationID); err != nil {
+		return nil, err
+	}
+	return s.organizationKey(ctx, organizationID, keyID)
+}
+
+// UpdateAPIKey renames a key and replaces its scopes.
+func (s *APIKeyService) UpdateAPIKey(
+	ctx context.Context,
+	actor Actor,
+	organizationID, keyID uuid.UUID,
+	name string,
+	scopes []domain.APIKeyScope,
+) (*domain.APIKey, error) {
+	var key *domain.APIKey
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.authorize(ctx, actor, organizationID); err != nil {
+			return err
+		}
+		var err error
+		key, err = s.organizationKey(ctx, organizationID, keyID)
+		if err != nil {
+			return err
+		}
+		if err := key.Update(name, scopes); err != nil {
+			return err
+		}
+		if err := s.apiKeyRepository.UpdateAPIKey(ctx, key); err != nil {
+			return err
+		}
+		return s.audit(ctx, actor, domain.AuditAPIKeyUpdated, key, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// RevokeAPIKey deletes a key, which stops working at once.
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, actor Actor, organizationID, keyID uuid.UUID) error {
+	return s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.authorize(ctx, actor, organizationID); err != nil {
+			return err
+		}
+		key, err := s.organizationKey(ctx, organizationID, keyID)
+		if err != nil {
+			return err
+		}
+		if err := s.apiKeyRepository.DeleteAPIKey(ctx, key.ID()); err != nil {
+			return err
+		}
+		return s.audit(ctx, actor, domain.AuditAPIKeyRevoked, key, time.Now())
+	})
+}
+
+// AuthenticateAPIKey returns the key a request was made with and the user it
+// acts as. Unknown, wrong and expired keys, and keys whose creator left the
+// organization, return domain.ErrAPIKeyInvalid; keys of suspended users
+// domain.ErrUserSuspended.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, rawKey string) (*domain.APIKey, *domain.User, error) {
+	prefix, secret, err := domain.SplitAPIKey(rawKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	key, err := s.apiKeyRepository.GetAPIKeyByPrefix(ctx, prefix)
+	if errors.Is(err, domain.ErrAPIKeyNotFound) {
+		return nil, nil, domain.ErrAPIKeyInvalid
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	if err := key.Verify(secret, now); err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.userRepository.GetUserByID(ctx, key.CreatedBy())
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.IsSuspended() {
+		return nil, nil, domain.ErrUserSuspended
+	}
+	if user.Role() != domain.UserRoleAdmin {
+		_, err := s.membershipRepository.GetMembership(ctx, key.OrganizationID(), user.ID())
+		if errors.Is(err, domain.ErrMembershipNotFound) {
+			return nil, nil, domain.ErrAPIKeyInvalid
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// The last use is informational; failing to store it does not fail the
+	// request.
+	if key.Use(now) {
+		if err := s.apiKeyRepository.MarkAPIKeyUsed(ctx, key); err != nil {
+			slog.Error("Failed to record API key use", "key_id", key.ID(), "error", err)
+		}
+	}
+	return key, user, nil
+}
+
+// authorize checks that the actor may manage the keys of an organization.
+// Keys cannot manage keys.
+func (s *APIKeyService) authorize(ctx context.Context, actor Actor, organizationID uuid.UUID) error {
+	if _, err := s.organizationRepository.GetOrganization(ctx, organizationID); err != nil {
+		return err
+	}
+	if actor.confined() {
+		return domain.ErrOrganizationForbidden
+	}
+	if actor.isAdmin() {
+		return nil
+	}
+	membership, err := s.membershipRepository.GetMembership(ctx, organizationID, actor.ID)
+	if errors.Is(err, domain.ErrMembershipNotFound) {
+		return domain.ErrOrganizationForbidden
+	}
+	if err != nil {
+		return err
+	}
+	if !membership.Can(domain.OrganizationActionManageAPIKeys) {
+		return domain.ErrOrganizationForbidden
+	}
+	return nil
+}
+
+// organizationKey returns a key of an organization. Keys of other
+// organizations are reported as not found.
+func (s *APIKeyService) organizationKey(
+	ctx context.Context,
+	organizationID, keyID uuid.UUID,
+) (*domain.APIKey, error) {
+	key, err := s.apiKeyRepository.GetAPIKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	if key.OrganizationID() != organizationID {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return key, nil
+}
+
+func (s *APIKeyService) audit(
+	ctx context.Context,
+	actor Actor,
+	action domain.AuditAction,
+	key *domain.APIKey,
+	now time.Time,
+) error {
+	record := domain.NewAuditRecord(actor.ID, action, domain.AuditTargetOrganization,
+		key.OrganizationID(), map[string]string{
+			"api_key_id": key.ID().String(),
+			"name":       key.Name(),
+			"scopes":     joinScopes(key.Scopes()),
+		}, now)
+	return s.auditRepository.CreateAuditRecord(ctx, record)
+}
+
+func joinScopes(scopes []domain.APIKeyScope) string {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	return strings.Join(names, " ")
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyService_KeysActAsCreatorWithinOrganization(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	queries := postgres.New(pool)
+	userRepository := postgres.NewUserRepository(queries)
+	organizationRepository := postgres.NewOrganizationRepository(queries)
+	organizationService := newTestOrganizationService(pool)
+	apiKeyService := NewAPIKeyService(
+		postgres.NewAPIKeyRepository(queries),
+		userRepository,
+		organizationRepository,
+		organizationRepository,
+		postgres.NewAuditRepository(queries),
+		postgres.NewPgxTxManager(pool),
+	)
+	owner := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleOrganizer)
+	manager := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleUser)
+	scanner := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleUser)
+	other := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleOrganizer)
+
+	organization, err := domain.NewOrganization(owner.ID(), "Riverside Concerts", "", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, organizationRepository.CreateOrganization(ctx, organization))
+	for user, role := range map[*domain.User]domain.OrganizationRole{
+		owner:   domain.OrganizationRoleOwner,
+		manager: domain.OrganizationRoleManager,
+		scanner: domain.OrganizationRoleScanner,
+	} {
+		membership, err := domain.NewMembership(organization.ID(), user, role, time.Now())
+		assert.NoError(t, err)
+		assert.NoError(t, organizationRepository.CreateMembership(ctx, membership))
+	}
+	managerActor := Actor{ID: manager.ID(), Role: manager.Role()}
+	scopes := []domain.APIKeyScope{domain.APIKeyScopeEventsWrite}
+
+	// Scanners may not manage keys.
+	_, _, err = apiKeyService.CreateAPIKey(ctx, Actor{ID: scanner.ID(), Role: scanner.Role()},
+		organization.ID(), "Kiosk", scopes, time.Time{})
+	assert.ErrorIs(t, err, domain.ErrOrganizationForbidden)
+	key, rawKey, err := apiKeyService.CreateAPIKey(ctx, managerActor, organization.ID(), "Kiosk", scopes, time.Time{})
+	assert.NoError(t, err)
+
+	authenticated, user, err := apiKeyService.AuthenticateAPIKey(ctx, rawKey)
+	assert.NoError(t, err)
+	assert.Equal(t, key.ID(), authenticated.ID())
+	assert.Equal(t, manager.ID(), user.ID())
+	assert.False(t, authenticated.LastUsedAt().IsZero())
+	_, _, err = apiKeyService.AuthenticateAPIKey(ctx, rawKey+"x")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyInvalid)
+
+	// The key acts on the events of its organization only, even for admins.
+	keyActor := Actor{ID: user.ID(), Role: user.Role(), OrganizationID: authenticated.OrganizationID()}
+	event := postgres.CreateTestEvent(ctx, t, pool,
+		postgres.WithOrganizer(owner.ID()), postgres.WithOrganization(organization.ID()))
+	otherEvent := postgres.CreateTestEvent(ctx, t, pool, postgres.WithOrganizer(other.ID()))
+	assert.NoError(t, organizationService.AuthorizeEvent(ctx, keyActor, event,
+		domain.OrganizationActionManageEvents))
+	assert.ErrorIs(t, organizationService.AuthorizeEvent(ctx, Actor{
+		ID: user.ID(), Role: domain.UserRoleAdmin, OrganizationID: organization.ID(),
+	}, otherEvent, domain.OrganizationActionManageEvents), domain.ErrOrganizationForbidden)
+	eventOrganization, err := organizationService.EventOrganization(ctx, keyActor, uuid.Nil)
+	assert.NoError(t, err)
+	assert.Equal(t, organization.ID(), eventOrganization.ID())
+	// Keys cannot manage keys.
+	_, err = apiKeyService.ListAPIKeys(ctx, keyActor, organization.ID())
+	assert.ErrorIs(t, err, domain.ErrOrganizationForbidden)
+
+	updated, err := apiKeyService.UpdateAPIKey(ctx, managerActor, organization.ID(), key.ID(), "Kiosk 2",
+		[]domain.APIKeyScope{domain.APIKeyScopeBookingsRead})
+	assert.NoError(t, err)
+	assert.True(t, updated.Allows(domain.APIKeyScopeBookingsRead))
+	keys, err := apiKeyService.ListAPIKeys(ctx, Actor{ID: owner.ID(), Role: owner.Role()}, organization.ID())
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assert.Equal(t, "Kiosk 2", keys[0].Name())
+
+	// The key stops working when its creator leaves the organization.
+	assert.NoError(t, organizationRepository.DeleteMembership(ctx, organization.ID(), manager.ID()))
+	_, _, err = apiKeyService.AuthenticateAPIKey(ctx, rawKey)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyInvalid)
+
+	assert.NoError(t, apiKeyService.RevokeAPIKey(ctx, Actor{ID: owner.ID(), Role: owner.Role()},
+		organization.ID(), key.ID()))
+	_, err = apiKeyService.GetAPIKey(ctx, Actor{ID: owner.ID(), Role: owner.Role()}, organization.ID(), key.ID())
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mati/go-ticket/internal/domain"
@@ -22,14 +23,28 @@ func TestBookingService_CreateBooking_Success(t *testing.T) {
 	eventRepository := postgres.NewEventRepository(queries)
 	bookingRepository := postgres.NewBookingRepository(queries)
 	outboxRepository := postgres.NewOutBoxRepository(queries)
+	presaleRepository := postgres.NewPresaleRepository(queries)
+	pricingRuleRepository := postgres.NewPricingRuleRepository(queries)
+	feeRepository := postgres.NewFeeRepository(queries)
 	txManager := postgres.NewPgxTxManager(pool)
-	bookingService := NewBookingService(eventRepository, bookingRepository, outboxRepository, txManager)
+	bookingService := NewBookingService(
+		eventRepository,
+		bookingRepository,
+		outboxRepository,
+		presaleRepository,
+		pricingRuleRepository,
+		feeRepository,
+		postgres.NewLedgerRepository(queries),
+		postgres.NewWalletRepository(queries),
+		postgres.NewUserRepository(queries),
+		txManager,
+	)
 
 	booking, err := domain.NewBooking(uuid.New(), event.ID(), "test@example.com", domain.BookingStatusPending)
 	assert.NoError(t, err)
 
 	// Create booking
-	err = bookingService.CreateBooking(ctx, booking)
+	err = bookingService.CreateBooking(ctx, booking, CreateBookingOptions{})
 	assert.NoError(t, err)
 
 	// Verify booking created
@@ -54,15 +69,29 @@ func TestBookingService_CreateBooking_EventNotFound(t *testing.T) {
 	eventRepository := postgres.NewEventRepository(queries)
 	bookingRepository := postgres.NewBookingRepository(queries)
 	outboxRepository := postgres.NewOutBoxRepository(queries)
+	presaleRepository := postgres.NewPresaleRepository(queries)
+	pricingRuleRepository := postgres.NewPricingRuleRepository(queries)
+	feeRepository := postgres.NewFeeRepository(queries)
 	txManager := postgres.NewPgxTxManager(pool)
-	bookingService := NewBookingService(eventRepository, bookingRepository, outboxRepository, txManager)
+	bookingService := NewBookingService(
+		eventRepository,
+		bookingRepository,
+		outboxRepository,
+		presaleRepository,
+		pricingRuleRepository,
+		feeRepository,
+		postgres.NewLedgerRepository(queries),
+		postgres.NewWalletRepository(queries),
+		postgres.NewUserRepository(queries),
+		txManager,
+	)
 
 	// Try to create booking for non-existent event
 	fakeEventID := uuid.New()
 	booking, err := domain.NewBooking(uuid.New(), fakeEventID, "test@example.com", domain.BookingStatusPending)
 	assert.NoError(t, err)
 
-	err = bookingService.CreateBooking(ctx, booking)
+	err = bookingService.CreateBooking(ctx, booking, CreateBookingOptions{})
 
 	// Verify error is domain.ErrEventNotFound
 	assert.Error(t, err)
@@ -81,14 +110,28 @@ func TestBookingService_CreateBooking_EventFull(t *testing.T) {
 	eventRepository := postgres.NewEventRepository(queries)
 	bookingRepository := postgres.NewBookingRepository(queries)
 	outboxRepository := postgres.NewOutBoxRepository(queries)
+	presaleRepository := postgres.NewPresaleRepository(queries)
+	pricingRuleRepository := postgres.NewPricingRuleRepository(queries)
+	feeRepository := postgres.NewFeeRepository(queries)
 	txManager := postgres.NewPgxTxManager(pool)
-	bookingService := NewBookingService(eventRepository, bookingRepository, outboxRepository, txManager)
+	bookingService := NewBookingService(
+		eventRepository,
+		bookingRepository,
+		outboxRepository,
+		presaleRepository,
+		pricingRuleRepository,
+		feeRepository,
+		postgres.NewLedgerRepository(queries),
+		postgres.NewWalletRepository(queries),
+		postgres.NewUserRepository(queries),
+		txManager,
+	)
 
 	// Try to create booking
 	booking, err := domain.NewBooking(uuid.New(), event.ID(), "test@example.com", domain.BookingStatusPending)
 	assert.NoError(t, err)
 
-	err = bookingService.CreateBooking(ctx, booking)
+	err = bookingService.CreateBooking(ctx, booking, CreateBookingOptions{})
 
 	// Verify error is domain.ErrEventIsFull
 	assert.Error(t, err)
@@ -107,14 +150,28 @@ func TestBookingService_CreateBooking_TransactionRollback(t *testing.T) {
 	eventRepository := postgres.NewEventRepository(queries)
 	bookingRepository := postgres.NewBookingRepository(queries)
 	outboxRepository := postgres.NewOutBoxRepository(queries)
+	presaleRepository := postgres.NewPresaleRepository(queries)
+	pricingRuleRepository := postgres.NewPricingRuleRepository(queries)
+	feeRepository := postgres.NewFeeRepository(queries)
 	txManager := postgres.NewPgxTxManager(pool)
-	bookingService := NewBookingService(eventRepository, bookingRepository, outboxRepository, txManager)
+	bookingService := NewBookingService(
+		eventRepository,
+		bookingRepository,
+		outboxRepository,
+		presaleRepository,
+		pricingRuleRepository,
+		feeRepository,
+		postgres.NewLedgerRepository(queries),
+		postgres.NewWalletRepository(queries),
+		postgres.NewUserRepository(queries),
+		txManager,
+	)
 
 	// Create first booking (should succeed)
 	booking1, err := domain.NewBooking(uuid.New(), event.ID(), "test1@example.com", domain.BookingStatusPending)
 	assert.NoError(t, err)
 
-	err = bookingService.CreateBooking(ctx, booking1)
+	err = bookingService.CreateBooking(ctx, booking1, CreateBookingOptions{})
 	assert.NoError(t, err)
 
 	// Verify event spots were reserved
@@ -125,7 +182,7 @@ func TestBookingService_CreateBooking_TransactionRollback(t *testing.T) {
 	booking2, err := domain.NewBooking(uuid.New(), event.ID(), "test2@example.com", domain.BookingStatusPending)
 	assert.NoError(t, err)
 
-	err = bookingService.CreateBooking(ctx, booking2)
+	err = bookingService.CreateBooking(ctx, booking2, CreateBookingOptions{})
 
 	// Verify error is domain.ErrEventIsFull
 	assert.Error(t, err)
@@ -145,3 +202,211 @@ func TestBookingService_CreateBooking_TransactionRollback(t *testing.T) {
 	assert.Error(t, err, "Second booking should not exist in database")
 	assert.ErrorIs(t, err, domain.ErrBookingNotFound, "Second booking should not exist in database")
 }
+
+func TestBookingService_CreateBooking_SalesWindow(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	queries := postgres.New(pool)
+	eventRepository := postgres.NewEventRepository(queries)
+	bookingRepository := postgres.NewBookingRepository(queries)
+	outboxRepository := postgres.NewOutBoxRepository(queries)
+	presaleRepository := postgres.NewPresaleRepository(queries)
+	pricingRuleRepository := postgres.NewPricingRuleRepository(queries)
+	feeRepository := postgres.NewFeeRepository(queries)
+	txManager := postgres.NewPgxTxManager(pool)
+	bookingService := NewBookingService(
+		eventRepository,
+		bookingRepository,
+		outboxRepository,
+		presaleRepository,
+		pricingRuleRepository,
+		feeRepository,
+		postgres.NewLedgerRepository(queries),
+		postgres.NewWalletRepository(queries),
+		postgres.NewUserRepository(queries),
+		txManager,
+	)
+
+	now := time.Now()
+	upcoming := postgres.CreateTestEvent(ctx, t, pool, postgres.WithSalesWindow(now.Add(time.Hour), time.Time{}))
+	closed := postgres.CreateTestEvent(ctx, t, pool, postgres.WithSalesWindow(time.Time{}, now.Add(-time.Minute)))
+
+	presale, err := domain.NewPresale(
+		uuid.New(),
+		upcoming.ID(),
+		"Fan club",
+		now.Add(-time.Hour),
+		now.Add(time.Hour),
+		"FANS",
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, presaleRepository.CreatePresale(ctx, presale))
+
+	tests := []struct {
+		name       string
+		eventID    uuid.UUID
+		accessCode string
+		wantErr    error
+	}{
+		{name: "not on sale yet", eventID: upcoming.ID(), wantErr: domain.ErrEventNotOnSale},
+		{name: "wrong presale code", eventID: upcoming.ID(), accessCode: "NOPE", wantErr: domain.ErrAccessCodeInvalid},
+		{name: "presale code", eventID: upcoming.ID(), accessCode: "FANS"},
+		{name: "sales closed", eventID: closed.ID(), accessCode: "FANS", wantErr: domain.ErrEventSalesClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			booking, err := domain.NewBooking(uuid.New(), tt.eventID, "test@example.com", domain.BookingStatusPending)
+			assert.NoError(t, err)
+
+			err = bookingService.CreateBooking(ctx, booking, CreateBookingOptions{AccessCode: tt.accessCode})
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	// Rejected bookings must not hold spots.
+	assert.Equal(t, 9, postgres.GetEventFromDB(ctx, t, pool, upcoming.ID()).AvailableSpots())
+	assert.Equal(t, 10, postgres.GetEventFromDB(ctx, t, pool, closed.ID()).AvailableSpots())
+}
+
+func TestBookingService_CreateBooking_LocksQuotedPrice(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	// Create event with 10 spots at 1000
+	event := postgres.CreateTestEvent(ctx, t, pool, postgres.WithCapacity(10), postgres.WithPrice(1000))
+
+	queries := postgres.New(pool)
+	eventRepository := postgres.NewEventRepository(queries)
+	bookingRepository := postgres.NewBookingRepository(queries)
+	outboxRepository := postgres.NewOutBoxRepository(queries)
+	presaleRepository := postgres.NewPresaleRepository(queries)
+	pricingRuleRepository := postgres.NewPricingRuleRepository(queries)
+	feeRepository := postgres.NewFeeRepository(queries)
+	txManager := postgres.NewPgxTxManager(pool)
+	bookingService := NewBookingService(
+		eventRepository,
+		bookingRepository,
+		outboxRepository,
+		presaleRepository,
+		pricingRuleRepository,
+		feeRepository,
+		postgres.NewLedgerRepository(queries),
+		postgres.NewWalletRepository(queries),
+		postgres.NewUserRepository(queries),
+		txManager,
+	)
+
+	// +20% once 10% of the capacity is sold
+	rule, err := domain.NewPricingRule(
+		uuid.New(), event.ID(), domain.PricingTriggerSoldPercent, 10, domain.PriceAdjustmentPercent, 20,
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, pricingRuleRepository.CreatePricingRule(ctx, rule))
+
+	first, err := domain.NewBooking(uuid.New(), event.ID(), "first@example.com", domain.BookingStatusPending)
+	assert.NoError(t, err)
+	assert.NoError(t, bookingService.CreateBooking(ctx, first, CreateBookingOptions{}))
+
+	second, err := domain.NewBooking(uuid.New(), event.ID(), "second@example.com", domain.BookingStatusPending)
+	assert.NoError(t, err)
+	assert.NoError(t, bookingService.CreateBooking(ctx, second, CreateBookingOptions{}))
+
+	assert.Equal(t, domain.UnmarshalMoney(1000, "EUR"), postgres.GetBookingFromDB(ctx, t, pool, first.ID()).Price())
+	assert.Equal(t, domain.UnmarshalMoney(1200, "EUR"), postgres.GetBookingFromDB(ctx, t, pool, second.ID()).Price())
+}
+
+func TestBookingService_CreateBooking_FeeAndVATBreakdown(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	organizer := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleOrganizer)
+	event := postgres.CreateTestEvent(
+		ctx, t, pool,
+		postgres.WithPrice(10000),
+		postgres.WithOrganizer(organizer.ID()),
+		postgres.WithVenueCountry("PL"),
+	)
+
+	queries := postgres.New(pool)
+	feeRepository := postgres.NewFeeRepository(queries)
+	bookingService := NewBookingService(
+		postgres.NewEventRepository(queries),
+		postgres.NewBookingRepository(queries),
+		postgres.NewOutBoxRepository(queries),
+		postgres.NewPresaleRepository(queries),
+		postgres.NewPricingRuleRepository(queries),
+		feeRepository,
+		postgres.NewLedgerRepository(queries),
+		postgres.NewWalletRepository(queries),
+		postgres.NewUserRepository(queries),
+		postgres.NewPgxTxManager(pool),
+	)
+
+	// 5% + 0.99 EUR service fee
+	fees, err := domain.NewFeeSchedule(500, 99)
+	assert.NoError(t, err)
+	assert.NoError(t, feeRepository.SaveFeeSchedule(ctx, organizer.ID(), fees))
+
+	booking, err := domain.NewBooking(uuid.New(), event.ID(), "buyer@example.com", domain.BookingStatusPending)
+	assert.NoError(t, err)
+	assert.NoError(t, bookingService.CreateBooking(ctx, booking, CreateBookingOptions{}))
+
+	stored := postgres.GetBookingFromDB(ctx, t, pool, booking.ID())
+	assert.Equal(t, []domain.LineItem{
+		{Kind: domain.LineItemBase, Amount: domain.UnmarshalMoney(10000, "EUR")},
+		{Kind: domain.LineItemServiceFee, Amount: domain.UnmarshalMoney(599, "EUR")},
+		{Kind: domain.LineItemVAT, Amount: domain.UnmarshalMoney(2438, "EUR"), RateBasisPoints: 2300},
+	}, stored.LineItems())
+	assert.Equal(t, domain.UnmarshalMoney(13037, "EUR"), stored.Total())
+}
+
+func TestBookingService_RefundBooking_ReleasesSpot(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	event := postgres.CreateTestEvent(ctx, t, pool, postgres.WithCapacity(10))
+	sharded := postgres.CreateTestEvent(ctx, t, pool, postgres.WithCapacity(10))
+
+	queries := postgres.New(pool)
+	inventoryRepository := postgres.NewInventoryRepository(queries)
+	bookingService := newTestBookingService(pool)
+	inventoryService := NewInventoryService(
+		postgres.NewEventRepository(queries),
+		inventoryRepository,
+		postgres.NewPgxTxManager(pool),
+	)
+	assert.NoError(t, inventoryService.ConfigureShards(ctx, sharded.ID(), 4))
+
+	refund := func(eventID uuid.UUID) {
+		booking, err := domain.NewBooking(uuid.New(), eventID, "buyer@example.com", domain.BookingStatusPending)
+		assert.NoError(t, err)
+		assert.NoError(t, bookingService.CreateBooking(ctx, booking, CreateBookingOptions{}))
+		_, err = bookingService.ConfirmBooking(ctx, booking.ID())
+		assert.NoError(t, err)
+		_, err = bookingService.RefundBooking(ctx, booking.ID())
+		assert.NoError(t, err)
+	}
+
+	refund(event.ID())
+	assert.Equal(t, 10, postgres.GetEventFromDB(ctx, t, pool, event.ID()).AvailableSpots())
+
+	refund(sharded.ID())
+	shards, err := inventoryRepository.ListInventoryShards(ctx, sharded.ID())
+	assert.NoError(t, err)
+	available := 0
+	for _, shard := range shards {
+		available += shard.AvailableSpots
+	}
+	assert.Equal(t, 10, available)
+
+	drifts, err := inventoryService.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, drifts)
+}
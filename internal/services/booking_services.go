@@ -3,21 +3,50 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/mati/go-ticket/internal/api/dto"
 	"github.com/mati/go-ticket/internal/domain"
 	"github.com/mati/go-ticket/internal/postgres"
 )
 
 type CreateBookingService interface {
-	CreateBooking(ctx context.Context, booking *domain.Booking) error
+	CreateBooking(ctx context.Context, booking *domain.Booking, opts CreateBookingOptions) error
+}
+
+type ConfirmBookingService interface {
+	ConfirmBooking(ctx context.Context, bookingID uuid.UUID) (*domain.Booking, error)
+	RefundBooking(ctx context.Context, bookingID uuid.UUID) (*domain.Booking, error)
+}
+
+// CreateBookingOptions carries optional inputs of a booking request.
+type CreateBookingOptions struct {
+	// AccessCode unlocks an active presale before general sales open.
+	AccessCode string
+	// WalletUserID is the user whose wallet balance pays for as much of the
+	// booking as it covers. uuid.Nil pays nothing from a wallet.
+	WalletUserID uuid.UUID
+	// CustomerID is the user placing the booking, who must have verified
+	// their email address. uuid.Nil skips the check.
+	CustomerID uuid.UUID
+	// OrganizationID restricts the booking to events of one organization,
+	// for bookings made with an API key. uuid.Nil allows any event.
+	OrganizationID uuid.UUID
 }
 
 type BookingService struct {
 	eventRepo   *postgres.EventRepository
 	bookingRepo *postgres.BookingRepository
 	outboxRepo  *postgres.OutBoxRepository
+	presaleRepo *postgres.PresaleRepository
+	pricingRepo *postgres.PricingRuleRepository
+	feeRepo     *postgres.FeeRepository
+	ledgerRepo  *postgres.LedgerRepository
+	walletRepo  *postgres.WalletRepository
+	userRepo    *postgres.UserRepository
 	tm          domain.TransactionManager
 }
 
@@ -25,21 +54,52 @@ func NewBookingService(
 	eventRepo *postgres.EventRepository,
 	bookingRepo *postgres.BookingRepository,
 	outboxRepo *postgres.OutBoxRepository,
+	presaleRepo *postgres.PresaleRepository,
+	pricingRepo *postgres.PricingRuleRepository,
+	feeRepo *postgres.FeeRepository,
+	ledgerRepo *postgres.LedgerRepository,
+	walletRepo *postgres.WalletRepository,
+	userRepo *postgres.UserRepository,
 	pool domain.TransactionManager,
 ) *BookingService {
 	return &BookingService{
 		eventRepo:   eventRepo,
 		bookingRepo: bookingRepo,
 		outboxRepo:  outboxRepo,
+		presaleRepo: presaleRepo,
+		pricingRepo: pricingRepo,
+		feeRepo:     feeRepo,
+		ledgerRepo:  ledgerRepo,
+		walletRepo:  walletRepo,
+		userRepo:    userRepo,
 		tm:          pool,
 	}
 }
 
-func (bs *BookingService) CreateBooking(ctx context.Context, booking *domain.Booking) error {
+func (bs *BookingService) CreateBooking(
+	ctx context.Context,
+	booking *domain.Booking,
+	opts CreateBookingOptions,
+) error {
 	err := bs.tm.RunInTx(ctx, func(ctx context.Context) error {
+		if err := bs.requireVerified(ctx, opts.CustomerID); err != nil {
+			return err
+		}
+		breakdown, err := bs.quote(ctx, booking.EventID(), opts)
+		if err != nil {
+			return err
+		}
 		if err := bs.eventRepo.ReserveSpots(ctx, booking.EventID(), 1); err != nil {
 			return err
 		}
+		if err := booking.LockPrice(breakdown); err != nil {
+			return err
+		}
+		if opts.WalletUserID != uuid.Nil {
+			if err := bs.payFromWallet(ctx, booking, opts.WalletUserID); err != nil {
+				return err
+			}
+		}
 		if err := bs.bookingRepo.CreateBooking(ctx, booking); err != nil {
 			return err
 		}
@@ -71,3 +131,242 @@ func (bs *BookingService) CreateBooking(ctx context.Context, booking *domain.Boo
 
 	return nil
 }
+
+// ConfirmBooking confirms a pending booking, posts the sale to the ledger and
+// publishes a BookingConfirmed event. Confirming an already confirmed booking
+// is a no-op.
+func (bs *BookingService) ConfirmBooking(ctx context.Context, bookingID uuid.UUID) (*domain.Booking, error) {
+	var booking *domain.Booking
+	err := bs.tm.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		booking, err = bs.bookingRepo.GetBookingByID(ctx, bookingID)
+		if err != nil {
+			return err
+		}
+		if booking.Status() == domain.BookingStatusConfirmed {
+			return nil
+		}
+		if err := booking.Confirm(); err != nil {
+			return err
+		}
+		if err := bs.bookingRepo.ConfirmBooking(ctx, bookingID); err != nil {
+			return err
+		}
+
+		event, err := bs.eventRepo.GetEvent(ctx, booking.EventID())
+		if err != nil {
+			return err
+		}
+		entry, err := domain.BookingJournalEntry(booking, event.OrganizerID())
+		if err != nil {
+			return err
+		}
+		if err := bs.ledgerRepo.PostEntry(ctx, entry); err != nil {
+			return err
+		}
+
+		return bs.publishBookingEvent(ctx, "BookingConfirmed", booking)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// payFromWallet spends as much of the user's wallet balance on the booking as
+// its total allows. The wallet stays locked until the booking is stored.
+func (bs *BookingService) payFromWallet(ctx context.Context, booking *domain.Booking, userID uuid.UUID) error {
+	wallet, err := bs.walletRepo.GetWalletForUpdate(ctx, userID, booking.Total().Currency())
+	if err != nil {
+		return err
+	}
+	amount := min(wallet.Balance().Amount(), booking.Total().Amount())
+	if amount <= 0 {
+		return nil
+	}
+	credit := booking.Total().WithAmount(amount)
+	if err := booking.ApplyCredit(credit); err != nil {
+		return err
+	}
+	transaction, err := wallet.Debit(domain.WalletBookingPayment, booking.ID(), credit)
+	if err != nil {
+		return err
+	}
+	return bs.walletRepo.SaveTransaction(ctx, wallet, transaction)
+}
+
+// RefundBooking cancels a confirmed booking, returns its spot to the event,
+// reverses its sale in the ledger and publishes a BookingRefunded event. The
+// full price, including any wallet credit spent, is credited to the
+// customer's wallet; customers without an account are refunded in cash.
+func (bs *BookingService) RefundBooking(ctx context.Context, bookingID uuid.UUID) (*domain.Booking, error) {
+	var booking *domain.Booking
+	err := bs.tm.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		booking, err = bs.bookingRepo.GetBookingByID(ctx, bookingID)
+		if err != nil {
+			return err
+		}
+		if err := booking.Refund(); err != nil {
+			return err
+		}
+		if err := bs.bookingRepo.RefundBooking(ctx, bookingID); err != nil {
+			return err
+		}
+		if err := bs.eventRepo.ReleaseSpots(ctx, booking.EventID(), 1); err != nil {
+			return err
+		}
+
+		sale, err := bs.ledgerRepo.GetEntry(ctx, domain.JournalBookingConfirmed, bookingID)
+		if err != nil {
+			return err
+		}
+		refund, err := sale.Reversal(domain.JournalBookingRefunded)
+		if err != nil {
+			return err
+		}
+		if err := bs.ledgerRepo.PostEntry(ctx, refund); err != nil {
+			return err
+		}
+		if err := bs.refundToWallet(ctx, booking); err != nil {
+			return err
+		}
+
+		return bs.publishBookingEvent(ctx, "BookingRefunded", booking)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// requireVerified returns ErrUserUnverified unless the customer verified
+// their email address.
+func (bs *BookingService) requireVerified(ctx context.Context, customerID uuid.UUID) error {
+	if customerID == uuid.Nil {
+		return nil
+	}
+	user, err := bs.userRepo.GetUserByID(ctx, customerID)
+	if err != nil {
+		return err
+	}
+	if !user.IsVerified() {
+		return domain.ErrUserUnverified
+	}
+	return nil
+}
+
+// refundToWallet credits a refunded booking to its customer's wallet. The
+// sale reversal returned the cash part to cash, so that part is moved to
+// customer credit; the credit spent was already returned by the reversal.
+func (bs *BookingService) refundToWallet(ctx context.Context, booking *domain.Booking) error {
+	user, err := bs.userRepo.GetUserByEmail(ctx, booking.UserEmail())
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	amount := booking.Total().WithAmount(booking.Total().Amount() + booking.CreditApplied().Amount())
+	if amount.Amount() <= 0 {
+		return nil
+	}
+	wallet, err := bs.walletRepo.GetWalletForUpdate(ctx, user.ID(), amount.Currency())
+	if err != nil {
+		return err
+	}
+	transaction, err := wallet.Credit(domain.WalletBookingRefund, booking.ID(), amount)
+	if err != nil {
+		return err
+	}
+	if err := bs.walletRepo.SaveTransaction(ctx, wallet, transaction); err != nil {
+		return err
+	}
+	if booking.Total().Amount() == 0 {
+		return nil
+	}
+
+	entry, err := domain.CustomerCreditJournalEntry(domain.JournalWalletRefund, transaction.ID(), booking.Total())
+	if err != nil {
+		return err
+	}
+	return bs.ledgerRepo.PostEntry(ctx, entry)
+}
+
+func (bs *BookingService) publishBookingEvent(ctx context.Context, name string, booking *domain.Booking) error {
+	eventData, err := json.Marshal(dto.ToBookingResponse(booking))
+	if err != nil {
+		return err
+	}
+	outboxEvent, err := domain.CreateOutboxEvent(
+		name,
+		eventData,
+		"booking_events_topic",
+		booking.ID(),
+	)
+	if err != nil {
+		return err
+	}
+	return bs.outboxRepo.Create(ctx, outboxEvent)
+}
+
+// quote enforces the event's sales window and returns the price breakdown
+// to lock into the booking. Presales are only loaded while general sales have
+// not opened yet.
+func (bs *BookingService) quote(
+	ctx context.Context,
+	eventID uuid.UUID,
+	opts CreateBookingOptions,
+) (domain.PriceBreakdown, error) {
+	event, err := bs.eventRepo.GetEvent(ctx, eventID)
+	if err != nil {
+		return domain.PriceBreakdown{}, err
+	}
+	if opts.OrganizationID != uuid.Nil && event.OrganizationID() != opts.OrganizationID {
+		return domain.PriceBreakdown{}, domain.ErrOrganizationForbidden
+	}
+
+	now := time.Now()
+	var presales []*domain.Presale
+	if salesStartAt, _ := event.SalesWindow(); now.Before(salesStartAt) {
+		presales, err = bs.presaleRepo.ListPresales(ctx, eventID)
+		if err != nil {
+			return domain.PriceBreakdown{}, err
+		}
+	}
+	if err := event.CheckOnSale(now, presales, opts.AccessCode); err != nil {
+		return domain.PriceBreakdown{}, err
+	}
+
+	rules, err := bs.pricingRepo.ListPricingRules(ctx, eventID)
+	if err != nil {
+		return domain.PriceBreakdown{}, err
+	}
+	price := domain.QuotePrice(event, rules, now)
+
+	fees, err := bs.feeSchedule(ctx, event)
+	if err != nil {
+		return domain.PriceBreakdown{}, err
+	}
+	vatRate := 0
+	if event.VenueCountry() != "" {
+		if vatRate, err = bs.feeRepo.GetVATRate(ctx, event.VenueCountry()); err != nil {
+			return domain.PriceBreakdown{}, err
+		}
+	}
+	return domain.PriceOrder(price, fees, vatRate), nil
+}
+
+// feeSchedule returns the organizer's service fee. Events without an
+// organizer, or whose organizer has no schedule, carry no fee.
+func (bs *BookingService) feeSchedule(ctx context.Context, event *domain.Event) (domain.FeeSchedule, error) {
+	if event.OrganizerID() == uuid.Nil {
+		return domain.FeeSchedule{}, nil
+	}
+	fees, err := bs.feeRepo.GetFeeSchedule(ctx, event.OrganizerID())
+	if errors.Is(err, domain.ErrFeeScheduleNotFound) {
+		return domain.FeeSchedule{}, nil
+	}
+	return fees, err
+}
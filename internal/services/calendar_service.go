@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/ical"
+)
+
+const (
+	calendarProdID        = "-//go-ticket//Go Ticket//EN"
+	calendarUIDDomain     = "go-ticket"
+	calendarFeedTokenKind = "calendar"
+)
+
+type CalendarServiceInterface interface {
+	EventCalendar(ctx context.Context, eventID uuid.UUID) (*ical.Calendar, error)
+	UserCalendar(ctx context.Context, feedToken string) (*ical.Calendar, error)
+	FeedToken(ctx context.Context, userID uuid.UUID) (string, error)
+	RegenerateFeedToken(ctx context.Context, userID uuid.UUID) (string, error)
+}
+
+type CalendarService struct {
+	eventRepository        domain.EventRepository
+	bookingRepository      domain.BookingRepository
+	userRepository         domain.UserRepository
+	calendarFeedRepository domain.CalendarFeedRepository
+	signer                 *auth.TokenSigner
+}
+
+// NewCalendarService creates a CalendarService. The signer has to use a key
+// of its own, so feed tokens cannot stand in for tokens of another kind.
+func NewCalendarService(
+	eventRepository domain.EventRepository,
+	bookingRepository domain.BookingRepository,
+	userRepository domain.UserRepository,
+	calendarFeedRepository domain.CalendarFeedRepository,
+	signer *auth.TokenSigner,
+) *CalendarService {
+	return &CalendarService{
+		eventRepository:        eventRepository,
+		bookingRepository:      bookingRepository,
+		userRepository:         userRepository,
+		calendarFeedRepository: calendarFeedRepository,
+		signer:                 signer,
+	}
+}
+
+// EventCalendar returns a calendar holding a single event.
+func (s *CalendarService) EventCalendar(ctx context.Context, eventID uuid.UUID) (*ical.Calendar, error) {
+	event, err := s.eventRepository.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	startAt, endAt := event.StartAndEndAt()
+	return &ical.Calendar{
+		ProdID: calendarProdID,
+		Method: "PUBLISH",
+		Events: []ical.Event{{
+			UID:          fmt.Sprintf("event-%s@%s", event.ID(), calendarUIDDomain),
+			Summary:      event.Name(),
+			Start:        startAt,
+			End:          endAt,
+			Stamp:        time.Now(),
+			LastModified: event.UpdatedAt(),
+			Sequence:     event.Revision(),
+			Status:       ical.StatusConfirmed,
+			Location:     eventLocation(event),
+		}},
+	}, nil
+}
+
+// UserCalendar returns the booking feed of the user the feed token was issued to.
+// Confirmed bookings are published as CONFIRMED and cancelled ones as CANCELLED,
+// so calendar clients remove entries they have already imported.
+func (s *CalendarService) UserCalendar(ctx context.Context, feedToken string) (*ical.Calendar, error) {
+	userID, err := s.userIDFromFeedToken(ctx, feedToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := s.bookingRepository.ListBookingsByUserEmail(ctx, user.Email())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookings: %w", err)
+	}
+
+	events := make(map[uuid.UUID]*domain.Event)
+	calendar := &ical.Calendar{
+		ProdID: calendarProdID,
+		Name:   "Go Ticket bookings",
+		Method: "PUBLISH",
+	}
+	now := time.Now()
+
+	for _, booking := range bookings {
+		// A booking is only ever cancelled after it was confirmed, which
+		// bumps the sequence on top of the changes made to the event.
+		var status ical.Status
+		var cancellations int
+		switch booking.Status() {
+		case domain.BookingStatusConfirmed:
+			status = ical.StatusConfirmed
+		case domain.BookingStatusCancelled:
+			status = ical.StatusCancelled
+			cancellations = 1
+		default:
+			continue
+		}
+
+		event, ok := events[booking.EventID()]
+		if !ok {
+			event, err = s.eventRepository.GetEvent(ctx, booking.EventID())
+			if err != nil {
+				return nil, err
+			}
+			events[booking.EventID()] = event
+		}
+
+		lastModified := booking.UpdatedAt()
+		if event.UpdatedAt().After(lastModified) {
+			lastModified = event.UpdatedAt()
+		}
+
+		startAt, endAt := event.StartAndEndAt()
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:          fmt.Sprintf("booking-%s@%s", booking.ID(), calendarUIDDomain),
+			Summary:      event.Name(),
+			Description:  fmt.Sprintf("Booking %s", booking.ID()),
+			Start:        startAt,
+			End:          endAt,
+			Stamp:        now,
+			LastModified: lastModified,
+			Sequence:     event.Revision() + cancellations,
+			Status:       status,
+			Location:     eventLocation(event),
+		})
+	}
+
+	return calendar, nil
+}
+
+// FeedToken returns the secret token that grants read access to the user's
+// calendar feed. The first call issues the feed secret the token is bound to.
+func (s *CalendarService) FeedToken(ctx context.Context, userID uuid.UUID) (string, error) {
+	feed, err := s.calendarFeedRepository.GetCalendarFeed(ctx, userID)
+	if errors.Is(err, domain.ErrCalendarFeedNotFound) {
+		// Concurrent first calls keep the secret stored first.
+		if err := s.calendarFeedRepository.CreateCalendarFeed(ctx, domain.NewCalendarFeed(userID, time.Now())); err != nil {
+			return "", err
+		}
+		feed, err = s.calendarFeedRepository.GetCalendarFeed(ctx, userID)
+	}
+	if err != nil {
+		return "", err
+	}
+	return s.signFeedToken(feed), nil
+}
+
+// RegenerateFeedToken replaces the feed secret of the user, so the tokens
+// handed out before stop working, and returns the new token.
+func (s *CalendarService) RegenerateFeedToken(ctx context.Context, userID uuid.UUID) (string, error) {
+	feed := domain.NewCalendarFeed(userID, time.Now())
+	if err := s.calendarFeedRepository.SaveCalendarFeed(ctx, feed); err != nil {
+		return "", err
+	}
+	return s.signFeedToken(feed), nil
+}
+
+func (s *CalendarService) signFeedToken(feed *domain.CalendarFeed) string {
+	return s.signer.Sign(calendarFeedTokenKind + ":" + feed.UserID().String() + ":" + feed.Secret())
+}
+
+func (s *CalendarService) userIDFromFeedToken(ctx context.Context, token string) (uuid.UUID, error) {
+	payload, err := s.signer.Verify(token)
+	if err != nil {
+		return uuid.Nil, domain.ErrInvalidCredentials
+	}
+	parts := strings.Split(payload, ":")
+	if len(parts) != 3 || parts[0] != calendarFeedTokenKind {
+		return uuid.Nil, domain.ErrInvalidCredentials
+	}
+	userID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, domain.ErrInvalidCredentials
+	}
+	feed, err := s.calendarFeedRepository.GetCalendarFeed(ctx, userID)
+	if errors.Is(err, domain.ErrCalendarFeedNotFound) {
+		return uuid.Nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if !feed.Matches(parts[2]) {
+		return uuid.Nil, domain.ErrInvalidCredentials
+	}
+	return userID, nil
+}
+
+// eventLocation returns the time zone of the event, or nil for UTC.
+func eventLocation(event *domain.Event) *time.Location {
+	location, err := time.LoadLocation(event.TimeZone())
+	if err != nil || location == time.UTC {
+		return nil
+	}
+	return location
+}
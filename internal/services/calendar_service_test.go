@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarService_FeedTokenCanBeRegenerated(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	queries := postgres.New(pool)
+	signer, err := auth.NewTokenSigner("calendar-secret")
+	assert.NoError(t, err)
+	calendarService := NewCalendarService(
+		postgres.NewEventRepository(queries),
+		postgres.NewBookingRepository(queries),
+		postgres.NewUserRepository(queries),
+		postgres.NewCalendarFeedRepository(queries),
+		signer,
+	)
+	user := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleUser)
+
+	token, err := calendarService.FeedToken(ctx, user.ID())
+	assert.NoError(t, err)
+	again, err := calendarService.FeedToken(ctx, user.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, token, again)
+	_, err = calendarService.UserCalendar(ctx, token)
+	assert.NoError(t, err)
+
+	// Regenerating turns away the URL handed out before.
+	regenerated, err := calendarService.RegenerateFeedToken(ctx, user.ID())
+	assert.NoError(t, err)
+	assert.NotEqual(t, token, regenerated)
+	_, err = calendarService.UserCalendar(ctx, token)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	_, err = calendarService.UserCalendar(ctx, regenerated)
+	assert.NoError(t, err)
+
+	// Tokens signed with another key are not feed tokens.
+	otherSigner, err := auth.NewTokenSigner("jwt-secret")
+	assert.NoError(t, err)
+	_, err = calendarService.UserCalendar(ctx, otherSigner.Sign("calendar:"+user.ID().String()))
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+}
+
+func TestCalendarService_EventSequenceAndTimeZone(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	queries := postgres.New(pool)
+	eventRepository := postgres.NewEventRepository(queries)
+	signer, err := auth.NewTokenSigner("calendar-secret")
+	assert.NoError(t, err)
+	calendarService := NewCalendarService(
+		eventRepository,
+		postgres.NewBookingRepository(queries),
+		postgres.NewUserRepository(queries),
+		postgres.NewCalendarFeedRepository(queries),
+		signer,
+	)
+	event := postgres.CreateTestEvent(ctx, t, pool)
+
+	stored, err := eventRepository.GetEvent(ctx, event.ID())
+	assert.NoError(t, err)
+	revision := stored.Revision()
+	assert.NoError(t, stored.SetTimeZone("Europe/Warsaw"))
+	assert.NoError(t, stored.UpdateName("Renamed"))
+	assert.NoError(t, eventRepository.UpdateEvent(ctx, stored))
+
+	calendar, err := calendarService.EventCalendar(ctx, event.ID())
+	assert.NoError(t, err)
+	assert.Len(t, calendar.Events, 1)
+	assert.Equal(t, revision+2, calendar.Events[0].Sequence)
+	assert.Equal(t, "Europe/Warsaw", calendar.Events[0].Location.String())
+}
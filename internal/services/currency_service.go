@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+
+	"github.com/mati/go-ticket/internal/currency"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type CurrencyServiceInterface interface {
+	Convert(ctx context.Context, amount domain.Money, to domain.Currency) (domain.Money, error)
+}
+
+// CurrencyService converts prices for display. Bookings are always charged
+// in the event's own currency.
+type CurrencyService struct {
+	rates currency.RateSource
+}
+
+func NewCurrencyService(rates currency.RateSource) *CurrencyService {
+	return &CurrencyService{rates: rates}
+}
+
+// Convert returns amount expressed in the target currency.
+func (s *CurrencyService) Convert(
+	ctx context.Context,
+	amount domain.Money,
+	to domain.Currency,
+) (domain.Money, error) {
+	if amount.Currency() == to {
+		return amount, nil
+	}
+	rate, err := s.rates.Rate(ctx, amount.Currency(), to)
+	if err != nil {
+		return domain.Money{}, err
+	}
+	return currency.Convert(amount, rate, to)
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+const (
+	userEventsTopic                = "user_events_topic"
+	verificationRequestedEventName = "EmailVerificationRequested"
+)
+
+type EmailVerificationServiceInterface interface {
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerification(ctx context.Context, userID uuid.UUID) error
+}
+
+type EmailVerificationService struct {
+	userRepository         domain.UserRepository
+	verificationRepository domain.EmailVerificationRepository
+	outboxRepository       domain.OutboxRepository
+	tm                     domain.TransactionManager
+	verifyURL              string
+}
+
+// NewEmailVerificationService creates the service. Verification links point
+// to verifyURL with the token in the "token" query parameter.
+func NewEmailVerificationService(
+	userRepository domain.UserRepository,
+	verificationRepository domain.EmailVerificationRepository,
+	outboxRepository domain.OutboxRepository,
+	tm domain.TransactionManager,
+	verifyURL string,
+) *EmailVerificationService {
+	return &EmailVerificationService{
+		userRepository:         userRepository,
+		verificationRepository: verificationRepository,
+		outboxRepository:       outboxRepository,
+		tm:                     tm,
+		verifyURL:              verifyURL,
+	}
+}
+
+// RequestVerification issues a verification token and writes the email
+// carrying its link to the outbox, so it is sent only if the surrounding
+// transaction commits.
+func (s *EmailVerificationService) RequestVerification(ctx context.Context, user *domain.User) error {
+	now := time.Now()
+	token, secret := domain.NewEmailVerificationToken(user.ID(), now)
+	if err := s.verificationRepository.CreateEmailVerificationToken(ctx, token); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(domain.AccountNotification{
+		ID:        token.ID(),
+		Kind:      domain.AccountNotificationEmailVerification,
+		UserEmail: user.Email(),
+		Link:      s.verifyURL + "?token=" + url.QueryEscape(secret),
+		ExpiresAt: token.ExpiresAt(),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return err
+	}
+	outboxEvent, err := domain.CreateOutboxEvent(verificationRequestedEventName, data, userEventsTopic, user.ID())
+	if err != nil {
+		return err
+	}
+	return s.outboxRepository.Create(ctx, outboxEvent)
+}
+
+// ResendVerification sends a new verification link to an unverified user.
+// Links sent before stop working.
+func (s *EmailVerificationService) ResendVerification(ctx context.Context, userID uuid.UUID) error {
+	return s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		user, err := s.userRepository.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.IsVerified() {
+			return domain.ErrUserAlreadyVerified
+		}
+		if err := s.verificationRepository.UseEmailVerificationTokens(ctx, user.ID(), time.Now()); err != nil {
+			return err
+		}
+		return s.RequestVerification(ctx, user)
+	})
+}
+
+// VerifyEmail activates the user a verification token was issued to and uses
+// up every outstanding token of that user.
+func (s *EmailVerificationService) VerifyEmail(ctx context.Context, token string) error {
+	return s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		verificationToken, err := s.verificationRepository.GetEmailVerificationTokenForUpdate(ctx, token)
+		if err != nil {
+			return err
+		}
+		if err := verificationToken.Use(now); err != nil {
+			return err
+		}
+
+		user, err := s.userRepository.GetUserByID(ctx, verificationToken.UserID())
+		if err != nil {
+			return err
+		}
+		if err := user.Verify(); err != nil {
+			return err
+		}
+		if err := s.userRepository.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		return s.verificationRepository.UseEmailVerificationTokens(ctx, user.ID(), now)
+	})
+}
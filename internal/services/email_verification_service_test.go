@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailVerificationService_VerifyUnblocksBooking(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	queries := postgres.New(pool)
+	userRepository := postgres.NewUserRepository(queries)
+	verificationService := newTestVerificationService(queries, userRepository, postgres.NewPgxTxManager(pool))
+	userService := newTestUserService(pool, newTestJWTService(t), &recordingRevoker{revoked: map[uuid.UUID]bool{}})
+	bookingService := newTestBookingService(pool)
+	event := postgres.CreateTestEvent(ctx, t, pool, postgres.WithCapacity(10))
+
+	assert.NoError(t, userService.RegisterUser(ctx, "verify@example.com", "password123"))
+	user, err := userRepository.GetUserByEmail(ctx, "verify@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.UserStatusUnverified, user.Status())
+	firstToken := pendingEmailToken(ctx, t, queries, user.ID(), domain.AccountNotificationEmailVerification)
+
+	opts := CreateBookingOptions{CustomerID: user.ID()}
+	booking, err := domain.NewBooking(uuid.New(), event.ID(), user.Email(), domain.BookingStatusPending)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, bookingService.CreateBooking(ctx, booking, opts), domain.ErrUserUnverified)
+
+	// A resent link replaces the first one.
+	assert.NoError(t, verificationService.ResendVerification(ctx, user.ID()))
+	token := pendingEmailToken(ctx, t, queries, user.ID(), domain.AccountNotificationEmailVerification)
+	assert.NotEqual(t, firstToken, token)
+	assert.ErrorIs(t, verificationService.VerifyEmail(ctx, firstToken), domain.ErrVerifyTokenInvalid)
+
+	assert.NoError(t, verificationService.VerifyEmail(ctx, token))
+	assert.ErrorIs(t, verificationService.VerifyEmail(ctx, token), domain.ErrVerifyTokenInvalid)
+	assert.ErrorIs(t, verificationService.ResendVerification(ctx, user.ID()), domain.ErrUserAlreadyVerified)
+	assert.NoError(t, bookingService.CreateBooking(ctx, booking, opts))
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestBookingService returns a BookingService backed by the test database.
+func newTestBookingService(pool *pgxpool.Pool) *BookingService {
+	queries := postgres.New(pool)
+	return NewBookingService(
+		postgres.NewEventRepository(queries),
+		postgres.NewBookingRepository(queries),
+		postgres.NewOutBoxRepository(queries),
+		postgres.NewPresaleRepository(queries),
+		postgres.NewPricingRuleRepository(queries),
+		postgres.NewFeeRepository(queries),
+		postgres.NewLedgerRepository(queries),
+		postgres.NewWalletRepository(queries),
+		postgres.NewUserRepository(queries),
+		postgres.NewPgxTxManager(pool),
+	)
+}
+
+var testClient = LoginClient{IPAddress: "203.0.113.7", UserAgent: "go-ticket-test"}
+
+type recordingRevoker struct {
+	revoked map[uuid.UUID]bool
+}
+
+func (r *recordingRevoker) Revoke(_ context.Context, tokenID uuid.UUID, _ time.Time) error {
+	r.revoked[tokenID] = true
+	return nil
+}
+
+func newTestVerificationService(
+	queries *postgres.Queries,
+	userRepository domain.UserRepository,
+	tm domain.TransactionManager,
+) *EmailVerificationService {
+	return NewEmailVerificationService(
+		userRepository,
+		postgres.NewEmailVerificationRepository(queries),
+		postgres.NewOutBoxRepository(queries),
+		tm,
+		"https://tickets.example.com/verify-email",
+	)
+}
+
+func newTestLoginSecurityService(queries *postgres.Queries) *LoginSecurityService {
+	return NewLoginSecurityService(postgres.NewLoginSecurityRepository(queries), postgres.NewOutBoxRepository(queries))
+}
+
+// newTestJWTService returns a JWTService signing with a fixed HMAC key.
+func newTestJWTService(t *testing.T) *auth.JWTService {
+	t.Helper()
+	keyring, err := auth.NewHMACKeyring("test-secret")
+	assert.NoError(t, err)
+	return auth.NewJWTService(keyring)
+}
+
+// newTestUserService returns a UserService backed by the test database that
+// signs access tokens with jwtService and revokes them through revoker.
+func newTestUserService(pool *pgxpool.Pool, jwtService *auth.JWTService, revoker TokenRevoker) *UserService {
+	queries := postgres.New(pool)
+	userRepository := postgres.NewUserRepository(queries)
+	txManager := postgres.NewPgxTxManager(pool)
+	return NewUserService(
+		userRepository,
+		postgres.NewRefreshTokenRepository(queries),
+		postgres.NewTwoFactorRepository(queries),
+		newTestVerificationService(queries, userRepository, txManager),
+		newTestLoginSecurityService(queries),
+		jwtService,
+		revoker,
+		txManager,
+	)
+}
+
+// pendingEmailToken returns the token of the latest account email of the
+// given kind waiting in the outbox for a user.
+func pendingEmailToken(
+	ctx context.Context,
+	t *testing.T,
+	queries *postgres.Queries,
+	userID uuid.UUID,
+	kind domain.AccountNotificationKind,
+) string {
+	t.Helper()
+
+	events, err := postgres.NewOutBoxRepository(queries).GetPendingEvents(ctx, 100)
+	assert.NoError(t, err)
+	var token string
+	for _, event := range events {
+		if event.Destination() != userEventsTopic || event.AggregateID() != userID {
+			continue
+		}
+		var notification domain.AccountNotification
+		assert.NoError(t, json.Unmarshal(event.EventData(), &notification))
+		if notification.Kind != kind {
+			continue
+		}
+		link, err := url.Parse(notification.Link)
+		assert.NoError(t, err)
+		token = link.Query().Get("token")
+	}
+	if token == "" {
+		t.Fatalf("expected a %s email in the outbox", kind)
+	}
+	return token
+}
+
+// recordingSuspender fails the next failures suspension flag writes.
+type recordingSuspender struct {
+	recordingRevoker
+	suspended map[uuid.UUID]bool
+	failures  int
+}
+
+func (s *recordingSuspender) SuspendUser(_ context.Context, userID uuid.UUID) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("redis unavailable")
+	}
+	s.suspended[userID] = true
+	return nil
+}
+
+func (s *recordingSuspender) UnsuspendUser(_ context.Context, userID uuid.UUID) error {
+	delete(s.suspended, userID)
+	return nil
+}
+
+// newTestOrganizationService returns an OrganizationService backed by the
+// test database.
+func newTestOrganizationService(pool *pgxpool.Pool) *OrganizationService {
+	queries := postgres.New(pool)
+	organizationRepository := postgres.NewOrganizationRepository(queries)
+	return NewOrganizationService(
+		postgres.NewUserRepository(queries),
+		organizationRepository,
+		organizationRepository,
+		postgres.NewEventRepository(queries),
+		postgres.NewBookingRepository(queries),
+		postgres.NewOrderRepository(queries),
+		postgres.NewAuditRepository(queries),
+		postgres.NewPgxTxManager(pool),
+	)
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type InventoryServiceInterface interface {
+	ConfigureShards(ctx context.Context, eventID uuid.UUID, shards int) error
+	Reconcile(ctx context.Context) ([]domain.InventoryDrift, error)
+}
+
+type InventoryService struct {
+	eventRepository     domain.EventRepository
+	inventoryRepository domain.InventoryRepository
+	tm                  domain.TransactionManager
+}
+
+func NewInventoryService(
+	eventRepository domain.EventRepository,
+	inventoryRepository domain.InventoryRepository,
+	tm domain.TransactionManager,
+) *InventoryService {
+	return &InventoryService{
+		eventRepository:     eventRepository,
+		inventoryRepository: inventoryRepository,
+		tm:                  tm,
+	}
+}
+
+// ConfigureShards splits the remaining spots of an event across the given
+// number of counter rows. Passing 0 folds them back onto the event row.
+func (s *InventoryService) ConfigureShards(ctx context.Context, eventID uuid.UUID, shards int) error {
+	return s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		event, err := s.eventRepository.GetEvent(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if err := event.ConfigureInventoryShards(shards); err != nil {
+			return err
+		}
+		return s.inventoryRepository.ReshardInventory(ctx, eventID, shards)
+	})
+}
+
+// Reconcile compares the shard totals of every sharded event with its
+// capacity minus active bookings and returns the events that drifted. Both
+// are read from one snapshot, so bookings made meanwhile are not mistaken
+// for drift. The aggregated total is written back to the event row either
+// way so reads that bypass the shards stay close to the truth.
+func (s *InventoryService) Reconcile(ctx context.Context) ([]domain.InventoryDrift, error) {
+	events, err := s.inventoryRepository.ListShardedEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []domain.InventoryDrift
+	for _, event := range events {
+		var (
+			shards []domain.InventoryShard
+			active int
+		)
+		err := s.tm.RunInTx(domain.WithRepeatableRead(ctx), func(ctx context.Context) error {
+			var err error
+			if shards, err = s.inventoryRepository.ListInventoryShards(ctx, event.ID()); err != nil {
+				return err
+			}
+			active, err = s.inventoryRepository.CountActiveBookings(ctx, event.ID())
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		sharded := 0
+		for _, shard := range shards {
+			sharded += shard.AvailableSpots
+		}
+
+		expected := event.Capacity() - active
+		if sharded != expected {
+			drifts = append(drifts, domain.InventoryDrift{
+				EventID:        event.ID(),
+				ShardedSpots:   sharded,
+				ExpectedSpots:  expected,
+				ActiveBookings: active,
+			})
+		}
+
+		if err := s.inventoryRepository.SyncAvailableSpots(ctx, event.ID(), sharded); err != nil {
+			return nil, err
+		}
+	}
+
+	return drifts, nil
+}
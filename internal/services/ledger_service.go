@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// recentSettlements is how many settlements are returned with a balance.
+const recentSettlements = 20
+
+type LedgerServiceInterface interface {
+	OrganizerBalance(ctx context.Context, organizerID uuid.UUID) (OrganizerBalance, error)
+}
+
+// OrganizerBalance is an organizer's balance per currency and latest payouts.
+type OrganizerBalance struct {
+	Balances    []domain.OrganizerBalance
+	Settlements []*domain.Settlement
+}
+
+type LedgerService struct {
+	ledgerRepository domain.LedgerRepository
+	tm               domain.TransactionManager
+}
+
+func NewLedgerService(ledgerRepository domain.LedgerRepository, tm domain.TransactionManager) *LedgerService {
+	return &LedgerService{
+		ledgerRepository: ledgerRepository,
+		tm:               tm,
+	}
+}
+
+func (s *LedgerService) OrganizerBalance(ctx context.Context, organizerID uuid.UUID) (OrganizerBalance, error) {
+	balances, err := s.ledgerRepository.OrganizerBalances(ctx, organizerID)
+	if err != nil {
+		return OrganizerBalance{}, err
+	}
+	settlements, err := s.ledgerRepository.ListSettlements(ctx, organizerID, recentSettlements)
+	if err != nil {
+		return OrganizerBalance{}, err
+	}
+	return OrganizerBalance{Balances: balances, Settlements: settlements}, nil
+}
+
+// RunPayouts settles every positive organizer balance and returns how many
+// settlements were created. Negative balances, left by refunds after a
+// payout, are carried over until new sales cover them.
+func (s *LedgerService) RunPayouts(ctx context.Context) (int, error) {
+	settled := 0
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		payables, err := s.ledgerRepository.LockPayableBalances(ctx)
+		if err != nil {
+			return err
+		}
+		for _, payable := range payables {
+			if payable.Amount.Amount() <= 0 {
+				continue
+			}
+			settlement, err := domain.NewSettlement(payable.OrganizerID, payable.Amount)
+			if err != nil {
+				return err
+			}
+			entry, err := domain.PayoutJournalEntry(settlement)
+			if err != nil {
+				return err
+			}
+			if err := s.ledgerRepository.CreateSettlement(ctx, settlement, entry); err != nil {
+				return err
+			}
+			settled++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return settled, nil
+}
+
+// Verify reconciles the ledger against the bookings and returns every discrepancy found.
+func (s *LedgerService) Verify(ctx context.Context) ([]domain.LedgerDiscrepancy, error) {
+	return s.ledgerRepository.Discrepancies(ctx)
+}
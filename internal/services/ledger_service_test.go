@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger_ConfirmPayoutRefundReconciles(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	organizer := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleOrganizer)
+	event := postgres.CreateTestEvent(
+		ctx, t, pool,
+		postgres.WithPrice(10000),
+		postgres.WithOrganizer(organizer.ID()),
+		postgres.WithVenueCountry("PL"),
+	)
+
+	queries := postgres.New(pool)
+	feeRepository := postgres.NewFeeRepository(queries)
+	bookingService := newTestBookingService(pool)
+	ledgerService := NewLedgerService(postgres.NewLedgerRepository(queries), postgres.NewPgxTxManager(pool))
+
+	fees, err := domain.NewFeeSchedule(500, 99)
+	assert.NoError(t, err)
+	assert.NoError(t, feeRepository.SaveFeeSchedule(ctx, organizer.ID(), fees))
+
+	var bookingIDs []uuid.UUID
+	for range 2 {
+		booking, err := domain.NewBooking(uuid.New(), event.ID(), "buyer@example.com", domain.BookingStatusPending)
+		assert.NoError(t, err)
+		assert.NoError(t, bookingService.CreateBooking(ctx, booking, CreateBookingOptions{}))
+		_, err = bookingService.ConfirmBooking(ctx, booking.ID())
+		assert.NoError(t, err)
+		bookingIDs = append(bookingIDs, booking.ID())
+	}
+
+	balance, err := ledgerService.OrganizerBalance(ctx, organizer.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.OrganizerBalance{{
+		Available: domain.UnmarshalMoney(20000, "EUR"),
+		PaidOut:   domain.UnmarshalMoney(0, "EUR"),
+	}}, balance.Balances)
+
+	settled, err := ledgerService.RunPayouts(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, settled)
+
+	// A refund after the payout leaves the organizer owing the ticket price.
+	_, err = bookingService.RefundBooking(ctx, bookingIDs[0])
+	assert.NoError(t, err)
+	_, err = bookingService.RefundBooking(ctx, bookingIDs[0])
+	assert.ErrorIs(t, err, domain.ErrBookingNotRefundable)
+
+	balance, err = ledgerService.OrganizerBalance(ctx, organizer.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.OrganizerBalance{{
+		Available: domain.UnmarshalMoney(-10000, "EUR"),
+		PaidOut:   domain.UnmarshalMoney(20000, "EUR"),
+	}}, balance.Balances)
+	assert.Len(t, balance.Settlements, 1)
+
+	settled, err = ledgerService.RunPayouts(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, settled)
+
+	discrepancies, err := ledgerService.Verify(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, discrepancies)
+
+	_, err = pool.Exec(ctx, "DELETE FROM journal_lines")
+	assert.Error(t, err, "journal must be append-only")
+}
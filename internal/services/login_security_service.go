@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+const (
+	newSignInEventName     = "NewSignInDetected"
+	accountLockedEventName = "AccountLockedOut"
+)
+
+// LoginClient tells where a sign-in attempt comes from.
+type LoginClient struct {
+	IPAddress string
+	UserAgent string
+}
+
+type LoginSecurityServiceInterface interface {
+	ListLogins(ctx context.Context, userID uuid.UUID, page domain.LoginHistoryPage) ([]*domain.LoginRecord, error)
+}
+
+// LoginSecurityService locks accounts out after repeated failed sign-ins and
+// keeps the login history of every account. Users are emailed when their
+// account is locked out, and when they sign in from an IP address or a device
+// they never used before.
+type LoginSecurityService struct {
+	loginSecurityRepository domain.LoginSecurityRepository
+	outboxRepository        domain.OutboxRepository
+}
+
+func NewLoginSecurityService(
+	loginSecurityRepository domain.LoginSecurityRepository,
+	outboxRepository domain.OutboxRepository,
+) *LoginSecurityService {
+	return &LoginSecurityService{
+		loginSecurityRepository: loginSecurityRepository,
+		outboxRepository:        outboxRepository,
+	}
+}
+
+// CheckLockout returns domain.ErrAccountLocked while the account of userID
+// is locked out. Call it in the transaction that records the attempt.
+func (s *LoginSecurityService) CheckLockout(ctx context.Context, userID uuid.UUID) error {
+	lockout, err := s.loginSecurityRepository.GetAccountLockoutForUpdate(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return lockout.Check(time.Now())
+}
+
+// RecordFailure adds a refused sign-in to the login history. Wrong passwords
+// and codes count toward locking the account out; the failure that locks it
+// writes an email about the lockout to the outbox.
+func (s *LoginSecurityService) RecordFailure(
+	ctx context.Context,
+	user *domain.User,
+	client LoginClient,
+	outcome domain.LoginOutcome,
+) error {
+	now := time.Now()
+	record := domain.NewLoginRecord(user.ID(), client.IPAddress, client.UserAgent, outcome, now)
+	if err := s.loginSecurityRepository.CreateLoginRecord(ctx, record); err != nil {
+		return err
+	}
+	if !outcome.CountsTowardLockout() {
+		return nil
+	}
+
+	lockout, err := s.loginSecurityRepository.GetAccountLockoutForUpdate(ctx, user.ID())
+	if err != nil {
+		return err
+	}
+	lockout.Fail(now)
+	if err := s.loginSecurityRepository.SaveAccountLockout(ctx, lockout); err != nil {
+		return err
+	}
+	if lockout.Check(now) == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(domain.AccountNotification{
+		ID:        record.ID(),
+		Kind:      domain.AccountNotificationAccountLocked,
+		UserEmail: user.Email(),
+		ExpiresAt: lockout.LockedUntil(),
+		CreatedAt: now,
+		IPAddress: record.IPAddress(),
+		UserAgent: record.UserAgent(),
+	})
+	if err != nil {
+		return err
+	}
+	outboxEvent, err := domain.CreateOutboxEvent(accountLockedEventName, data, userEventsTopic, user.ID())
+	if err != nil {
+		return err
+	}
+	return s.outboxRepository.Create(ctx, outboxEvent)
+}
+
+// RecordSuccess adds a sign-in to the login history and clears the failed
+// ones. A sign-in from an unfamiliar IP address or device writes an email
+// about it to the outbox, so it is sent only if the sign-in commits.
+func (s *LoginSecurityService) RecordSuccess(ctx context.Context, user *domain.User, client LoginClient) error {
+	now := time.Now()
+	record := domain.NewLoginRecord(user.ID(), client.IPAddress, client.UserAgent, domain.LoginOutcomeSuccess, now)
+	familiarity, err := s.loginSecurityRepository.GetLoginFamiliarity(
+		ctx,
+		user.ID(),
+		record.IPAddress(),
+		record.UserAgent(),
+	)
+	if err != nil {
+		return err
+	}
+	if err := s.loginSecurityRepository.DeleteAccountLockout(ctx, user.ID()); err != nil {
+		return err
+	}
+	if err := s.loginSecurityRepository.CreateLoginRecord(ctx, record); err != nil {
+		return err
+	}
+	if !familiarity.Unfamiliar() {
+		return nil
+	}
+
+	data, err := json.Marshal(domain.AccountNotification{
+		ID:        record.ID(),
+		Kind:      domain.AccountNotificationNewSignIn,
+		UserEmail: user.Email(),
+		CreatedAt: now,
+		IPAddress: record.IPAddress(),
+		UserAgent: record.UserAgent(),
+	})
+	if err != nil {
+		return err
+	}
+	outboxEvent, err := domain.CreateOutboxEvent(newSignInEventName, data, userEventsTopic, user.ID())
+	if err != nil {
+		return err
+	}
+	return s.outboxRepository.Create(ctx, outboxEvent)
+}
+
+// ListLogins returns a page of a user's sign-in attempts, newest first.
+func (s *LoginSecurityService) ListLogins(
+	ctx context.Context,
+	userID uuid.UUID,
+	page domain.LoginHistoryPage,
+) ([]*domain.LoginRecord, error) {
+	return s.loginSecurityRepository.ListLoginRecords(ctx, userID, page)
+}
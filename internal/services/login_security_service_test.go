@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginSecurityService_LockoutHistoryAndNewDeviceEmail(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	queries := postgres.New(pool)
+	userRepository := postgres.NewUserRepository(queries)
+	loginSecurityService := newTestLoginSecurityService(queries)
+	userService := newTestUserService(pool, newTestJWTService(t), &recordingRevoker{revoked: map[uuid.UUID]bool{}})
+	assert.NoError(t, userService.RegisterUser(ctx, "guarded@example.com", "password123"))
+	user, err := userRepository.GetUserByEmail(ctx, "guarded@example.com")
+	assert.NoError(t, err)
+	accountEmails := func(kind domain.AccountNotificationKind) []domain.AccountNotification {
+		events, err := postgres.NewOutBoxRepository(queries).GetPendingEvents(ctx, 100)
+		assert.NoError(t, err)
+		var emails []domain.AccountNotification
+		for _, event := range events {
+			var notification domain.AccountNotification
+			assert.NoError(t, json.Unmarshal(event.EventData(), &notification))
+			if event.AggregateID() == user.ID() && notification.Kind == kind {
+				emails = append(emails, notification)
+			}
+		}
+		return emails
+	}
+
+	// Wrong passwords lock the account out, even for the right password. The
+	// lockout is refused like an unknown address and told to the owner by
+	// email only.
+	for range domain.LockoutThreshold {
+		_, err = userService.LoginUser(ctx, "guarded@example.com", "wrong-password", testClient)
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	}
+	_, err = userService.LoginUser(ctx, "guarded@example.com", "password123", testClient)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	_, err = userService.LoginUser(ctx, "nobody@example.com", "password123", testClient)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	locked := accountEmails(domain.AccountNotificationAccountLocked)
+	assert.Len(t, locked, 1)
+	assert.Equal(t, "guarded@example.com", locked[0].UserEmail)
+	assert.True(t, locked[0].ExpiresAt.After(locked[0].CreatedAt))
+
+	records, err := loginSecurityService.ListLogins(ctx, user.ID(), domain.LoginHistoryPage{}.WithDefaults())
+	assert.NoError(t, err)
+	assert.Len(t, records, domain.LockoutThreshold+1)
+	assert.Equal(t, domain.LoginOutcomeLocked, records[0].Outcome())
+	assert.Equal(t, domain.LoginOutcomeInvalidPassword, records[1].Outcome())
+	assert.Equal(t, testClient.IPAddress, records[0].IPAddress())
+	assert.Equal(t, testClient.UserAgent, records[0].UserAgent())
+
+	// Once the lockout expires, sign-ins from a new device are emailed about,
+	// but not the first sign-in of the account.
+	assert.NoError(t, postgres.NewLoginSecurityRepository(queries).DeleteAccountLockout(ctx, user.ID()))
+	_, err = userService.LoginUser(ctx, "guarded@example.com", "password123", testClient)
+	assert.NoError(t, err)
+	assert.Empty(t, accountEmails(domain.AccountNotificationNewSignIn))
+
+	newDevice := LoginClient{IPAddress: testClient.IPAddress, UserAgent: "another-browser"}
+	_, err = userService.LoginUser(ctx, "guarded@example.com", "password123", newDevice)
+	assert.NoError(t, err)
+	_, err = userService.LoginUser(ctx, "guarded@example.com", "password123", newDevice)
+	assert.NoError(t, err)
+	emails := accountEmails(domain.AccountNotificationNewSignIn)
+	assert.Len(t, emails, 1)
+	assert.Equal(t, "another-browser", emails[0].UserAgent)
+	assert.Equal(t, "guarded@example.com", emails[0].UserEmail)
+}
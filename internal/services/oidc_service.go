@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/oidc"
+)
+
+type OIDCServiceInterface interface {
+	StartLogin(ctx context.Context, provider, loginHint string) (string, error)
+	CompleteLogin(ctx context.Context, provider, state, code string, client LoginClient) (Login, error)
+}
+
+// IdentityProvider is an OpenID Connect provider users sign in with.
+type IdentityProvider interface {
+	AuthCodeURL(ctx context.Context, state, nonce, codeVerifier, loginHint string) (string, error)
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (oidc.Identity, error)
+}
+
+// SignInCompleter completes the sign-in of users who proved who they are,
+// challenging them for a second factor when they use one.
+type SignInCompleter interface {
+	CompleteSignIn(ctx context.Context, user *domain.User, client LoginClient) (Login, error)
+}
+
+// OIDCService signs users in with OpenID Connect providers and hands out
+// our own sessions. Provider accounts are linked to users by their verified
+// email address on first sign-in; users are created when none matches.
+type OIDCService struct {
+	providers              map[string]IdentityProvider
+	userRepository         domain.UserRepository
+	identityRepository     domain.IdentityRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	signIns                SignInCompleter
+	revoker                TokenRevoker
+	tm                     domain.TransactionManager
+}
+
+func NewOIDCService(
+	providers map[string]IdentityProvider,
+	userRepository domain.UserRepository,
+	identityRepository domain.IdentityRepository,
+	refreshTokenRepository domain.RefreshTokenRepository,
+	signIns SignInCompleter,
+	revoker TokenRevoker,
+	tm domain.TransactionManager,
+) *OIDCService {
+	return &OIDCService{
+		providers:              providers,
+		userRepository:         userRepository,
+		identityRepository:     identityRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		signIns:                signIns,
+		revoker:                revoker,
+		tm:                     tm,
+	}
+}
+
+// StartLogin stores a new sign-in and returns the provider URL to send the
+// user to.
+func (s *OIDCService) StartLogin(ctx context.Context, provider, loginHint string) (string, error) {
+	identityProvider, ok := s.providers[provider]
+	if !ok {
+		return "", domain.ErrOIDCProviderUnknown
+	}
+	login, state := domain.NewOIDCLogin(provider, time.Now())
+	authURL, err := identityProvider.AuthCodeURL(ctx, state, login.Nonce(), login.CodeVerifier(), loginHint)
+	if err != nil {
+		return "", err
+	}
+	if err := s.identityRepository.CreateOIDCLogin(ctx, login); err != nil {
+		return "", err
+	}
+	return authURL, nil
+}
+
+// CompleteLogin redeems the code the provider sent the user back with and
+// signs in the user the provider account is linked to. Users of two-factor
+// authentication are challenged for their code as after a password login.
+func (s *OIDCService) CompleteLogin(
+	ctx context.Context,
+	provider, state, code string,
+	client LoginClient,
+) (Login, error) {
+	identityProvider, ok := s.providers[provider]
+	if !ok {
+		return Login{}, domain.ErrOIDCProviderUnknown
+	}
+	pending, err := s.identityRepository.TakeOIDCLogin(ctx, state)
+	if err != nil {
+		return Login{}, err
+	}
+	if err := pending.Complete(provider, time.Now()); err != nil {
+		return Login{}, err
+	}
+	identity, err := identityProvider.Exchange(ctx, code, pending.CodeVerifier(), pending.Nonce())
+	if err != nil {
+		slog.Warn("OIDC code exchange failed", "provider", provider, "error", err)
+		return Login{}, domain.ErrOIDCLoginFailed
+	}
+
+	var (
+		login   Login
+		revoked []uuid.UUID
+	)
+	err = s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		var user *domain.User
+		user, revoked, err = s.linkedUser(ctx, provider, identity)
+		if err != nil {
+			return err
+		}
+		if user.IsSuspended() {
+			return domain.ErrUserSuspended
+		}
+		login, err = s.signIns.CompleteSignIn(ctx, user, client)
+		return err
+	})
+	if err != nil {
+		return Login{}, err
+	}
+	if err := revokeAccessTokens(ctx, s.revoker, revoked); err != nil {
+		return Login{}, err
+	}
+	return login, nil
+}
+
+// linkedUser returns the user a provider account is linked to, linking it on
+// first sign-in to the user with the same email address, or a new one. Only
+// addresses the provider verified are linked, and they count as verified
+// for us too. It also returns the access tokens to revoke once the sign-in
+// is committed.
+func (s *OIDCService) linkedUser(
+	ctx context.Context,
+	provider string,
+	identity oidc.Identity,
+) (*domain.User, []uuid.UUID, error) {
+	linked, err := s.identityRepository.GetUserIdentity(ctx, provider, identity.Subject)
+	if err == nil {
+		user, err := s.userRepository.GetUserByID(ctx, linked.UserID())
+		return user, nil, err
+	}
+	if !errors.Is(err, domain.ErrIdentityNotFound) {
+		return nil, nil, err
+	}
+	if !identity.EmailVerified || identity.Email == "" {
+		return nil, nil, domain.ErrOIDCEmailUnverified
+	}
+
+	var revoked []uuid.UUID
+	user, err := s.userRepository.GetUserByEmail(ctx, identity.Email)
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		if user, err = s.createUser(ctx, identity.Email); err != nil {
+			return nil, nil, err
+		}
+	case err != nil:
+		return nil, nil, err
+	case !user.IsVerified():
+		if revoked, err = s.claimUnverifiedUser(ctx, user); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	linked = domain.NewUserIdentity(user.ID(), provider, identity.Subject, identity.Email, time.Now())
+	if err := s.identityRepository.CreateUserIdentity(ctx, linked); err != nil {
+		return nil, nil, err
+	}
+	return user, revoked, nil
+}
+
+// claimUnverifiedUser verifies an account nobody proved to own before the
+// provider account is linked to it. Whoever registered it may not be the
+// owner of the address, so its password is replaced by a random one and its
+// sessions are ended; the owner may set a password with a password reset.
+// It returns the access tokens of the ended sessions.
+func (s *OIDCService) claimUnverifiedUser(ctx context.Context, user *domain.User) ([]uuid.UUID, error) {
+	passwordHash, err := auth.HashPassword(rand.Text())
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	if err := user.UpdatePassword(passwordHash); err != nil {
+		return nil, err
+	}
+	if err := user.Verify(); err != nil {
+		return nil, err
+	}
+	if err := s.userRepository.UpdateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return s.refreshTokenRepository.RevokeUserTokens(ctx, user.ID(), now, now.Add(-auth.AccessTokenTTL))
+}
+
+// createUser registers a verified user for a provider account. Its random
+// password is never shown; the user may set one with a password reset.
+func (s *OIDCService) createUser(ctx context.Context, email string) (*domain.User, error) {
+	passwordHash, err := auth.HashPassword(rand.Text())
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	user, err := domain.NewUser(uuid.New(), email, passwordHash, domain.UserRoleUser)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.Verify(); err != nil {
+		return nil, err
+	}
+	if err := s.userRepository.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/oidc"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+// followOIDCLogin sends the user to the provider URL and returns the state
+// and code the provider redirects back with.
+func followOIDCLogin(t *testing.T, authURL string) (string, string) {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	location, err := url.Parse(resp.Header.Get("Location"))
+	assert.NoError(t, err)
+	return location.Query().Get("state"), location.Query().Get("code")
+}
+
+func TestOIDCService_LinksVerifiedEmailToUser(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	var mock *oidc.MockProvider
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mock.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+	mock, err := oidc.NewMockProvider(server.URL)
+	assert.NoError(t, err)
+	provider := oidc.NewProvider(oidc.ProviderConfig{
+		Name:        "mock",
+		Issuer:      server.URL,
+		ClientID:    "go-ticket",
+		RedirectURL: "http://localhost:8080/auth/oidc/mock/callback",
+	}, server.Client())
+
+	jwtService := newTestJWTService(t)
+	queries := postgres.New(pool)
+	userRepository := postgres.NewUserRepository(queries)
+	revoker := &recordingRevoker{revoked: map[uuid.UUID]bool{}}
+	userService := newTestUserService(pool, jwtService, revoker)
+	oidcService := NewOIDCService(
+		map[string]IdentityProvider{"mock": provider},
+		userRepository,
+		postgres.NewIdentityRepository(queries),
+		postgres.NewRefreshTokenRepository(queries),
+		userService,
+		revoker,
+		postgres.NewPgxTxManager(pool),
+	)
+
+	_, err = oidcService.StartLogin(ctx, "unknown", "")
+	assert.ErrorIs(t, err, domain.ErrOIDCProviderUnknown)
+
+	// The first sign-in links the provider account to the registered user
+	// and verifies their address. Whoever registered it may not own the
+	// address, so their password and sessions stop working.
+	assert.NoError(t, userService.RegisterUser(ctx, "linked@example.com", "password123"))
+	registered, err := userRepository.GetUserByEmail(ctx, "linked@example.com")
+	assert.NoError(t, err)
+	assert.False(t, registered.IsVerified())
+	squatter, err := userService.LoginUser(ctx, "linked@example.com", "password123", testClient)
+	assert.NoError(t, err)
+
+	authURL, err := oidcService.StartLogin(ctx, "mock", "linked@example.com")
+	assert.NoError(t, err)
+	state, code := followOIDCLogin(t, authURL)
+	login, err := oidcService.CompleteLogin(ctx, "mock", state, code, testClient)
+	assert.NoError(t, err)
+	claims, err := jwtService.VerifyToken(login.Session.AccessToken.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, registered.ID(), claims.UserID)
+	linked, err := userRepository.GetUserByID(ctx, registered.ID())
+	assert.NoError(t, err)
+	assert.True(t, linked.IsVerified())
+	_, err = userService.LoginUser(ctx, "linked@example.com", "password123", testClient)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	assert.True(t, revoker.revoked[squatter.Session.AccessToken.ID])
+
+	// A state completes once.
+	_, err = oidcService.CompleteLogin(ctx, "mock", state, code, testClient)
+	assert.ErrorIs(t, err, domain.ErrOIDCStateInvalid)
+
+	// Later sign-ins reuse the linked identity.
+	authURL, err = oidcService.StartLogin(ctx, "mock", "linked@example.com")
+	assert.NoError(t, err)
+	state, code = followOIDCLogin(t, authURL)
+	login, err = oidcService.CompleteLogin(ctx, "mock", state, code, testClient)
+	assert.NoError(t, err)
+	claims, err = jwtService.VerifyToken(login.Session.AccessToken.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, registered.ID(), claims.UserID)
+
+	// Unknown addresses get a new, verified user.
+	authURL, err = oidcService.StartLogin(ctx, "mock", "new@example.com")
+	assert.NoError(t, err)
+	state, code = followOIDCLogin(t, authURL)
+	_, err = oidcService.CompleteLogin(ctx, "mock", state, code, testClient)
+	assert.NoError(t, err)
+	created, err := userRepository.GetUserByEmail(ctx, "new@example.com")
+	assert.NoError(t, err)
+	assert.True(t, created.IsVerified())
+}
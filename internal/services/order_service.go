@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// overdueOrderBatch is how many overdue orders one expiry run handles.
+const overdueOrderBatch = 100
+
+type OrderServiceInterface interface {
+	CreateInvoiceOrder(ctx context.Context, order *domain.Order, opts CreateBookingOptions) error
+	GetOrder(ctx context.Context, orderID uuid.UUID, requester Requester) (*domain.Order, error)
+	MarkOrderPaid(ctx context.Context, orderID uuid.UUID) (*domain.Order, error)
+}
+
+// orderBookingService creates and confirms the bookings of an order.
+type orderBookingService interface {
+	CreateBookingService
+	ConfirmBookingService
+}
+
+type OrderService struct {
+	orderRepository   domain.OrderRepository
+	bookingRepository domain.BookingRepository
+	eventRepository   domain.EventRepository
+	bookingService    orderBookingService
+	tm                domain.TransactionManager
+}
+
+func NewOrderService(
+	orderRepository domain.OrderRepository,
+	bookingRepository domain.BookingRepository,
+	eventRepository domain.EventRepository,
+	bookingService orderBookingService,
+	tm domain.TransactionManager,
+) *OrderService {
+	return &OrderService{
+		orderRepository:   orderRepository,
+		bookingRepository: bookingRepository,
+		eventRepository:   eventRepository,
+		bookingService:    bookingService,
+		tm:                tm,
+	}
+}
+
+// CreateInvoiceOrder stores an order and books a spot for each attendee in
+// one transaction, so either every seat is reserved or none. The invoice must
+// fall due before the event starts.
+func (s *OrderService) CreateInvoiceOrder(
+	ctx context.Context,
+	order *domain.Order,
+	opts CreateBookingOptions,
+) error {
+	return s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		event, err := s.eventRepository.GetEvent(ctx, order.EventID())
+		if err != nil {
+			return err
+		}
+		if startAt, _ := event.StartAndEndAt(); order.DueAt().After(startAt) {
+			return domain.ErrOrderDueDateInvalid
+		}
+
+		if err := s.orderRepository.CreateOrder(ctx, order); err != nil {
+			return err
+		}
+		for _, booking := range order.Bookings() {
+			if err := s.bookingService.CreateBooking(ctx, booking, opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetOrder returns an order with its bookings. Orders of other purchasers are
+// reported as not found.
+func (s *OrderService) GetOrder(ctx context.Context, orderID uuid.UUID, requester Requester) (*domain.Order, error) {
+	order, err := s.orderRepository.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !requester.Staff && order.PurchaserEmail() != requester.Email {
+		return nil, domain.ErrOrderNotFound
+	}
+	return order, nil
+}
+
+// MarkOrderPaid records the invoice payment and confirms every booking of the
+// order, posting each sale to the ledger.
+func (s *OrderService) MarkOrderPaid(ctx context.Context, orderID uuid.UUID) (*domain.Order, error) {
+	var order *domain.Order
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		order, err = s.orderRepository.GetOrderForUpdate(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		if err := order.MarkPaid(); err != nil {
+			return err
+		}
+		for _, booking := range order.Bookings() {
+			if _, err := s.bookingService.ConfirmBooking(ctx, booking.ID()); err != nil {
+				return err
+			}
+		}
+		if err := s.orderRepository.UpdateOrderStatus(ctx, order); err != nil {
+			return err
+		}
+
+		// Reload so the returned bookings carry their confirmed status.
+		order, err = s.orderRepository.GetOrder(ctx, orderID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// ExpireOverdueOrders cancels the pending bookings of orders whose invoice
+// was not paid by its due date and releases their spots. It returns how many
+// orders expired.
+func (s *OrderService) ExpireOverdueOrders(ctx context.Context) (int, error) {
+	expired := 0
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		orders, err := s.orderRepository.ListOverdueOrders(ctx, now, overdueOrderBatch)
+		if err != nil {
+			return err
+		}
+		for _, order := range orders {
+			if err := order.Expire(now); err != nil {
+				return err
+			}
+
+			released := 0
+			for _, booking := range order.Bookings() {
+				// Bookings confirmed one by one by staff keep their seat.
+				if booking.Status() != domain.BookingStatusPending {
+					continue
+				}
+				if err := s.bookingRepository.CancelBooking(ctx, booking.ID()); err != nil {
+					return err
+				}
+				released++
+			}
+			if released > 0 {
+				if err := s.eventRepository.ReleaseSpots(ctx, order.EventID(), released); err != nil {
+					return err
+				}
+			}
+
+			if err := s.orderRepository.UpdateOrderStatus(ctx, order); err != nil {
+				return err
+			}
+			expired++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return expired, nil
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderService_InvoiceOrderLifecycle(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+	event := postgres.CreateTestEvent(ctx, t, pool, postgres.WithCapacity(5))
+
+	queries := postgres.New(pool)
+	orderService := NewOrderService(
+		postgres.NewOrderRepository(queries),
+		postgres.NewBookingRepository(queries),
+		postgres.NewEventRepository(queries),
+		newTestBookingService(pool),
+		postgres.NewPgxTxManager(pool),
+	)
+
+	newOrder := func(seats int) *domain.Order {
+		order, err := domain.NewInvoiceOrder(
+			uuid.New(), event.ID(), "buyer@example.com", "Acme Corp", make([]string, seats), time.Now().Add(30*time.Minute),
+		)
+		assert.NoError(t, err)
+		assert.NoError(t, orderService.CreateInvoiceOrder(ctx, order, CreateBookingOptions{}))
+		return order
+	}
+
+	tooMany, err := domain.NewInvoiceOrder(
+		uuid.New(), event.ID(), "buyer@example.com", "", make([]string, 6), time.Now().Add(30*time.Minute),
+	)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, orderService.CreateInvoiceOrder(ctx, tooMany, CreateBookingOptions{}), domain.ErrEventIsFull)
+	assert.Equal(t, 5, postgres.GetEventFromDB(ctx, t, pool, event.ID()).AvailableSpots())
+
+	paid := newOrder(2)
+	order, err := orderService.MarkOrderPaid(ctx, paid.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusPaid, order.Status())
+	for _, booking := range order.Bookings() {
+		assert.Equal(t, domain.BookingStatusConfirmed, booking.Status())
+	}
+	_, err = orderService.MarkOrderPaid(ctx, paid.ID())
+	assert.ErrorIs(t, err, domain.ErrOrderNotAwaitingPayment)
+
+	_, err = orderService.GetOrder(ctx, paid.ID(), Requester{Email: "other@example.com"})
+	assert.ErrorIs(t, err, domain.ErrOrderNotFound)
+
+	unpaid := newOrder(3)
+	assert.Equal(t, 0, postgres.GetEventFromDB(ctx, t, pool, event.ID()).AvailableSpots())
+
+	expired, err := orderService.ExpireOverdueOrders(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, expired, "orders are not expired before their due date")
+
+	_, err = pool.Exec(ctx, "UPDATE orders SET due_at = NOW() - INTERVAL '1 minute' WHERE id = $1", unpaid.ID())
+	assert.NoError(t, err)
+	expired, err = orderService.ExpireOverdueOrders(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	order, err = orderService.GetOrder(ctx, unpaid.ID(), Requester{Email: "buyer@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusExpired, order.Status())
+	for _, booking := range order.Bookings() {
+		assert.Equal(t, domain.BookingStatusCancelled, booking.Status())
+	}
+	assert.Equal(t, 3, postgres.GetEventFromDB(ctx, t, pool, event.ID()).AvailableSpots())
+}
@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// Actor identifies the signed-in user acting on an organization or on its
+// events and bookings.
+type Actor struct {
+	ID   uuid.UUID
+	Role domain.UserRole
+	// OrganizationID confines an actor signed in with an API key to the
+	// organization of the key. It is uuid.Nil for users signed in themselves.
+	OrganizationID uuid.UUID
+}
+
+func (a Actor) isAdmin() bool {
+	return a.Role == domain.UserRoleAdmin
+}
+
+// confined reports whether the actor may not act beyond one organization.
+func (a Actor) confined() bool {
+	return a.OrganizationID != uuid.Nil
+}
+
+type OrganizationServiceInterface interface {
+	ListMyOrganizations(ctx context.Context, userID uuid.UUID) ([]*domain.Membership, error)
+	GetOrganization(
+		ctx context.Context,
+		actor Actor,
+		organizationID uuid.UUID,
+	) (*domain.Organization, []*domain.Membership, error)
+	InviteMember(
+		ctx context.Context,
+		actor Actor,
+		organizationID uuid.UUID,
+		email string,
+		role domain.OrganizationRole,
+	) (*domain.Membership, error)
+	RemoveMember(ctx context.Context, actor Actor, organizationID, userID uuid.UUID) error
+	EventOrganization(ctx context.Context, actor Actor, organizationID uuid.UUID) (*domain.Organization, error)
+	AuthorizeEvent(ctx context.Context, actor Actor, event *domain.Event, action domain.OrganizationAction) error
+	AuthorizeBooking(ctx context.Context, actor Actor, bookingID uuid.UUID, action domain.OrganizationAction) error
+	AuthorizeOrder(ctx context.Context, actor Actor, orderID uuid.UUID, action domain.OrganizationAction) error
+}
+
+// OrganizationService manages the members of organizations and decides what
+// they may do with the events, bookings and orders of their organization.
+type OrganizationService struct {
+	userRepository         domain.UserRepository
+	organizationRepository domain.OrganizationRepository
+	membershipRepository   domain.MembershipRepository
+	eventRepository        domain.EventRepository
+	bookingRepository      domain.BookingRepository
+	orderRepository        domain.OrderRepository
+	auditRepository        domain.AuditRepository
+	tm                     domain.TransactionManager
+}
+
+func NewOrganizationService(
+	userRepository domain.UserRepository,
+	organizationRepository domain.OrganizationRepository,
+	membershipRepository domain.MembershipRepository,
+	eventRepository domain.EventRepository,
+	bookingRepository domain.BookingRepository,
+	orderRepository domain.OrderRepository,
+	auditRepository domain.AuditRepository,
+	tm domain.TransactionManager,
+) *OrganizationService {
+	return &OrganizationService{
+		userRepository:         userRepository,
+		organizationRepository: organizationRepository,
+		membershipRepository:   membershipRepository,
+		eventRepository:        eventRepository,
+		bookingRepository:      bookingRepository,
+		orderRepository:        orderRepository,
+		auditRepository:        auditRepository,
+		tm:                     tm,
+	}
+}
+
+// ListMyOrganizations returns the memberships of a user.
+func (s *OrganizationService) ListMyOrganizations(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]*domain.Membership, error) {
+	return s.membershipRepository.ListUserMemberships(ctx, userID)
+}
+
+// GetOrganization returns an organization with its members. Only members and
+// admins may see it.
+func (s *OrganizationService) GetOrganization(
+	ctx context.Context,
+	actor Actor,
+	organizationID uuid.UUID,
+) (*domain.Organization, []*domain.Membership, error) {
+	organization, err := s.organizationRepository.GetOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !actor.isAdmin() {
+		if _, err := s.membership(ctx, actor, organizationID); err != nil {
+			return nil, nil, err
+		}
+	}
+	members, err := s.membershipRepository.ListOrganizationMembers(ctx, organizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return organization, members, nil
+}
+
+// InviteMember adds the account registered with an email to an organization.
+// Owners and managers invite members with the roles they may assign.
+func (s *OrganizationService) InviteMember(
+	ctx context.Context,
+	actor Actor,
+	organizationID uuid.UUID,
+	email string,
+	role domain.OrganizationRole,
+) (*domain.Membership, error) {
+	if _, err := domain.ParseOrganizationRole(string(role)); err != nil {
+		return nil, err
+	}
+	var membership *domain.Membership
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		if _, err := s.organizationRepository.GetOrganization(ctx, organizationID); err != nil {
+			return err
+		}
+		if err := s.authorizeAssign(ctx, actor, organizationID, role); err != nil {
+			return err
+		}
+		user, err := s.userRepository.GetUserByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+		membership, err = domain.NewMembership(organizationID, user, role, now)
+		if err != nil {
+			return err
+		}
+		if err := s.membershipRepository.CreateMembership(ctx, membership); err != nil {
+			return err
+		}
+		record := domain.NewAuditRecord(actor.ID, domain.AuditMemberAdded, domain.AuditTargetOrganization,
+			organizationID, map[string]string{
+				"user_id": user.ID().String(),
+				"role":    string(role),
+			}, now)
+		return s.auditRepository.CreateAuditRecord(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+// RemoveMember takes a user out of an organization. Members may leave on
+// their own; removing others takes a role that may assign theirs. The owner
+// always stays.
+func (s *OrganizationService) RemoveMember(ctx context.Context, actor Actor, organizationID, userID uuid.UUID) error {
+	return s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		organization, err := s.organizationRepository.GetOrganization(ctx, organizationID)
+		if err != nil {
+			return err
+		}
+		if organization.OwnerID() == userID {
+			return domain.ErrMemberIsOwner
+		}
+		membership, err := s.membershipRepository.GetMembership(ctx, organizationID, userID)
+		if err != nil {
+			return err
+		}
+		if actor.ID != userID {
+			if err := s.authorizeAssign(ctx, actor, organizationID, membership.Role()); err != nil {
+				return err
+			}
+		}
+		if err := s.membershipRepository.DeleteMembership(ctx, organizationID, userID); err != nil {
+			return err
+		}
+		record := domain.NewAuditRecord(actor.ID, domain.AuditMemberRemoved, domain.AuditTargetOrganization,
+			organizationID, map[string]string{
+				"user_id": userID.String(),
+				"role":    string(membership.Role()),
+			}, time.Now())
+		return s.auditRepository.CreateAuditRecord(ctx, record)
+	})
+}
+
+// EventOrganization returns the organization a new event of the actor
+// belongs to. Without an organizationID it is the one organization the actor
+// manages events for, or the organization of the actor's API key. It is nil
+// for admins and for organizers without an organization, whose events belong
+// to no organization.
+func (s *OrganizationService) EventOrganization(
+	ctx context.Context,
+	actor Actor,
+	organizationID uuid.UUID,
+) (*domain.Organization, error) {
+	if actor.confined() {
+		if organizationID != uuid.Nil && organizationID != actor.OrganizationID {
+			return nil, domain.ErrOrganizationForbidden
+		}
+		organizationID = actor.OrganizationID
+	}
+	if organizationID != uuid.Nil {
+		if !actor.isAdmin() {
+			if err := s.authorizeMember(ctx, actor, organizationID, domain.OrganizationActionManageEvents); err != nil {
+				return nil, err
+			}
+		}
+		return s.organizationRepository.GetOrganization(ctx, organizationID)
+	}
+
+	memberships, err := s.membershipRepository.ListUserMemberships(ctx, actor.ID)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []*domain.Membership
+	for _, membership := range memberships {
+		if membership.Can(domain.OrganizationActionManageEvents) {
+			candidates = append(candidates, membership)
+		}
+	}
+	switch {
+	case len(candidates) == 1:
+		return s.organizationRepository.GetOrganization(ctx, candidates[0].OrganizationID())
+	case len(candidates) > 1:
+		return nil, domain.ErrOrganizationRequired
+	case actor.isAdmin() || actor.Role == domain.UserRoleOrganizer:
+		return nil, nil
+	default:
+		return nil, domain.ErrOrganizationForbidden
+	}
+}
+
+// AuthorizeEvent checks that the actor may take an action on an event.
+// Admins may do anything. Events of an organization need a membership whose
+// role allows the action; events without one only their organizer. Actors
+// with an API key may only act on events of its organization.
+func (s *OrganizationService) AuthorizeEvent(
+	ctx context.Context,
+	actor Actor,
+	event *domain.Event,
+	action domain.OrganizationAction,
+) error {
+	if actor.confined() && event.OrganizationID() != actor.OrganizationID {
+		return domain.ErrOrganizationForbidden
+	}
+	if actor.isAdmin() {
+		return nil
+	}
+	if event.OrganizationID() == uuid.Nil {
+		if actor.Role == domain.UserRoleOrganizer && event.OrganizerID() == actor.ID {
+			return nil
+		}
+		return domain.ErrOrganizationForbidden
+	}
+	return s.authorizeMember(ctx, actor, event.OrganizationID(), action)
+}
+
+// AuthorizeBooking checks that the actor may take an action on a booking of
+// an event, as AuthorizeEvent does for the event.
+func (s *OrganizationService) AuthorizeBooking(
+	ctx context.Context,
+	actor Actor,
+	bookingID uuid.UUID,
+	action domain.OrganizationAction,
+) error {
+	if actor.isAdmin() && !actor.confined() {
+		return nil
+	}
+	booking, err := s.bookingRepository.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return err
+	}
+	event, err := s.eventRepository.GetEvent(ctx, booking.EventID())
+	if err != nil {
+		return err
+	}
+	return s.AuthorizeEvent(ctx, actor, event, action)
+}
+
+// AuthorizeOrder checks that the actor may take an action on a group order of
+// an event, as AuthorizeEvent does for the event.
+func (s *OrganizationService) AuthorizeOrder(
+	ctx context.Context,
+	actor Actor,
+	orderID uuid.UUID,
+	action domain.OrganizationAction,
+) error {
+	if actor.isAdmin() && !actor.confined() {
+		return nil
+	}
+	order, err := s.orderRepository.GetOrder(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	event, err := s.eventRepository.GetEvent(ctx, order.EventID())
+	if err != nil {
+		return err
+	}
+	return s.AuthorizeEvent(ctx, actor, event, action)
+}
+
+func (s *OrganizationService) membership(
+	ctx context.Context,
+	actor Actor,
+	organizationID uuid.UUID,
+) (*domain.Membership, error) {
+	membership, err := s.membershipRepository.GetMembership(ctx, organizationID, actor.ID)
+	if errors.Is(err, domain.ErrMembershipNotFound) {
+		return nil, domain.ErrOrganizationForbidden
+	}
+	return membership, err
+}
+
+func (s *OrganizationService) authorizeMember(
+	ctx context.Context,
+	actor Actor,
+	organizationID uuid.UUID,
+	action domain.OrganizationAction,
+) error {
+	membership, err := s.membership(ctx, actor, organizationID)
+	if err != nil {
+		return err
+	}
+	if !membership.Can(action) {
+		return domain.ErrOrganizationForbidden
+	}
+	return nil
+}
+
+// authorizeAssign checks that the actor may add or remove members with a
+// role. Admins may manage every role but the owner's.
+func (s *OrganizationService) authorizeAssign(
+	ctx context.Context,
+	actor Actor,
+	organizationID uuid.UUID,
+	role domain.OrganizationRole,
+) error {
+	if actor.isAdmin() {
+		if role == domain.OrganizationRoleOwner {
+			return domain.ErrOrganizationForbidden
+		}
+		return nil
+	}
+	membership, err := s.membership(ctx, actor, organizationID)
+	if err != nil {
+		return err
+	}
+	if !membership.Can(domain.OrganizationActionManageMembers) || !membership.Role().CanAssign(role) {
+		return domain.ErrOrganizationForbidden
+	}
+	return nil
+}
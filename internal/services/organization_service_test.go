@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizationService_MembershipRolesScopeAccess(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	organizationRepository := postgres.NewOrganizationRepository(postgres.New(pool))
+	organizationService := newTestOrganizationService(pool)
+	owner := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleOrganizer)
+	manager := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleUser)
+	scanner := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleUser)
+	outsider := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleOrganizer)
+
+	organization, err := domain.NewOrganization(owner.ID(), "Riverside Concerts", "", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, organizationRepository.CreateOrganization(ctx, organization))
+	ownership, err := domain.NewMembership(organization.ID(), owner, domain.OrganizationRoleOwner, time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, organizationRepository.CreateMembership(ctx, ownership))
+	ownerActor := Actor{ID: owner.ID(), Role: owner.Role()}
+	managerActor := Actor{ID: manager.ID(), Role: manager.Role()}
+	scannerActor := Actor{ID: scanner.ID(), Role: scanner.Role()}
+	outsiderActor := Actor{ID: outsider.ID(), Role: outsider.Role()}
+
+	_, err = organizationService.InviteMember(ctx, ownerActor, organization.ID(), manager.Email(),
+		domain.OrganizationRoleManager)
+	assert.NoError(t, err)
+	// Managers may add box office and scanner staff but not other managers.
+	_, err = organizationService.InviteMember(ctx, managerActor, organization.ID(), outsider.Email(),
+		domain.OrganizationRoleManager)
+	assert.ErrorIs(t, err, domain.ErrOrganizationForbidden)
+	_, err = organizationService.InviteMember(ctx, managerActor, organization.ID(), scanner.Email(),
+		domain.OrganizationRoleScanner)
+	assert.NoError(t, err)
+	_, err = organizationService.InviteMember(ctx, managerActor, organization.ID(), scanner.Email(),
+		domain.OrganizationRoleScanner)
+	assert.ErrorIs(t, err, domain.ErrMembershipExists)
+
+	// A manager without the global organizer role creates events for the
+	// organization, paid out to its owner.
+	eventOrganization, err := organizationService.EventOrganization(ctx, managerActor, uuid.Nil)
+	assert.NoError(t, err)
+	assert.Equal(t, organization.ID(), eventOrganization.ID())
+	_, err = organizationService.EventOrganization(ctx, scannerActor, uuid.Nil)
+	assert.ErrorIs(t, err, domain.ErrOrganizationForbidden)
+	_, err = organizationService.EventOrganization(ctx, outsiderActor, organization.ID())
+	assert.ErrorIs(t, err, domain.ErrOrganizationForbidden)
+
+	event := postgres.CreateTestEvent(ctx, t, pool,
+		postgres.WithOrganizer(owner.ID()), postgres.WithOrganization(organization.ID()))
+	assert.NoError(t, organizationService.AuthorizeEvent(ctx, managerActor, event,
+		domain.OrganizationActionManageEvents))
+	assert.NoError(t, organizationService.AuthorizeEvent(ctx, scannerActor, event,
+		domain.OrganizationActionViewBookings))
+	assert.ErrorIs(t, organizationService.AuthorizeEvent(ctx, scannerActor, event,
+		domain.OrganizationActionManageBookings), domain.ErrOrganizationForbidden)
+	assert.ErrorIs(t, organizationService.AuthorizeEvent(ctx, outsiderActor, event,
+		domain.OrganizationActionViewBookings), domain.ErrOrganizationForbidden)
+
+	// Orders of the event are visible to its members only, whatever their
+	// account role.
+	queries := postgres.New(pool)
+	orderService := NewOrderService(
+		postgres.NewOrderRepository(queries),
+		postgres.NewBookingRepository(queries),
+		postgres.NewEventRepository(queries),
+		newTestBookingService(pool),
+		postgres.NewPgxTxManager(pool),
+	)
+	order, err := domain.NewInvoiceOrder(
+		uuid.New(), event.ID(), "buyer@example.com", "Acme Corp", make([]string, 2), time.Now().Add(time.Hour),
+	)
+	assert.NoError(t, err)
+	assert.NoError(t, orderService.CreateInvoiceOrder(ctx, order, CreateBookingOptions{}))
+	assert.NoError(t, organizationService.AuthorizeOrder(ctx, scannerActor, order.ID(),
+		domain.OrganizationActionViewBookings))
+	assert.ErrorIs(t, organizationService.AuthorizeOrder(ctx, outsiderActor, order.ID(),
+		domain.OrganizationActionViewBookings), domain.ErrOrganizationForbidden)
+
+	assert.ErrorIs(t, organizationService.RemoveMember(ctx, managerActor, organization.ID(), owner.ID()),
+		domain.ErrMemberIsOwner)
+	assert.NoError(t, organizationService.RemoveMember(ctx, managerActor, organization.ID(), scanner.ID()))
+	assert.ErrorIs(t, organizationService.AuthorizeEvent(ctx, scannerActor, event,
+		domain.OrganizationActionViewBookings), domain.ErrOrganizationForbidden)
+
+	_, members, err := organizationService.GetOrganization(ctx, ownerActor, organization.ID())
+	assert.NoError(t, err)
+	assert.Len(t, members, 2)
+}
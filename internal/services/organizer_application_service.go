@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type OrganizerApplicationServiceInterface interface {
+	Apply(ctx context.Context, userID uuid.UUID, details OrganizerApplicationDetails) (*domain.OrganizerApplication, error)
+	GetMyApplication(ctx context.Context, userID uuid.UUID) (*domain.OrganizerApplication, error)
+	ListApplications(ctx context.Context, filter domain.ApplicationFilter) ([]*domain.OrganizerApplication, error)
+	Approve(ctx context.Context, actorID, applicationID uuid.UUID) (*domain.OrganizerApplication, error)
+	Reject(ctx context.Context, actorID, applicationID uuid.UUID, note string) (*domain.OrganizerApplication, error)
+}
+
+// OrganizerApplicationDetails describes the organization a user applies for.
+type OrganizerApplicationDetails struct {
+	OrganizationName string
+	Website          string
+	Description      string
+}
+
+// OrganizerApplicationService runs the self-service organizer onboarding:
+// users apply, admins approve or reject.
+type OrganizerApplicationService struct {
+	userRepository         domain.UserRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	organizationRepository domain.OrganizationRepository
+	membershipRepository   domain.MembershipRepository
+	applicationRepository  domain.OrganizerApplicationRepository
+	auditRepository        domain.AuditRepository
+	revoker                TokenRevoker
+	tm                     domain.TransactionManager
+}
+
+func NewOrganizerApplicationService(
+	userRepository domain.UserRepository,
+	refreshTokenRepository domain.RefreshTokenRepository,
+	organizationRepository domain.OrganizationRepository,
+	membershipRepository domain.MembershipRepository,
+	applicationRepository domain.OrganizerApplicationRepository,
+	auditRepository domain.AuditRepository,
+	revoker TokenRevoker,
+	tm domain.TransactionManager,
+) *OrganizerApplicationService {
+	return &OrganizerApplicationService{
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		organizationRepository: organizationRepository,
+		membershipRepository:   membershipRepository,
+		applicationRepository:  applicationRepository,
+		auditRepository:        auditRepository,
+		revoker:                revoker,
+		tm:                     tm,
+	}
+}
+
+// Apply submits an organizer application for review. Only verified users
+// who cannot organize events yet may apply, one application at a time.
+func (s *OrganizerApplicationService) Apply(
+	ctx context.Context,
+	userID uuid.UUID,
+	details OrganizerApplicationDetails,
+) (*domain.OrganizerApplication, error) {
+	user, err := s.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role() != domain.UserRoleUser {
+		return nil, domain.ErrUserAlreadyOrganizer
+	}
+	if !user.IsVerified() {
+		return nil, domain.ErrUserUnverified
+	}
+
+	application, err := domain.NewOrganizerApplication(
+		userID, details.OrganizationName, details.Website, details.Description, time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applicationRepository.CreateOrganizerApplication(ctx, application); err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+// GetMyApplication returns the latest application of a user.
+func (s *OrganizerApplicationService) GetMyApplication(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*domain.OrganizerApplication, error) {
+	return s.applicationRepository.GetLatestOrganizerApplication(ctx, userID)
+}
+
+// ListApplications returns a page of applications in a status, oldest first.
+func (s *OrganizerApplicationService) ListApplications(
+	ctx context.Context,
+	filter domain.ApplicationFilter,
+) ([]*domain.OrganizerApplication, error) {
+	return s.applicationRepository.ListOrganizerApplications(ctx, filter.WithPageDefaults())
+}
+
+// Approve accepts an application: the applicant becomes an organizer and
+// the owner member of a new organization. Their live access tokens are
+// revoked while their sessions stay, so the next refresh issues a token with
+// the new role.
+func (s *OrganizerApplicationService) Approve(
+	ctx context.Context,
+	actorID, applicationID uuid.UUID,
+) (*domain.OrganizerApplication, error) {
+	var application *domain.OrganizerApplication
+	var now time.Time
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now = time.Now()
+		var err error
+		application, err = s.applicationRepository.GetOrganizerApplicationForUpdate(ctx, applicationID)
+		if err != nil {
+			return err
+		}
+		if !application.IsPending() {
+			return domain.ErrApplicationNotPending
+		}
+		user, err := s.userRepository.GetUserByID(ctx, application.UserID())
+		if err != nil {
+			return err
+		}
+		// Users who were organizers before keep their organization.
+		organization, err := s.organizationRepository.GetOrganizationByOwner(ctx, user.ID())
+		if errors.Is(err, domain.ErrOrganizationNotFound) {
+			organization, err = s.createOrganization(ctx, user, application, now)
+		}
+		if err != nil {
+			return err
+		}
+		if err := application.Approve(actorID, organization.ID(), now); err != nil {
+			return err
+		}
+
+		if user.Role() == domain.UserRoleUser {
+			if err := user.UpdateRole(domain.UserRoleOrganizer); err != nil {
+				return err
+			}
+			if err := s.userRepository.UpdateUser(ctx, user); err != nil {
+				return err
+			}
+		}
+		if err := s.applicationRepository.UpdateOrganizerApplication(ctx, application); err != nil {
+			return err
+		}
+		record := domain.NewAuditRecord(actorID, domain.AuditOrganizerApproved, domain.AuditTargetUser, user.ID(),
+			map[string]string{
+				"application_id":  application.ID().String(),
+				"organization_id": organization.ID().String(),
+			}, now)
+		return s.auditRepository.CreateAuditRecord(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tokenIDs, err := s.refreshTokenRepository.ListUserAccessTokens(
+		ctx, application.UserID(), now.Add(-auth.AccessTokenTTL),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return application, revokeAccessTokens(ctx, s.revoker, tokenIDs)
+}
+
+func (s *OrganizerApplicationService) createOrganization(
+	ctx context.Context,
+	owner *domain.User,
+	application *domain.OrganizerApplication,
+	now time.Time,
+) (*domain.Organization, error) {
+	organization, err := domain.NewOrganization(owner.ID(), application.OrganizationName(), application.Website(), now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.organizationRepository.CreateOrganization(ctx, organization); err != nil {
+		return nil, err
+	}
+	membership, err := domain.NewMembership(organization.ID(), owner, domain.OrganizationRoleOwner, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.membershipRepository.CreateMembership(ctx, membership); err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+// Reject turns an application down with a note for the applicant.
+func (s *OrganizerApplicationService) Reject(
+	ctx context.Context,
+	actorID, applicationID uuid.UUID,
+	note string,
+) (*domain.OrganizerApplication, error) {
+	var application *domain.OrganizerApplication
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		var err error
+		application, err = s.applicationRepository.GetOrganizerApplicationForUpdate(ctx, applicationID)
+		if err != nil {
+			return err
+		}
+		if err := application.Reject(actorID, note, now); err != nil {
+			return err
+		}
+		if err := s.applicationRepository.UpdateOrganizerApplication(ctx, application); err != nil {
+			return err
+		}
+		record := domain.NewAuditRecord(actorID, domain.AuditOrganizerRejected, domain.AuditTargetUser,
+			application.UserID(), map[string]string{
+				"application_id": application.ID().String(),
+				"note":           application.ReviewNote(),
+			}, now)
+		return s.auditRepository.CreateAuditRecord(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return application, nil
+}
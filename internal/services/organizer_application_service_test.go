@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrganizerApplicationService_ApprovalMakesOrganizer(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	jwtService := newTestJWTService(t)
+	queries := postgres.New(pool)
+	userRepository := postgres.NewUserRepository(queries)
+	organizationRepository := postgres.NewOrganizationRepository(queries)
+	txManager := postgres.NewPgxTxManager(pool)
+	revoker := &recordingRevoker{revoked: map[uuid.UUID]bool{}}
+	verificationService := newTestVerificationService(queries, userRepository, txManager)
+	userService := newTestUserService(pool, jwtService, revoker)
+	applicationService := NewOrganizerApplicationService(
+		userRepository,
+		postgres.NewRefreshTokenRepository(queries),
+		organizationRepository,
+		organizationRepository,
+		organizationRepository,
+		postgres.NewAuditRepository(queries),
+		revoker,
+		txManager,
+	)
+	admin := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleAdmin)
+	details := OrganizerApplicationDetails{OrganizationName: "Riverside Concerts"}
+
+	assert.NoError(t, userService.RegisterUser(ctx, "applicant@example.com", "password123"))
+	login, err := userService.LoginUser(ctx, "applicant@example.com", "password123", testClient)
+	assert.NoError(t, err)
+	user, err := userRepository.GetUserByEmail(ctx, "applicant@example.com")
+	assert.NoError(t, err)
+
+	_, err = applicationService.Apply(ctx, user.ID(), details)
+	assert.ErrorIs(t, err, domain.ErrUserUnverified)
+	token := pendingEmailToken(ctx, t, queries, user.ID(), domain.AccountNotificationEmailVerification)
+	assert.NoError(t, verificationService.VerifyEmail(ctx, token))
+	application, err := applicationService.Apply(ctx, user.ID(), details)
+	assert.NoError(t, err)
+	_, err = applicationService.Apply(ctx, user.ID(), details)
+	assert.ErrorIs(t, err, domain.ErrApplicationPending)
+	pending, err := applicationService.ListApplications(ctx, domain.ApplicationFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+
+	application, err = applicationService.Approve(ctx, admin.ID(), application.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ApplicationStatusApproved, application.Status())
+	_, err = applicationService.Approve(ctx, admin.ID(), application.ID())
+	assert.ErrorIs(t, err, domain.ErrApplicationNotPending)
+
+	organization, err := organizationRepository.GetOrganizationByOwner(ctx, user.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, application.OrganizationID(), organization.ID())
+	assert.Equal(t, "Riverside Concerts", organization.Name())
+
+	// The old access token is revoked but the session survives, and the
+	// refreshed token carries the new role.
+	assert.True(t, revoker.revoked[login.Session.AccessToken.ID])
+	session, err := userService.RefreshSession(ctx, login.Session.RefreshToken)
+	assert.NoError(t, err)
+	claims, err := jwtService.VerifyToken(session.AccessToken.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.UserRoleOrganizer, claims.Role)
+
+	_, err = applicationService.Apply(ctx, user.ID(), details)
+	assert.ErrorIs(t, err, domain.ErrUserAlreadyOrganizer)
+}
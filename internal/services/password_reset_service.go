@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+const passwordResetRequestedEventName = "PasswordResetRequested"
+
+type PasswordResetServiceInterface interface {
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, password string) error
+}
+
+type PasswordResetService struct {
+	userRepository         domain.UserRepository
+	resetRepository        domain.PasswordResetRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	outboxRepository       domain.OutboxRepository
+	revoker                TokenRevoker
+	tm                     domain.TransactionManager
+	resetURL               string
+}
+
+// NewPasswordResetService creates the service. Reset links point to resetURL
+// with the token in the "token" query parameter.
+func NewPasswordResetService(
+	userRepository domain.UserRepository,
+	resetRepository domain.PasswordResetRepository,
+	refreshTokenRepository domain.RefreshTokenRepository,
+	outboxRepository domain.OutboxRepository,
+	revoker TokenRevoker,
+	tm domain.TransactionManager,
+	resetURL string,
+) *PasswordResetService {
+	return &PasswordResetService{
+		userRepository:         userRepository,
+		resetRepository:        resetRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		outboxRepository:       outboxRepository,
+		revoker:                revoker,
+		tm:                     tm,
+		resetURL:               resetURL,
+	}
+}
+
+// RequestPasswordReset emails a reset link to the account with the given
+// address. Unknown addresses succeed silently, and the email is written to the
+// outbox in the same transaction as its token and sent by the relay, so the
+// response does not reveal whether the account exists.
+func (s *PasswordResetService) RequestPasswordReset(ctx context.Context, email string) error {
+	return s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		user, err := s.userRepository.GetUserByEmail(ctx, email)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return nil
+			}
+			return err
+		}
+
+		now := time.Now()
+		token, secret := domain.NewPasswordResetToken(user.ID(), now)
+		if err := s.resetRepository.CreatePasswordResetToken(ctx, token); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(domain.AccountNotification{
+			ID:        token.ID(),
+			Kind:      domain.AccountNotificationPasswordReset,
+			UserEmail: user.Email(),
+			Link:      s.resetURL + "?token=" + url.QueryEscape(secret),
+			ExpiresAt: token.ExpiresAt(),
+			CreatedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+		outboxEvent, err := domain.CreateOutboxEvent(passwordResetRequestedEventName, data, userEventsTopic, user.ID())
+		if err != nil {
+			return err
+		}
+		return s.outboxRepository.Create(ctx, outboxEvent)
+	})
+}
+
+// ResetPassword sets a new password with a reset token. The token and every
+// other outstanding one of the user are used up, and all sessions of the
+// user end, including their live access tokens.
+func (s *PasswordResetService) ResetPassword(ctx context.Context, token, password string) error {
+	if err := domain.ValidatePassword(password); err != nil {
+		return err
+	}
+	passwordHash, err := auth.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	var revoked []uuid.UUID
+	err = s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		resetToken, err := s.resetRepository.GetPasswordResetTokenForUpdate(ctx, token)
+		if err != nil {
+			return err
+		}
+		if err := resetToken.Use(now); err != nil {
+			return err
+		}
+
+		user, err := s.userRepository.GetUserByID(ctx, resetToken.UserID())
+		if err != nil {
+			return err
+		}
+		if err := user.UpdatePassword(passwordHash); err != nil {
+			return err
+		}
+		if err := s.userRepository.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		if err := s.resetRepository.UsePasswordResetTokens(ctx, user.ID(), now); err != nil {
+			return err
+		}
+
+		revoked, err = s.refreshTokenRepository.RevokeUserTokens(ctx, user.ID(), now, now.Add(-auth.AccessTokenTTL))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return revokeAccessTokens(ctx, s.revoker, revoked)
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordResetService_ResetEndsSessions(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	revoker := &recordingRevoker{revoked: map[uuid.UUID]bool{}}
+	queries := postgres.New(pool)
+	userRepository := postgres.NewUserRepository(queries)
+	userService := newTestUserService(pool, newTestJWTService(t), revoker)
+	resetService := NewPasswordResetService(
+		userRepository,
+		postgres.NewPasswordResetRepository(queries),
+		postgres.NewRefreshTokenRepository(queries),
+		postgres.NewOutBoxRepository(queries),
+		revoker,
+		postgres.NewPgxTxManager(pool),
+		"https://tickets.example.com/reset-password",
+	)
+
+	assert.NoError(t, userService.RegisterUser(ctx, "reset@example.com", "password123"))
+	login, err := userService.LoginUser(ctx, "reset@example.com", "password123", testClient)
+	assert.NoError(t, err)
+
+	// Unknown addresses look the same to the caller and send nothing.
+	assert.NoError(t, resetService.RequestPasswordReset(ctx, "nobody@example.com"))
+	assert.NoError(t, resetService.RequestPasswordReset(ctx, "reset@example.com"))
+	user, err := userRepository.GetUserByEmail(ctx, "reset@example.com")
+	assert.NoError(t, err)
+	token := pendingEmailToken(ctx, t, queries, user.ID(), domain.AccountNotificationPasswordReset)
+
+	assert.ErrorIs(t, resetService.ResetPassword(ctx, token, "short"), domain.ErrUserPasswordTooShort)
+	assert.NoError(t, resetService.ResetPassword(ctx, token, "new-password123"))
+	assert.ErrorIs(t, resetService.ResetPassword(ctx, token, "other-password123"), domain.ErrResetTokenInvalid)
+
+	_, err = userService.LoginUser(ctx, "reset@example.com", "password123", testClient)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	_, err = userService.LoginUser(ctx, "reset@example.com", "new-password123", testClient)
+	assert.NoError(t, err)
+	_, err = userService.RefreshSession(ctx, login.Session.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+	assert.True(t, revoker.revoked[login.Session.AccessToken.ID])
+}
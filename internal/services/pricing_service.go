@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type PricingServiceInterface interface {
+	CurrentPrice(ctx context.Context, event *domain.Event) (domain.Money, error)
+	CreateRule(ctx context.Context, rule *domain.PricingRule) error
+	ListRules(ctx context.Context, eventID uuid.UUID) ([]*domain.PricingRule, error)
+	DeleteRule(ctx context.Context, eventID, ruleID uuid.UUID) error
+}
+
+type PricingService struct {
+	eventRepository domain.EventRepository
+	ruleRepository  domain.PricingRuleRepository
+}
+
+func NewPricingService(
+	eventRepository domain.EventRepository,
+	ruleRepository domain.PricingRuleRepository,
+) *PricingService {
+	return &PricingService{
+		eventRepository: eventRepository,
+		ruleRepository:  ruleRepository,
+	}
+}
+
+// CurrentPrice quotes the event's price from its pricing rules, current
+// availability and the current time.
+func (s *PricingService) CurrentPrice(ctx context.Context, event *domain.Event) (domain.Money, error) {
+	rules, err := s.ruleRepository.ListPricingRules(ctx, event.ID())
+	if err != nil {
+		return domain.Money{}, err
+	}
+	return domain.QuotePrice(event, rules, time.Now()), nil
+}
+
+// CreateRule adds a pricing rule to an existing event.
+func (s *PricingService) CreateRule(ctx context.Context, rule *domain.PricingRule) error {
+	if _, err := s.eventRepository.GetEvent(ctx, rule.EventID()); err != nil {
+		return err
+	}
+	return s.ruleRepository.CreatePricingRule(ctx, rule)
+}
+
+// ListRules returns the pricing rules of an event.
+func (s *PricingService) ListRules(ctx context.Context, eventID uuid.UUID) ([]*domain.PricingRule, error) {
+	return s.ruleRepository.ListPricingRules(ctx, eventID)
+}
+
+// DeleteRule removes a pricing rule from an event.
+func (s *PricingService) DeleteRule(ctx context.Context, eventID, ruleID uuid.UUID) error {
+	return s.ruleRepository.DeletePricingRule(ctx, eventID, ruleID)
+}
@@ -0,0 +1,369 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+const (
+	userErasureTopic    = "user_erasure_topic"
+	userErasedEventName = "UserErased"
+	dataExportsPerBatch = 10
+)
+
+type PrivacyServiceInterface interface {
+	RequestDataExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, []byte, error)
+	EraseUser(ctx context.Context, userID uuid.UUID) error
+}
+
+// PrivacyService serves the data protection rights of users: a copy of the
+// data held about them, and erasure of their account.
+type PrivacyService struct {
+	userRepository          domain.UserRepository
+	profileRepository       domain.ProfileRepository
+	bookingRepository       domain.BookingRepository
+	loginSecurityRepository domain.LoginSecurityRepository
+	organizationRepository  domain.OrganizationRepository
+	dataExportRepository    domain.DataExportRepository
+	erasureRepository       domain.ErasureRepository
+	auditRepository         domain.AuditRepository
+	outboxRepository        domain.OutboxRepository
+	blobStore               domain.BlobStore
+	suspender               UserSuspender
+	tm                      domain.TransactionManager
+}
+
+func NewPrivacyService(
+	userRepository domain.UserRepository,
+	profileRepository domain.ProfileRepository,
+	bookingRepository domain.BookingRepository,
+	loginSecurityRepository domain.LoginSecurityRepository,
+	organizationRepository domain.OrganizationRepository,
+	dataExportRepository domain.DataExportRepository,
+	erasureRepository domain.ErasureRepository,
+	auditRepository domain.AuditRepository,
+	outboxRepository domain.OutboxRepository,
+	blobStore domain.BlobStore,
+	suspender UserSuspender,
+	tm domain.TransactionManager,
+) *PrivacyService {
+	return &PrivacyService{
+		userRepository:          userRepository,
+		profileRepository:       profileRepository,
+		bookingRepository:       bookingRepository,
+		loginSecurityRepository: loginSecurityRepository,
+		organizationRepository:  organizationRepository,
+		dataExportRepository:    dataExportRepository,
+		erasureRepository:       erasureRepository,
+		auditRepository:         auditRepository,
+		outboxRepository:        outboxRepository,
+		blobStore:               blobStore,
+		suspender:               suspender,
+		tm:                      tm,
+	}
+}
+
+// RequestDataExport returns the latest export of a user's data, with its
+// archive once it is ready. A new export is requested when there is none,
+// or the latest failed or is past its retention.
+func (s *PrivacyService) RequestDataExport(
+	ctx context.Context,
+	userID uuid.UUID,
+) (*domain.DataExport, []byte, error) {
+	export, err := s.dataExportRepository.GetLatestDataExport(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrDataExportNotFound) {
+		return nil, nil, err
+	}
+	if err != nil || export.Expired(time.Now()) {
+		export, err = s.newDataExport(ctx, userID)
+		return export, nil, err
+	}
+	if export.Status() != domain.DataExportReady {
+		return export, nil, nil
+	}
+
+	archive, err := s.blobStore.Get(ctx, export.BlobKey())
+	if errors.Is(err, domain.ErrBlobNotFound) {
+		export, err = s.newDataExport(ctx, userID)
+		return export, nil, err
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return export, archive, nil
+}
+
+func (s *PrivacyService) newDataExport(ctx context.Context, userID uuid.UUID) (*domain.DataExport, error) {
+	export := domain.NewDataExport(userID, time.Now())
+	if err := s.dataExportRepository.CreateDataExport(ctx, export); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// BuildDataExports deletes the exports past their retention, then builds the
+// archives of a batch of pending ones and returns how many it built. Exports
+// that cannot be built are marked failed, so users can request them anew.
+func (s *PrivacyService) BuildDataExports(ctx context.Context) (int, error) {
+	now := time.Now()
+	expired, err := s.dataExportRepository.DeleteDataExportsCompletedBefore(ctx, now.Add(-domain.DataExportRetention))
+	if err != nil {
+		return 0, err
+	}
+	s.deleteBlobs(ctx, expired)
+
+	built := 0
+	err = s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		exports, err := s.dataExportRepository.ListPendingDataExportsForUpdate(ctx, dataExportsPerBatch)
+		if err != nil {
+			return err
+		}
+		for _, export := range exports {
+			if err := s.buildDataExport(ctx, export); err != nil {
+				slog.Error("Failed to build data export", "export_id", export.ID(), "error", err)
+				export.Fail(time.Now())
+			} else {
+				export.Complete(time.Now())
+				built++
+			}
+			if err := s.dataExportRepository.UpdateDataExport(ctx, export); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return built, nil
+}
+
+type exportedProfile struct {
+	ID            uuid.UUID                      `json:"id"`
+	Email         string                         `json:"email"`
+	Role          string                         `json:"role"`
+	Status        string                         `json:"status"`
+	DisplayName   string                         `json:"displayName"`
+	Phone         string                         `json:"phone"`
+	Locale        string                         `json:"locale"`
+	TimeZone      string                         `json:"timeZone"`
+	Notifications domain.NotificationPreferences `json:"notifications"`
+	CreatedAt     time.Time                      `json:"createdAt"`
+	UpdatedAt     time.Time                      `json:"updatedAt"`
+}
+
+type exportedBooking struct {
+	ID        uuid.UUID    `json:"id"`
+	EventID   uuid.UUID    `json:"eventId"`
+	OrderID   *uuid.UUID   `json:"orderId,omitempty"`
+	Status    string       `json:"status"`
+	Price     domain.Money `json:"price"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+type exportedLogin struct {
+	IPAddress string    `json:"ipAddress"`
+	UserAgent string    `json:"userAgent"`
+	Outcome   string    `json:"outcome"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// buildDataExport stores a ZIP archive holding the account and profile,
+// bookings and login history of the export's user as JSON files.
+func (s *PrivacyService) buildDataExport(ctx context.Context, export *domain.DataExport) error {
+	user, err := s.userRepository.GetUserByID(ctx, export.UserID())
+	if err != nil {
+		return err
+	}
+	profile, err := s.profileRepository.GetProfile(ctx, user.ID())
+	if err != nil {
+		return err
+	}
+	bookings, err := s.bookingRepository.ListBookingsByUserEmail(ctx, user.Email())
+	if err != nil {
+		return err
+	}
+	logins, err := s.listAllLogins(ctx, user.ID())
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", exportedProfile{
+			ID:            user.ID(),
+			Email:         user.Email(),
+			Role:          string(user.Role()),
+			Status:        string(user.Status()),
+			DisplayName:   profile.DisplayName(),
+			Phone:         profile.Phone(),
+			Locale:        profile.Locale(),
+			TimeZone:      profile.TimeZone(),
+			Notifications: profile.Notifications(),
+			CreatedAt:     user.CreatedAt(),
+			UpdatedAt:     user.UpdatedAt(),
+		}},
+		{"bookings.json", exportBookings(bookings)},
+		{"login_history.json", exportLogins(logins)},
+	}
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return err
+		}
+		entry, err := writer.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.CreatedAt(),
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := entry.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return s.blobStore.Put(ctx, export.BlobKey(), archive.Bytes())
+}
+
+func (s *PrivacyService) listAllLogins(ctx context.Context, userID uuid.UUID) ([]*domain.LoginRecord, error) {
+	var logins []*domain.LoginRecord
+	page := domain.LoginHistoryPage{Limit: domain.MaxLoginPageSize}
+	for {
+		records, err := s.loginSecurityRepository.ListLoginRecords(ctx, userID, page)
+		if err != nil {
+			return nil, err
+		}
+		logins = append(logins, records...)
+		if len(records) < page.Limit {
+			return logins, nil
+		}
+		page.Offset += page.Limit
+	}
+}
+
+func exportBookings(bookings []*domain.Booking) []exportedBooking {
+	exported := make([]exportedBooking, len(bookings))
+	for i, booking := range bookings {
+		exported[i] = exportedBooking{
+			ID:        booking.ID(),
+			EventID:   booking.EventID(),
+			Status:    string(booking.Status()),
+			Price:     booking.Price(),
+			CreatedAt: booking.CreatedAt(),
+			UpdatedAt: booking.UpdatedAt(),
+		}
+		if orderID := booking.OrderID(); orderID != uuid.Nil {
+			exported[i].OrderID = &orderID
+		}
+	}
+	return exported
+}
+
+func exportLogins(logins []*domain.LoginRecord) []exportedLogin {
+	exported := make([]exportedLogin, len(logins))
+	for i, login := range logins {
+		exported[i] = exportedLogin{
+			IPAddress: login.IPAddress(),
+			UserAgent: login.UserAgent(),
+			Outcome:   string(login.Outcome()),
+			CreatedAt: login.CreatedAt(),
+		}
+	}
+	return exported
+}
+
+// EraseUser erases the account of a user on their request. Their
+// credentials, sessions, login history, profile, memberships and data
+// exports are deleted, and their bookings and orders are kept for the books
+// under an anonymized email address. A UserErased event written to the
+// outbox tells downstream consumers to purge what they keep about the user.
+//
+// Organization owners have to hand their organization over first.
+func (s *PrivacyService) EraseUser(ctx context.Context, userID uuid.UUID) error {
+	var blobKeys []string
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		user, err := s.userRepository.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.IsErased() {
+			return domain.ErrUserErased
+		}
+		_, err = s.organizationRepository.GetOrganizationByOwner(ctx, userID)
+		if err == nil {
+			return domain.ErrOwnerCannotErase
+		}
+		if !errors.Is(err, domain.ErrOrganizationNotFound) {
+			return err
+		}
+
+		if blobKeys, err = s.dataExportRepository.DeleteUserDataExports(ctx, userID); err != nil {
+			return err
+		}
+		email := user.Email()
+		user.Erase(now)
+		if err := s.erasureRepository.EraseUserData(ctx, userID, email, user.Email()); err != nil {
+			return err
+		}
+		if err := s.userRepository.UpdateUser(ctx, user); err != nil {
+			return err
+		}
+		record := domain.NewAuditRecord(userID, domain.AuditUserErased, domain.AuditTargetUser, userID, nil, now)
+		if err := s.auditRepository.CreateAuditRecord(ctx, record); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(domain.UserErasure{
+			UserID:        userID,
+			RecipientHash: domain.RecipientHash(email),
+			ErasedAt:      now,
+		})
+		if err != nil {
+			return err
+		}
+		outboxEvent, err := domain.CreateOutboxEvent(userErasedEventName, data, userErasureTopic, userID)
+		if err != nil {
+			return err
+		}
+		return s.outboxRepository.Create(ctx, outboxEvent)
+	})
+	if err != nil {
+		return err
+	}
+	// Erasure deleted the sessions; the flag also turns away the access
+	// tokens still in flight.
+	if err := s.suspender.SuspendUser(ctx, userID); err != nil {
+		return err
+	}
+	s.deleteBlobs(ctx, blobKeys)
+	return nil
+}
+
+// deleteBlobs deletes the archives of deleted exports. A blob left behind is
+// unreachable, so failures are only logged.
+func (s *PrivacyService) deleteBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.blobStore.Delete(ctx, key); err != nil {
+			slog.Error("Failed to delete data export archive", "key", key, "error", err)
+		}
+	}
+}
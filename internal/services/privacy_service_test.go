@@ -0,0 +1,125 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/blob"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrivacyService_ExportAndErasure(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	queries := postgres.New(pool)
+	userRepository := postgres.NewUserRepository(queries)
+	bookingRepository := postgres.NewBookingRepository(queries)
+	organizationRepository := postgres.NewOrganizationRepository(queries)
+	txManager := postgres.NewPgxTxManager(pool)
+	suspender := &recordingSuspender{
+		recordingRevoker: recordingRevoker{revoked: map[uuid.UUID]bool{}},
+		suspended:        map[uuid.UUID]bool{},
+	}
+	userService := newTestUserService(pool, newTestJWTService(t), suspender)
+	blobStore, err := blob.NewFileSystemStore(t.TempDir())
+	assert.NoError(t, err)
+	privacyService := NewPrivacyService(
+		userRepository,
+		postgres.NewProfileRepository(queries),
+		bookingRepository,
+		postgres.NewLoginSecurityRepository(queries),
+		organizationRepository,
+		postgres.NewDataExportRepository(queries),
+		postgres.NewErasureRepository(queries),
+		postgres.NewAuditRepository(queries),
+		postgres.NewOutBoxRepository(queries),
+		blobStore,
+		suspender,
+		txManager,
+	)
+
+	assert.NoError(t, userService.RegisterUser(ctx, "leaving@example.com", "password123"))
+	user, err := userRepository.GetUserByEmail(ctx, "leaving@example.com")
+	assert.NoError(t, err)
+	login, err := userService.LoginUser(ctx, "leaving@example.com", "password123", testClient)
+	assert.NoError(t, err)
+	event := postgres.CreateTestEvent(ctx, t, pool)
+	booking, err := domain.NewBooking(uuid.New(), event.ID(), "leaving@example.com", domain.BookingStatusConfirmed)
+	assert.NoError(t, err)
+	assert.NoError(t, bookingRepository.CreateBooking(ctx, booking))
+
+	// The export is built in the background.
+	export, archive, err := privacyService.RequestDataExport(ctx, user.ID())
+	assert.NoError(t, err)
+	assert.Nil(t, archive)
+	assert.Equal(t, domain.DataExportPending, export.Status())
+	built, err := privacyService.BuildDataExports(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, built)
+	ready, archive, err := privacyService.RequestDataExport(ctx, user.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, export.ID(), ready.ID())
+	assert.Equal(t, domain.DataExportReady, ready.Status())
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	assert.NoError(t, err)
+	files := map[string]string{}
+	for _, file := range reader.File {
+		content, err := file.Open()
+		assert.NoError(t, err)
+		data, err := io.ReadAll(content)
+		assert.NoError(t, err)
+		files[file.Name] = string(data)
+	}
+	assert.Contains(t, files["profile.json"], "leaving@example.com")
+	assert.Contains(t, files["bookings.json"], booking.ID().String())
+	assert.Contains(t, files["login_history.json"], testClient.UserAgent)
+
+	// Organization owners have to hand their organization over first.
+	owner := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleOrganizer)
+	organization, err := domain.NewOrganization(owner.ID(), "Riverside Concerts", "", time.Now())
+	assert.NoError(t, err)
+	assert.NoError(t, organizationRepository.CreateOrganization(ctx, organization))
+	assert.ErrorIs(t, privacyService.EraseUser(ctx, owner.ID()), domain.ErrOwnerCannotErase)
+
+	// Erasure keeps the booking under an anonymized address and ends every
+	// session.
+	assert.NoError(t, privacyService.EraseUser(ctx, user.ID()))
+	assert.True(t, suspender.suspended[user.ID()])
+	erased, err := userRepository.GetUserByID(ctx, user.ID())
+	assert.NoError(t, err)
+	assert.True(t, erased.IsErased())
+	kept, err := bookingRepository.GetBookingByID(ctx, booking.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ErasedEmail(user.ID()), kept.UserEmail())
+	assert.Equal(t, domain.BookingStatusConfirmed, kept.Status())
+	_, err = userService.RefreshSession(ctx, login.Session.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+	_, err = userService.LoginUser(ctx, "leaving@example.com", "password123", testClient)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	_, err = blobStore.Get(ctx, export.BlobKey())
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+	assert.ErrorIs(t, privacyService.EraseUser(ctx, user.ID()), domain.ErrUserErased)
+
+	events, err := postgres.NewOutBoxRepository(queries).GetPendingEvents(ctx, 100)
+	assert.NoError(t, err)
+	var erasures []domain.UserErasure
+	for _, outboxEvent := range events {
+		if outboxEvent.EventName() == userErasedEventName {
+			var erasure domain.UserErasure
+			assert.NoError(t, json.Unmarshal(outboxEvent.EventData(), &erasure))
+			erasures = append(erasures, erasure)
+		}
+	}
+	assert.Len(t, erasures, 1)
+	assert.Equal(t, domain.RecipientHash("leaving@example.com"), erasures[0].RecipientHash)
+}
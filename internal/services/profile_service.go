@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type ProfileServiceInterface interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*domain.User, *domain.Profile, error)
+	UpdateProfile(
+		ctx context.Context,
+		userID uuid.UUID,
+		update domain.ProfileUpdate,
+	) (*domain.User, *domain.Profile, error)
+}
+
+// ProfileService keeps the profiles of users and tells senders whether a
+// recipient wants a notification.
+type ProfileService struct {
+	userRepository    domain.UserRepository
+	profileRepository domain.ProfileRepository
+	tm                domain.TransactionManager
+}
+
+func NewProfileService(
+	userRepository domain.UserRepository,
+	profileRepository domain.ProfileRepository,
+	tm domain.TransactionManager,
+) *ProfileService {
+	return &ProfileService{
+		userRepository:    userRepository,
+		profileRepository: profileRepository,
+		tm:                tm,
+	}
+}
+
+// GetProfile returns a user with their profile.
+func (s *ProfileService) GetProfile(ctx context.Context, userID uuid.UUID) (*domain.User, *domain.Profile, error) {
+	user, err := s.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	profile, err := s.profileRepository.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, profile, nil
+}
+
+// UpdateProfile applies an update to the profile of a user.
+func (s *ProfileService) UpdateProfile(
+	ctx context.Context,
+	userID uuid.UUID,
+	update domain.ProfileUpdate,
+) (*domain.User, *domain.Profile, error) {
+	var user *domain.User
+	var profile *domain.Profile
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		if user, err = s.userRepository.GetUserByID(ctx, userID); err != nil {
+			return err
+		}
+		if profile, err = s.profileRepository.GetProfile(ctx, userID); err != nil {
+			return err
+		}
+		if err := profile.Update(update, time.Now()); err != nil {
+			return err
+		}
+		return s.profileRepository.SaveProfile(ctx, profile)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return user, profile, nil
+}
+
+// AllowsNotification reports whether the user with the email address wants
+// notifications of the category on the channel. Addresses without an
+// account get the default preferences; erased accounts get nothing.
+func (s *ProfileService) AllowsNotification(
+	ctx context.Context,
+	email string,
+	channel domain.NotificationChannel,
+	category domain.NotificationCategory,
+) (bool, error) {
+	user, err := s.userRepository.GetUserByEmail(ctx, email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.DefaultNotificationPreferences().Allows(channel, category), nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if user.IsErased() {
+		return false, nil
+	}
+	profile, err := s.profileRepository.GetProfile(ctx, user.ID())
+	if err != nil {
+		return false, err
+	}
+	return profile.Notifications().Allows(channel, category), nil
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileService_UpdateAndNotificationPreferences(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	queries := postgres.New(pool)
+	userRepository := postgres.NewUserRepository(queries)
+	profileService := NewProfileService(
+		userRepository,
+		postgres.NewProfileRepository(queries),
+		postgres.NewPgxTxManager(pool),
+	)
+	user := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleUser)
+
+	// Users who never edited their profile get the defaults.
+	allowed, err := profileService.AllowsNotification(
+		ctx, user.Email(), domain.NotificationChannelEmail, domain.NotificationBooking,
+	)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	displayName, timeZone := "Jane", "Europe/Warsaw"
+	_, profile, err := profileService.UpdateProfile(ctx, user.ID(), domain.ProfileUpdate{
+		DisplayName: &displayName,
+		TimeZone:    &timeZone,
+		Channels: map[domain.NotificationChannel]domain.ChannelPreference{
+			domain.NotificationChannelEmail: {Enabled: false},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane", profile.DisplayName())
+
+	_, stored, err := profileService.GetProfile(ctx, user.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Warsaw", stored.TimeZone())
+	assert.False(t, stored.Notifications().Channels[domain.NotificationChannelEmail].Enabled)
+
+	// Opting out of email stops booking emails but not account emails.
+	allowed, err = profileService.AllowsNotification(
+		ctx, user.Email(), domain.NotificationChannelEmail, domain.NotificationBooking,
+	)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+	allowed, err = profileService.AllowsNotification(
+		ctx, user.Email(), domain.NotificationChannelEmail, domain.NotificationAccount,
+	)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+
+	// A rejected update leaves the stored profile alone.
+	_, _, err = profileService.UpdateProfile(ctx, user.ID(), domain.ProfileUpdate{
+		DisplayName: &timeZone,
+		Channels: map[domain.NotificationChannel]domain.ChannelPreference{
+			domain.NotificationChannelSMS: {Enabled: true},
+		},
+	})
+	assert.ErrorIs(t, err, domain.ErrPhoneRequired)
+	_, stored, err = profileService.GetProfile(ctx, user.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane", stored.DisplayName())
+
+	// Guests without an account get the defaults.
+	allowed, err = profileService.AllowsNotification(
+		ctx, "guest@example.com", domain.NotificationChannelEmail, domain.NotificationMarketing,
+	)
+	assert.NoError(t, err)
+	assert.False(t, allowed)
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/pdf"
+)
+
+type ReceiptServiceInterface interface {
+	IssueReceipt(ctx context.Context, bookingID uuid.UUID) (*domain.Receipt, error)
+	GetReceipt(ctx context.Context, bookingID uuid.UUID, requester Requester) (*domain.Receipt, []byte, error)
+}
+
+type ReceiptService struct {
+	bookingRepository domain.BookingRepository
+	eventRepository   domain.EventRepository
+	receiptRepository domain.ReceiptRepository
+	blobStore         domain.BlobStore
+	tm                domain.TransactionManager
+}
+
+func NewReceiptService(
+	bookingRepository domain.BookingRepository,
+	eventRepository domain.EventRepository,
+	receiptRepository domain.ReceiptRepository,
+	blobStore domain.BlobStore,
+	tm domain.TransactionManager,
+) *ReceiptService {
+	return &ReceiptService{
+		bookingRepository: bookingRepository,
+		eventRepository:   eventRepository,
+		receiptRepository: receiptRepository,
+		blobStore:         blobStore,
+		tm:                tm,
+	}
+}
+
+// IssueReceipt numbers, renders and stores the receipt of a confirmed
+// booking. It is idempotent: a booking that already has a receipt keeps it,
+// so redelivered events do not burn invoice numbers.
+func (s *ReceiptService) IssueReceipt(ctx context.Context, bookingID uuid.UUID) (*domain.Receipt, error) {
+	var receipt *domain.Receipt
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		existing, err := s.receiptRepository.GetReceipt(ctx, bookingID)
+		if err == nil {
+			receipt = existing
+			return nil
+		}
+		if !errors.Is(err, domain.ErrReceiptNotFound) {
+			return err
+		}
+
+		booking, err := s.bookingRepository.GetBookingByID(ctx, bookingID)
+		if err != nil {
+			return err
+		}
+		if booking.Status() != domain.BookingStatusConfirmed {
+			return fmt.Errorf("booking %s is %s: %w", bookingID, booking.Status(), domain.ErrBookingStatusInvalid)
+		}
+		event, err := s.eventRepository.GetEvent(ctx, booking.EventID())
+		if err != nil {
+			return err
+		}
+
+		sequence, err := s.receiptRepository.NextInvoiceSequence(ctx, event.OrganizerID())
+		if err != nil {
+			return err
+		}
+		receipt = domain.NewReceipt(bookingID, event.OrganizerID(), sequence, time.Now())
+
+		var document bytes.Buffer
+		if _, err := renderReceipt(receipt, booking, event).WriteTo(&document); err != nil {
+			return err
+		}
+		if err := s.blobStore.Put(ctx, receipt.BlobKey(), document.Bytes()); err != nil {
+			return err
+		}
+		return s.receiptRepository.CreateReceipt(ctx, receipt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// GetReceipt returns a receipt and its PDF. Bookings of other customers are
+// reported as not found. A confirmed booking whose receipt event has not been
+// handled yet gets its receipt issued on the spot.
+func (s *ReceiptService) GetReceipt(
+	ctx context.Context,
+	bookingID uuid.UUID,
+	requester Requester,
+) (*domain.Receipt, []byte, error) {
+	booking, err := s.bookingRepository.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !requester.Staff && booking.UserEmail() != requester.Email {
+		return nil, nil, domain.ErrBookingNotFound
+	}
+
+	receipt, err := s.receiptRepository.GetReceipt(ctx, bookingID)
+	if errors.Is(err, domain.ErrReceiptNotFound) && booking.Status() == domain.BookingStatusConfirmed {
+		receipt, err = s.IssueReceipt(ctx, bookingID)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	document, err := s.blobStore.Get(ctx, receipt.BlobKey())
+	if err != nil {
+		return nil, nil, err
+	}
+	return receipt, document, nil
+}
+
+var lineItemLabels = map[domain.LineItemKind]string{
+	domain.LineItemBase:         "Ticket",
+	domain.LineItemServiceFee:   "Service fee",
+	domain.LineItemVAT:          "VAT",
+	domain.LineItemWalletCredit: "Paid from wallet",
+}
+
+func renderReceipt(receipt *domain.Receipt, booking *domain.Booking, event *domain.Event) *pdf.Document {
+	const (
+		left   = 50.0
+		right  = pdf.PageWidth - 50
+		amount = 420.0
+	)
+
+	doc := pdf.New()
+	page := doc.AddPage()
+	y := pdf.PageHeight - 70
+
+	page.Text(left, y, pdf.Bold, 20, "Receipt")
+	y -= 30
+	startAt, _ := event.StartAndEndAt()
+	for _, row := range [][2]string{
+		{"Invoice number", receipt.InvoiceNumber()},
+		{"Issue date", receipt.CreatedAt().UTC().Format("2006-01-02")},
+		{"Booking", booking.ID().String()},
+		{"Billed to", booking.UserEmail()},
+		{"Event", event.Name()},
+		{"Event date", startAt.UTC().Format("2006-01-02 15:04 MST")},
+	} {
+		page.Text(left, y, pdf.Bold, 10, row[0])
+		page.Text(left+110, y, pdf.Regular, 10, row[1])
+		y -= 16
+	}
+	if event.OrganizerID() != uuid.Nil {
+		page.Text(left, y, pdf.Bold, 10, "Organizer")
+		page.Text(left+110, y, pdf.Regular, 10, event.OrganizerID().String())
+		y -= 16
+	}
+
+	y -= 20
+	page.Text(left, y, pdf.Bold, 11, "Description")
+	page.Text(amount, y, pdf.Bold, 11, "Amount")
+	y -= 6
+	page.Line(left, y, right, y)
+	y -= 16
+
+	items := booking.LineItems()
+	if len(items) == 0 {
+		items = []domain.LineItem{{Kind: domain.LineItemBase, Amount: booking.Price()}}
+	}
+	for _, item := range items {
+		label := lineItemLabels[item.Kind]
+		if label == "" {
+			label = string(item.Kind)
+		}
+		if item.RateBasisPoints > 0 {
+			label = fmt.Sprintf("%s (%s%%)", label, formatBasisPoints(item.RateBasisPoints))
+		}
+		page.Text(left, y, pdf.Regular, 11, label)
+		page.Text(amount, y, pdf.Regular, 11, item.Amount.String())
+		y -= 18
+	}
+
+	y += 6
+	page.Line(left, y, right, y)
+	y -= 18
+	page.Text(left, y, pdf.Bold, 12, "Total")
+	page.Text(amount, y, pdf.Bold, 12, booking.Total().String())
+
+	return doc
+}
+
+// formatBasisPoints renders 2300 as "23" and 750 as "7.5".
+func formatBasisPoints(bps int) string {
+	whole, fraction := bps/100, bps%100
+	switch {
+	case fraction == 0:
+		return fmt.Sprintf("%d", whole)
+	case fraction%10 == 0:
+		return fmt.Sprintf("%d.%d", whole, fraction/10)
+	default:
+		return fmt.Sprintf("%d.%02d", whole, fraction)
+	}
+}
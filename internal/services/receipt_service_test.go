@@ -0,0 +1,76 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/blob"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiptService_IssueReceipt_SequentialPerOrganizer(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	organizer := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleOrganizer)
+	event := postgres.CreateTestEvent(ctx, t, pool, postgres.WithOrganizer(organizer.ID()))
+
+	queries := postgres.New(pool)
+	bookingRepository := postgres.NewBookingRepository(queries)
+	blobStore, err := blob.NewFileSystemStore(t.TempDir())
+	assert.NoError(t, err)
+	receiptService := NewReceiptService(
+		bookingRepository,
+		postgres.NewEventRepository(queries),
+		postgres.NewReceiptRepository(queries),
+		blobStore,
+		postgres.NewPgxTxManager(pool),
+	)
+
+	var bookingIDs []uuid.UUID
+	for range 2 {
+		booking, err := domain.NewBooking(uuid.New(), event.ID(), "buyer@example.com", domain.BookingStatusConfirmed)
+		assert.NoError(t, err)
+		assert.NoError(t, bookingRepository.CreateBooking(ctx, booking))
+		bookingIDs = append(bookingIDs, booking.ID())
+	}
+
+	first, err := receiptService.IssueReceipt(ctx, bookingIDs[0])
+	assert.NoError(t, err)
+	second, err := receiptService.IssueReceipt(ctx, bookingIDs[1])
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), first.Sequence())
+	assert.Equal(t, int64(2), second.Sequence())
+
+	// Redelivered events keep the original number.
+	again, err := receiptService.IssueReceipt(ctx, bookingIDs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, first.InvoiceNumber(), again.InvoiceNumber())
+
+	_, document, err := receiptService.GetReceipt(ctx, bookingIDs[0], Requester{Email: "buyer@example.com"})
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(document, []byte("%PDF-")))
+
+	_, _, err = receiptService.GetReceipt(ctx, bookingIDs[0], Requester{Email: "other@example.com"})
+	assert.ErrorIs(t, err, domain.ErrBookingNotFound)
+
+	// A confirmed booking whose receipt event was not handled yet gets its
+	// receipt on request; other bookings have none.
+	confirmed, err := domain.NewBooking(uuid.New(), event.ID(), "buyer@example.com", domain.BookingStatusConfirmed)
+	assert.NoError(t, err)
+	assert.NoError(t, bookingRepository.CreateBooking(ctx, confirmed))
+	late, document, err := receiptService.GetReceipt(ctx, confirmed.ID(), Requester{Email: "buyer@example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), late.Sequence())
+	assert.True(t, bytes.HasPrefix(document, []byte("%PDF-")))
+
+	pending, err := domain.NewBooking(uuid.New(), event.ID(), "buyer@example.com", domain.BookingStatusPending)
+	assert.NoError(t, err)
+	assert.NoError(t, bookingRepository.CreateBooking(ctx, pending))
+	_, _, err = receiptService.GetReceipt(ctx, pending.ID(), Requester{Email: "buyer@example.com"})
+	assert.ErrorIs(t, err, domain.ErrReceiptNotFound)
+}
@@ -0,0 +1,8 @@
+package services
+
+// Requester identifies who reads a customer resource such as a receipt or an
+// order. Staff may read any, customers only their own.
+type Requester struct {
+	Email string
+	Staff bool
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+const (
+	salesEventsTopic       = "event_sales_topic"
+	salesOpenedEventName   = "SalesOpened"
+	salesClosedEventName   = "SalesClosed"
+	salesScheduleBatchSize = 100
+)
+
+type SalesScheduleService struct {
+	scheduleRepository domain.SalesScheduleRepository
+	outboxRepository   domain.OutboxRepository
+	tm                 domain.TransactionManager
+}
+
+func NewSalesScheduleService(
+	scheduleRepository domain.SalesScheduleRepository,
+	outboxRepository domain.OutboxRepository,
+	tm domain.TransactionManager,
+) *SalesScheduleService {
+	return &SalesScheduleService{
+		scheduleRepository: scheduleRepository,
+		outboxRepository:   outboxRepository,
+		tm:                 tm,
+	}
+}
+
+// EmitDueTransitions writes a SalesOpened or SalesClosed outbox event for
+// every event whose sales boundary has passed since the last run and
+// returns how many were written. Events are locked with SKIP LOCKED, so
+// several instances can run the scheduler without publishing twice.
+func (s *SalesScheduleService) EmitDueTransitions(ctx context.Context) (int, error) {
+	emitted := 0
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+
+		opened, err := s.scheduleRepository.ListEventsDueForSalesOpen(ctx, now, salesScheduleBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, event := range opened {
+			if err := s.emit(ctx, salesOpenedEventName, event, now); err != nil {
+				return err
+			}
+			if err := s.scheduleRepository.MarkSalesOpenedEmitted(ctx, event.ID()); err != nil {
+				return err
+			}
+		}
+
+		closed, err := s.scheduleRepository.ListEventsDueForSalesClose(ctx, now, salesScheduleBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, event := range closed {
+			if err := s.emit(ctx, salesClosedEventName, event, now); err != nil {
+				return err
+			}
+			if err := s.scheduleRepository.MarkSalesClosedEmitted(ctx, event.ID()); err != nil {
+				return err
+			}
+		}
+
+		emitted = len(opened) + len(closed)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return emitted, nil
+}
+
+func (s *SalesScheduleService) emit(ctx context.Context, name string, event *domain.Event, now time.Time) error {
+	salesStartAt, salesEndAt := event.SalesWindow()
+	payload := domain.SalesTransition{
+		EventID:    event.ID(),
+		Name:       event.Name(),
+		OccurredAt: now,
+	}
+	if !salesStartAt.IsZero() {
+		payload.SalesStartAt = &salesStartAt
+	}
+	if !salesEndAt.IsZero() {
+		payload.SalesEndAt = &salesEndAt
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	outboxEvent, err := domain.CreateOutboxEvent(name, data, salesEventsTopic, event.ID())
+	if err != nil {
+		return err
+	}
+	return s.outboxRepository.Create(ctx, outboxEvent)
+}
@@ -0,0 +1,438 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type TwoFactorServiceInterface interface {
+	Status(ctx context.Context, userID uuid.UUID) (TwoFactorStatus, error)
+	Enroll(ctx context.Context, userID uuid.UUID) (TwoFactorEnrollment, error)
+	Confirm(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	Disable(ctx context.Context, userID uuid.UUID, code string) error
+	EnrollForLogin(ctx context.Context, challengeToken string) (TwoFactorEnrollment, error)
+	CompleteLogin(ctx context.Context, challengeToken, code string, client LoginClient) (TwoFactorLogin, error)
+	RequiredRoles(ctx context.Context) ([]domain.UserRole, error)
+	RequireForRoles(ctx context.Context, actorID uuid.UUID, roles []domain.UserRole) ([]domain.UserRole, error)
+	ResetTwoFactor(ctx context.Context, actorID, userID uuid.UUID) error
+}
+
+// SessionStarter starts sessions for users who completed every step of
+// signing in.
+type SessionStarter interface {
+	StartSession(ctx context.Context, user *domain.User, client LoginClient) (Session, error)
+}
+
+// TwoFactorStatus tells whether a user turned two-factor authentication on
+// and whether their role requires it.
+type TwoFactorStatus struct {
+	Enabled           bool
+	Required          bool
+	RecoveryCodesLeft int
+}
+
+// TwoFactorEnrollment is what an authenticator app needs to produce codes.
+// ProvisioningURI is the otpauth URI to show as a QR code.
+type TwoFactorEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// TwoFactorLogin is the session a completed login challenge starts. When the
+// challenge confirmed a new authenticator it also holds the user's recovery
+// codes, shown this one time.
+type TwoFactorLogin struct {
+	Session       Session
+	RecoveryCodes []string
+}
+
+// TwoFactorService manages TOTP authenticators and recovery codes, completes
+// login challenges and lets admins require two-factor authentication for
+// elevated roles.
+type TwoFactorService struct {
+	userRepository      domain.UserRepository
+	twoFactorRepository domain.TwoFactorRepository
+	auditRepository     domain.AuditRepository
+	sessions            SessionStarter
+	loginGuard          LoginGuard
+	issuer              string
+	tm                  domain.TransactionManager
+}
+
+// NewTwoFactorService creates a TwoFactorService. issuer names the service in
+// authenticator apps.
+func NewTwoFactorService(
+	userRepository domain.UserRepository,
+	twoFactorRepository domain.TwoFactorRepository,
+	auditRepository domain.AuditRepository,
+	sessions SessionStarter,
+	loginGuard LoginGuard,
+	issuer string,
+	tm domain.TransactionManager,
+) *TwoFactorService {
+	return &TwoFactorService{
+		userRepository:      userRepository,
+		twoFactorRepository: twoFactorRepository,
+		auditRepository:     auditRepository,
+		sessions:            sessions,
+		loginGuard:          loginGuard,
+		issuer:              issuer,
+		tm:                  tm,
+	}
+}
+
+// Status returns the two-factor authentication status of a user.
+func (s *TwoFactorService) Status(ctx context.Context, userID uuid.UUID) (TwoFactorStatus, error) {
+	user, err := s.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return TwoFactorStatus{}, err
+	}
+	requiredRoles, err := s.twoFactorRepository.ListTwoFactorRequiredRoles(ctx)
+	if err != nil {
+		return TwoFactorStatus{}, err
+	}
+	status := TwoFactorStatus{Required: domain.RequiresTwoFactor(requiredRoles, user.Role())}
+
+	twoFactor, err := s.twoFactorRepository.GetTwoFactorForUpdate(ctx, userID)
+	if errors.Is(err, domain.ErrTwoFactorNotEnrolled) {
+		return status, nil
+	}
+	if err != nil {
+		return TwoFactorStatus{}, err
+	}
+	if status.Enabled = twoFactor.IsEnabled(); status.Enabled {
+		if status.RecoveryCodesLeft, err = s.twoFactorRepository.CountRecoveryCodes(ctx, userID); err != nil {
+			return TwoFactorStatus{}, err
+		}
+	}
+	return status, nil
+}
+
+// Enroll gives a signed-in user a new authenticator secret. It stays pending
+// until confirmed with a first code; enrolling again replaces a pending one.
+func (s *TwoFactorService) Enroll(ctx context.Context, userID uuid.UUID) (TwoFactorEnrollment, error) {
+	var enrollment TwoFactorEnrollment
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		enrollment, err = s.enroll(ctx, userID, time.Now())
+		return err
+	})
+	if err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+	return enrollment, nil
+}
+
+// Confirm enables a pending authenticator with a first code and returns the
+// user's recovery codes.
+func (s *TwoFactorService) Confirm(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	var recoveryCodes []string
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		twoFactor, err := s.twoFactorRepository.GetTwoFactorForUpdate(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if twoFactor.IsEnabled() {
+			return domain.ErrTwoFactorEnabled
+		}
+		if err := s.verifyCode(ctx, twoFactor, code, now); err != nil {
+			return err
+		}
+		recoveryCodes, err = s.enable(ctx, twoFactor, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of a user, who proves
+// they hold the authenticator with a code.
+func (s *TwoFactorService) RegenerateRecoveryCodes(
+	ctx context.Context,
+	userID uuid.UUID,
+	code string,
+) ([]string, error) {
+	var recoveryCodes []string
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		twoFactor, err := s.enabledTwoFactor(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if err := s.verifyCode(ctx, twoFactor, code, now); err != nil {
+			return err
+		}
+		if err := s.twoFactorRepository.SaveTwoFactor(ctx, twoFactor); err != nil {
+			return err
+		}
+		recoveryCodes, err = s.replaceRecoveryCodes(ctx, userID, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+// Disable turns two-factor authentication off with a code. Users whose role
+// requires it cannot.
+func (s *TwoFactorService) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	return s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		user, err := s.userRepository.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		requiredRoles, err := s.twoFactorRepository.ListTwoFactorRequiredRoles(ctx)
+		if err != nil {
+			return err
+		}
+		if domain.RequiresTwoFactor(requiredRoles, user.Role()) {
+			return domain.ErrTwoFactorRequired
+		}
+		twoFactor, err := s.enabledTwoFactor(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if err := s.verifyCode(ctx, twoFactor, code, time.Now()); err != nil {
+			return err
+		}
+		return s.twoFactorRepository.DeleteTwoFactor(ctx, userID)
+	})
+}
+
+// EnrollForLogin enrolls an authenticator for a user challenged at login
+// whose role requires two-factor authentication they have not set up. They
+// complete the challenge with a first code of the new authenticator.
+func (s *TwoFactorService) EnrollForLogin(ctx context.Context, challengeToken string) (TwoFactorEnrollment, error) {
+	var enrollment TwoFactorEnrollment
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		challenge, err := s.twoFactorRepository.GetLoginChallengeForUpdate(ctx, challengeToken)
+		if err != nil {
+			return err
+		}
+		if err := challenge.Check(now); err != nil {
+			return err
+		}
+		enrollment, err = s.enroll(ctx, challenge.UserID(), now)
+		return err
+	})
+	if err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+	return enrollment, nil
+}
+
+// CompleteLogin completes a login challenge with a code of the user's
+// authenticator or a recovery code, and starts their session. Wrong codes
+// count against the challenge and toward locking the account out. Completing
+// it with a pending authenticator enables it.
+func (s *TwoFactorService) CompleteLogin(
+	ctx context.Context,
+	challengeToken, code string,
+	client LoginClient,
+) (TwoFactorLogin, error) {
+	var login TwoFactorLogin
+	var failed bool
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		challenge, err := s.twoFactorRepository.GetLoginChallengeForUpdate(ctx, challengeToken)
+		if err != nil {
+			return err
+		}
+		if err := challenge.Check(now); err != nil {
+			return err
+		}
+		twoFactor, err := s.twoFactorRepository.GetTwoFactorForUpdate(ctx, challenge.UserID())
+		if err != nil {
+			return err
+		}
+		user, err := s.userRepository.GetUserByID(ctx, challenge.UserID())
+		if err != nil {
+			return err
+		}
+		if err := s.verifyCode(ctx, twoFactor, code, now); err != nil {
+			if !errors.Is(err, domain.ErrTwoFactorCodeInvalid) {
+				return err
+			}
+			// Commit the attempt; the error is reported after the transaction.
+			failed = true
+			challenge.Fail()
+			if err := s.twoFactorRepository.UpdateLoginChallenge(ctx, challenge); err != nil {
+				return err
+			}
+			return s.loginGuard.RecordFailure(ctx, user, client, domain.LoginOutcomeInvalidCode)
+		}
+
+		if twoFactor.IsEnabled() {
+			err = s.twoFactorRepository.SaveTwoFactor(ctx, twoFactor)
+		} else {
+			login.RecoveryCodes, err = s.enable(ctx, twoFactor, now)
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.twoFactorRepository.DeleteLoginChallenge(ctx, challenge); err != nil {
+			return err
+		}
+		if user.IsSuspended() {
+			return domain.ErrUserSuspended
+		}
+		login.Session, err = s.sessions.StartSession(ctx, user, client)
+		return err
+	})
+	if err != nil {
+		return TwoFactorLogin{}, err
+	}
+	if failed {
+		return TwoFactorLogin{}, domain.ErrTwoFactorCodeInvalid
+	}
+	return login, nil
+}
+
+// RequiredRoles returns the roles required to use two-factor authentication.
+func (s *TwoFactorService) RequiredRoles(ctx context.Context) ([]domain.UserRole, error) {
+	return s.twoFactorRepository.ListTwoFactorRequiredRoles(ctx)
+}
+
+// RequireForRoles replaces the roles required to use two-factor
+// authentication. Their users are challenged from their next sign-in on, and
+// enroll an authenticator then if they have none.
+func (s *TwoFactorService) RequireForRoles(
+	ctx context.Context,
+	actorID uuid.UUID,
+	roles []domain.UserRole,
+) ([]domain.UserRole, error) {
+	if err := domain.ValidateTwoFactorRoles(roles); err != nil {
+		return nil, err
+	}
+	roles = slices.Compact(slices.Sorted(slices.Values(roles)))
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		if err := s.twoFactorRepository.SetTwoFactorRequiredRoles(ctx, roles, actorID, now); err != nil {
+			return err
+		}
+		names := make([]string, len(roles))
+		for i, role := range roles {
+			names[i] = string(role)
+		}
+		record := domain.NewAuditRecord(actorID, domain.AuditTwoFactorRequired, domain.AuditTargetSecurityPolicy,
+			uuid.Nil, map[string]string{"roles": strings.Join(names, ",")}, now)
+		return s.auditRepository.CreateAuditRecord(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// ResetTwoFactor removes the authenticator and recovery codes of a user who
+// lost both, so they can sign in with their password and enroll again.
+func (s *TwoFactorService) ResetTwoFactor(ctx context.Context, actorID, userID uuid.UUID) error {
+	if actorID == userID {
+		return domain.ErrUserSelfModification
+	}
+	return s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		if _, err := s.userRepository.GetUserByID(ctx, userID); err != nil {
+			return err
+		}
+		if _, err := s.twoFactorRepository.GetTwoFactorForUpdate(ctx, userID); err != nil {
+			return err
+		}
+		if err := s.twoFactorRepository.DeleteTwoFactor(ctx, userID); err != nil {
+			return err
+		}
+		record := domain.NewAuditRecord(actorID, domain.AuditUserTwoFactorReset, domain.AuditTargetUser,
+			userID, nil, time.Now())
+		return s.auditRepository.CreateAuditRecord(ctx, record)
+	})
+}
+
+// enroll stores a pending authenticator with a new secret for a user who has
+// no enabled one.
+func (s *TwoFactorService) enroll(ctx context.Context, userID uuid.UUID, now time.Time) (TwoFactorEnrollment, error) {
+	user, err := s.userRepository.GetUserByID(ctx, userID)
+	if err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+	current, err := s.twoFactorRepository.GetTwoFactorForUpdate(ctx, userID)
+	switch {
+	case err == nil && current.IsEnabled():
+		return TwoFactorEnrollment{}, domain.ErrTwoFactorEnabled
+	case err != nil && !errors.Is(err, domain.ErrTwoFactorNotEnrolled):
+		return TwoFactorEnrollment{}, err
+	}
+
+	secret := auth.NewTOTPSecret()
+	if err := s.twoFactorRepository.SaveTwoFactor(ctx, domain.NewTwoFactor(userID, secret, now)); err != nil {
+		return TwoFactorEnrollment{}, err
+	}
+	return TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(s.issuer, user.Email(), secret),
+	}, nil
+}
+
+// enable turns a confirmed authenticator on and issues recovery codes.
+func (s *TwoFactorService) enable(ctx context.Context, twoFactor *domain.TwoFactor, now time.Time) ([]string, error) {
+	if err := twoFactor.Enable(now); err != nil {
+		return nil, err
+	}
+	if err := s.twoFactorRepository.SaveTwoFactor(ctx, twoFactor); err != nil {
+		return nil, err
+	}
+	return s.replaceRecoveryCodes(ctx, twoFactor.UserID(), now)
+}
+
+func (s *TwoFactorService) replaceRecoveryCodes(
+	ctx context.Context,
+	userID uuid.UUID,
+	now time.Time,
+) ([]string, error) {
+	codes, secrets := domain.NewRecoveryCodes(userID, now)
+	if err := s.twoFactorRepository.ReplaceRecoveryCodes(ctx, userID, codes); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+// enabledTwoFactor returns the enabled authenticator of a user, locked.
+func (s *TwoFactorService) enabledTwoFactor(ctx context.Context, userID uuid.UUID) (*domain.TwoFactor, error) {
+	twoFactor, err := s.twoFactorRepository.GetTwoFactorForUpdate(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !twoFactor.IsEnabled() {
+		return nil, domain.ErrTwoFactorNotEnrolled
+	}
+	return twoFactor, nil
+}
+
+// verifyCode accepts a current code of the authenticator, recording its
+// period on twoFactor for the caller to save, or an unused recovery code once
+// the authenticator is enabled.
+func (s *TwoFactorService) verifyCode(
+	ctx context.Context,
+	twoFactor *domain.TwoFactor,
+	code string,
+	now time.Time,
+) error {
+	if step, ok := auth.VerifyTOTP(twoFactor.Secret(), code, now); ok {
+		return twoFactor.UseStep(step)
+	}
+	if !twoFactor.IsEnabled() || len(strings.TrimSpace(code)) <= auth.TOTPDigits {
+		return domain.ErrTwoFactorCodeInvalid
+	}
+	return s.twoFactorRepository.UseRecoveryCode(ctx, twoFactor.UserID(), code, now)
+}
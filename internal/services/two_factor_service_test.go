@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+// currentTOTPCode returns the code an authenticator app shows now, offset by
+// a number of periods.
+func currentTOTPCode(t *testing.T, secret string, offset int64) string {
+	t.Helper()
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(time.Now())+offset)
+	assert.NoError(t, err)
+	return code
+}
+
+func TestTwoFactorService_LoginChallengeAndRequiredRoles(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	queries := postgres.New(pool)
+	userRepository := postgres.NewUserRepository(queries)
+	auditRepository := postgres.NewAuditRepository(queries)
+	loginSecurityRepository := postgres.NewLoginSecurityRepository(queries)
+	userService := newTestUserService(pool, newTestJWTService(t), &recordingRevoker{revoked: map[uuid.UUID]bool{}})
+	twoFactorService := NewTwoFactorService(
+		userRepository,
+		postgres.NewTwoFactorRepository(queries),
+		auditRepository,
+		userService,
+		newTestLoginSecurityService(queries),
+		"go-ticket",
+		postgres.NewPgxTxManager(pool),
+	)
+	admin := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleAdmin)
+
+	// Enrolling and confirming turns two-factor authentication on.
+	assert.NoError(t, userService.RegisterUser(ctx, "totp@example.com", "password123"))
+	user, err := userRepository.GetUserByEmail(ctx, "totp@example.com")
+	assert.NoError(t, err)
+	enrollment, err := twoFactorService.Enroll(ctx, user.ID())
+	assert.NoError(t, err)
+	assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/go-ticket:totp@example.com")
+	firstCode := currentTOTPCode(t, enrollment.Secret, 0)
+	recoveryCodes, err := twoFactorService.Confirm(ctx, user.ID(), firstCode)
+	assert.NoError(t, err)
+	assert.Len(t, recoveryCodes, domain.RecoveryCodeCount)
+	_, err = twoFactorService.Enroll(ctx, user.ID())
+	assert.ErrorIs(t, err, domain.ErrTwoFactorEnabled)
+
+	// The password alone yields a challenge, which takes neither a used code
+	// nor a used recovery code.
+	login, err := userService.LoginUser(ctx, "totp@example.com", "password123", testClient)
+	assert.NoError(t, err)
+	assert.NotNil(t, login.Challenge)
+	assert.False(t, login.Challenge.EnrollmentRequired)
+	assert.Empty(t, login.Session.RefreshToken)
+	_, err = twoFactorService.CompleteLogin(ctx, login.Challenge.Token, firstCode, testClient)
+	assert.ErrorIs(t, err, domain.ErrTwoFactorCodeInvalid)
+	completed, err := twoFactorService.CompleteLogin(
+		ctx, login.Challenge.Token, strings.ToLower(recoveryCodes[0]), testClient,
+	)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, completed.Session.RefreshToken)
+	assert.Empty(t, completed.RecoveryCodes)
+	_, err = twoFactorService.CompleteLogin(ctx, login.Challenge.Token, recoveryCodes[1], testClient)
+	assert.ErrorIs(t, err, domain.ErrLoginChallengeInvalid)
+
+	login, err = userService.LoginUser(ctx, "totp@example.com", "password123", testClient)
+	assert.NoError(t, err)
+	_, err = twoFactorService.CompleteLogin(ctx, login.Challenge.Token, recoveryCodes[0], testClient)
+	assert.ErrorIs(t, err, domain.ErrTwoFactorCodeInvalid)
+	status, err := twoFactorService.Status(ctx, user.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, TwoFactorStatus{Enabled: true, RecoveryCodesLeft: domain.RecoveryCodeCount - 1}, status)
+
+	// Wrong codes use the challenge up and lock the account out.
+	for range domain.MaxLoginChallengeAttempts - 1 {
+		_, err = twoFactorService.CompleteLogin(
+			ctx, login.Challenge.Token, currentTOTPCode(t, enrollment.Secret, 5), testClient,
+		)
+		assert.ErrorIs(t, err, domain.ErrTwoFactorCodeInvalid)
+	}
+	_, err = twoFactorService.CompleteLogin(
+		ctx, login.Challenge.Token, currentTOTPCode(t, enrollment.Secret, 1), testClient,
+	)
+	assert.ErrorIs(t, err, domain.ErrLoginChallengeInvalid)
+	_, err = userService.LoginUser(ctx, "totp@example.com", "password123", testClient)
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	// The lockout expires.
+	assert.NoError(t, loginSecurityRepository.DeleteAccountLockout(ctx, user.ID()))
+
+	// Requiring two-factor authentication for organizers makes organizers
+	// without an authenticator enroll one at their next sign-in.
+	_, err = twoFactorService.RequireForRoles(ctx, admin.ID(), []domain.UserRole{domain.UserRoleUser})
+	assert.ErrorIs(t, err, domain.ErrTwoFactorRoleInvalid)
+	roles, err := twoFactorService.RequireForRoles(ctx, admin.ID(),
+		[]domain.UserRole{domain.UserRoleOrganizer, domain.UserRoleOrganizer})
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.UserRole{domain.UserRoleOrganizer}, roles)
+	records, err := auditRepository.ListAuditRecords(ctx, domain.AuditTargetSecurityPolicy, uuid.Nil, 10)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+
+	assert.NoError(t, userService.RegisterUser(ctx, "organizer@example.com", "password123"))
+	organizer, err := userRepository.GetUserByEmail(ctx, "organizer@example.com")
+	assert.NoError(t, err)
+	assert.NoError(t, organizer.UpdateRole(domain.UserRoleOrganizer))
+	assert.NoError(t, userRepository.UpdateUser(ctx, organizer))
+
+	login, err = userService.LoginUser(ctx, "organizer@example.com", "password123", testClient)
+	assert.NoError(t, err)
+	assert.True(t, login.Challenge.EnrollmentRequired)
+	enrollment, err = twoFactorService.EnrollForLogin(ctx, login.Challenge.Token)
+	assert.NoError(t, err)
+	completed, err = twoFactorService.CompleteLogin(
+		ctx, login.Challenge.Token, currentTOTPCode(t, enrollment.Secret, 0), testClient,
+	)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, completed.Session.RefreshToken)
+	assert.Len(t, completed.RecoveryCodes, domain.RecoveryCodeCount)
+	err = twoFactorService.Disable(ctx, organizer.ID(), completed.RecoveryCodes[0])
+	assert.ErrorIs(t, err, domain.ErrTwoFactorRequired)
+
+	// Admins reset the authenticator of users who lost it.
+	assert.NoError(t, twoFactorService.ResetTwoFactor(ctx, admin.ID(), user.ID()))
+	login, err = userService.LoginUser(ctx, "totp@example.com", "password123", testClient)
+	assert.NoError(t, err)
+	assert.Nil(t, login.Challenge)
+	assert.NotEmpty(t, login.Session.RefreshToken)
+}
@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mati/go-ticket/internal/auth"
@@ -11,21 +13,98 @@ import (
 
 type UserServiceInterface interface {
 	RegisterUser(ctx context.Context, email, password string) error
-	LoginUser(ctx context.Context, email, password string) (string, error)
+	LoginUser(ctx context.Context, email, password string, client LoginClient) (Login, error)
+	RefreshSession(ctx context.Context, refreshToken string) (Session, error)
+	Logout(ctx context.Context, logout Logout) error
+}
+
+// Session is the token pair handed out on login and on every refresh.
+type Session struct {
+	AccessToken  auth.AccessToken
+	RefreshToken string
+}
+
+// Login is the outcome of a sign-in: a session, or a challenge the user
+// must complete with their second factor first.
+type Login struct {
+	Session   Session
+	Challenge *TwoFactorChallenge
+}
+
+// TwoFactorChallenge is completed with a code from the user's authenticator
+// app, or a recovery code. When EnrollmentRequired is set the user's role
+// requires two-factor authentication they have not set up, so they enroll
+// an authenticator with the challenge first.
+type TwoFactorChallenge struct {
+	Token              string
+	ExpiresAt          time.Time
+	EnrollmentRequired bool
+}
+
+// Logout describes the session to end. The access token of the request is
+// always revoked; the refresh token, when given, ends its session, and
+// AllSessions ends every session of the user.
+type Logout struct {
+	UserID               uuid.UUID
+	AccessTokenID        uuid.UUID
+	AccessTokenExpiresAt time.Time
+	RefreshToken         string
+	AllSessions          bool
+}
+
+// VerificationRequester emails a new user a link to verify their address.
+type VerificationRequester interface {
+	RequestVerification(ctx context.Context, user *domain.User) error
+}
+
+// LoginGuard locks accounts out after repeated failed sign-ins and keeps
+// their login history.
+type LoginGuard interface {
+	CheckLockout(ctx context.Context, userID uuid.UUID) error
+	RecordFailure(ctx context.Context, user *domain.User, client LoginClient, outcome domain.LoginOutcome) error
+	RecordSuccess(ctx context.Context, user *domain.User, client LoginClient) error
+}
+
+// TokenRevoker denylists access tokens until they expire.
+type TokenRevoker interface {
+	Revoke(ctx context.Context, tokenID uuid.UUID, expiresAt time.Time) error
 }
 
 type UserService struct {
-	userRepository domain.UserRepository
-	jwtService     *auth.JWTService
+	userRepository         domain.UserRepository
+	refreshTokenRepository domain.RefreshTokenRepository
+	twoFactorRepository    domain.TwoFactorRepository
+	verifications          VerificationRequester
+	loginGuard             LoginGuard
+	jwtService             *auth.JWTService
+	revoker                TokenRevoker
+	tm                     domain.TransactionManager
 }
 
-func NewUserService(userRepository domain.UserRepository, jwtService *auth.JWTService) *UserService {
+func NewUserService(
+	userRepository domain.UserRepository,
+	refreshTokenRepository domain.RefreshTokenRepository,
+	twoFactorRepository domain.TwoFactorRepository,
+	verifications VerificationRequester,
+	loginGuard LoginGuard,
+	jwtService *auth.JWTService,
+	revoker TokenRevoker,
+	tm domain.TransactionManager,
+) *UserService {
 	return &UserService{
-		userRepository: userRepository,
-		jwtService:     jwtService,
+		userRepository:         userRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		twoFactorRepository:    twoFactorRepository,
+		verifications:          verifications,
+		loginGuard:             loginGuard,
+		jwtService:             jwtService,
+		revoker:                revoker,
+		tm:                     tm,
 	}
 }
 
+// RegisterUser creates an unverified user and emails them a verification
+// link. The user can sign in right away but cannot book until verified.
 func (s *UserService) RegisterUser(ctx context.Context, email, password string) error {
 	if err := domain.ValidatePassword(password); err != nil {
 		return err
@@ -38,10 +117,22 @@ func (s *UserService) RegisterUser(ctx context.Context, email, password string)
 	if err != nil {
 		return err
 	}
-	return s.userRepository.CreateUser(ctx, newUser)
+	return s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepository.CreateUser(ctx, newUser); err != nil {
+			return err
+		}
+		return s.verifications.RequestVerification(ctx, newUser)
+	})
 }
 
-func (s *UserService) LoginUser(ctx context.Context, email, password string) (string, error) {
+// LoginUser verifies the credentials and starts a new session, or challenges
+// users of two-factor authentication for their code. Accounts locked out
+// after repeated wrong passwords are refused whatever the password, with the
+// same error as unknown addresses so the lockout does not tell which ones are
+// registered; the owner is emailed about it instead. Suspended users are
+// refused once their password is confirmed. Every attempt on an existing
+// account is kept in its login history.
+func (s *UserService) LoginUser(ctx context.Context, email, password string, client LoginClient) (Login, error) {
 	userFromDB, err := s.userRepository.GetUserByEmail(ctx, email)
 
 	hashToVerify := "$2a$10$dummyhashfortimingattackprotection1234567890123456"
@@ -51,13 +142,205 @@ func (s *UserService) LoginUser(ctx context.Context, email, password string) (st
 
 	verifyErr := auth.VerifyPassword(hashToVerify, password)
 
-	if err != nil || verifyErr != nil {
-		return "", domain.ErrInvalidCredentials
+	if err != nil {
+		return Login{}, domain.ErrInvalidCredentials
+	}
+
+	var login Login
+	var refused error
+	err = s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		var outcome domain.LoginOutcome
+		err := s.loginGuard.CheckLockout(ctx, userFromDB.ID())
+		switch {
+		case errors.Is(err, domain.ErrAccountLocked):
+			outcome, refused = domain.LoginOutcomeLocked, domain.ErrInvalidCredentials
+		case err != nil:
+			return err
+		case verifyErr != nil:
+			outcome, refused = domain.LoginOutcomeInvalidPassword, domain.ErrInvalidCredentials
+		case userFromDB.IsSuspended():
+			outcome, refused = domain.LoginOutcomeSuspended, domain.ErrUserSuspended
+		default:
+			login, err = s.CompleteSignIn(ctx, userFromDB, client)
+			return err
+		}
+		// Commit the attempt; the error is reported after the transaction.
+		return s.loginGuard.RecordFailure(ctx, userFromDB, client, outcome)
+	})
+	if err != nil {
+		return Login{}, err
+	}
+	if refused != nil {
+		return Login{}, refused
+	}
+	return login, nil
+}
+
+// RefreshSession exchanges a refresh token for a new token pair of the same
+// session. Each refresh token works once: presenting a used one means it was
+// copied, so the whole session is revoked, including its live access tokens.
+func (s *UserService) RefreshSession(ctx context.Context, refreshToken string) (Session, error) {
+	var session Session
+	var reused bool
+	var revoked []uuid.UUID
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		token, err := s.refreshTokenRepository.GetRefreshTokenForUpdate(ctx, refreshToken)
+		if err != nil {
+			return err
+		}
+		if err := token.Use(now); err != nil {
+			if !errors.Is(err, domain.ErrRefreshTokenReused) {
+				return err
+			}
+			// Commit the revocation; the error is reported after the transaction.
+			reused = true
+			revoked, err = s.refreshTokenRepository.RevokeFamily(ctx, token.FamilyID(), now, now.Add(-auth.AccessTokenTTL))
+			return err
+		}
+		if err := s.refreshTokenRepository.MarkRefreshTokenUsed(ctx, token); err != nil {
+			return err
+		}
+
+		user, err := s.userRepository.GetUserByID(ctx, token.UserID())
+		if err != nil {
+			return err
+		}
+		if user.IsSuspended() {
+			return domain.ErrUserSuspended
+		}
+		session, err = s.issueSession(ctx, user, token.FamilyID())
+		return err
+	})
+	if err != nil {
+		return Session{}, err
+	}
+	if reused {
+		if err := revokeAccessTokens(ctx, s.revoker, revoked); err != nil {
+			return Session{}, err
+		}
+		return Session{}, domain.ErrRefreshTokenReused
+	}
+	return session, nil
+}
+
+// Logout revokes the access token of the request and ends the sessions
+// described by logout.
+func (s *UserService) Logout(ctx context.Context, logout Logout) error {
+	var revoked []uuid.UUID
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		now := time.Now()
+		if logout.AllSessions {
+			var err error
+			revoked, err = s.refreshTokenRepository.RevokeUserTokens(ctx, logout.UserID, now, now.Add(-auth.AccessTokenTTL))
+			return err
+		}
+		if logout.RefreshToken == "" {
+			return nil
+		}
+		token, err := s.refreshTokenRepository.GetRefreshTokenForUpdate(ctx, logout.RefreshToken)
+		if err != nil {
+			return err
+		}
+		if token.UserID() != logout.UserID {
+			return domain.ErrRefreshTokenInvalid
+		}
+		revoked, err = s.refreshTokenRepository.RevokeFamily(ctx, token.FamilyID(), now, now.Add(-auth.AccessTokenTTL))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.revoker.Revoke(ctx, logout.AccessTokenID, logout.AccessTokenExpiresAt); err != nil {
+		return err
+	}
+	return revokeAccessTokens(ctx, s.revoker, revoked)
+}
+
+// CompleteSignIn starts a session for a user who proved who they are, such
+// as with their password or an identity provider. Users who turned two-factor
+// authentication on, or whose role requires it, are challenged for their
+// code instead.
+func (s *UserService) CompleteSignIn(ctx context.Context, user *domain.User, client LoginClient) (Login, error) {
+	challenge, err := s.secondFactorChallenge(ctx, user)
+	if err != nil {
+		return Login{}, err
+	}
+	if challenge != nil {
+		return Login{Challenge: challenge}, nil
+	}
+	session, err := s.StartSession(ctx, user, client)
+	if err != nil {
+		return Login{}, err
+	}
+	return Login{Session: session}, nil
+}
+
+// StartSession starts a new session for a user who completed every step of
+// signing in, and records the sign-in in their login history.
+func (s *UserService) StartSession(ctx context.Context, user *domain.User, client LoginClient) (Session, error) {
+	if err := s.loginGuard.RecordSuccess(ctx, user, client); err != nil {
+		return Session{}, err
+	}
+	return s.issueSession(ctx, user, uuid.Nil)
+}
+
+// secondFactorChallenge stores a login challenge for users with an enabled
+// authenticator or a role that requires one, and returns nil for others.
+func (s *UserService) secondFactorChallenge(ctx context.Context, user *domain.User) (*TwoFactorChallenge, error) {
+	enabled := false
+	twoFactor, err := s.twoFactorRepository.GetTwoFactorForUpdate(ctx, user.ID())
+	switch {
+	case err == nil:
+		enabled = twoFactor.IsEnabled()
+	case !errors.Is(err, domain.ErrTwoFactorNotEnrolled):
+		return nil, err
+	}
+	if !enabled {
+		requiredRoles, err := s.twoFactorRepository.ListTwoFactorRequiredRoles(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !domain.RequiresTwoFactor(requiredRoles, user.Role()) {
+			return nil, nil
+		}
+	}
+
+	challenge, token := domain.NewLoginChallenge(user.ID(), time.Now())
+	if err := s.twoFactorRepository.CreateLoginChallenge(ctx, challenge); err != nil {
+		return nil, err
 	}
+	return &TwoFactorChallenge{
+		Token:              token,
+		ExpiresAt:          challenge.ExpiresAt(),
+		EnrollmentRequired: !enabled,
+	}, nil
+}
 
-	token, err := s.jwtService.GenerateToken(userFromDB)
+// issueSession signs an access token and stores a refresh token paired with
+// it. familyID is uuid.Nil for a new session.
+func (s *UserService) issueSession(ctx context.Context, user *domain.User, familyID uuid.UUID) (Session, error) {
+	access, err := s.jwtService.GenerateToken(user)
 	if err != nil {
-		return "", err
+		return Session{}, err
+	}
+	token, secret := domain.NewRefreshToken(user.ID(), familyID, access.ID, time.Now())
+	if err := s.refreshTokenRepository.CreateRefreshToken(ctx, token); err != nil {
+		return Session{}, err
+	}
+	return Session{AccessToken: access, RefreshToken: secret}, nil
+}
+
+// revokeAccessTokens denylists access tokens issued with revoked refresh
+// tokens. Their exact expiry is not stored, so they are denylisted for a full
+// access token lifetime.
+func revokeAccessTokens(ctx context.Context, revoker TokenRevoker, tokenIDs []uuid.UUID) error {
+	expiresAt := time.Now().Add(auth.AccessTokenTTL)
+	for _, id := range tokenIDs {
+		if err := revoker.Revoke(ctx, id, expiresAt); err != nil {
+			return err
+		}
 	}
-	return token, nil
+	return nil
 }
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserService_RefreshRotationAndReuseDetection(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	jwtService := newTestJWTService(t)
+	revoker := &recordingRevoker{revoked: map[uuid.UUID]bool{}}
+	userService := newTestUserService(pool, jwtService, revoker)
+
+	assert.NoError(t, userService.RegisterUser(ctx, "session@example.com", "password123"))
+	login, err := userService.LoginUser(ctx, "session@example.com", "password123", testClient)
+	assert.NoError(t, err)
+
+	rotated, err := userService.RefreshSession(ctx, login.Session.RefreshToken)
+	assert.NoError(t, err)
+	assert.NotEqual(t, login.Session.RefreshToken, rotated.RefreshToken)
+	assert.NotEqual(t, login.Session.AccessToken.ID, rotated.AccessToken.ID)
+
+	// Replaying the first refresh token revokes the whole session.
+	_, err = userService.RefreshSession(ctx, login.Session.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+	assert.True(t, revoker.revoked[login.Session.AccessToken.ID])
+	assert.True(t, revoker.revoked[rotated.AccessToken.ID])
+	_, err = userService.RefreshSession(ctx, rotated.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+
+	// Logout revokes the current access token and ends the session.
+	login, err = userService.LoginUser(ctx, "session@example.com", "password123", testClient)
+	assert.NoError(t, err)
+	claims, err := jwtService.VerifyToken(login.Session.AccessToken.Token)
+	assert.NoError(t, err)
+	err = userService.Logout(ctx, Logout{
+		UserID:               claims.UserID,
+		AccessTokenID:        login.Session.AccessToken.ID,
+		AccessTokenExpiresAt: login.Session.AccessToken.ExpiresAt,
+		RefreshToken:         login.Session.RefreshToken,
+	})
+	assert.NoError(t, err)
+	assert.True(t, revoker.revoked[login.Session.AccessToken.ID])
+	_, err = userService.RefreshSession(ctx, login.Session.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenInvalid)
+}
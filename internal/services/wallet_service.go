@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+// recentWalletTransactions is how many transactions are returned with a wallet.
+const recentWalletTransactions = 20
+
+type WalletServiceInterface interface {
+	IssueGiftCard(ctx context.Context, amount domain.Money, issuedBy uuid.UUID) (*domain.GiftCard, string, error)
+	RedeemGiftCard(ctx context.Context, userID uuid.UUID, code string) (*domain.Wallet, error)
+	GetWallet(ctx context.Context, userID uuid.UUID) (WalletSummary, error)
+}
+
+// WalletSummary is a user's balance per currency and latest transactions.
+type WalletSummary struct {
+	Wallets      []*domain.Wallet
+	Transactions []*domain.WalletTransaction
+}
+
+type WalletService struct {
+	walletRepository domain.WalletRepository
+	ledgerRepository domain.LedgerRepository
+	tm               domain.TransactionManager
+}
+
+func NewWalletService(
+	walletRepository domain.WalletRepository,
+	ledgerRepository domain.LedgerRepository,
+	tm domain.TransactionManager,
+) *WalletService {
+	return &WalletService{
+		walletRepository: walletRepository,
+		ledgerRepository: ledgerRepository,
+		tm:               tm,
+	}
+}
+
+// IssueGiftCard records a sold gift card and returns it with its code. The
+// payment is booked from cash to customer credit.
+func (s *WalletService) IssueGiftCard(
+	ctx context.Context,
+	amount domain.Money,
+	issuedBy uuid.UUID,
+) (*domain.GiftCard, string, error) {
+	card, code, err := domain.NewGiftCard(amount, issuedBy)
+	if err != nil {
+		return nil, "", err
+	}
+	err = s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.walletRepository.CreateGiftCard(ctx, card); err != nil {
+			return err
+		}
+		entry, err := domain.CustomerCreditJournalEntry(domain.JournalGiftCardIssued, card.ID(), amount)
+		if err != nil {
+			return err
+		}
+		return s.ledgerRepository.PostEntry(ctx, entry)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return card, code, nil
+}
+
+// RedeemGiftCard credits a gift card to the user's wallet in its currency.
+// The card is locked, so concurrent redemptions of one code credit it once.
+func (s *WalletService) RedeemGiftCard(ctx context.Context, userID uuid.UUID, code string) (*domain.Wallet, error) {
+	var wallet *domain.Wallet
+	err := s.tm.RunInTx(ctx, func(ctx context.Context) error {
+		card, err := s.walletRepository.GetGiftCardForUpdate(ctx, code)
+		if err != nil {
+			return err
+		}
+		if err := card.Redeem(userID, time.Now()); err != nil {
+			return err
+		}
+		if err := s.walletRepository.RedeemGiftCard(ctx, card); err != nil {
+			return err
+		}
+
+		wallet, err = s.walletRepository.GetWalletForUpdate(ctx, userID, card.Amount().Currency())
+		if err != nil {
+			return err
+		}
+		transaction, err := wallet.Credit(domain.WalletGiftCardRedeemed, card.ID(), card.Amount())
+		if err != nil {
+			return err
+		}
+		return s.walletRepository.SaveTransaction(ctx, wallet, transaction)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wallet, nil
+}
+
+func (s *WalletService) GetWallet(ctx context.Context, userID uuid.UUID) (WalletSummary, error) {
+	wallets, err := s.walletRepository.ListWallets(ctx, userID)
+	if err != nil {
+		return WalletSummary{}, err
+	}
+	transactions, err := s.walletRepository.ListTransactions(ctx, userID, recentWalletTransactions)
+	if err != nil {
+		return WalletSummary{}, err
+	}
+	return WalletSummary{Wallets: wallets, Transactions: transactions}, nil
+}
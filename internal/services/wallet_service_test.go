@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/mati/go-ticket/internal/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWallet_GiftCardPaysBookingAndRefundCreditsWallet(t *testing.T) {
+	ctx := context.Background()
+	pool := postgres.SetupDb(ctx, t)
+
+	admin := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleAdmin)
+	buyer := postgres.CreateTestUser(ctx, t, pool, domain.UserRoleUser)
+	event := postgres.CreateTestEvent(ctx, t, pool, postgres.WithPrice(10000))
+
+	queries := postgres.New(pool)
+	ledgerRepository := postgres.NewLedgerRepository(queries)
+	walletRepository := postgres.NewWalletRepository(queries)
+	bookingService := newTestBookingService(pool)
+	walletService := NewWalletService(walletRepository, ledgerRepository, postgres.NewPgxTxManager(pool))
+	ledgerService := NewLedgerService(ledgerRepository, postgres.NewPgxTxManager(pool))
+
+	_, code, err := walletService.IssueGiftCard(ctx, domain.UnmarshalMoney(2500, "EUR"), admin.ID())
+	assert.NoError(t, err)
+	wallet, err := walletService.RedeemGiftCard(ctx, buyer.ID(), strings.ToLower(code))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2500), wallet.Balance().Amount())
+	_, err = walletService.RedeemGiftCard(ctx, buyer.ID(), code)
+	assert.ErrorIs(t, err, domain.ErrGiftCardRedeemed)
+
+	booking, err := domain.NewBooking(uuid.New(), event.ID(), buyer.Email(), domain.BookingStatusPending)
+	assert.NoError(t, err)
+	assert.NoError(t, bookingService.CreateBooking(ctx, booking, CreateBookingOptions{WalletUserID: buyer.ID()}))
+	assert.Equal(t, int64(7500), booking.Total().Amount())
+	_, err = bookingService.ConfirmBooking(ctx, booking.ID())
+	assert.NoError(t, err)
+
+	summary, err := walletService.GetWallet(ctx, buyer.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), summary.Wallets[0].Balance().Amount())
+
+	_, err = bookingService.RefundBooking(ctx, booking.ID())
+	assert.NoError(t, err)
+
+	summary, err = walletService.GetWallet(ctx, buyer.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10000), summary.Wallets[0].Balance().Amount(), "the full price returns to the wallet")
+	assert.Len(t, summary.Transactions, 3)
+
+	discrepancies, err := ledgerService.Verify(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, discrepancies)
+}
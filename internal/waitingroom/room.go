@@ -0,0 +1,315 @@
+// Package waitingroom implements a Redis-backed admission queue for
+// high-demand on-sales.
+package waitingroom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	activeEventsKey = "waitingroom:active"
+
+	ticketTokenKind = "queue"
+	passTokenKind   = "pass"
+)
+
+// Ticket is handed out when a user joins the queue.
+type Ticket struct {
+	Position  int64
+	PollToken string
+}
+
+// Status describes where a ticket holder currently stands.
+type Status struct {
+	Position       int64
+	Admitted       bool
+	AdmissionPass  string
+	PassExpiresAt  time.Time
+	AdmitPerSecond int
+}
+
+// admitScript admits up to the configured rate of queued users, at most once
+// per second across every replica calling AdmitNext: the first call of a
+// second claims the slot key, and the others leave the queue alone.
+//
+// KEYS: rate, queue, slot. ARGV: admitted key prefix, pass TTL in ms.
+var admitScript = redis.NewScript(`
+local rate = tonumber(redis.call('GET', KEYS[1]))
+if not rate or rate <= 0 then
+	return 0
+end
+if not redis.call('SET', KEYS[3], 1, 'NX', 'PX', 1000) then
+	return 0
+end
+local members = redis.call('ZPOPMIN', KEYS[2], rate)
+for i = 1, #members, 2 do
+	redis.call('SET', ARGV[1] .. members[i], 1, 'PX', ARGV[2])
+end
+return #members / 2
+`)
+
+// Room is a per-event admission queue. Users wait in a sorted set ordered by
+// join time and are moved to the admitted state at a fixed rate by AdmitNext.
+// An admission lets the user make one booking.
+type Room struct {
+	client  *redis.Client
+	signer  *auth.TokenSigner
+	passTTL time.Duration
+}
+
+// NewRoom creates a Room. The signer has to use a key of its own, so queue
+// tickets and passes cannot stand in for tokens of another kind.
+func NewRoom(client *redis.Client, signer *auth.TokenSigner, passTTL time.Duration) *Room {
+	return &Room{
+		client:  client,
+		signer:  signer,
+		passTTL: passTTL,
+	}
+}
+
+// Activate opens the waiting room for an event, admitting admitPerSecond users every second.
+func (r *Room) Activate(ctx context.Context, eventID uuid.UUID, admitPerSecond int) error {
+	if admitPerSecond <= 0 {
+		return domain.ErrWaitingRoomRateInvalid
+	}
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, rateKey(eventID), admitPerSecond, 0)
+		pipe.SAdd(ctx, activeEventsKey, eventID.String())
+		return nil
+	})
+	return err
+}
+
+// Deactivate closes the waiting room and drops everyone still in the queue.
+// Passes that were already issued stay valid until they expire.
+func (r *Room) Deactivate(ctx context.Context, eventID uuid.UUID) error {
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.SRem(ctx, activeEventsKey, eventID.String())
+		pipe.Del(ctx, rateKey(eventID), queueKey(eventID))
+		return nil
+	})
+	return err
+}
+
+func (r *Room) IsActive(ctx context.Context, eventID uuid.UUID) (bool, error) {
+	return r.client.SIsMember(ctx, activeEventsKey, eventID.String()).Result()
+}
+
+// Join puts the user in the event queue. Joining again keeps the original place.
+func (r *Room) Join(ctx context.Context, eventID, userID uuid.UUID) (*Ticket, error) {
+	active, err := r.IsActive(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, domain.ErrWaitingRoomInactive
+	}
+
+	ticket := &Ticket{PollToken: r.signer.Sign(ticketPayload(eventID, userID))}
+
+	admitted, err := r.client.Exists(ctx, admittedKey(eventID, userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if admitted > 0 {
+		return ticket, nil
+	}
+
+	err = r.client.ZAddNX(ctx, queueKey(eventID), redis.Z{
+		Score:  float64(time.Now().UnixNano()),
+		Member: userID.String(),
+	}).Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to join queue: %w", err)
+	}
+
+	rank, err := r.client.ZRank(ctx, queueKey(eventID), userID.String()).Result()
+	if err != nil {
+		return nil, err
+	}
+	ticket.Position = rank + 1
+	return ticket, nil
+}
+
+// Status reports the queue position of the ticket holder, or an admission
+// pass once the holder has been admitted.
+func (r *Room) Status(ctx context.Context, pollToken string) (*Status, error) {
+	eventID, userID, err := r.parseTicket(pollToken)
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := r.client.Get(ctx, rateKey(eventID)).Int()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	ttl, err := r.client.PTTL(ctx, admittedKey(eventID, userID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		expiresAt := time.Now().Add(ttl)
+		return &Status{
+			Admitted:       true,
+			AdmissionPass:  r.signer.Sign(passPayload(eventID, userID, expiresAt)),
+			PassExpiresAt:  expiresAt,
+			AdmitPerSecond: rate,
+		}, nil
+	}
+
+	rank, err := r.client.ZRank(ctx, queueKey(eventID), userID.String()).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, domain.ErrQueueTicketInvalid
+		}
+		return nil, err
+	}
+	return &Status{Position: rank + 1, AdmitPerSecond: rate}, nil
+}
+
+// AdmitNext admits the next batch of users of every active waiting room.
+// It is meant to be called once per second, and calls on other replicas
+// within the same second admit nobody.
+func (r *Room) AdmitNext(ctx context.Context) error {
+	eventIDs, err := r.client.SMembers(ctx, activeEventsKey).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, rawID := range eventIDs {
+		eventID, err := uuid.Parse(rawID)
+		if err != nil {
+			continue
+		}
+		if err := r.admit(ctx, eventID); err != nil {
+			return fmt.Errorf("failed to admit users for event %s: %w", eventID, err)
+		}
+	}
+	return nil
+}
+
+func (r *Room) admit(ctx context.Context, eventID uuid.UUID) error {
+	return admitScript.Run(ctx, r.client,
+		[]string{rateKey(eventID), queueKey(eventID), slotKey(eventID)},
+		admittedKeyPrefix(eventID),
+		r.passTTL.Milliseconds(),
+	).Err()
+}
+
+// RequiresPass reports whether bookings for the event must present an admission pass.
+func (r *Room) RequiresPass(ctx context.Context, eventID uuid.UUID) (bool, error) {
+	return r.IsActive(ctx, eventID)
+}
+
+// ConsumePass checks that the pass was issued to the user for the event and
+// has not expired, and uses up the admission behind it, so it lets one
+// booking through.
+func (r *Room) ConsumePass(ctx context.Context, pass string, eventID, userID uuid.UUID) error {
+	if _, err := r.verifyPass(pass, eventID, userID); err != nil {
+		return err
+	}
+	deleted, err := r.client.Del(ctx, admittedKey(eventID, userID)).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return domain.ErrAdmissionPassInvalid
+	}
+	return nil
+}
+
+// RestorePass gives back the admission a pass used up, for bookings that
+// failed. It keeps the expiry of the pass.
+func (r *Room) RestorePass(ctx context.Context, pass string, eventID, userID uuid.UUID) error {
+	expiresAt, err := r.verifyPass(pass, eventID, userID)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, admittedKey(eventID, userID), 1, time.Until(expiresAt)).Err()
+}
+
+// verifyPass checks the pass and returns when it expires.
+func (r *Room) verifyPass(pass string, eventID, userID uuid.UUID) (time.Time, error) {
+	payload, err := r.signer.Verify(pass)
+	if err != nil {
+		return time.Time{}, domain.ErrAdmissionPassInvalid
+	}
+
+	parts := strings.Split(payload, ":")
+	if len(parts) != 4 || parts[0] != passTokenKind {
+		return time.Time{}, domain.ErrAdmissionPassInvalid
+	}
+	if parts[1] != eventID.String() || parts[2] != userID.String() {
+		return time.Time{}, domain.ErrAdmissionPassInvalid
+	}
+	unix, err := strconv.ParseInt(parts[3], 10, 64)
+	if err != nil {
+		return time.Time{}, domain.ErrAdmissionPassInvalid
+	}
+	expiresAt := time.Unix(unix, 0)
+	if !time.Now().Before(expiresAt) {
+		return time.Time{}, domain.ErrAdmissionPassInvalid
+	}
+	return expiresAt, nil
+}
+
+func (r *Room) parseTicket(pollToken string) (eventID, userID uuid.UUID, err error) {
+	payload, err := r.signer.Verify(pollToken)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, domain.ErrQueueTicketInvalid
+	}
+
+	parts := strings.Split(payload, ":")
+	if len(parts) != 3 || parts[0] != ticketTokenKind {
+		return uuid.Nil, uuid.Nil, domain.ErrQueueTicketInvalid
+	}
+	eventID, err = uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, domain.ErrQueueTicketInvalid
+	}
+	userID, err = uuid.Parse(parts[2])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, domain.ErrQueueTicketInvalid
+	}
+	return eventID, userID, nil
+}
+
+func ticketPayload(eventID, userID uuid.UUID) string {
+	return fmt.Sprintf("%s:%s:%s", ticketTokenKind, eventID, userID)
+}
+
+func passPayload(eventID, userID uuid.UUID, expiresAt time.Time) string {
+	return fmt.Sprintf("%s:%s:%s:%d", passTokenKind, eventID, userID, expiresAt.Unix())
+}
+
+func rateKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("waitingroom:%s:rate", eventID)
+}
+
+func queueKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("waitingroom:%s:queue", eventID)
+}
+
+// slotKey is held for a second by the AdmitNext call that admitted users.
+func slotKey(eventID uuid.UUID) string {
+	return fmt.Sprintf("waitingroom:%s:slot", eventID)
+}
+
+func admittedKeyPrefix(eventID uuid.UUID) string {
+	return fmt.Sprintf("waitingroom:%s:admitted:", eventID)
+}
+
+func admittedKey(eventID, userID uuid.UUID) string {
+	return admittedKeyPrefix(eventID) + userID.String()
+}
@@ -0,0 +1,138 @@
+package waitingroom
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/mati/go-ticket/internal/auth"
+	"github.com/mati/go-ticket/internal/domain"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRoom(t *testing.T) *Room {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	return newTestRoom(t, mr)
+}
+
+// newTestRoom returns a Room on mr, as one replica of the app would have.
+func newTestRoom(t *testing.T, mr *miniredis.Miniredis) *Room {
+	t.Helper()
+	client := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	signer, err := auth.NewTokenSigner("test-secret")
+	require.NoError(t, err)
+	return NewRoom(client, signer, 10*time.Minute)
+}
+
+func TestRoom_AdmitsAtConfiguredRate(t *testing.T) {
+	ctx := context.Background()
+	room := setupRoom(t)
+	eventID := uuid.New()
+
+	require.NoError(t, room.Activate(ctx, eventID, 2))
+
+	users := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+	tickets := make([]*Ticket, len(users))
+	for i, userID := range users {
+		ticket, err := room.Join(ctx, eventID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(i+1), ticket.Position)
+		tickets[i] = ticket
+	}
+
+	require.NoError(t, room.AdmitNext(ctx))
+
+	for i := 0; i < 2; i++ {
+		status, err := room.Status(ctx, tickets[i].PollToken)
+		require.NoError(t, err)
+		assert.True(t, status.Admitted)
+		assert.NoError(t, room.ConsumePass(ctx, status.AdmissionPass, eventID, users[i]))
+	}
+
+	status, err := room.Status(ctx, tickets[2].PollToken)
+	require.NoError(t, err)
+	assert.False(t, status.Admitted)
+	assert.Equal(t, int64(1), status.Position)
+}
+
+func TestRoom_JoinInactive(t *testing.T) {
+	room := setupRoom(t)
+
+	_, err := room.Join(context.Background(), uuid.New(), uuid.New())
+
+	assert.ErrorIs(t, err, domain.ErrWaitingRoomInactive)
+}
+
+func TestRoom_VerifyPass_WrongEventOrUser(t *testing.T) {
+	ctx := context.Background()
+	room := setupRoom(t)
+	eventID, userID := uuid.New(), uuid.New()
+
+	require.NoError(t, room.Activate(ctx, eventID, 1))
+	ticket, err := room.Join(ctx, eventID, userID)
+	require.NoError(t, err)
+	require.NoError(t, room.AdmitNext(ctx))
+	status, err := room.Status(ctx, ticket.PollToken)
+	require.NoError(t, err)
+
+	pass := status.AdmissionPass
+	assert.ErrorIs(t, room.ConsumePass(ctx, pass, uuid.New(), userID), domain.ErrAdmissionPassInvalid)
+	assert.ErrorIs(t, room.ConsumePass(ctx, pass, eventID, uuid.New()), domain.ErrAdmissionPassInvalid)
+	assert.ErrorIs(t, room.ConsumePass(ctx, ticket.PollToken, eventID, userID), domain.ErrAdmissionPassInvalid)
+}
+
+func TestRoom_PassAdmitsOneBooking(t *testing.T) {
+	ctx := context.Background()
+	room := setupRoom(t)
+	eventID, userID := uuid.New(), uuid.New()
+
+	require.NoError(t, room.Activate(ctx, eventID, 1))
+	ticket, err := room.Join(ctx, eventID, userID)
+	require.NoError(t, err)
+	require.NoError(t, room.AdmitNext(ctx))
+	status, err := room.Status(ctx, ticket.PollToken)
+	require.NoError(t, err)
+
+	require.NoError(t, room.ConsumePass(ctx, status.AdmissionPass, eventID, userID))
+	assert.ErrorIs(t, room.ConsumePass(ctx, status.AdmissionPass, eventID, userID), domain.ErrAdmissionPassInvalid)
+
+	// A failed booking gives the pass back.
+	require.NoError(t, room.RestorePass(ctx, status.AdmissionPass, eventID, userID))
+	assert.NoError(t, room.ConsumePass(ctx, status.AdmissionPass, eventID, userID))
+}
+
+func TestRoom_AdmitsAtConfiguredRateAcrossReplicas(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	replicas := []*Room{newTestRoom(t, mr), newTestRoom(t, mr), newTestRoom(t, mr)}
+	eventID := uuid.New()
+
+	require.NoError(t, replicas[0].Activate(ctx, eventID, 2))
+	for range 10 {
+		_, err := replicas[0].Join(ctx, eventID, uuid.New())
+		require.NoError(t, err)
+	}
+
+	for _, replica := range replicas {
+		require.NoError(t, replica.AdmitNext(ctx))
+	}
+	queued, err := mr.ZMembers(queueKey(eventID))
+	require.NoError(t, err)
+	assert.Len(t, queued, 8)
+
+	// The next second admits the next batch.
+	mr.FastForward(time.Second)
+	for _, replica := range replicas {
+		require.NoError(t, replica.AdmitNext(ctx))
+	}
+	queued, err = mr.ZMembers(queueKey(eventID))
+	require.NoError(t, err)
+	assert.Len(t, queued, 6)
+}
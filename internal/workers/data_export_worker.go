@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type DataExportBuilder interface {
+	BuildDataExports(ctx context.Context) (int, error)
+}
+
+// DataExportWorker periodically builds the data exports users requested and
+// deletes the ones past their retention.
+type DataExportWorker struct {
+	builder  DataExportBuilder
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewDataExportWorker(builder DataExportBuilder, interval time.Duration, logger *slog.Logger) *DataExportWorker {
+	return &DataExportWorker{builder: builder, interval: interval, logger: logger}
+}
+
+func (w *DataExportWorker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Data export worker is shutting down...")
+			return nil
+		case <-ticker.C:
+			built, err := w.builder.BuildDataExports(ctx)
+			if err != nil {
+				w.logger.Error("Failed to build data exports", "error", err)
+				continue
+			}
+			if built > 0 {
+				w.logger.Info("Built data exports", "count", built)
+			}
+		}
+	}
+}
@@ -11,21 +11,37 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// emailSentTTL is how long sent emails are remembered, so redelivered
+// messages are not sent twice.
+const emailSentTTL = 7 * 24 * time.Hour
+
+// emailMessage is a booking notification, or an account notification when
+// Kind is set. Both carry an ID for idempotency and the recipient.
+type emailMessage struct {
+	domain.BookingEventPayload
+	Kind domain.AccountNotificationKind `json:"kind"`
+}
+
+// EmailWorker sends the emails queued for users, unless their notification
+// preferences turn them down.
 type EmailWorker struct {
 	logger      *slog.Logger
 	redisClient *redis.Client
 	consumer    domain.NotificationConsumer
+	preferences domain.NotificationPreferenceChecker
 }
 
 func NewEmailWorker(
 	logger *slog.Logger,
 	redisClient *redis.Client,
 	consumer domain.NotificationConsumer,
+	preferences domain.NotificationPreferenceChecker,
 ) *EmailWorker {
 	return &EmailWorker{
 		redisClient: redisClient,
 		logger:      logger,
 		consumer:    consumer,
+		preferences: preferences,
 	}
 }
 
@@ -40,14 +56,14 @@ func (e *EmailWorker) Start(ctx context.Context) error {
 			e.logger.Info("EmailWorker shutting down")
 			return nil
 		case msgs := <-msg:
-			var booking domain.BookingEventPayload
-			err := json.Unmarshal(msgs.Body, &booking)
+			var email emailMessage
+			err := json.Unmarshal(msgs.Body, &email)
 			if err != nil {
 				e.logger.Error("failed unmarshal booking event: ", "error", err)
 				_ = msgs.Reject()
 				continue
 			}
-			key := fmt.Sprintf("email:sent:%s", booking.ID)
+			key := fmt.Sprintf("email:sent:%s", email.ID)
 			exists, err := e.redisClient.Exists(ctx, key).Result()
 			if err != nil {
 				e.logger.Error("failed checking if email exists: ", "error", err)
@@ -65,8 +81,41 @@ func (e *EmailWorker) Start(ctx context.Context) error {
 				continue
 			}
 
-			e.logger.Info("Sending email to:", "booking_id", booking.UserEmail)
-			err = e.redisClient.Set(ctx, key, 1, 7*24*time.Hour).Err()
+			category := domain.NotificationBooking
+			if email.Kind != "" {
+				category = domain.NotificationAccount
+			}
+			allowed, err := e.preferences.AllowsNotification(
+				ctx,
+				email.UserEmail,
+				domain.NotificationChannelEmail,
+				category,
+			)
+			if err != nil {
+				e.logger.Error("failed checking notification preferences: ", "error", err)
+				_ = msgs.Reject()
+				continue
+			}
+			if !allowed {
+				e.logger.Info("EmailWorker skipped due to notification preferences", "category", category)
+				if err := msgs.Ack(); err != nil {
+					e.logger.Error("failed acknowledging email: ", "error", err)
+				}
+				continue
+			}
+
+			if email.Kind != "" {
+				e.logger.Info("Sending account email to:", "kind", email.Kind, "user_email", email.UserEmail)
+			} else {
+				e.logger.Info("Sending email to:", "booking_id", email.UserEmail)
+			}
+			recipient := recipientKey(domain.RecipientHash(email.UserEmail))
+			_, err = e.redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Set(ctx, key, 1, emailSentTTL)
+				pipe.SAdd(ctx, recipient, key)
+				pipe.Expire(ctx, recipient, emailSentTTL)
+				return nil
+			})
 			if err != nil {
 				e.logger.Error("failed sending email: ", "error", err)
 				_ = msgs.Reject()
@@ -80,3 +129,19 @@ func (e *EmailWorker) Start(ctx context.Context) error {
 		}
 	}
 }
+
+// PurgeRecipient forgets the emails sent to a recipient, for users who
+// erased their account.
+func (e *EmailWorker) PurgeRecipient(ctx context.Context, recipientHash string) error {
+	recipient := recipientKey(recipientHash)
+	keys, err := e.redisClient.SMembers(ctx, recipient).Result()
+	if err != nil {
+		return err
+	}
+	return e.redisClient.Del(ctx, append(keys, recipient)...).Err()
+}
+
+// recipientKey names the set of the email:sent keys of a recipient.
+func recipientKey(recipientHash string) string {
+	return fmt.Sprintf("email:recipient:%s", recipientHash)
+}
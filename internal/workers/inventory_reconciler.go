@@ -0,0 +1,56 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/mati/go-ticket/internal/domain"
+)
+
+type InventoryReconciler interface {
+	Reconcile(ctx context.Context) ([]domain.InventoryDrift, error)
+}
+
+// InventoryReconcileWorker periodically checks sharded event inventories
+// against their bookings and reports any drift.
+type InventoryReconcileWorker struct {
+	reconciler InventoryReconciler
+	interval   time.Duration
+	logger     *slog.Logger
+}
+
+func NewInventoryReconcileWorker(
+	reconciler InventoryReconciler,
+	interval time.Duration,
+	logger *slog.Logger,
+) *InventoryReconcileWorker {
+	return &InventoryReconcileWorker{reconciler: reconciler, interval: interval, logger: logger}
+}
+
+func (w *InventoryReconcileWorker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Inventory reconciler is shutting down...")
+			return nil
+		case <-ticker.C:
+			drifts, err := w.reconciler.Reconcile(ctx)
+			if err != nil {
+				w.logger.Error("Failed to reconcile inventory", "error", err)
+				continue
+			}
+			for _, drift := range drifts {
+				w.logger.Warn("Inventory drift detected",
+					"event_id", drift.EventID,
+					"sharded_spots", drift.ShardedSpots,
+					"expected_spots", drift.ExpectedSpots,
+					"active_bookings", drift.ActiveBookings,
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type KeyRotator interface {
+	Rotate(ctx context.Context) error
+}
+
+// KeyRotationWorker periodically rotates the token signing keys when due and
+// reloads them, so every instance picks up keys created by the others.
+type KeyRotationWorker struct {
+	rotator  KeyRotator
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewKeyRotationWorker(rotator KeyRotator, interval time.Duration, logger *slog.Logger) *KeyRotationWorker {
+	return &KeyRotationWorker{rotator: rotator, interval: interval, logger: logger}
+}
+
+func (w *KeyRotationWorker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Key rotation worker is shutting down...")
+			return nil
+		case <-ticker.C:
+			if err := w.rotator.Rotate(ctx); err != nil {
+				w.logger.Error("Failed to rotate signing keys", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type OrderExpirer interface {
+	ExpireOverdueOrders(ctx context.Context) (int, error)
+}
+
+// OrderExpiryWorker periodically releases the spots of group orders whose
+// invoice was not paid in time.
+type OrderExpiryWorker struct {
+	expirer  OrderExpirer
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewOrderExpiryWorker(expirer OrderExpirer, interval time.Duration, logger *slog.Logger) *OrderExpiryWorker {
+	return &OrderExpiryWorker{expirer: expirer, interval: interval, logger: logger}
+}
+
+func (w *OrderExpiryWorker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Order expiry worker is shutting down...")
+			return nil
+		case <-ticker.C:
+			expired, err := w.expirer.ExpireOverdueOrders(ctx)
+			if err != nil {
+				w.logger.Error("Failed to expire overdue orders", "error", err)
+				continue
+			}
+			if expired > 0 {
+				w.logger.Info("Expired unpaid orders", "count", expired)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type PayoutRunner interface {
+	RunPayouts(ctx context.Context) (int, error)
+}
+
+// PayoutWorker periodically settles what the platform owes organizers.
+type PayoutWorker struct {
+	runner   PayoutRunner
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewPayoutWorker(runner PayoutRunner, interval time.Duration, logger *slog.Logger) *PayoutWorker {
+	return &PayoutWorker{runner: runner, interval: interval, logger: logger}
+}
+
+func (w *PayoutWorker) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Payout worker is shutting down...")
+			return nil
+		case <-ticker.C:
+			settled, err := w.runner.RunPayouts(ctx)
+			if err != nil {
+				w.logger.Error("Failed to run payouts", "error", err)
+				continue
+			}
+			if settled > 0 {
+				w.logger.Info("Settled organizer balances", "count", settled)
+			}
+		}
+	}
+}
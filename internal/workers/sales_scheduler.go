@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type SalesTransitionEmitter interface {
+	EmitDueTransitions(ctx context.Context) (int, error)
+}
+
+// SalesScheduler publishes SalesOpened/SalesClosed events once an event's
+// sales window boundary has passed.
+type SalesScheduler struct {
+	emitter  SalesTransitionEmitter
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewSalesScheduler(emitter SalesTransitionEmitter, interval time.Duration, logger *slog.Logger) *SalesScheduler {
+	return &SalesScheduler{emitter: emitter, interval: interval, logger: logger}
+}
+
+func (w *SalesScheduler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Sales scheduler is shutting down...")
+			return nil
+		case <-ticker.C:
+			emitted, err := w.emitter.EmitDueTransitions(ctx)
+			if err != nil {
+				w.logger.Error("Failed to emit sales transitions", "error", err)
+				continue
+			}
+			if emitted > 0 {
+				w.logger.Info("Emitted sales transitions", "count", emitted)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type QueueAdmitter interface {
+	AdmitNext(ctx context.Context) error
+}
+
+// WaitingRoomAdmitter lets the next batch of queued users in once per second.
+type WaitingRoomAdmitter struct {
+	admitter QueueAdmitter
+	logger   *slog.Logger
+}
+
+func NewWaitingRoomAdmitter(admitter QueueAdmitter, logger *slog.Logger) *WaitingRoomAdmitter {
+	return &WaitingRoomAdmitter{admitter: admitter, logger: logger}
+}
+
+func (w *WaitingRoomAdmitter) Start(ctx context.Context) error {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Waiting room admitter is shutting down...")
+			return nil
+		case <-ticker.C:
+			if err := w.admitter.AdmitNext(ctx); err != nil {
+				w.logger.Error("Failed to admit queued users", "error", err)
+			}
+		}
+	}
+}